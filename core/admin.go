@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	// static assets
 	_ "github.com/SpectoLabs/hoverfly/core/statik"
@@ -17,11 +18,25 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 )
 
+// AdminPathPrefix - path prefix under which the admin API is served on the proxy port
+// when Cfg.AdminOnProxyPort is enabled
+const AdminPathPrefix = "/__hoverfly"
+
 type AdminApi struct{}
 
 // Starts the Admin API on a new HTTP port. Port is chosen by
 // hoverfly.Cfg.AdminPort.
 func (this *AdminApi) StartAdminInterface(hoverfly *Hoverfly) {
+	// admin interface starting message
+	log.WithFields(log.Fields{
+		"AdminPort": hoverfly.Cfg.AdminPort,
+	}).Info("Admin interface is starting...")
+
+	http.ListenAndServe(fmt.Sprintf("%s:%s", hoverfly.Cfg.ListenOnHost, hoverfly.Cfg.AdminPort), this.Handler(hoverfly))
+}
+
+// Handler - builds the handler serving the admin API and dashboard
+func (this *AdminApi) Handler(hoverfly *Hoverfly) http.Handler {
 	router := bone.New()
 
 	mux := this.addAdminApiRoutes(router, hoverfly)
@@ -30,12 +45,21 @@ func (this *AdminApi) StartAdminInterface(hoverfly *Hoverfly) {
 
 	n.UseHandler(mux)
 
-	// admin interface starting message
-	log.WithFields(log.Fields{
-		"AdminPort": hoverfly.Cfg.AdminPort,
-	}).Info("Admin interface is starting...")
+	return n
+}
+
+// withAdminOnPathPrefix - serves requests made directly to the proxy port under AdminPathPrefix
+// with the admin API. Everything else, including proxied requests which always have an
+// absolute URL, is handled by the proxy.
+func withAdminOnPathPrefix(admin, proxy http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !r.URL.IsAbs() && (r.URL.Path == AdminPathPrefix || strings.HasPrefix(r.URL.Path, AdminPathPrefix+"/")) {
+			http.StripPrefix(AdminPathPrefix, admin).ServeHTTP(w, r)
+			return
+		}
 
-	http.ListenAndServe(fmt.Sprintf("%s:%s", hoverfly.Cfg.ListenOnHost, hoverfly.Cfg.AdminPort), n)
+		proxy.ServeHTTP(w, r)
+	})
 }
 
 // Will add the handlers to the router.
@@ -44,6 +68,9 @@ func (this *AdminApi) addAdminApiRoutes(router *bone.Mux, d *Hoverfly) *bone.Mux
 		AB:                 d.Authentication,
 		SecretKey:          d.Cfg.SecretKey,
 		JWTExpirationDelta: d.Cfg.JWTExpirationDelta,
+		JWTRefreshWindow:   d.Cfg.JWTRefreshWindow,
+		FailedLoginLimit:   d.Cfg.FailedLoginLimit,
+		FailedLoginLockout: d.Cfg.FailedLoginLockout,
 		Enabled:            d.Cfg.AuthEnabled,
 	}
 
@@ -94,22 +121,28 @@ func (this *AdminApi) addDashboardRoutes(router *bone.Mux) *bone.Mux {
 
 func getAllHandlers(hoverfly *Hoverfly) []handlers.AdminHandler {
 	list := []handlers.AdminHandler{
-		&handlers.HealthHandler{},
+		&handlers.HealthHandler{Hoverfly: hoverfly},
+		&handlers.MetricsHandler{Hoverfly: hoverfly},
 
 		&v2.HoverflyHandler{Hoverfly: hoverfly},
 		&v2.HoverflyDestinationHandler{Hoverfly: hoverfly},
+		&v2.HoverflyUpstreamRoutesHandler{Hoverfly: hoverfly},
 		&v2.HoverflyModeHandler{Hoverfly: hoverfly},
 		&v2.HoverflyMiddlewareHandler{Hoverfly: hoverfly},
 		&v2.HoverflyUsageHandler{Hoverfly: hoverfly},
 		&v2.HoverflyVersionHandler{Hoverfly: hoverfly},
+		&v2.HoverflyCACertHandler{Hoverfly: hoverfly},
 		&v2.HoverflyUpstreamProxyHandler{Hoverfly: hoverfly},
 		&v2.HoverflyPACHandler{Hoverfly: hoverfly},
 		&v2.HoverflyCORSHandler{Hoverfly: hoverfly},
+		&v2.HoverflyMissResponseHandler{Hoverfly: hoverfly},
 		&v2.SimulationHandler{Hoverfly: hoverfly},
+		&v2.SimulationDelaysHandler{Hoverfly: hoverfly},
+		&v2.SimulationMatchHandler{Hoverfly: hoverfly},
 		&v2.CacheHandler{Hoverfly: hoverfly},
 		&v2.LogsHandler{Hoverfly: hoverfly.StoreLogsHook},
 		&v2.JournalHandler{Hoverfly: hoverfly.Journal},
-		&v2.ShutdownHandler{},
+		&v2.ShutdownHandler{Hoverfly: hoverfly},
 		&v2.StateHandler{Hoverfly: hoverfly},
 		&v2.DiffHandler{Hoverfly: hoverfly},
 	}
@@ -1,9 +1,12 @@
 package hoverfly
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-zoo/bone"
 	. "github.com/onsi/gomega"
@@ -28,3 +31,85 @@ func Test_AdminAPI_Serves404(t *testing.T) {
 
 	Expect(respRec.Code, http.StatusNotFound)
 }
+
+func Test_withAdminOnPathPrefix_ServesAdminForDirectRequestsUnderThePrefix(t *testing.T) {
+	RegisterTestingT(t)
+
+	admin := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("admin " + r.URL.Path))
+	})
+	proxy := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("proxy"))
+	})
+
+	unit := withAdminOnPathPrefix(admin, proxy)
+
+	respRec := httptest.NewRecorder()
+	unit.ServeHTTP(respRec, httptest.NewRequest("GET", "/__hoverfly/api/health", nil))
+	Expect(respRec.Body.String()).To(Equal("admin /api/health"))
+
+	respRec = httptest.NewRecorder()
+	unit.ServeHTTP(respRec, httptest.NewRequest("GET", "/__hoverflyish", nil))
+	Expect(respRec.Body.String()).To(Equal("proxy"))
+
+	respRec = httptest.NewRecorder()
+	unit.ServeHTTP(respRec, httptest.NewRequest("GET", "http://test.com/__hoverfly/api/health", nil))
+	Expect(respRec.Body.String()).To(Equal("proxy"))
+}
+
+func Test_Hoverfly_StartProxy_ServesAdminApiOnProxyPortWhenEnabled(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.ListenOnHost = "127.0.0.1"
+	unit.Cfg.ProxyPort = "0"
+	unit.Cfg.AdminOnProxyPort = true
+
+	Expect(unit.StartProxy()).To(BeNil())
+	defer unit.StopProxy()
+
+	response, err := http.Get("http://" + unit.SL.Addr().String() + "/__hoverfly/api/health")
+	Expect(err).To(BeNil())
+	defer response.Body.Close()
+
+	Expect(response.StatusCode).To(Equal(http.StatusOK))
+}
+
+func Test_Hoverfly_SetDestination_RespondsWhenTheAdminApiIsOnTheProxyPort(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.ListenOnHost = "127.0.0.1"
+	unit.Cfg.ProxyPort = "5558"
+	unit.Cfg.AdminOnProxyPort = true
+	unit.Cfg.ShutdownGracePeriod = 10 * time.Second
+
+	Expect(unit.StartProxy()).To(BeNil())
+	defer func() {
+		unit.mu.Lock()
+		unit.StopProxy()
+		unit.mu.Unlock()
+	}()
+
+	adminUrl := "http://127.0.0.1:5558/__hoverfly/api/v2/hoverfly/destination"
+	client := &http.Client{Timeout: 2 * time.Second}
+
+	request, err := http.NewRequest(http.MethodPut, adminUrl, strings.NewReader(`{"destination": "newdest"}`))
+	Expect(err).To(BeNil())
+
+	response, err := client.Do(request)
+	Expect(err).To(BeNil())
+	defer response.Body.Close()
+
+	Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+	Eventually(func() string {
+		response, err := client.Get(adminUrl)
+		if err != nil {
+			return ""
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		return string(body)
+	}, 5*time.Second).Should(ContainSubstring("newdest"))
+}
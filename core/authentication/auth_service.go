@@ -4,7 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-
+	"sync"
 	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/authentication/backends"
@@ -20,16 +20,24 @@ type FailedAttempts struct {
 	LastFailed time.Time
 }
 
-var Attempts FailedAttempts
+// Attempts - failed login attempts for each username, so that one user getting their password wrong does not
+// lock out everyone else
+var Attempts = map[string]*FailedAttempts{}
+
+var attemptsMutex sync.Mutex
 
-func HasReachedFailedAttemptsLimit(limit int, timeout string) bool {
-	if Attempts.Count >= limit {
-		failureTimeout, _ := time.ParseDuration(timeout)
+// HasReachedFailedAttemptsLimit - whether the username is locked out after more than limit failed attempts, the
+// last of which was within the timeout. Every attempt while locked out counts as failed, extending the lockout.
+func HasReachedFailedAttemptsLimit(username string, limit int, timeout time.Duration) bool {
+	attemptsMutex.Lock()
+	defer attemptsMutex.Unlock()
 
-		if time.Now().Sub(Attempts.LastFailed) > failureTimeout {
-			Attempts.Count = 0
+	attempts, found := Attempts[username]
+	if found && attempts.Count >= limit {
+		if time.Now().Sub(attempts.LastFailed) > timeout {
+			delete(Attempts, username)
 		} else {
-			updateFailedAttempts()
+			updateFailedAttempts(username, timeout)
 			return true
 		}
 	}
@@ -37,15 +45,44 @@ func HasReachedFailedAttemptsLimit(limit int, timeout string) bool {
 	return false
 }
 
-func updateFailedAttempts() {
-	Attempts.Count++
-	Attempts.LastFailed = time.Now()
+// ResetFailedAttempts - clears the failed attempts of a username after they log in successfully
+func ResetFailedAttempts(username string) {
+	attemptsMutex.Lock()
+	defer attemptsMutex.Unlock()
+
+	delete(Attempts, username)
+}
+
+func recordFailedAttempt(username string, timeout time.Duration) {
+	attemptsMutex.Lock()
+	defer attemptsMutex.Unlock()
+
+	updateFailedAttempts(username, timeout)
+}
+
+func updateFailedAttempts(username string, timeout time.Duration) {
+	attempts, found := Attempts[username]
+	if !found {
+		// forget usernames whose attempts have timed out, so that guessing usernames cannot grow the map forever
+		for name, expired := range Attempts {
+			if time.Now().Sub(expired.LastFailed) > timeout {
+				delete(Attempts, name)
+			}
+		}
+		attempts = &FailedAttempts{}
+		Attempts[username] = attempts
+	}
+
+	attempts.Count++
+	attempts.LastFailed = time.Now()
 }
 
-func Login(requestUser *backends.User, ab backends.Authentication, secret []byte, exp int) (int, []byte) {
-	authBackend := InitJWTAuthenticationBackend(ab, secret, exp)
+// Login - returns a token for the user when their credentials are correct. After limit failed attempts for a
+// username, further attempts are refused with a 429 until none have been made for the lockout duration.
+func Login(requestUser *backends.User, ab backends.Authentication, secret []byte, exp, limit int, lockout time.Duration) (int, []byte) {
+	authBackend := InitJWTAuthenticationBackend(ab, secret, exp, 0)
 
-	if HasReachedFailedAttemptsLimit(3, "10m") {
+	if HasReachedFailedAttemptsLimit(requestUser.Username, limit, lockout) {
 		return http.StatusTooManyRequests, []byte("")
 	}
 
@@ -54,17 +91,18 @@ func Login(requestUser *backends.User, ab backends.Authentication, secret []byte
 		if err != nil {
 			return http.StatusInternalServerError, []byte("")
 		} else {
+			ResetFailedAttempts(requestUser.Username)
 			response, _ := json.Marshal(TokenAuthentication{token})
 			return http.StatusOK, response
 		}
 	}
 
-	updateFailedAttempts()
+	recordFailedAttempt(requestUser.Username, lockout)
 	return http.StatusUnauthorized, []byte("")
 }
 
 func IsJwtTokenValid(token string, ab backends.Authentication, secret []byte, exp int) bool {
-	authBackend := InitJWTAuthenticationBackend(ab, secret, exp)
+	authBackend := InitJWTAuthenticationBackend(ab, secret, exp, 0)
 
 	jwtToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -75,27 +113,83 @@ func IsJwtTokenValid(token string, ab backends.Authentication, secret []byte, ex
 	})
 
 	if err == nil && jwtToken.Valid && !authBackend.IsInBlacklist(token) {
+		// tokens stop working once their user has been deleted
+		if claims, ok := jwtToken.Claims.(jwt.MapClaims); ok {
+			if username, _ := claims["username"].(string); username != "" {
+				user, err := ab.GetUser(username)
+				return err == nil && user != nil
+			}
+		}
 		return true
 	} else {
 		return false
 	}
 }
 
-func RefreshToken(requestUser *backends.User, ab backends.Authentication, secret []byte, exp int) []byte {
-	authBackend := InitJWTAuthenticationBackend(ab, secret, exp)
-	token, err := authBackend.GenerateToken(requestUser.UUID, requestUser.Username)
+// GetTokenUsername - returns the username a valid token was issued to
+func GetTokenUsername(token string, secret []byte) (string, error) {
+	jwtToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return secret, nil
+	})
 	if err != nil {
-		panic(err)
+		return "", err
 	}
-	response, err := json.Marshal(TokenAuthentication{token})
+
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", fmt.Errorf("Token has no claims")
+	}
+
+	username, _ := claims["username"].(string)
+	return username, nil
+}
+
+// RefreshToken - issues a new token to the user of the given token, which can have expired up to the refresh
+// window ago. The given token is invalidated.
+func RefreshToken(token string, ab backends.Authentication, secret []byte, exp, refreshWindow int) (int, []byte) {
+	authBackend := InitJWTAuthenticationBackend(ab, secret, exp, refreshWindow)
+
+	// expiry is checked against the refresh window below, rather than when parsing
+	parser := jwt.Parser{SkipClaimsValidation: true}
+	jwtToken, err := parser.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return authBackend.SecretKey, nil
+	})
+	if err != nil || !jwtToken.Valid || authBackend.IsInBlacklist(token) {
+		return http.StatusUnauthorized, []byte("")
+	}
+
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok || !authBackend.CanRefresh(claims["exp"]) {
+		return http.StatusUnauthorized, []byte("")
+	}
+
+	username, _ := claims["username"].(string)
+	user, err := ab.GetUser(username)
+	if err != nil || user == nil {
+		return http.StatusUnauthorized, []byte("")
+	}
+
+	newToken, err := authBackend.GenerateToken(user.UUID, user.Username)
 	if err != nil {
-		panic(err)
+		return http.StatusInternalServerError, []byte("")
 	}
-	return response
+	// a token refreshed within the second it was issued is identical to the old one
+	if newToken != token {
+		authBackend.Logout(token)
+	}
+
+	response, _ := json.Marshal(TokenAuthentication{newToken})
+	return http.StatusOK, response
 }
 
 func Logout(req *http.Request, ab backends.Authentication, secret []byte, exp int) error {
-	authBackend := InitJWTAuthenticationBackend(ab, secret, exp)
+	authBackend := InitJWTAuthenticationBackend(ab, secret, exp, 0)
 	tokenString := req.Header.Get("Authorization")
 	return authBackend.Logout(tokenString)
 }
@@ -1,10 +1,14 @@
 package authentication_test
 
 import (
+	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/authentication"
+	"github.com/SpectoLabs/hoverfly/core/authentication/backends"
+	"github.com/SpectoLabs/hoverfly/core/cache"
 	. "github.com/onsi/gomega"
 )
 
@@ -13,54 +17,190 @@ var timeLayout = "2006-01-02T15:04:05.000Z"
 func Test_HasReachFailedAttemptsLimit_ReturnsFalseIfAttemptsIsBelowOrEqualToLimit(t *testing.T) {
 	RegisterTestingT(t)
 
-	authentication.Attempts.Count = 0
-	Expect(authentication.HasReachedFailedAttemptsLimit(3, "10s")).To(BeFalse())
+	authentication.Attempts = map[string]*authentication.FailedAttempts{}
+	Expect(authentication.HasReachedFailedAttemptsLimit("bob", 3, 10*time.Second)).To(BeFalse())
 
-	authentication.Attempts.Count = 1
-	Expect(authentication.HasReachedFailedAttemptsLimit(3, "10s")).To(BeFalse())
-
-	authentication.Attempts.Count = 2
-	Expect(authentication.HasReachedFailedAttemptsLimit(3, "10s")).To(BeFalse())
-
-	authentication.Attempts.Count = 3
-	Expect(authentication.HasReachedFailedAttemptsLimit(3, "10s")).To(BeFalse())
+	for _, count := range []int{1, 2, 3} {
+		authentication.Attempts["bob"] = &authentication.FailedAttempts{Count: count, LastFailed: time.Now()}
+		Expect(authentication.HasReachedFailedAttemptsLimit("bob", 4, 10*time.Second)).To(BeFalse())
+	}
 }
 
 func Test_HasReachFailedAttemptsLimit_ReturnsTrueIfAttemptsIsAboveLimit_AndLastFailedIsWithinTheTimeoutPeriod(t *testing.T) {
 	RegisterTestingT(t)
 
-	authentication.Attempts.Count = 4
-	authentication.Attempts.LastFailed = time.Now()
-	Expect(authentication.HasReachedFailedAttemptsLimit(3, "10s")).To(BeTrue())
+	authentication.Attempts = map[string]*authentication.FailedAttempts{
+		"bob": {Count: 4, LastFailed: time.Now()},
+	}
+	Expect(authentication.HasReachedFailedAttemptsLimit("bob", 3, 10*time.Second)).To(BeTrue())
 }
 
 func Test_HasReachFailedAttemptsLimit_IncreasesCountIfAttemptsIsAboveLimit_AndLastFailedIsWithinTheTimeoutPeriod(t *testing.T) {
 	RegisterTestingT(t)
 
-	authentication.Attempts.Count = 4
-	authentication.Attempts.LastFailed = time.Now()
+	authentication.Attempts = map[string]*authentication.FailedAttempts{
+		"bob": {Count: 4, LastFailed: time.Now()},
+	}
 
-	authentication.HasReachedFailedAttemptsLimit(3, "10s")
+	authentication.HasReachedFailedAttemptsLimit("bob", 3, 10*time.Second)
 
-	Expect(authentication.Attempts.Count).To(Equal(5))
+	Expect(authentication.Attempts["bob"].Count).To(Equal(5))
 }
 
 func Test_HasReachFailedAttemptsLimit_ReturnsFalseIfAttemptsIsAboveLimit_ButLastFailedTimeIsAfterTimeoutPeriod(t *testing.T) {
 	RegisterTestingT(t)
 
-	authentication.Attempts.Count = 4
-
-	authentication.Attempts.LastFailed, _ = time.Parse(timeLayout, "2017-04-01T10:45:26.371Z")
-	Expect(authentication.HasReachedFailedAttemptsLimit(3, "10s")).To(BeFalse())
+	lastFailed, _ := time.Parse(timeLayout, "2017-04-01T10:45:26.371Z")
+	authentication.Attempts = map[string]*authentication.FailedAttempts{
+		"bob": {Count: 4, LastFailed: lastFailed},
+	}
+	Expect(authentication.HasReachedFailedAttemptsLimit("bob", 3, 10*time.Second)).To(BeFalse())
 }
 
 func Test_HasReachFailedAttemptsLimit_ResetsCountIfAttemptsIsAboveLimit_ButLastFailedTimeIsAfterTimeoutPeriod(t *testing.T) {
 	RegisterTestingT(t)
 
-	authentication.Attempts.Count = 4
+	lastFailed, _ := time.Parse(timeLayout, "2017-04-01T10:45:26.371Z")
+	authentication.Attempts = map[string]*authentication.FailedAttempts{
+		"bob": {Count: 4, LastFailed: lastFailed},
+	}
+
+	authentication.HasReachedFailedAttemptsLimit("bob", 3, 10*time.Second)
+
+	Expect(authentication.Attempts).ToNot(HaveKey("bob"))
+}
+
+func Test_HasReachFailedAttemptsLimit_OnlyLocksOutTheUsernameWithFailedAttempts(t *testing.T) {
+	RegisterTestingT(t)
+
+	authentication.Attempts = map[string]*authentication.FailedAttempts{
+		"bob": {Count: 4, LastFailed: time.Now()},
+	}
+
+	Expect(authentication.HasReachedFailedAttemptsLimit("bob", 3, 10*time.Second)).To(BeTrue())
+	Expect(authentication.HasReachedFailedAttemptsLimit("alice", 3, 10*time.Second)).To(BeFalse())
+}
+
+func Test_Login_LocksOutAfterTheLimitOfFailedAttempts_UntilTheLockoutHasPassed(t *testing.T) {
+	RegisterTestingT(t)
+
+	authentication.Attempts = map[string]*authentication.FailedAttempts{}
+	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
+	ab.AddUser("bob", "password", true)
+	wrongPassword := &backends.User{Username: "bob", Password: "wrong"}
+	rightPassword := &backends.User{Username: "bob", Password: "password"}
+
+	for i := 0; i < 2; i++ {
+		status, _ := authentication.Login(wrongPassword, ab, []byte("verysecret"), 100, 2, 200*time.Millisecond)
+		Expect(status).To(Equal(http.StatusUnauthorized))
+	}
+
+	status, _ := authentication.Login(rightPassword, ab, []byte("verysecret"), 100, 2, 200*time.Millisecond)
+	Expect(status).To(Equal(http.StatusTooManyRequests))
+
+	time.Sleep(300 * time.Millisecond)
+
+	status, _ = authentication.Login(rightPassword, ab, []byte("verysecret"), 100, 2, 200*time.Millisecond)
+	Expect(status).To(Equal(http.StatusOK))
+}
+
+func Test_Login_ResetsFailedAttemptsAfterASuccessfulLogin(t *testing.T) {
+	RegisterTestingT(t)
+
+	authentication.Attempts = map[string]*authentication.FailedAttempts{}
+	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
+	ab.AddUser("bob", "password", true)
+
+	authentication.Login(&backends.User{Username: "bob", Password: "wrong"}, ab, []byte("verysecret"), 100, 2, time.Minute)
+	Expect(authentication.Attempts).To(HaveKey("bob"))
+
+	status, _ := authentication.Login(&backends.User{Username: "bob", Password: "password"}, ab, []byte("verysecret"), 100, 2, time.Minute)
+	Expect(status).To(Equal(http.StatusOK))
+	Expect(authentication.Attempts).ToNot(HaveKey("bob"))
+}
+
+func Test_Login_DoesNotLockOutOtherUsernames(t *testing.T) {
+	RegisterTestingT(t)
+
+	authentication.Attempts = map[string]*authentication.FailedAttempts{}
+	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
+	ab.AddUser("bob", "password", true)
+	ab.AddUser("alice", "password", true)
+
+	for i := 0; i < 3; i++ {
+		authentication.Login(&backends.User{Username: "bob", Password: "wrong"}, ab, []byte("verysecret"), 100, 2, time.Minute)
+	}
+
+	status, _ := authentication.Login(&backends.User{Username: "bob", Password: "password"}, ab, []byte("verysecret"), 100, 2, time.Minute)
+	Expect(status).To(Equal(http.StatusTooManyRequests))
+
+	status, _ = authentication.Login(&backends.User{Username: "alice", Password: "password"}, ab, []byte("verysecret"), 100, 2, time.Minute)
+	Expect(status).To(Equal(http.StatusOK))
+}
+
+func generateTokenForTest(ab backends.Authentication, exp int) string {
+	user, _ := ab.GetUser("bob")
+	token, err := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), exp, 0).GenerateToken(user.UUID, user.Username)
+	Expect(err).To(BeNil())
+	return token
+}
+
+func Test_RefreshToken_IssuesANewTokenAndInvalidatesTheOldOne(t *testing.T) {
+	RegisterTestingT(t)
+
+	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
+	ab.AddUser("bob", "password", true)
+	token := generateTokenForTest(ab, 100)
+	time.Sleep(time.Second)
+
+	status, response := authentication.RefreshToken(token, ab, []byte("verysecret"), 100, 60)
+	Expect(status).To(Equal(http.StatusOK))
+
+	var refreshed authentication.TokenAuthentication
+	Expect(json.Unmarshal(response, &refreshed)).To(Succeed())
+	Expect(refreshed.Token).ToNot(Equal(token))
+
+	Expect(authentication.IsJwtTokenValid(refreshed.Token, ab, []byte("verysecret"), 100)).To(BeTrue())
+	Expect(authentication.IsJwtTokenValid(token, ab, []byte("verysecret"), 100)).To(BeFalse())
+}
+
+func Test_RefreshToken_RefreshesExpiredTokensWithinTheRefreshWindow(t *testing.T) {
+	RegisterTestingT(t)
+
+	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
+	ab.AddUser("bob", "password", true)
+	token := generateTokenForTest(ab, -10)
+	Expect(authentication.IsJwtTokenValid(token, ab, []byte("verysecret"), 100)).To(BeFalse())
+
+	status, _ := authentication.RefreshToken(token, ab, []byte("verysecret"), 100, 60)
+	Expect(status).To(Equal(http.StatusOK))
+}
+
+func Test_RefreshToken_RejectsTokensExpiredForLongerThanTheRefreshWindow(t *testing.T) {
+	RegisterTestingT(t)
+
+	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
+	ab.AddUser("bob", "password", true)
+	token := generateTokenForTest(ab, -10)
+
+	status, _ := authentication.RefreshToken(token, ab, []byte("verysecret"), 100, 5)
+	Expect(status).To(Equal(http.StatusUnauthorized))
+
+	status, _ = authentication.RefreshToken(token, ab, []byte("verysecret"), 100, 0)
+	Expect(status).To(Equal(http.StatusUnauthorized))
+}
+
+func Test_RefreshToken_RejectsInvalidTokens(t *testing.T) {
+	RegisterTestingT(t)
+
+	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
+	ab.AddUser("bob", "password", true)
+	token := generateTokenForTest(ab, 100)
 
-	authentication.Attempts.LastFailed, _ = time.Parse(timeLayout, "2017-04-01T10:45:26.371Z")
-	authentication.HasReachedFailedAttemptsLimit(3, "10s")
+	status, _ := authentication.RefreshToken(token, ab, []byte("othersecret"), 100, 60)
+	Expect(status).To(Equal(http.StatusUnauthorized))
 
-	Expect(authentication.Attempts.Count).To(Equal(0))
+	ab.InvalidateToken(token)
+	status, _ = authentication.RefreshToken(token, ab, []byte("verysecret"), 100, 60)
+	Expect(status).To(Equal(http.StatusUnauthorized))
 }
@@ -14,7 +14,7 @@ import (
 type User struct {
 	UUID     string `json:"uuid" form:"-"`
 	Username string `json:"username" form:"username"`
-	Password string `json:"password" form:"password"`
+	Password string `json:"password,omitempty" form:"password"`
 	IsAdmin  bool   `json:"is_admin" form:"is_admin"`
 }
 
@@ -45,6 +45,7 @@ type Authentication interface {
 	AddUserHashedPassword(username, passwordHash string, admin bool) (err error)
 	GetUser(username string) (user *User, err error)
 	GetAllUsers() (users []User, err error)
+	DeleteUser(username string) (err error)
 	InvalidateToken(token string) (err error)
 	IsTokenBlacklisted(token string) (blacklisted bool, err error)
 }
@@ -150,12 +151,19 @@ func (b *CacheAuthBackend) GetAllUsers() (users []User, err error) {
 	users = make([]User, len(values), len(values))
 	for i, user := range values {
 		decodedUser, err := DecodeUser(user)
+		if err != nil {
+			return nil, err
+		}
 		users[i] = *decodedUser
-		return users, err
 	}
 	return users, err
 }
 
+// DeleteUser - removes the user with the provided username
+func (b *CacheAuthBackend) DeleteUser(username string) error {
+	return b.userCache.Delete([]byte(username))
+}
+
 func logUserError(err error, username string) {
 	log.WithFields(log.Fields{
 		"error":    err.Error(),
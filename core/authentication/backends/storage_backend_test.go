@@ -0,0 +1,40 @@
+package backends
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/cache"
+	. "github.com/onsi/gomega"
+)
+
+func Test_CacheAuthBackend_GetAllUsers_ReturnsEveryUser(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
+	Expect(unit.AddUser("admin", "password", true)).To(Succeed())
+	Expect(unit.AddUser("bob", "password", false)).To(Succeed())
+
+	users, err := unit.GetAllUsers()
+	Expect(err).To(BeNil())
+
+	Expect(users).To(HaveLen(2))
+	usernames := []string{users[0].Username, users[1].Username}
+	Expect(usernames).To(ConsistOf("admin", "bob"))
+}
+
+func Test_CacheAuthBackend_DeleteUser_RemovesTheUser(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
+	Expect(unit.AddUser("admin", "password", true)).To(Succeed())
+	Expect(unit.AddUser("bob", "password", false)).To(Succeed())
+
+	Expect(unit.DeleteUser("bob")).To(Succeed())
+
+	_, err := unit.GetUser("bob")
+	Expect(err).ToNot(BeNil())
+
+	users, err := unit.GetAllUsers()
+	Expect(err).To(BeNil())
+	Expect(users).To(HaveLen(1))
+}
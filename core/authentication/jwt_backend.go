@@ -15,13 +15,11 @@ import (
 type JWTAuthenticationBackend struct {
 	SecretKey          []byte
 	JWTExpirationDelta int
-	AuthBackend        backends.Authentication
+	// RefreshWindow - seconds after a token expires during which it can still be refreshed
+	RefreshWindow int
+	AuthBackend   backends.Authentication
 }
 
-const (
-	expireOffset = 3600
-)
-
 //Token - container for jwt.Token for encoding
 type Token struct {
 	Token *jwt.Token
@@ -37,18 +35,19 @@ func (t *Token) Encode() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func InitJWTAuthenticationBackend(ab backends.Authentication, secret []byte, exp int) *JWTAuthenticationBackend {
+func InitJWTAuthenticationBackend(ab backends.Authentication, secret []byte, exp, refreshWindow int) *JWTAuthenticationBackend {
 	return &JWTAuthenticationBackend{
 		SecretKey:          secret,
 		AuthBackend:        ab,
 		JWTExpirationDelta: exp,
+		RefreshWindow:      refreshWindow,
 	}
 }
 
 func (backend *JWTAuthenticationBackend) GenerateToken(userUUID, username string) (string, error) {
 	token := jwt.New(jwt.SigningMethodHS512)
 	claims := token.Claims.(jwt.MapClaims)
-	claims["exp"] = time.Now().Add(time.Hour * time.Duration(backend.JWTExpirationDelta)).Unix()
+	claims["exp"] = time.Now().Add(time.Second * time.Duration(backend.JWTExpirationDelta)).Unix()
 	claims["iat"] = time.Now().Unix()
 	claims["username"] = username
 	claims["sub"] = userUUID
@@ -83,15 +82,20 @@ func (backend *JWTAuthenticationBackend) Authenticate(user *backends.User) bool
 	return user.Username == dbUser.Username && bcrypt.CompareHashAndPassword([]byte(dbUser.Password), []byte(user.Password)) == nil
 }
 
+// getTokenRemainingValidity - returns the seconds left until a token with the given expiry can no longer be
+// refreshed, which is negative once the refresh window has passed
 func (backend *JWTAuthenticationBackend) getTokenRemainingValidity(timestamp interface{}) int {
 	if validity, ok := timestamp.(float64); ok {
 		tm := time.Unix(int64(validity), 0)
 		remainer := tm.Sub(time.Now())
-		if remainer > 0 {
-			return int(remainer.Seconds() + expireOffset)
-		}
+		return int(remainer.Seconds()) + backend.RefreshWindow
 	}
-	return expireOffset
+	return 0
+}
+
+// CanRefresh - whether a token with the given expiry is still within its refresh window
+func (backend *JWTAuthenticationBackend) CanRefresh(timestamp interface{}) bool {
+	return backend.getTokenRemainingValidity(timestamp) > 0
 }
 
 func (backend *JWTAuthenticationBackend) Logout(tokenString string) error {
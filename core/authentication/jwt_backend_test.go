@@ -2,10 +2,12 @@ package authentication_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/authentication"
 	"github.com/SpectoLabs/hoverfly/core/authentication/backends"
 	"github.com/SpectoLabs/hoverfly/core/cache"
+	"github.com/golang-jwt/jwt/v4"
 	. "github.com/onsi/gomega"
 )
 
@@ -13,11 +15,25 @@ func TestGenerateToken(t *testing.T) {
 	RegisterTestingT(t)
 
 	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
-	jwtBackend := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), 100)
+	jwtBackend := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), 100, 0)
 
 	Expect(jwtBackend.GenerateToken("userUUIDhereVeryLong", "userx")).ToNot(BeEmpty())
 }
 
+func TestGenerateToken_ExpiresAfterTheExpirationDeltaInSeconds(t *testing.T) {
+	RegisterTestingT(t)
+
+	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
+	jwtBackend := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), 100, 0)
+
+	tokenString, err := jwtBackend.GenerateToken("userUUIDhereVeryLong", "userx")
+	Expect(err).To(BeNil())
+
+	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
+	Expect(err).To(BeNil())
+	Expect(token.Claims.(jwt.MapClaims)["exp"]).To(BeNumerically("~", time.Now().Add(100*time.Second).Unix(), 1))
+}
+
 func TestAuthenticate(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -25,7 +41,7 @@ func TestAuthenticate(t *testing.T) {
 	username := "beloveduser"
 	passw := "12345"
 	ab.AddUser(username, passw, true)
-	jwtBackend := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), 100)
+	jwtBackend := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), 100, 0)
 	user := &backends.User{
 		Username: string(username),
 		Password: string(passw),
@@ -40,7 +56,7 @@ func TestAuthenticateFail(t *testing.T) {
 
 	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
 
-	jwtBackend := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), 100)
+	jwtBackend := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), 100, 0)
 	user := &backends.User{
 		Username: "shouldntbehere",
 		Password: "secret",
@@ -55,7 +71,7 @@ func TestLogout(t *testing.T) {
 
 	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
 
-	jwtBackend := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), 100)
+	jwtBackend := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), 100, 0)
 
 	tokenString := "exampletokenstring"
 
@@ -69,7 +85,7 @@ func TestNotBlacklisted(t *testing.T) {
 	RegisterTestingT(t)
 
 	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
-	jwtBackend := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), 100)
+	jwtBackend := authentication.InitJWTAuthenticationBackend(ab, []byte("verysecret"), 100, 0)
 
 	tokenString := "exampleTokenStringThatIsNotBlacklisted"
 
@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/boltdb/bolt"
+	"github.com/hashicorp/golang-lru/simplelru"
+	log "github.com/sirupsen/logrus"
+)
+
+// FastCacheCodec - converts FastCache values to and from bytes so that they can be persisted
+type FastCacheCodec interface {
+	Encode(value interface{}) ([]byte, error)
+	Decode(data []byte) (interface{}, error)
+}
+
+// BoltDBFastCache - FastCache which persists its entries to a BoltDB bucket, so that they survive a restart.
+// It holds a fixed number of entries, the least recently used entry is removed from the bucket once it is full.
+type BoltDBFastCache struct {
+	cache *BoltCache
+	codec FastCacheCodec
+
+	// keys - tracks which entries were used least recently, the values themselves are only held in the bucket
+	keys *simplelru.LRU
+	size int
+	mu   sync.Mutex
+}
+
+// NewBoltDBFastCache - returns a FastCache storing up to size entries in the given bucket, encoded with the codec.
+// Entries left in the bucket by an earlier run are kept, apart from those over the size.
+func NewBoltDBFastCache(db *bolt.DB, bucket []byte, codec FastCacheCodec, size int) (*BoltDBFastCache, error) {
+	keys, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &BoltDBFastCache{
+		cache: NewBoltDBCache(db, bucket),
+		codec: codec,
+		keys:  keys,
+		size:  size,
+	}
+
+	stored, err := c.cache.GetAllKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	var evicted [][]byte
+	for key := range stored {
+		if c.keys.Len() == size {
+			evicted = append(evicted, []byte(key))
+			continue
+		}
+		c.keys.Add(key, nil)
+	}
+
+	if len(evicted) > 0 {
+		err = db.Update(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket(c.cache.CurrentBucket)
+			for _, key := range evicted {
+				if err := bucket.Delete(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (c *BoltDBFastCache) Set(key, value interface{}) error {
+	data, err := c.codec.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	storedKey := fmt.Sprint(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var evicted interface{}
+	if !c.keys.Contains(storedKey) && c.keys.Len() == c.size {
+		evicted, _, _ = c.keys.GetOldest()
+	}
+
+	err = c.cache.DS.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(c.cache.CurrentBucket)
+		if err != nil {
+			return err
+		}
+		if evicted != nil {
+			if err := bucket.Delete([]byte(evicted.(string))); err != nil {
+				return err
+			}
+		}
+		return bucket.Put([]byte(storedKey), data)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.keys.Add(storedKey, nil)
+
+	return nil
+}
+
+func (c *BoltDBFastCache) Get(key interface{}) (interface{}, bool) {
+	storedKey := fmt.Sprint(key)
+
+	c.mu.Lock()
+	c.keys.Get(storedKey)
+	c.mu.Unlock()
+
+	data, err := c.cache.Get([]byte(storedKey))
+	if err != nil {
+		return nil, false
+	}
+
+	value, err := c.codec.Decode(data)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+			"key":   key,
+		}).Warn("Failed to decode cache entry")
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (c *BoltDBFastCache) GetAllEntries() (map[interface{}]interface{}, error) {
+	stored, err := c.cache.GetAllEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[interface{}]interface{}, len(stored))
+	for key, data := range stored {
+		value, err := c.codec.Decode(data)
+		if err != nil {
+			return nil, err
+		}
+		entries[key] = value
+	}
+
+	return entries, nil
+}
+
+func (c *BoltDBFastCache) RecordsCount() (int, error) {
+	return c.cache.RecordsCount()
+}
+
+func (c *BoltDBFastCache) Delete(key interface{}) error {
+	storedKey := fmt.Sprint(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.keys.Remove(storedKey)
+
+	return c.cache.Delete([]byte(storedKey))
+}
+
+// DeleteData - removes the bucket with all entries, an empty cache has no bucket to remove
+func (c *BoltDBFastCache) DeleteData() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.keys.Purge()
+
+	err := c.cache.DS.Update(func(tx *bolt.Tx) error {
+		err := tx.DeleteBucket(c.cache.CurrentBucket)
+		if err == bolt.ErrBucketNotFound {
+			return nil
+		}
+		return err
+	})
+	return err
+}
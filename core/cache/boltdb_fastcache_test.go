@@ -0,0 +1,188 @@
+package cache_test
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/cache"
+	. "github.com/onsi/gomega"
+)
+
+type stringCodec struct{}
+
+func (stringCodec) Encode(value interface{}) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("not a string")
+	}
+	return []byte(s), nil
+}
+
+func (stringCodec) Decode(data []byte) (interface{}, error) {
+	return string(data), nil
+}
+
+func Test_BoltDBFastCache_SetAndGet(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastSetAndGet"), stringCodec{}, 1000)
+
+	Expect(unit.Set("key", "value")).To(Succeed())
+
+	value, found := unit.Get("key")
+	Expect(found).To(BeTrue())
+	Expect(value).To(Equal("value"))
+}
+
+func Test_BoltDBFastCache_Get_NonExistingKey(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastNonExisting"), stringCodec{}, 1000)
+
+	value, found := unit.Get("should not be here")
+	Expect(found).To(BeFalse())
+	Expect(value).To(BeNil())
+}
+
+func Test_BoltDBFastCache_Set_ReturnsEncodingError(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastEncodingError"), stringCodec{}, 1000)
+
+	Expect(unit.Set("key", 1)).To(MatchError("not a string"))
+}
+
+func Test_BoltDBFastCache_EntriesArePersistedInTheBucket(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastPersisted"), stringCodec{}, 1000)
+	unit.Set("key", "value")
+
+	reopened, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastPersisted"), stringCodec{}, 1000)
+
+	entries, err := reopened.GetAllEntries()
+	Expect(err).To(BeNil())
+	Expect(entries).To(Equal(map[interface{}]interface{}{"key": "value"}))
+}
+
+func Test_BoltDBFastCache_Delete(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastDelete"), stringCodec{}, 1000)
+	unit.Set("key1", "value1")
+	unit.Set("key2", "value2")
+
+	Expect(unit.Delete("key1")).To(Succeed())
+
+	_, found := unit.Get("key1")
+	Expect(found).To(BeFalse())
+
+	value, found := unit.Get("key2")
+	Expect(found).To(BeTrue())
+	Expect(value).To(Equal("value2"))
+}
+
+func Test_BoltDBFastCache_DeleteData(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastDeleteData"), stringCodec{}, 1000)
+	unit.Set("key1", "value1")
+	unit.Set("key2", "value2")
+
+	Expect(unit.DeleteData()).To(Succeed())
+
+	count, err := unit.RecordsCount()
+	Expect(err).To(BeNil())
+	Expect(count).To(Equal(0))
+
+	_, found := unit.Get("key1")
+	Expect(found).To(BeFalse())
+}
+
+func Test_BoltDBFastCache_DeleteData_SucceedsWhenEmpty(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastDeleteEmpty"), stringCodec{}, 1000)
+
+	Expect(unit.DeleteData()).To(Succeed())
+}
+
+func Test_BoltDBFastCache_IsSafeForConcurrentUse(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastConcurrent"), stringCodec{}, 1000)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := strconv.Itoa(i)
+			unit.Set(key, key)
+			unit.Get(key)
+		}(i)
+	}
+	wg.Wait()
+
+	count, err := unit.RecordsCount()
+	Expect(err).To(BeNil())
+	Expect(count).To(Equal(20))
+}
+
+func Test_BoltDBFastCache_NewBoltDBFastCache_RejectsNonPositiveSize(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := cache.NewBoltDBFastCache(TestDB, []byte("fastNoSize"), stringCodec{}, 0)
+	Expect(err).ToNot(BeNil())
+}
+
+func Test_BoltDBFastCache_Set_EvictsTheLeastRecentlyUsedEntryWhenFull(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastEviction"), stringCodec{}, 2)
+	unit.Set("key1", "value1")
+	unit.Set("key2", "value2")
+	unit.Get("key1")
+	unit.Set("key3", "value3")
+
+	entries, err := unit.GetAllEntries()
+	Expect(err).To(BeNil())
+	Expect(entries).To(Equal(map[interface{}]interface{}{"key1": "value1", "key3": "value3"}))
+}
+
+func Test_BoltDBFastCache_Set_DoesNotEvictWhenReplacingAnEntry(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastReplace"), stringCodec{}, 2)
+	unit.Set("key1", "value1")
+	unit.Set("key2", "value2")
+	unit.Set("key2", "updated")
+
+	entries, err := unit.GetAllEntries()
+	Expect(err).To(BeNil())
+	Expect(entries).To(Equal(map[interface{}]interface{}{"key1": "value1", "key2": "updated"}))
+}
+
+func Test_BoltDBFastCache_NewBoltDBFastCache_DropsPersistedEntriesOverTheSize(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, _ := cache.NewBoltDBFastCache(TestDB, []byte("fastReopenSmaller"), stringCodec{}, 3)
+	unit.Set("key1", "value1")
+	unit.Set("key2", "value2")
+	unit.Set("key3", "value3")
+
+	reopened, err := cache.NewBoltDBFastCache(TestDB, []byte("fastReopenSmaller"), stringCodec{}, 2)
+	Expect(err).To(BeNil())
+
+	count, err := reopened.RecordsCount()
+	Expect(err).To(BeNil())
+	Expect(count).To(Equal(2))
+
+	reopened.Set("key4", "value4")
+
+	count, err = reopened.RecordsCount()
+	Expect(err).To(BeNil())
+	Expect(count).To(Equal(2))
+}
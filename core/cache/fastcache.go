@@ -6,5 +6,6 @@ type FastCache interface {
 	Get(key interface{}) (interface{}, bool)
 	GetAllEntries() (map[interface{}]interface{}, error)
 	RecordsCount() (int, error)
+	Delete(key interface{}) error
 	DeleteData() error
 }
@@ -34,8 +34,9 @@ func (c *LRUFastCache) Get(key interface{}) (value interface{}, found bool) {
 func (c *LRUFastCache) GetAllEntries() (map[interface{}]interface{}, error) {
 	entries := make(map[interface{}]interface{}, c.cache.Len())
 
+	// Peek so that listing the cache does not change which entries are evicted next
 	for _, key := range c.cache.Keys() {
-		value, _ := c.cache.Get(key)
+		value, _ := c.cache.Peek(key)
 		entries[key] = value
 	}
 
@@ -46,6 +47,11 @@ func (c *LRUFastCache) RecordsCount() (count int, err error) {
 	return c.cache.Len(), nil
 }
 
+func (c *LRUFastCache) Delete(key interface{}) (err error) {
+	c.cache.Remove(key)
+	return nil
+}
+
 func (c *LRUFastCache) DeleteData() (err error) {
 	c.cache.Purge()
 	return nil
@@ -84,6 +84,24 @@ func Test_LRUFastCache_GetRecordCount(t *testing.T) {
 	Expect(recordCount).To(Equal(2))
 }
 
+func Test_LRUFastCache_Delete(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := cache.NewDefaultLRUCache()
+
+	unit.Set(testKey1, testValue1)
+	unit.Set(testKey2, testValue2)
+
+	Expect(unit.Delete(testKey1)).To(Succeed())
+
+	_, found := unit.Get(testKey1)
+	Expect(found).To(BeFalse())
+
+	value, found := unit.Get(testKey2)
+	Expect(found).To(BeTrue())
+	Expect(value).To(Equal(testValue2))
+}
+
 func Test_LRUFastCache_DeleteData(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -99,3 +117,58 @@ func Test_LRUFastCache_DeleteData(t *testing.T) {
 
 	Expect(recordCount).To(Equal(0))
 }
+
+func Test_LRUFastCache_EvictsLeastRecentlyUsedEntryWhenFull(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, err := cache.NewLRUCache(2)
+	Expect(err).To(BeNil())
+
+	unit.Set("one", 1)
+	unit.Set("two", 2)
+
+	_, found := unit.Get("one")
+	Expect(found).To(BeTrue())
+
+	unit.Set("three", 3)
+
+	_, found = unit.Get("two")
+	Expect(found).To(BeFalse())
+
+	_, found = unit.Get("one")
+	Expect(found).To(BeTrue())
+
+	_, found = unit.Get("three")
+	Expect(found).To(BeTrue())
+
+	recordCount, err := unit.RecordsCount()
+	Expect(err).To(BeNil())
+	Expect(recordCount).To(Equal(2))
+}
+
+func Test_LRUFastCache_GetAllEntriesDoesNotChangeEvictionOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, err := cache.NewLRUCache(2)
+	Expect(err).To(BeNil())
+
+	unit.Set("one", 1)
+	unit.Set("two", 2)
+
+	unit.GetAllEntries()
+
+	unit.Set("three", 3)
+
+	_, found := unit.Get("one")
+	Expect(found).To(BeFalse())
+
+	_, found = unit.Get("two")
+	Expect(found).To(BeTrue())
+}
+
+func Test_NewLRUCache_ReturnsErrorForInvalidSize(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := cache.NewLRUCache(0)
+	Expect(err).ToNot(BeNil())
+}
@@ -0,0 +1,173 @@
+package hoverfly
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/boltdb/bolt"
+	log "github.com/sirupsen/logrus"
+)
+
+// RequestCacheBucketName - BoltDB bucket holding the persisted request cache
+const RequestCacheBucketName = "requestCache"
+
+// SimulationPairsBucketName - BoltDB bucket holding the persisted simulation pairs, keyed by their position
+const SimulationPairsBucketName = "simulationPairs"
+
+// OpenCacheDatabase returns the BoltDB database the request cache and the simulation pairs are persisted to. It is
+// nil when no cache database path is set.
+func OpenCacheDatabase(cfg *Configuration) (*bolt.DB, error) {
+	if cfg.CacheDatabasePath == "" {
+		return nil, nil
+	}
+
+	db, err := bolt.Open(cfg.CacheDatabasePath, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open cache database %s: %s", cfg.CacheDatabasePath, err.Error())
+	}
+
+	return db, nil
+}
+
+// UseCacheDatabase - loads the simulation pairs persisted by an earlier run and persists the pairs from now on. When
+// the persisted pairs cannot be read they are left as they are, and nothing is persisted.
+func (hf *Hoverfly) UseCacheDatabase(db *bolt.DB) error {
+	views, err := getPersistedPairs(db)
+	if err != nil {
+		return err
+	}
+	hf.cacheDB = db
+
+	for i := range views {
+		pair := models.NewRequestMatcherResponsePairFromView(&views[i])
+		if hf.Cfg != nil && hf.Cfg.NoImportCheck {
+			hf.Simulation.AddPairWithoutCheck(pair)
+		} else {
+			hf.Simulation.AddPair(pair)
+		}
+	}
+
+	if len(views) > 0 {
+		log.WithFields(log.Fields{
+			"pairs": len(views),
+		}).Info("Loaded simulation pairs from the cache database")
+	}
+
+	return nil
+}
+
+// CloseCacheDatabase - closes the cache database, it is a no-op when there is none
+func (hf *Hoverfly) CloseCacheDatabase() error {
+	hf.persistMu.Lock()
+	defer hf.persistMu.Unlock()
+
+	if hf.cacheDB == nil {
+		return nil
+	}
+
+	err := hf.cacheDB.Close()
+	hf.cacheDB = nil
+	return err
+}
+
+func getPersistedPairs(db *bolt.DB) ([]v2.RequestMatcherResponsePairViewV5, error) {
+	var views []v2.RequestMatcherResponsePairViewV5
+
+	err := db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(SimulationPairsBucketName))
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(key, data []byte) error {
+			var view v2.RequestMatcherResponsePairViewV5
+			if err := json.Unmarshal(data, &view); err != nil {
+				return fmt.Errorf("Unable to read persisted simulation pair: %s", err.Error())
+			}
+			views = append(views, view)
+			return nil
+		})
+	})
+
+	return views, err
+}
+
+// persistPair - appends a pair added to the end of the simulation, so a capture session does not rewrite every pair
+func (hf *Hoverfly) persistPair(pair *models.RequestMatcherResponsePair) {
+	if hf.cacheDB == nil {
+		return
+	}
+
+	hf.persistMu.Lock()
+	defer hf.persistMu.Unlock()
+
+	err := hf.cacheDB.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(SimulationPairsBucketName))
+		if err != nil {
+			return err
+		}
+		return putPersistedPair(bucket, pair)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Warn("Failed to persist simulation pair")
+	}
+}
+
+// persistPairs - replaces the persisted pairs with the pairs in the simulation
+func (hf *Hoverfly) persistPairs() {
+	if hf.cacheDB == nil {
+		return
+	}
+
+	hf.persistMu.Lock()
+	defer hf.persistMu.Unlock()
+
+	pairs := hf.Simulation.GetMatchingPairs()
+
+	err := hf.cacheDB.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket([]byte(SimulationPairsBucketName)); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
+		bucket, err := tx.CreateBucket([]byte(SimulationPairsBucketName))
+		if err != nil {
+			return err
+		}
+
+		for i := range pairs {
+			if err := putPersistedPair(bucket, &pairs[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Warn("Failed to persist simulation pairs")
+	}
+}
+
+func putPersistedPair(bucket *bolt.Bucket, pair *models.RequestMatcherResponsePair) error {
+	data, err := json.Marshal(pair.BuildView())
+	if err != nil {
+		return err
+	}
+
+	sequence, err := bucket.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	// big endian keys keep the cursor in the order the pairs were added
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, sequence)
+
+	return bucket.Put(key, data)
+}
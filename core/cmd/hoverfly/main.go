@@ -27,7 +27,10 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/SpectoLabs/goproxy"
@@ -40,7 +43,9 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/matching"
 	mw "github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/SpectoLabs/hoverfly/core/templating"
 	"github.com/SpectoLabs/hoverfly/core/util"
+	"github.com/mitchellh/go-homedir"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -83,6 +88,8 @@ var (
 	destination   = flag.String("destination", ".", "Control which URLs Hoverfly should intercept and process, it can be string or regex")
 	webserver     = flag.Bool("webserver", false, "Start Hoverfly in webserver mode (simulate mode)")
 
+	adminOnProxyPort = flag.Bool("admin-on-proxy-port", false, "Serve the admin API on the proxy port under the /__hoverfly path prefix instead of on a separate admin port")
+
 	addNew          = flag.Bool("add", false, "Add new user '-add -username hfadmin -password hfpass'")
 	addUser         = flag.String("username", "", "Username for new user")
 	addPassword     = flag.String("password", "", "Password for new user")
@@ -90,16 +97,24 @@ var (
 	isAdmin         = flag.Bool("admin", true, "Supply '-admin=false' to make this non admin user")
 	authEnabled     = flag.Bool("auth", false, "Enable authentication")
 
+	tokenExpiration    = flag.Duration("token-expiration", hv.DefaultJWTExpirationDelta*time.Second, "How long admin API tokens are valid for, eg. 15m. Overrides the HoverflyTokenExpiration environment variable")
+	tokenRefreshWindow = flag.Duration("token-refresh-window", hv.DefaultJWTRefreshWindow*time.Second, "How long after expiring an admin API token can still be exchanged for a new one at /api/refresh-token-auth, eg. 5m")
+	failedLoginLimit   = flag.Int("failed-login-limit", hv.DefaultFailedLoginLimit, "Number of failed logins to the admin API after which a username is locked out")
+	failedLoginLockout = flag.Duration("failed-login-lockout", hv.DefaultFailedLoginLockout, "How long a username is locked out for after too many failed logins, restarted by every attempt made while locked out")
+
 	generateCA = flag.Bool("generate-ca-cert", false, "Generate CA certificate and private key for MITM")
 	certName   = flag.String("cert-name", "hoverfly.proxy", "Cert name")
 	certOrg    = flag.String("cert-org", "Hoverfly Authority", "Organisation name for new cert")
-	cert       = flag.String("cert", "", "CA certificate used to sign MITM certificates")
-	key        = flag.String("key", "", "Private key of the CA used to sign MITM certificates")
+	cert       = flag.String("cert", "", "Path to the CA certificate used to sign MITM certificates, or the PEM encoded certificate itself")
+	key        = flag.String("key", "", "Path to the private key of the CA used to sign MITM certificates, or the PEM encoded key itself")
 
 	tlsVerification    = flag.Bool("tls-verification", true, "Turn on/off tls verification for outgoing requests (will not try to verify certificates)")
+	upstreamCABundle   = flag.String("upstream-ca-bundle", "", "Path to a PEM file of CA certificates trusted, in addition to the system roots, when verifying outgoing requests")
 	plainHttpTunneling = flag.Bool("plain-http-tunneling", false, "Use plain http tunneling to host with non-443 port")
+	http2              = flag.Bool("http2", false, "Negotiate HTTP/2 with clients over HTTPS connections intercepted by the proxy")
 
-	upstreamProxy = flag.String("upstream-proxy", "", "Specify an upstream proxy for hoverfly to route traffic through")
+	upstreamProxy   = flag.String("upstream-proxy", "", "Specify an upstream proxy for hoverfly to route traffic through")
+	upstreamTimeout = flag.Duration("upstream-timeout", hv.DefaultUpstreamTimeout, "Time given to a destination to respond to a request, including sending the body, before it fails with 504 Gateway Timeout, eg. 1m (0 is no timeout)")
 
 	databasePath = flag.String("db-path", "", "A path to a BoltDB file with persisted user and token data for authentication (DEPRECATED)")
 	database     = flag.String("db", inmemoryBackend, "Storage to use - 'boltdb' or 'memory' which will not write anything to disk (DEPRECATED)")
@@ -111,16 +126,31 @@ var (
 	logNoColor = flag.Bool("log-no-color", false, "Disable colors for logging")
 
 	journalSize   = flag.Int("journal-size", 1000, "Set the size of request/response journal")
-	cacheSize     = flag.Int("cache-size", 1000, "Set the size of request/response cache")
+	cacheSize     = flag.Int("cache-size", 1000, "Set the maximum number of entries in the request/response cache, the least recently used entries are evicted once it is full")
+	cacheDBPath   = flag.String("cache-db-path", "", "A path to a BoltDB file to persist the simulation pairs and the request/response cache to, so that they survive a restart")
 	cors          = flag.Bool("cors", false, "Enable CORS support")
 	noImportCheck = flag.Bool("no-import-check", false, "Skip duplicate request check when importing simulations")
 
+	middlewareTimeout          = flag.Duration("middleware-timeout", 0, "Time given to middleware to process a request or response before it is treated as failed, eg. 10s (default is no timeout)")
+	middlewareRemoteAttempts   = flag.Int("middleware-remote-attempts", 1, "Number of times to send a payload to remote middleware before it is treated as failed")
+	middlewareRemoteRetryDelay = flag.Duration("middleware-remote-retry-delay", 100*time.Millisecond, "Time to wait before retrying remote middleware, doubled after each retry")
+
+	shutdownGracePeriod     = flag.Duration("shutdown-grace-period", hv.DefaultShutdownGracePeriod, "Time given to in-flight proxy requests to complete when Hoverfly is stopped")
+	randomSeed              = flag.Int64("random-seed", 0, "Seed the random templating helpers and faker so that templated responses are the same on every run (unseeded by default)")
+	requestLog              = flag.String("request-log", "", "Path to a file which every request served by the proxy is appended to as line-delimited JSON (disabled by default)")
+	matchedPairHeaders      = flag.Bool("matched-pair-headers", false, "Add Hoverfly-Matched and Hoverfly-Pair-Index headers to simulated responses, showing which pair in the simulation served the request")
+	compressResponses       = flag.Bool("compress-responses", false, "Gzip simulated responses when the request has an Accept-Encoding header which includes gzip")
+	preserveContentEncoding = flag.Bool("capture-preserve-encoding", false, "Store gzip and deflate encoded responses as the original compressed bytes in capture mode, instead of decompressing them")
+
 	pacFile = flag.String("pac-file", "", "Path to the pac file to be imported on startup")
 
+	simulationDirectory = flag.String("simulation-dir", "", "Directory to load a simulation from on startup. If -simulation is not set, the most recently modified simulation in the directory is loaded")
+	simulationName      = flag.String("simulation", "", "Name of a simulation file to load on startup from -simulation-dir, or from the .hoverfly directory in the home directory if -simulation-dir is not set")
+
 	clientAuthenticationDestination = flag.String("client-authentication-destination", "", "Regular expression of destination with client authentication")
-	clientAuthenticationClientCert  = flag.String("client-authentication-client-cert", "", "Path to the client certification file used for authentication")
-	clientAuthenticationClientKey   = flag.String("client-authentication-client-key", "", "Path to the client key file used for authentication")
-	clientAuthenticationCACert      = flag.String("client-authentication-ca-cert", "", "Path to the ca cert file used for authentication")
+	clientAuthenticationClientCert  = flag.String("client-authentication-client-cert", "", "Path to the client certificate file used for authentication, or the PEM encoded certificate itself")
+	clientAuthenticationClientKey   = flag.String("client-authentication-client-key", "", "Path to the client key file used for authentication, or the PEM encoded key itself")
+	clientAuthenticationCACert      = flag.String("client-authentication-ca-cert", "", "Path to the ca cert file used for authentication, or the PEM encoded certificate itself")
 )
 
 var CA_CERT = []byte(`-----BEGIN CERTIFICATE-----
@@ -184,6 +214,15 @@ func init() {
 	goproxy.GoproxyCa = tlsc
 }
 
+// describeCertificateFlag - returns the path given to a certificate or key flag, so that it can be logged
+// without logging the certificate or key itself when it is given as PEM
+func describeCertificateFlag(value string) string {
+	if strings.Contains(value, "-----BEGIN") {
+		return "PEM"
+	}
+	return value
+}
+
 func isFlagPassed(name string) bool {
 	found := false
 	flag.Visit(func(f *flag.Flag) {
@@ -197,7 +236,7 @@ func isFlagPassed(name string) bool {
 func main() {
 	hoverfly := hv.NewHoverfly()
 
-	flag.Var(&importFlags, "import", "Import from file or from URL (i.e. '-import my_service.json' or '-import http://mypage.com/service_x.json'")
+	flag.Var(&importFlags, "import", "Import from file, URL or S3/GCS URI (i.e. '-import my_service.json', '-import http://mypage.com/service_x.json' or '-import s3://bucket/service_x.json'")
 	flag.Var(&destinationFlags, "dest", "Specify which hosts to process (i.e. '-dest fooservice.org -dest barservice.org -dest catservice.org') - other hosts will be ignored will passthrough'")
 	flag.Var(&logOutputFlags, "logs-output", "Specify locations for output logs, options are \"console\" and \"file\" (default \"console\")")
 	flag.StringVar(&responseBodyFilesPath, "response-body-files-path", "", "When a response contains a relative bodyFile, it will be resolved against this path (default is CWD)")
@@ -326,7 +365,7 @@ func main() {
 		goproxy.GoproxyCa = *tlsc
 
 	} else if *cert != "" && *key != "" {
-		tlsc, err := tls.LoadX509KeyPair(*cert, *key)
+		tlsc, err := hv.LoadCACertificate(*cert, *key)
 		if err != nil {
 			log.WithFields(log.Fields{
 				"error": err.Error(),
@@ -336,8 +375,8 @@ func main() {
 		goproxy.GoproxyCa = tlsc
 
 		log.WithFields(log.Fields{
-			"certificate": *cert,
-			"key":         *key,
+			"certificate": describeCertificateFlag(*cert),
+			"key":         describeCertificateFlag(*key),
 		}).Info("Default keys have been overwritten")
 	}
 
@@ -370,11 +409,26 @@ func main() {
 		cfg.SetUpstreamProxy(*upstreamProxy)
 
 		log.WithFields(log.Fields{
-			"url": *upstreamProxy,
+			"url": util.RedactURL(cfg.UpstreamProxy),
 		}).Info("Upstream proxy has been set")
 	}
 
+	if *upstreamTimeout < 0 {
+		log.WithFields(log.Fields{
+			"upstream-timeout": *upstreamTimeout,
+		}).Fatal("Upstream timeout must not be negative")
+	}
+	cfg.UpstreamTimeout = *upstreamTimeout
+
 	cfg.PlainHttpTunneling = *plainHttpTunneling
+	cfg.HTTP2 = *http2
+	cfg.AdminOnProxyPort = *adminOnProxyPort
+	cfg.PreserveContentEncoding = *preserveContentEncoding
+	cfg.CompressResponses = *compressResponses
+	cfg.MatchedPairHeaders = *matchedPairHeaders
+	cfg.RequestLogPath = *requestLog
+	cfg.RandomSeed = *randomSeed
+	cfg.ShutdownGracePeriod = *shutdownGracePeriod
 
 	if *cors {
 		cfg.CORS = *cs.DefaultCORSConfigs()
@@ -397,6 +451,9 @@ func main() {
 		log.Error(err.Error())
 	}
 	cfg.Middleware = *newMiddleware
+	cfg.Middleware.Timeout = *middlewareTimeout
+	cfg.Middleware.RemoteAttempts = *middlewareRemoteAttempts
+	cfg.Middleware.RemoteRetryDelay = *middlewareRemoteRetryDelay
 
 	mode := getInitialMode(cfg)
 
@@ -408,6 +465,35 @@ func main() {
 		cfg.AuthEnabled = true
 	}
 
+	if isFlagPassed("token-expiration") {
+		if *tokenExpiration < time.Second {
+			log.WithFields(log.Fields{
+				"token-expiration": *tokenExpiration,
+			}).Fatal("Token expiration must be at least one second")
+		}
+		cfg.JWTExpirationDelta = int(tokenExpiration.Seconds())
+	}
+
+	if *tokenRefreshWindow < 0 {
+		log.WithFields(log.Fields{
+			"token-refresh-window": *tokenRefreshWindow,
+		}).Fatal("Token refresh window must not be negative")
+	}
+	cfg.JWTRefreshWindow = int(tokenRefreshWindow.Seconds())
+
+	if *failedLoginLimit <= 0 {
+		log.WithFields(log.Fields{
+			"failed-login-limit": *failedLoginLimit,
+		}).Fatal("Failed login limit must be a positive number")
+	}
+	if *failedLoginLockout <= 0 {
+		log.WithFields(log.Fields{
+			"failed-login-lockout": *failedLoginLockout,
+		}).Fatal("Failed login lockout must be a positive duration")
+	}
+	cfg.FailedLoginLimit = *failedLoginLimit
+	cfg.FailedLoginLockout = *failedLoginLockout
+
 	// disabling tls verification if flag or env variable is set to 'false' (defaults to true)
 	if !cfg.TLSVerification || !*tlsVerification {
 		cfg.TLSVerification = false
@@ -415,6 +501,17 @@ func main() {
 		log.Info("TLS certificate verification has been disabled")
 	}
 
+	if *upstreamCABundle != "" {
+		rootCAs, err := hv.LoadCABundle(*upstreamCABundle)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error": err.Error(),
+				"path":  *upstreamCABundle,
+			}).Fatal("Failed to load upstream CA bundle")
+		}
+		cfg.UpstreamRootCAs = rootCAs
+	}
+
 	if len(destinationFlags) > 0 {
 		cfg.Destination = strings.Join(destinationFlags[:], "|")
 
@@ -460,17 +557,24 @@ func main() {
 	}
 	cfg.DisableCache = *disableCache
 	cfg.CacheSize = *cacheSize
+	cfg.CacheDatabasePath = *cacheDBPath
 	if cfg.DisableCache {
 		log.Info("Request cache has been disabled")
-	} else {
-		// Request cache is always in-memory
-		requestCache, err = cache.NewLRUCache(cfg.CacheSize)
-		if err != nil {
-			log.WithFields(log.Fields{
-				"error":      err.Error(),
-				"cache-size": cfg.CacheSize,
-			}).Fatal("Failed to create cache")
-		}
+	}
+	cacheDB, err := hv.OpenCacheDatabase(cfg)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error":         err.Error(),
+			"cache-db-path": cfg.CacheDatabasePath,
+		}).Fatal("Failed to open cache database")
+	}
+	requestCache, err = hv.NewRequestCache(cfg, cacheDB)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error":         err.Error(),
+			"cache-size":    cfg.CacheSize,
+			"cache-db-path": cfg.CacheDatabasePath,
+		}).Fatal("Failed to create cache")
 	}
 
 	authBackend := backends.NewCacheBasedAuthBackend(tokenCache, userCache)
@@ -481,7 +585,53 @@ func main() {
 		Webserver:    cfg.Webserver,
 	}
 	hoverfly.Authentication = authBackend
-	hoverfly.HTTP = hv.GetDefaultHoverflyHTTPClient(hoverfly.Cfg.TLSVerification, hoverfly.Cfg.UpstreamProxy)
+	hoverfly.HTTP = hv.GetDefaultHoverflyHTTPClient(hoverfly.Cfg.TLSVerification, hoverfly.Cfg.UpstreamProxy, hoverfly.Cfg.UpstreamRootCAs, hoverfly.Cfg.UpstreamTimeout)
+
+	if cacheDB != nil {
+		if err := hoverfly.UseCacheDatabase(cacheDB); err != nil {
+			log.WithFields(log.Fields{
+				"error":         err.Error(),
+				"cache-db-path": cfg.CacheDatabasePath,
+			}).Fatal("Failed to load simulation pairs from the cache database")
+		}
+
+		// close the cache database when Hoverfly is stopped with a signal, so that writes in progress complete
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-signals
+			log.Warning("Shutting down")
+			hoverfly.StopProxy()
+			hoverfly.CloseCacheDatabase()
+			os.Exit(0)
+		}()
+	}
+
+	if err := hoverfly.LoadClientAuthentication(); err != nil {
+		log.WithFields(log.Fields{
+			"error":       err.Error(),
+			"destination": cfg.ClientAuthenticationDestination,
+		}).Fatal("Failed to load client authentication")
+	}
+
+	if err := hoverfly.LoadRequestLog(); err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+			"path":  cfg.RequestLogPath,
+		}).Fatal("Failed to open request log")
+	}
+	if cfg.RequestLogPath != "" {
+		log.WithFields(log.Fields{
+			"path": cfg.RequestLogPath,
+		}).Info("Request log has been enabled")
+	}
+
+	if cfg.RandomSeed != 0 {
+		templating.SeedRandom(cfg.RandomSeed)
+		log.WithFields(log.Fields{
+			"seed": cfg.RandomSeed,
+		}).Info("Random templating helpers have been seeded")
+	}
 
 	// if add new user supplied - adding it to database
 	if *addNew || *authEnabled {
@@ -554,6 +704,34 @@ func main() {
 		hoverfly.CacheMatcher.PreloadCache(hoverfly.Simulation)
 	}
 
+	// loading a simulation from the simulation directory
+	if *simulationDirectory != "" || *simulationName != "" {
+		directory := *simulationDirectory
+		if directory == "" {
+			homeDirectory, err := homedir.Dir()
+			if err != nil {
+				log.WithFields(log.Fields{
+					"error": err.Error(),
+				}).Fatal("Failed to get home directory to load simulation from")
+			}
+			directory = filepath.Join(homeDirectory, ".hoverfly")
+		}
+
+		err := hoverfly.ImportFromDirectory(directory, *simulationName)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error":      err.Error(),
+				"directory":  directory,
+				"simulation": *simulationName,
+			}).Fatal("Failed to load simulation from directory")
+		}
+		log.WithFields(log.Fields{
+			"directory":  directory,
+			"simulation": *simulationName,
+		}).Info("Loaded simulation from directory")
+		hoverfly.CacheMatcher.PreloadCache(hoverfly.Simulation)
+	}
+
 	// start metrics registry flush
 	if *metrics {
 		hoverfly.Counter.Init()
@@ -579,6 +757,11 @@ func main() {
 		}).Fatal("Failed to start proxy")
 	}
 
+	if cfg.AdminOnProxyPort {
+		// the proxy is already serving the admin API, so there is no admin port to start
+		select {}
+	}
+
 	// starting admin interface, this is blocking
 	adminApi := hv.AdminApi{}
 	adminApi.StartAdminInterface(hoverfly)
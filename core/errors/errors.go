@@ -3,7 +3,8 @@ package errors
 import "github.com/SpectoLabs/hoverfly/core/models"
 
 type HoverflyError struct {
-	Message string
+	Message     string
+	ClosestMiss *models.ClosestMiss
 }
 
 func (err HoverflyError) Error() string {
@@ -29,7 +30,8 @@ func MatchingFailedError(closestMiss *models.ClosestMiss) *HoverflyError {
 		message = message + closestMiss.GetMessage()
 	}
 	return &HoverflyError{
-		Message: message,
+		Message:     message,
+		ClosestMiss: closestMiss,
 	}
 }
 
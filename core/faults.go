@@ -0,0 +1,98 @@
+package hoverfly
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SpectoLabs/goproxy"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/SpectoLabs/hoverfly/core/util"
+	log "github.com/sirupsen/logrus"
+)
+
+var errInjectedFault = errors.New("Hoverfly injected fault")
+
+type connContextKey struct{}
+
+// withConnection stores the client connection in the context of requests read from it, so that
+// reset and truncate faults can close the connection
+func withConnection(ctx context.Context, conn net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, conn)
+}
+
+func connectionFromRequest(req *http.Request) net.Conn {
+	conn, _ := req.Context().Value(connContextKey{}).(net.Conn)
+	return conn
+}
+
+// failingReader returns the injected fault error on every read, which makes the proxy abandon the response
+// when the client connection cannot be closed directly, as with HTTPS requests
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errInjectedFault
+}
+
+// applyFault injects the fault for the request, if there is one and it is chosen by its probability,
+// into a response served from the simulation
+func (hf *Hoverfly) applyFault(req *http.Request, requestDetails models.RequestDetails, result modes.ProcessResult) modes.ProcessResult {
+	fault := hf.Simulation.Faults.GetFault(requestDetails)
+	if fault == nil || !fault.ShouldInject() {
+		return result
+	}
+
+	log.WithFields(log.Fields{
+		"urlPattern": fault.UrlPattern,
+		"type":       fault.Type,
+	}).Info("Injecting fault into the response")
+
+	response := result.Response
+	conn := connectionFromRequest(req)
+
+	switch fault.Type {
+	case models.FaultError:
+		response = goproxy.NewResponse(req, goproxy.ContentTypeText, fault.GetStatus(), errInjectedFault.Error())
+	case models.FaultReset:
+		if conn != nil {
+			conn.Close()
+		}
+		response.Body = ioutil.NopCloser(failingReader{})
+	case models.FaultTruncate:
+		body, _ := util.GetResponseBody(response)
+		truncatedBody := body[:len(body)/2]
+		if conn != nil {
+			// a partial HTTP/2 response cannot be written to the connection, so it is closed instead
+			if req.ProtoMajor == 1 {
+				writeTruncatedResponse(conn, response, body, truncatedBody)
+			}
+			conn.Close()
+		}
+		response.Body = ioutil.NopCloser(io.MultiReader(strings.NewReader(truncatedBody), failingReader{}))
+	}
+
+	return modes.ProcessResult{Response: response, Matched: true}
+}
+
+// writeTruncatedResponse writes the response with the Content-Length of the full body but only part of the body
+func writeTruncatedResponse(conn net.Conn, response *http.Response, body, truncatedBody string) {
+	header := response.Header.Clone()
+	header.Del("Transfer-Encoding")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "HTTP/1.1 %d %s\r\n", response.StatusCode, http.StatusText(response.StatusCode))
+	header.Write(&buffer)
+	buffer.WriteString("\r\n")
+	buffer.WriteString(truncatedBody)
+
+	conn.Write(buffer.Bytes())
+}
@@ -0,0 +1,115 @@
+package hoverfly
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+// testTools writes the body with a new line
+const faultTestBody = "{'message': 'here'}\n"
+
+func faultTestTools(fault v2.FaultView) (*httptest.Server, *Hoverfly) {
+	server, unit := testTools(200, "{'message': 'here'}")
+
+	unit.Cfg.SetMode("capture")
+	unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/faulty", nil))
+	unit.Cfg.SetMode("simulate")
+
+	Expect(unit.SetFaults([]v2.FaultView{fault})).To(Succeed())
+
+	return server, unit
+}
+
+func Test_Hoverfly_processRequest_InjectsAnErrorFault(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := faultTestTools(v2.FaultView{UrlPattern: "somehost.com/faulty", Type: models.FaultError, Status: 503, Probability: 1})
+	defer server.Close()
+
+	response := unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/faulty", nil))
+
+	Expect(response.StatusCode).To(Equal(http.StatusServiceUnavailable))
+	body, _ := ioutil.ReadAll(response.Body)
+	Expect(string(body)).To(Equal("Hoverfly injected fault"))
+}
+
+func Test_Hoverfly_processRequest_DoesNotInjectFaultsIntoMisses(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := faultTestTools(v2.FaultView{UrlPattern: "somehost.com", Type: models.FaultError, Probability: 1})
+	defer server.Close()
+
+	response := unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/missed", nil))
+
+	Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+	body, _ := ioutil.ReadAll(response.Body)
+	Expect(string(body)).To(ContainSubstring("Hoverfly Error!"))
+}
+
+func Test_Hoverfly_processRequest_ResetFaultFailsTheResponseBodyWithoutAConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := faultTestTools(v2.FaultView{UrlPattern: "somehost.com/faulty", Type: models.FaultReset, Probability: 1})
+	defer server.Close()
+
+	response := unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/faulty", nil))
+
+	_, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(Equal(errInjectedFault))
+}
+
+func Test_Hoverfly_processRequest_TruncateFaultFailsTheResponseBodyPartWayWithoutAConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := faultTestTools(v2.FaultView{UrlPattern: "somehost.com/faulty", Type: models.FaultTruncate, Probability: 1})
+	defer server.Close()
+
+	response := unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/faulty", nil))
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(Equal(errInjectedFault))
+	Expect(string(body)).To(Equal(faultTestBody[:len(faultTestBody)/2]))
+}
+
+func Test_Hoverfly_processRequest_ResetFaultClosesTheConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := faultTestTools(v2.FaultView{UrlPattern: "somehost.com/faulty", Type: models.FaultReset, Probability: 1})
+	defer server.Close()
+
+	client, conn := net.Pipe()
+	request := httptest.NewRequest("GET", "http://somehost.com/faulty", nil)
+	go unit.processRequest(request.WithContext(withConnection(request.Context(), conn)))
+
+	_, err := http.ReadResponse(bufio.NewReader(client), request)
+	Expect(err).To(Equal(io.ErrUnexpectedEOF))
+}
+
+func Test_Hoverfly_processRequest_TruncateFaultWritesPartOfTheBodyAndClosesTheConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := faultTestTools(v2.FaultView{UrlPattern: "somehost.com/faulty", Type: models.FaultTruncate, Probability: 1})
+	defer server.Close()
+
+	client, conn := net.Pipe()
+	request := httptest.NewRequest("GET", "http://somehost.com/faulty", nil)
+	go unit.processRequest(request.WithContext(withConnection(request.Context(), conn)))
+
+	response, err := http.ReadResponse(bufio.NewReader(client), request)
+	Expect(err).To(BeNil())
+	Expect(response.StatusCode).To(Equal(http.StatusOK))
+	Expect(response.ContentLength).To(Equal(int64(len(faultTestBody))))
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(Equal(io.ErrUnexpectedEOF))
+	Expect(string(body)).To(Equal(faultTestBody[:len(faultTestBody)/2]))
+}
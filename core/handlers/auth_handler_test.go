@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/authentication/backends"
+	"github.com/SpectoLabs/hoverfly/core/cache"
+	"github.com/go-zoo/bone"
+	. "github.com/onsi/gomega"
+)
+
+func newTestAuthHandler(enabled bool) (*AuthHandler, *bone.Mux) {
+	ab := backends.NewCacheBasedAuthBackend(cache.NewInMemoryCache(), cache.NewInMemoryCache())
+	ab.AddUser("admin", "password", true)
+	ab.AddUser("bob", "password", false)
+
+	unit := &AuthHandler{
+		AB:                 ab,
+		SecretKey:          []byte("secret"),
+		JWTExpirationDelta: 100,
+		JWTRefreshWindow:   60,
+		FailedLoginLimit:   3,
+		FailedLoginLockout: 10 * time.Minute,
+		Enabled:            enabled,
+	}
+
+	mux := bone.New()
+	unit.RegisterRoutes(mux)
+	return unit, mux
+}
+
+func loginForTest(mux *bone.Mux, username string) string {
+	login, _ := http.NewRequest("POST", "/api/token-auth", strings.NewReader(`{"username": "`+username+`", "password": "password"}`))
+	response := httptest.NewRecorder()
+	mux.ServeHTTP(response, login)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	var token struct {
+		Token string `json:"token"`
+	}
+	Expect(json.Unmarshal(response.Body.Bytes(), &token)).To(Succeed())
+	return token.Token
+}
+
+func doAuthHandlerRequest(mux *bone.Mux, method, url, body, token string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(method, url, strings.NewReader(body))
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	response := httptest.NewRecorder()
+	mux.ServeHTTP(response, request)
+	return response
+}
+
+func Test_AuthHandler_GetUsers_ReturnsUsersWithoutPasswords(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, mux := newTestAuthHandler(false)
+
+	request, _ := http.NewRequest("GET", "/api/v2/users", nil)
+	response := httptest.NewRecorder()
+	mux.ServeHTTP(response, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).ToNot(ContainSubstring("password"))
+
+	var users AllUsersResponse
+	Expect(json.Unmarshal(response.Body.Bytes(), &users)).To(Succeed())
+	Expect(users.Users).To(HaveLen(2))
+}
+
+func Test_AuthHandler_GetUsers_RequiresAuthentication(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, mux := newTestAuthHandler(true)
+
+	request, _ := http.NewRequest("GET", "/api/v2/users", nil)
+	response := httptest.NewRecorder()
+	mux.ServeHTTP(response, request)
+
+	Expect(response.Code).To(Equal(http.StatusUnauthorized))
+
+	request, _ = http.NewRequest("GET", "/api/v2/users", nil)
+	request.Header.Set("Authorization", "Bearer "+loginForTest(mux, "admin"))
+	response = httptest.NewRecorder()
+	mux.ServeHTTP(response, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(ContainSubstring(`"username":"bob"`))
+}
+
+func Test_AuthHandler_AddUser_AddsAUserWhoCanLogIn(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, mux := newTestAuthHandler(true)
+
+	response := doAuthHandlerRequest(mux, "POST", "/api/v2/users", `{"username": "carol", "password": "longenough"}`, loginForTest(mux, "admin"))
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(ContainSubstring(`"username":"carol"`))
+
+	user, err := unit.AB.GetUser("carol")
+	Expect(err).To(BeNil())
+	Expect(user.Password).ToNot(Equal("longenough"))
+	Expect(user.IsAdmin).To(BeFalse())
+
+	login := doAuthHandlerRequest(mux, "POST", "/api/token-auth", `{"username": "carol", "password": "longenough"}`, "")
+	Expect(login.Code).To(Equal(http.StatusOK))
+}
+
+func Test_AuthHandler_AddUser_ValidatesTheUser(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, mux := newTestAuthHandler(false)
+
+	response := doAuthHandlerRequest(mux, "POST", "/api/v2/users", `{"username": "carol", "password": "short"}`, "")
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(response.Body.String()).To(ContainSubstring("Password must be at least 8 characters"))
+
+	response = doAuthHandlerRequest(mux, "POST", "/api/v2/users", `{"username": "carolcarol", "password": "carolcarol"}`, "")
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(response.Body.String()).To(ContainSubstring("Password must not be the same as the username"))
+
+	response = doAuthHandlerRequest(mux, "POST", "/api/v2/users", `{"password": "longenough"}`, "")
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(response.Body.String()).To(ContainSubstring("Username is required"))
+
+	response = doAuthHandlerRequest(mux, "POST", "/api/v2/users", `{"username": "bob", "password": "longenough"}`, "")
+	Expect(response.Code).To(Equal(http.StatusConflict))
+	Expect(response.Body.String()).To(ContainSubstring("User bob already exists"))
+}
+
+func Test_AuthHandler_AddUser_RequiresAnAdmin(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, mux := newTestAuthHandler(true)
+
+	response := doAuthHandlerRequest(mux, "POST", "/api/v2/users", `{"username": "carol", "password": "longenough"}`, loginForTest(mux, "bob"))
+	Expect(response.Code).To(Equal(http.StatusForbidden))
+	Expect(response.Body.String()).To(ContainSubstring("Only admin users can manage users"))
+}
+
+func Test_AuthHandler_DeleteUser_DeletesTheUserAndTheirAccess(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, mux := newTestAuthHandler(true)
+	bobToken := loginForTest(mux, "bob")
+
+	response := doAuthHandlerRequest(mux, "DELETE", "/api/v2/users/bob", "", loginForTest(mux, "admin"))
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).ToNot(ContainSubstring(`"username":"bob"`))
+
+	users, _ := unit.AB.GetAllUsers()
+	Expect(users).To(HaveLen(1))
+
+	response = doAuthHandlerRequest(mux, "GET", "/api/v2/users", "", bobToken)
+	Expect(response.Code).To(Equal(http.StatusUnauthorized))
+}
+
+func Test_AuthHandler_DeleteUser_ErrorsWhenTheUserDoesNotExist(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, mux := newTestAuthHandler(false)
+
+	response := doAuthHandlerRequest(mux, "DELETE", "/api/v2/users/carol", "", "")
+	Expect(response.Code).To(Equal(http.StatusNotFound))
+	Expect(response.Body.String()).To(ContainSubstring("User carol does not exist"))
+}
+
+func Test_AuthHandler_DeleteUser_DoesNotDeleteTheLastAdmin(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, mux := newTestAuthHandler(false)
+
+	response := doAuthHandlerRequest(mux, "DELETE", "/api/v2/users/admin", "", "")
+	Expect(response.Code).To(Equal(http.StatusConflict))
+	Expect(response.Body.String()).To(ContainSubstring("Cannot delete the last admin user"))
+
+	response = doAuthHandlerRequest(mux, "POST", "/api/v2/users", `{"username": "carol", "password": "longenough", "is_admin": true}`, "")
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	response = doAuthHandlerRequest(mux, "DELETE", "/api/v2/users/admin", "", "")
+	Expect(response.Code).To(Equal(http.StatusOK))
+}
+
+func Test_AuthHandler_RefreshToken_ReturnsANewTokenForTheBearerToken(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, mux := newTestAuthHandler(true)
+	token := loginForTest(mux, "bob")
+
+	response := doAuthHandlerRequest(mux, "GET", "/api/refresh-token-auth", "", token)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	var refreshed struct {
+		Token string `json:"token"`
+	}
+	Expect(json.Unmarshal(response.Body.Bytes(), &refreshed)).To(Succeed())
+	Expect(refreshed.Token).ToNot(BeEmpty())
+
+	Expect(doAuthHandlerRequest(mux, "GET", "/api/v2/users", "", refreshed.Token).Code).To(Equal(http.StatusOK))
+}
+
+func Test_AuthHandler_RefreshToken_RequiresAToken(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, mux := newTestAuthHandler(true)
+
+	Expect(doAuthHandlerRequest(mux, "GET", "/api/refresh-token-auth", "", "").Code).To(Equal(http.StatusUnauthorized))
+	Expect(doAuthHandlerRequest(mux, "GET", "/api/refresh-token-auth", "", "not-a-token").Code).To(Equal(http.StatusUnauthorized))
+}
+
+func Test_AuthHandler_Login_SetsRetryAfterWhenLockedOut(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, mux := newTestAuthHandler(true)
+
+	for i := 0; i < 3; i++ {
+		Expect(doAuthHandlerRequest(mux, "POST", "/api/token-auth", `{"username": "locked", "password": "wrong"}`, "").Code).To(Equal(http.StatusUnauthorized))
+	}
+
+	response := doAuthHandlerRequest(mux, "POST", "/api/token-auth", `{"username": "locked", "password": "wrong"}`, "")
+	Expect(response.Code).To(Equal(http.StatusTooManyRequests))
+	Expect(response.Header().Get("Retry-After")).To(Equal("600"))
+}
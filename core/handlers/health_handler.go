@@ -12,12 +12,21 @@ type HealthView struct {
 	Message string `json:"message"`
 }
 
-type HealthHandler struct{}
+type HoverflyReadiness interface {
+	IsProxyReady() bool
+}
+
+type HealthHandler struct {
+	Hoverfly HoverflyReadiness
+}
 
 func (this *HealthHandler) RegisterRoutes(mux *bone.Mux, am *AuthHandler) {
 	mux.Get("/api/health", negroni.New(
 		negroni.HandlerFunc(this.Get),
 	))
+	mux.Get("/api/ready", negroni.New(
+		negroni.HandlerFunc(this.GetReady),
+	))
 }
 
 func (this *HealthHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
@@ -34,3 +43,25 @@ func (this *HealthHandler) Get(w http.ResponseWriter, req *http.Request, next ht
 
 	WriteResponse(w, bytes)
 }
+
+// GetReady only reports Hoverfly as ready once the proxy is accepting connections,
+// whereas Get only shows that the admin API is up
+func (this *HealthHandler) GetReady(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	if this.Hoverfly == nil || !this.Hoverfly.IsProxyReady() {
+		WriteErrorResponse(w, "Hoverfly proxy is not accepting connections", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var response HealthView
+	response.Message = "Hoverfly is ready"
+
+	bytes, err := util.JSONMarshal(response)
+	if err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	WriteResponse(w, bytes)
+}
@@ -0,0 +1,44 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	. "github.com/onsi/gomega"
+)
+
+type HoverflyReadinessStub struct {
+	ProxyReady bool
+}
+
+func (this HoverflyReadinessStub) IsProxyReady() bool {
+	return this.ProxyReady
+}
+
+func Test_HealthHandler_GetReady_ReturnsOkWhenProxyIsReady(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := handlers.HealthHandler{Hoverfly: HoverflyReadinessStub{ProxyReady: true}}
+
+	request, _ := http.NewRequest("GET", "/api/ready", nil)
+	response := httptest.NewRecorder()
+	unit.GetReady(response, request, nil)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(ContainSubstring("Hoverfly is ready"))
+}
+
+func Test_HealthHandler_GetReady_ReturnsServiceUnavailableWhenProxyIsNotReady(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := handlers.HealthHandler{Hoverfly: HoverflyReadinessStub{ProxyReady: false}}
+
+	request, _ := http.NewRequest("GET", "/api/ready", nil)
+	response := httptest.NewRecorder()
+	unit.GetReady(response, request, nil)
+
+	Expect(response.Code).To(Equal(http.StatusServiceUnavailable))
+	Expect(response.Body.String()).To(ContainSubstring("Hoverfly proxy is not accepting connections"))
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type HoverflyMetrics interface {
+	WriteMetrics(io.Writer) error
+}
+
+type MetricsHandler struct {
+	Hoverfly HoverflyMetrics
+}
+
+func (this *MetricsHandler) RegisterRoutes(mux *bone.Mux, am *AuthHandler) {
+	mux.Get("/metrics", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+}
+
+// Get writes the Hoverfly counters in the Prometheus text exposition format
+func (this *MetricsHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var buffer bytes.Buffer
+	if err := this.Hoverfly.WriteMetrics(&buffer); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	WriteResponseWithContentType(w, buffer.Bytes(), prometheusContentType)
+}
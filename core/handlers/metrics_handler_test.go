@@ -0,0 +1,52 @@
+package handlers_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	. "github.com/onsi/gomega"
+)
+
+type HoverflyMetricsStub struct {
+	Metrics string
+	Error   error
+}
+
+func (this HoverflyMetricsStub) WriteMetrics(w io.Writer) error {
+	if this.Error != nil {
+		return this.Error
+	}
+	_, err := io.WriteString(w, this.Metrics)
+	return err
+}
+
+func Test_MetricsHandler_Get_ReturnsMetricsAsPrometheusText(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := handlers.MetricsHandler{Hoverfly: HoverflyMetricsStub{Metrics: "hoverfly_cache_hits_total 3\n"}}
+
+	request, _ := http.NewRequest("GET", "/metrics", nil)
+	response := httptest.NewRecorder()
+	unit.Get(response, request, nil)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Content-Type")).To(Equal("text/plain; version=0.0.4; charset=utf-8"))
+	Expect(response.Body.String()).To(Equal("hoverfly_cache_hits_total 3\n"))
+}
+
+func Test_MetricsHandler_Get_ReturnsErrorWhenMetricsCannotBeWritten(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := handlers.MetricsHandler{Hoverfly: HoverflyMetricsStub{Error: fmt.Errorf("broken")}}
+
+	request, _ := http.NewRequest("GET", "/metrics", nil)
+	response := httptest.NewRecorder()
+	unit.Get(response, request, nil)
+
+	Expect(response.Code).To(Equal(http.StatusInternalServerError))
+	Expect(response.Body.String()).To(ContainSubstring("broken"))
+}
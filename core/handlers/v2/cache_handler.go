@@ -12,6 +12,7 @@ import (
 type HoverflyCache interface {
 	GetCache() (CacheView, error)
 	FlushCache() error
+	FlushCacheForDestination(destination string) error
 }
 
 type CacheHandler struct {
@@ -46,7 +47,12 @@ func (this *CacheHandler) Get(w http.ResponseWriter, req *http.Request, next htt
 }
 
 func (this *CacheHandler) Delete(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
-	err := this.Hoverfly.FlushCache()
+	var err error
+	if destination := req.URL.Query().Get("destination"); destination != "" {
+		err = this.Hoverfly.FlushCacheForDestination(destination)
+	} else {
+		err = this.Hoverfly.FlushCache()
+	}
 	if err != nil {
 		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -16,6 +16,8 @@ type HoverflyCacheStub struct {
 	GetError    bool
 	FlushCalled bool
 	FlushError  bool
+
+	FlushedDestination string
 }
 
 func (this HoverflyCacheStub) GetCache() (CacheView, error) {
@@ -59,6 +61,16 @@ func (this *HoverflyCacheStub) FlushCache() error {
 	return nil
 }
 
+func (this *HoverflyCacheStub) FlushCacheForDestination(destination string) error {
+	this.FlushedDestination = destination
+
+	if this.FlushError {
+		return errors.New("There was an error")
+	}
+
+	return nil
+}
+
 func Test_Get_ReturnsTheCache(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -117,6 +129,23 @@ func Test_Delete_CallsFlushCache(t *testing.T) {
 	Expect(stubHoverfly.FlushCalled).To(BeTrue())
 }
 
+func Test_Delete_WithDestination_CallsFlushCacheForDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyCacheStub{}
+	unit := CacheHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("DELETE", "/api/v2/cache?destination=api.example.com", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Delete, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	Expect(stubHoverfly.FlushCalled).To(BeFalse())
+	Expect(stubHoverfly.FlushedDestination).To(Equal("api.example.com"))
+}
+
 func Test_Delete_ReturnsNiceErrorMessage(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflyCACert interface {
+	GetCACertificate() ([]byte, error)
+}
+
+// HoverflyCACertHandler serves the CA certificate Hoverfly signs MITM certificates with, so that it can be
+// installed into clients which need to trust Hoverfly when it intercepts HTTPS requests
+type HoverflyCACertHandler struct {
+	Hoverfly HoverflyCACert
+}
+
+func (this *HoverflyCACertHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/hoverfly/ca-cert", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Options("/api/v2/hoverfly/ca-cert", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *HoverflyCACertHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	certificate, err := this.Hoverfly.GetCACertificate()
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="hoverfly-ca.pem"`)
+	handlers.WriteResponseWithContentType(w, certificate, "application/x-pem-file")
+}
+
+func (this *HoverflyCACertHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET")
+	handlers.WriteResponse(w, []byte(""))
+}
@@ -0,0 +1,67 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflyCACertStub struct {
+	err error
+}
+
+func (this HoverflyCACertStub) GetCACertificate() ([]byte, error) {
+	if this.err != nil {
+		return nil, this.err
+	}
+	return []byte("-----BEGIN CERTIFICATE-----\ntest\n-----END CERTIFICATE-----\n"), nil
+}
+
+func Test_HoverflyCACertHandler_GetReturnsCertificate(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyCACertHandler{Hoverfly: HoverflyCACertStub{}}
+
+	request, err := http.NewRequest("GET", "/api/v2/hoverfly/ca-cert", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Content-Type")).To(Equal("application/x-pem-file"))
+	Expect(response.Header().Get("Content-Disposition")).To(Equal(`attachment; filename="hoverfly-ca.pem"`))
+	Expect(response.Body.String()).To(Equal("-----BEGIN CERTIFICATE-----\ntest\n-----END CERTIFICATE-----\n"))
+}
+
+func Test_HoverflyCACertHandler_GetReturnsErrorWhenThereIsNoCertificate(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyCACertHandler{Hoverfly: HoverflyCACertStub{err: errors.New("No CA certificate has been loaded")}}
+
+	request, err := http.NewRequest("GET", "/api/v2/hoverfly/ca-cert", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusInternalServerError))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("No CA certificate has been loaded"))
+}
+
+func Test_HoverflyCACertHandler_Options_GetsOptions(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyCACertHandler{Hoverfly: HoverflyCACertStub{}}
+
+	request, err := http.NewRequest("OPTIONS", "/api/v2/hoverfly/ca-cert", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Options, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, GET"))
+}
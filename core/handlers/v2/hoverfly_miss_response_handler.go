@@ -0,0 +1,72 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflyMissResponse interface {
+	GetMissResponse() MissResponseView
+	SetMissResponse(MissResponseView) error
+	DeleteMissResponse()
+}
+
+type HoverflyMissResponseHandler struct {
+	Hoverfly HoverflyMissResponse
+}
+
+func (this *HoverflyMissResponseHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/hoverfly/miss-response", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Put("/api/v2/hoverfly/miss-response", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Put),
+	))
+	mux.Delete("/api/v2/hoverfly/miss-response", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Delete),
+	))
+	mux.Options("/api/v2/hoverfly/miss-response", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *HoverflyMissResponseHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	bytes, _ := json.Marshal(this.Hoverfly.GetMissResponse())
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *HoverflyMissResponseHandler) Put(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var missResponseView MissResponseView
+	err := handlers.ReadFromRequest(req, &missResponseView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), 400)
+		return
+	}
+
+	err = this.Hoverfly.SetMissResponse(missResponseView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), 422)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+func (this *HoverflyMissResponseHandler) Delete(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	this.Hoverfly.DeleteMissResponse()
+
+	this.Get(w, req, next)
+}
+
+func (this *HoverflyMissResponseHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, PUT, DELETE")
+	handlers.WriteResponse(w, []byte(""))
+}
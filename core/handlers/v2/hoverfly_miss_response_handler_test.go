@@ -0,0 +1,116 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflyMissResponseStub struct {
+	MissResponse MissResponseView
+}
+
+func (this HoverflyMissResponseStub) GetMissResponse() MissResponseView {
+	return this.MissResponse
+}
+
+func (this *HoverflyMissResponseStub) SetMissResponse(missResponse MissResponseView) error {
+	if missResponse.Status == 0 {
+		return fmt.Errorf("status must be a valid HTTP status code")
+	}
+
+	this.MissResponse = missResponse
+	return nil
+}
+
+func (this *HoverflyMissResponseStub) DeleteMissResponse() {
+	this.MissResponse = MissResponseView{}
+}
+
+func unmarshalMissResponseView(buffer *bytes.Buffer) (MissResponseView, error) {
+	var missResponseView MissResponseView
+
+	body, err := ioutil.ReadAll(buffer)
+	if err != nil {
+		return missResponseView, err
+	}
+
+	err = json.Unmarshal(body, &missResponseView)
+	return missResponseView, err
+}
+
+func Test_HoverflyMissResponseHandler_Get_ReturnsMissResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMissResponseStub{MissResponse: MissResponseView{Status: 404, Body: "not found"}}
+	unit := HoverflyMissResponseHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	missResponseView, err := unmarshalMissResponseView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(missResponseView).To(Equal(MissResponseView{Status: 404, Body: "not found"}))
+}
+
+func Test_HoverflyMissResponseHandler_Put_SetsMissResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMissResponseStub{}
+	unit := HoverflyMissResponseHandler{Hoverfly: stubHoverfly}
+
+	bodyBytes, err := json.Marshal(MissResponseView{Status: 404, Body: "not found", Templated: true})
+	Expect(err).To(BeNil())
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBuffer(bodyBytes)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	missResponseView, err := unmarshalMissResponseView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(missResponseView).To(Equal(MissResponseView{Status: 404, Body: "not found", Templated: true}))
+}
+
+func Test_HoverflyMissResponseHandler_Put_ReturnsUnprocessableEntityWhenMissResponseIsInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMissResponseStub{}
+	unit := HoverflyMissResponseHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBuffer([]byte(`{"body": "not found"}`))))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusUnprocessableEntity))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("status must be a valid HTTP status code"))
+}
+
+func Test_HoverflyMissResponseHandler_Delete_DeletesMissResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyMissResponseStub{MissResponse: MissResponseView{Status: 404}}
+	unit := HoverflyMissResponseHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("DELETE", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Delete, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	missResponseView, err := unmarshalMissResponseView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(missResponseView).To(Equal(MissResponseView{}))
+}
@@ -0,0 +1,75 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflyUpstreamRoutes interface {
+	GetUpstreamRoutes() []UpstreamRouteView
+	AddUpstreamRoute(UpstreamRouteView) error
+	DeleteUpstreamRoutes()
+}
+
+type HoverflyUpstreamRoutesHandler struct {
+	Hoverfly HoverflyUpstreamRoutes
+}
+
+func (this *HoverflyUpstreamRoutesHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/hoverfly/destination/routes", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Post("/api/v2/hoverfly/destination/routes", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Post),
+	))
+	mux.Delete("/api/v2/hoverfly/destination/routes", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Delete),
+	))
+	mux.Options("/api/v2/hoverfly/destination/routes", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *HoverflyUpstreamRoutesHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var routesView UpstreamRoutesView
+	routesView.Routes = this.Hoverfly.GetUpstreamRoutes()
+
+	bytes, _ := json.Marshal(routesView)
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *HoverflyUpstreamRoutesHandler) Post(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	var routeView UpstreamRouteView
+	err := handlers.ReadFromRequest(r, &routeView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), 400)
+		return
+	}
+
+	err = this.Hoverfly.AddUpstreamRoute(routeView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), 422)
+		return
+	}
+
+	this.Get(w, r, next)
+}
+
+func (this *HoverflyUpstreamRoutesHandler) Delete(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	this.Hoverfly.DeleteUpstreamRoutes()
+
+	this.Get(w, r, next)
+}
+
+func (this *HoverflyUpstreamRoutesHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, POST, DELETE")
+	handlers.WriteResponse(w, []byte(""))
+}
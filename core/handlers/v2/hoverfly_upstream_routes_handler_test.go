@@ -0,0 +1,119 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflyUpstreamRoutesStub struct {
+	Routes []UpstreamRouteView
+}
+
+func (this HoverflyUpstreamRoutesStub) GetUpstreamRoutes() []UpstreamRouteView {
+	return this.Routes
+}
+
+func (this *HoverflyUpstreamRoutesStub) AddUpstreamRoute(route UpstreamRouteView) error {
+	if route.Upstream == "error" {
+		return fmt.Errorf("upstream is not a valid host")
+	}
+
+	this.Routes = append(this.Routes, route)
+	return nil
+}
+
+func (this *HoverflyUpstreamRoutesStub) DeleteUpstreamRoutes() {
+	this.Routes = []UpstreamRouteView{}
+}
+
+func unmarshalUpstreamRoutesView(buffer *bytes.Buffer) (UpstreamRoutesView, error) {
+	var routesView UpstreamRoutesView
+
+	body, err := ioutil.ReadAll(buffer)
+	if err != nil {
+		return routesView, err
+	}
+
+	err = json.Unmarshal(body, &routesView)
+	return routesView, err
+}
+
+func Test_HoverflyUpstreamRoutesHandler_Get_ReturnsRoutes(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyUpstreamRoutesStub{Routes: []UpstreamRouteView{{Pattern: "payments", Upstream: "http://localhost:9000"}}}
+	unit := HoverflyUpstreamRoutesHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	routesView, err := unmarshalUpstreamRoutesView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(routesView.Routes).To(ConsistOf(UpstreamRouteView{Pattern: "payments", Upstream: "http://localhost:9000"}))
+}
+
+func Test_HoverflyUpstreamRoutesHandler_Post_AddsRouteAndReturnsRoutes(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyUpstreamRoutesStub{}
+	unit := HoverflyUpstreamRoutesHandler{Hoverfly: stubHoverfly}
+
+	bodyBytes, err := json.Marshal(UpstreamRouteView{Pattern: "payments", Upstream: "http://localhost:9000"})
+	Expect(err).To(BeNil())
+
+	request, err := http.NewRequest("POST", "", ioutil.NopCloser(bytes.NewBuffer(bodyBytes)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	routesView, err := unmarshalUpstreamRoutesView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(routesView.Routes).To(ConsistOf(UpstreamRouteView{Pattern: "payments", Upstream: "http://localhost:9000"}))
+}
+
+func Test_HoverflyUpstreamRoutesHandler_Post_ReturnsUnprocessableEntityWhenRouteIsInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyUpstreamRoutesStub{}
+	unit := HoverflyUpstreamRoutesHandler{Hoverfly: stubHoverfly}
+
+	bodyBytes, err := json.Marshal(UpstreamRouteView{Pattern: "payments", Upstream: "error"})
+	Expect(err).To(BeNil())
+
+	request, err := http.NewRequest("POST", "", ioutil.NopCloser(bytes.NewBuffer(bodyBytes)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusUnprocessableEntity))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("upstream is not a valid host"))
+}
+
+func Test_HoverflyUpstreamRoutesHandler_Delete_DeletesRoutes(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyUpstreamRoutesStub{Routes: []UpstreamRouteView{{Pattern: "payments", Upstream: "http://localhost:9000"}}}
+	unit := HoverflyUpstreamRoutesHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("DELETE", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Delete, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	routesView, err := unmarshalUpstreamRoutesView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(routesView.Routes).To(BeEmpty())
+}
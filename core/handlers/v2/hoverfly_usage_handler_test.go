@@ -20,6 +20,8 @@ func (this HoverflyUsageStub) GetStats() metrics.Stats {
 
 	metrics.Counters["countOne"] = int64(1)
 	metrics.Counters["countTwo"] = int64(2)
+	metrics.Matching.CacheHits = int64(3)
+	metrics.Matching.Misses = int64(4)
 
 	return metrics
 }
@@ -43,6 +45,9 @@ func TestHoverflyUsageHandlerGetReturnsMetrics(t *testing.T) {
 	Expect(usageView.Usage.Counters).To(HaveLen(2))
 	Expect(usageView.Usage.Counters).To(HaveKeyWithValue("countOne", int64(1)))
 	Expect(usageView.Usage.Counters).To(HaveKeyWithValue("countTwo", int64(2)))
+
+	Expect(usageView.Usage.Matching.CacheHits).To(Equal(int64(3)))
+	Expect(usageView.Usage.Matching.Misses).To(Equal(int64(4)))
 }
 
 func Test_HoverflyUsageHandler_Options_GetsOptions(t *testing.T) {
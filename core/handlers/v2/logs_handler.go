@@ -22,6 +22,7 @@ const defaultTimestampFormat = time.RFC3339
 
 type HoverflyLogs interface {
 	GetLogs(limit int, from *time.Time) ([]*logrus.Entry, error)
+	GetFilteredLogs(limit int, from *time.Time, level logrus.Level, component string) ([]*logrus.Entry, error)
 }
 
 type LogsHandler struct {
@@ -51,7 +52,24 @@ func (this *LogsHandler) Get(w http.ResponseWriter, req *http.Request, next http
 
 	fromTime := util.GetUnixTimeQueryParam(req, "from")
 
-	logs, err := this.Hoverfly.GetLogs(limitQuery, fromTime)
+	levelQuery := queryParams.Get("level")
+	componentQuery := queryParams.Get("component")
+
+	var logs []*logrus.Entry
+	var err error
+	if levelQuery != "" || componentQuery != "" {
+		level := logrus.DebugLevel
+		if levelQuery != "" {
+			level, err = logrus.ParseLevel(levelQuery)
+			if err != nil {
+				handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		logs, err = this.Hoverfly.GetFilteredLogs(limitQuery, fromTime, level, componentQuery)
+	} else {
+		logs, err = this.Hoverfly.GetLogs(limitQuery, fromTime)
+	}
 	if err != nil {
 		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -72,7 +90,7 @@ func (this *LogsHandler) Options(w http.ResponseWriter, r *http.Request, next ht
 }
 
 func logsToLogsView(logs []*logrus.Entry) LogsView {
-	var logInterfaces []map[string]interface{}
+	logInterfaces := []map[string]interface{}{}
 	for _, entry := range logs {
 		data := make(map[string]interface{}, len(entry.Data)+3)
 
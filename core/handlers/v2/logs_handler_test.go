@@ -16,9 +16,18 @@ import (
 )
 
 type HoverflyLogsStub struct {
-	limit    int
-	from     *time.Time
-	disabled bool
+	limit     int
+	from      *time.Time
+	level     *logrus.Level
+	component string
+	disabled  bool
+	entries   []*logrus.Entry
+}
+
+func (this *HoverflyLogsStub) GetFilteredLogs(limit int, from *time.Time, level logrus.Level, component string) ([]*logrus.Entry, error) {
+	this.level = &level
+	this.component = component
+	return this.GetLogs(limit, from)
 }
 
 func (this *HoverflyLogsStub) GetLogs(limit int, from *time.Time) ([]*logrus.Entry, error) {
@@ -28,6 +37,9 @@ func (this *HoverflyLogsStub) GetLogs(limit int, from *time.Time) ([]*logrus.Ent
 
 	this.limit = limit
 	this.from = from
+	if this.entries != nil {
+		return this.entries, nil
+	}
 	return []*logrus.Entry{{
 		Level:   logrus.InfoLevel,
 		Message: "a line of logs",
@@ -56,6 +68,43 @@ func Test_LogsHandler_Get_ReturnsLogsView(t *testing.T) {
 	Expect(logsView.Logs[0]["msg"]).To(Equal("a line of logs"))
 }
 
+func Test_LogsHandler_Get_ReturnsAnEmptyArrayWhenThereAreNoLogs(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyLogsStub{entries: []*logrus.Entry{}}
+	unit := LogsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(Equal(`{"logs":[]}`))
+}
+
+func Test_LogsHandler_Get_ReturnsMultiLineMessagesAsOneLog(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyLogsStub{entries: []*logrus.Entry{
+		{Level: logrus.InfoLevel, Message: "first line\nsecond line"},
+		{Level: logrus.WarnLevel, Message: "another log"},
+	}}
+	unit := LogsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	logsView, err := unmarshalLogsView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(logsView.Logs).To(HaveLen(2))
+	Expect(logsView.Logs[0]["msg"]).To(Equal("first line\nsecond line"))
+	Expect(logsView.Logs[1]["level"]).To(Equal("warning"))
+}
+
 func Test_LogsHandler_Get_SetsTheDefaultLimitIfNoneIsSpecified(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -99,6 +148,66 @@ func Test_LogsHandler_Get_SetsTheFromTimeIfFromQueryProvided(t *testing.T) {
 	Expect(stubHoverfly.from.Unix()).To(Equal(int64(1497521986)))
 }
 
+func Test_LogsHandler_Get_FiltersByLevelIfLevelQueryProvided(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyLogsStub{}
+	unit := LogsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "?level=warn", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(*stubHoverfly.level).To(Equal(logrus.WarnLevel))
+	Expect(stubHoverfly.component).To(Equal(""))
+}
+
+func Test_LogsHandler_Get_FiltersByComponentIfComponentQueryProvided(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyLogsStub{}
+	unit := LogsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "?component=matching", nil)
+	Expect(err).To(BeNil())
+
+	makeRequestOnHandler(unit.Get, request)
+
+	Expect(*stubHoverfly.level).To(Equal(logrus.DebugLevel))
+	Expect(stubHoverfly.component).To(Equal("matching"))
+}
+
+func Test_LogsHandler_Get_DoesNotFilterIfNoLevelOrComponentQueryProvided(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyLogsStub{}
+	unit := LogsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "", nil)
+	Expect(err).To(BeNil())
+
+	makeRequestOnHandler(unit.Get, request)
+
+	Expect(stubHoverfly.level).To(BeNil())
+}
+
+func Test_LogsHandler_Get_ReturnsBadRequestIfLevelIsInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyLogsStub{}
+	unit := LogsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "?level=loud", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(stubHoverfly.level).To(BeNil())
+}
+
 func Test_LogsHandler_Get_DoesNotSetTimeIfFromQueryIsBadTime(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -10,7 +10,13 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+type HoverflyShutdown interface {
+	StopProxy()
+	CloseCacheDatabase() error
+}
+
 type ShutdownHandler struct {
+	Hoverfly HoverflyShutdown
 }
 
 func (this *ShutdownHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
@@ -27,6 +33,10 @@ func (this *ShutdownHandler) Delete(w http.ResponseWriter, req *http.Request, ne
 	handlers.WriteResponse(w, []byte(""))
 	go func() {
 		log.Warning("Shutting down")
+		if this.Hoverfly != nil {
+			this.Hoverfly.StopProxy()
+			this.Hoverfly.CloseCacheDatabase()
+		}
 		os.Exit(0)
 	}()
 }
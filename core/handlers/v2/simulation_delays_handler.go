@@ -0,0 +1,78 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflySimulationDelays interface {
+	GetResponseDelays() v1.ResponseDelayPayloadView
+	SetResponseDelays(v1.ResponseDelayPayloadView) error
+	DeleteResponseDelays()
+}
+
+type SimulationDelaysHandler struct {
+	Hoverfly HoverflySimulationDelays
+}
+
+func (this *SimulationDelaysHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/simulation/delays", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Put("/api/v2/simulation/delays", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Put),
+	))
+	mux.Delete("/api/v2/simulation/delays", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Delete),
+	))
+	mux.Options("/api/v2/simulation/delays", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *SimulationDelaysHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	delaysView := this.Hoverfly.GetResponseDelays()
+	if delaysView.Data == nil {
+		delaysView.Data = []v1.ResponseDelayView{}
+	}
+
+	bytes, _ := json.Marshal(delaysView)
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *SimulationDelaysHandler) Put(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	var delaysView v1.ResponseDelayPayloadView
+	err := handlers.ReadFromRequest(r, &delaysView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), 400)
+		return
+	}
+
+	err = this.Hoverfly.SetResponseDelays(delaysView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), 422)
+		return
+	}
+
+	this.Get(w, r, next)
+}
+
+func (this *SimulationDelaysHandler) Delete(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	this.Hoverfly.DeleteResponseDelays()
+
+	this.Get(w, r, next)
+}
+
+func (this *SimulationDelaysHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, PUT, DELETE")
+	handlers.WriteResponse(w, []byte(""))
+}
@@ -0,0 +1,134 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	. "github.com/onsi/gomega"
+)
+
+type HoverflySimulationDelaysStub struct {
+	Delays []v1.ResponseDelayView
+}
+
+func (this HoverflySimulationDelaysStub) GetResponseDelays() v1.ResponseDelayPayloadView {
+	return v1.ResponseDelayPayloadView{Data: this.Delays}
+}
+
+func (this *HoverflySimulationDelaysStub) SetResponseDelays(delaysView v1.ResponseDelayPayloadView) error {
+	for _, delay := range delaysView.Data {
+		if delay.Delay < 0 {
+			return fmt.Errorf("Config error - Delay cannot be negative in: %v", delay)
+		}
+	}
+
+	this.Delays = delaysView.Data
+	return nil
+}
+
+func (this *HoverflySimulationDelaysStub) DeleteResponseDelays() {
+	this.Delays = nil
+}
+
+func unmarshalResponseDelayPayloadView(buffer *bytes.Buffer) (v1.ResponseDelayPayloadView, error) {
+	var delaysView v1.ResponseDelayPayloadView
+
+	body, err := ioutil.ReadAll(buffer)
+	if err != nil {
+		return delaysView, err
+	}
+
+	err = json.Unmarshal(body, &delaysView)
+	return delaysView, err
+}
+
+func Test_SimulationDelaysHandler_Get_ReturnsDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationDelaysStub{Delays: []v1.ResponseDelayView{{UrlPattern: "payments", HttpMethod: "GET", Delay: 500}}}
+	unit := SimulationDelaysHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	delaysView, err := unmarshalResponseDelayPayloadView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(delaysView.Data).To(ConsistOf(v1.ResponseDelayView{UrlPattern: "payments", HttpMethod: "GET", Delay: 500}))
+}
+
+func Test_SimulationDelaysHandler_Get_ReturnsEmptyListWithoutDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationDelaysHandler{Hoverfly: &HoverflySimulationDelaysStub{}}
+
+	request, err := http.NewRequest("GET", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(Equal(`{"data":[]}`))
+}
+
+func Test_SimulationDelaysHandler_Put_ReplacesDelaysAndReturnsDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationDelaysStub{Delays: []v1.ResponseDelayView{{UrlPattern: "payments", Delay: 500}}}
+	unit := SimulationDelaysHandler{Hoverfly: stubHoverfly}
+
+	bodyBytes, err := json.Marshal(v1.ResponseDelayPayloadView{Data: []v1.ResponseDelayView{{UrlPattern: "orders", HttpMethod: "POST", Delay: 100}}})
+	Expect(err).To(BeNil())
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBuffer(bodyBytes)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	delaysView, err := unmarshalResponseDelayPayloadView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(delaysView.Data).To(ConsistOf(v1.ResponseDelayView{UrlPattern: "orders", HttpMethod: "POST", Delay: 100}))
+}
+
+func Test_SimulationDelaysHandler_Put_ReturnsUnprocessableEntityWhenDelayIsInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationDelaysHandler{Hoverfly: &HoverflySimulationDelaysStub{}}
+
+	bodyBytes, err := json.Marshal(v1.ResponseDelayPayloadView{Data: []v1.ResponseDelayView{{UrlPattern: "orders", Delay: -1}}})
+	Expect(err).To(BeNil())
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBuffer(bodyBytes)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusUnprocessableEntity))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(ContainSubstring("Delay cannot be negative"))
+}
+
+func Test_SimulationDelaysHandler_Delete_DeletesDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationDelaysStub{Delays: []v1.ResponseDelayView{{UrlPattern: "payments", Delay: 500}}}
+	unit := SimulationDelaysHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("DELETE", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Delete, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	delaysView, err := unmarshalResponseDelayPayloadView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(delaysView.Data).To(BeEmpty())
+}
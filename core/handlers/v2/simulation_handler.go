@@ -19,6 +19,8 @@ type HoverflySimulation interface {
 	GetFilteredSimulation(string) (SimulationViewV5, error)
 	PutSimulation(SimulationViewV5) SimulationImportResult
 	DeleteSimulation()
+	GetSimulationPair(string) (RequestMatcherResponsePairViewV5, error)
+	DeleteSimulationPair(string) error
 }
 
 type SimulationHandler struct {
@@ -46,6 +48,18 @@ func (this *SimulationHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHa
 		negroni.HandlerFunc(this.Options),
 	))
 
+	mux.Get("/api/v2/simulation/pairs/:id", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.GetPair),
+	))
+	mux.Delete("/api/v2/simulation/pairs/:id", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.DeletePair),
+	))
+	mux.Options("/api/v2/simulation/pairs/:id", negroni.New(
+		negroni.HandlerFunc(this.OptionsPair),
+	))
+
 	mux.Get("/api/v2/simulation/schema", negroni.New(
 		negroni.HandlerFunc(am.RequireTokenAuthentication),
 		negroni.HandlerFunc(this.GetSchema),
@@ -99,6 +113,33 @@ func (this *SimulationHandler) Delete(w http.ResponseWriter, req *http.Request,
 	this.Get(w, req, next)
 }
 
+func (this *SimulationHandler) GetPair(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	pairView, err := this.Hoverfly.GetSimulationPair(bone.GetValue(req, "id"))
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	bytes, _ := util.JSONMarshal(pairView)
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *SimulationHandler) DeletePair(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	err := this.Hoverfly.DeleteSimulationPair(bone.GetValue(req, "id"))
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+func (this *SimulationHandler) OptionsPair(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, DELETE")
+	handlers.WriteResponse(w, []byte(""))
+}
+
 func (this *SimulationHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 	w.Header().Add("Allow", "OPTIONS, GET, PUT, DELETE")
 	handlers.WriteResponse(w, []byte(""))
@@ -5,17 +5,21 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"fmt"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
 	. "github.com/onsi/gomega"
 )
 
 type HoverflySimulationStub struct {
 	Deleted    bool
+	DeletedId  string
 	Simulation SimulationViewV5
 	UrlPattern string
 	Filtered   bool
@@ -66,6 +70,22 @@ func (this *HoverflySimulationStub) DeleteSimulation() {
 	this.Deleted = true
 }
 
+func (this *HoverflySimulationStub) GetSimulationPair(id string) (RequestMatcherResponsePairViewV5, error) {
+	if id != "0" {
+		return RequestMatcherResponsePairViewV5{}, fmt.Errorf("No pair found with index or hash %s", id)
+	}
+	simulation, _ := this.GetSimulation()
+	return simulation.RequestResponsePairs[0], nil
+}
+
+func (this *HoverflySimulationStub) DeleteSimulationPair(id string) error {
+	if id != "0" {
+		return fmt.Errorf("No pair found with index or hash %s", id)
+	}
+	this.DeletedId = id
+	return nil
+}
+
 func (this *HoverflySimulationStub) PutSimulation(simulation SimulationViewV5) SimulationImportResult {
 	this.Simulation = simulation
 	return SimulationImportResult{}
@@ -83,6 +103,14 @@ func (this HoverflySimulationErrorStub) GetFilteredSimulation(urlPattern string)
 
 func (this *HoverflySimulationErrorStub) DeleteSimulation() {}
 
+func (this *HoverflySimulationErrorStub) GetSimulationPair(id string) (RequestMatcherResponsePairViewV5, error) {
+	return RequestMatcherResponsePairViewV5{}, fmt.Errorf("error")
+}
+
+func (this *HoverflySimulationErrorStub) DeleteSimulationPair(id string) error {
+	return fmt.Errorf("error")
+}
+
 func (this *HoverflySimulationErrorStub) PutSimulation(simulation SimulationViewV5) SimulationImportResult {
 	return SimulationImportResult{
 		Err: fmt.Errorf("error"),
@@ -101,6 +129,14 @@ func (this HoverflySimulationWarningStub) GetFilteredSimulation(urlPattern strin
 
 func (this *HoverflySimulationWarningStub) DeleteSimulation() {}
 
+func (this *HoverflySimulationWarningStub) GetSimulationPair(id string) (RequestMatcherResponsePairViewV5, error) {
+	return RequestMatcherResponsePairViewV5{}, nil
+}
+
+func (this *HoverflySimulationWarningStub) DeleteSimulationPair(id string) error {
+	return nil
+}
+
 func (this *HoverflySimulationWarningStub) PutSimulation(simulation SimulationViewV5) SimulationImportResult {
 	return SimulationImportResult{
 		WarningMessages: []SimulationImportWarning{{"This is a warning", "url"}},
@@ -637,3 +673,87 @@ func unmarshalResultView(buffer *bytes.Buffer) (SimulationImportResult, error) {
 
 	return result, nil
 }
+
+func getPairOnHandler(unit SimulationHandler, id string) *http.Response {
+	mux := bone.New()
+	mux.Get("/api/v2/simulation/pairs/:id", negroni.New(negroni.HandlerFunc(unit.GetPair)))
+
+	request, _ := http.NewRequest("GET", "/api/v2/simulation/pairs/"+id, nil)
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	return recorder.Result()
+}
+
+func TestSimulationHandler_GetPair_ReturnsThePairWithTheId(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationHandler{Hoverfly: &HoverflySimulationStub{}}
+
+	response := getPairOnHandler(unit, "0")
+
+	Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+	var pairView RequestMatcherResponsePairViewV5
+	Expect(json.NewDecoder(response.Body).Decode(&pairView)).To(Succeed())
+	Expect(pairView.RequestMatcher.Path[0].Value).To(Equal("/testing"))
+	Expect(pairView.Response.Body).To(Equal("test-body"))
+}
+
+func TestSimulationHandler_GetPair_ReturnsNotFoundForAnUnknownId(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationHandler{Hoverfly: &HoverflySimulationStub{}}
+
+	response := getPairOnHandler(unit, "5")
+
+	Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+
+	body, _ := ioutil.ReadAll(response.Body)
+	errorView, err := unmarshalErrorView(bytes.NewBuffer(body))
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("No pair found with index or hash 5"))
+}
+
+func deletePairOnHandler(unit SimulationHandler, id string) *http.Response {
+	mux := bone.New()
+	mux.Delete("/api/v2/simulation/pairs/:id", negroni.New(negroni.HandlerFunc(unit.DeletePair)))
+
+	request, _ := http.NewRequest("DELETE", "/api/v2/simulation/pairs/"+id, nil)
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	return recorder.Result()
+}
+
+func TestSimulationHandler_DeletePair_DeletesThePairWithTheId(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	response := deletePairOnHandler(unit, "0")
+
+	Expect(response.StatusCode).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.DeletedId).To(Equal("0"))
+
+	body, _ := ioutil.ReadAll(response.Body)
+	simulationView, err := unmarshalSimulationViewV5(bytes.NewBuffer(body))
+	Expect(err).To(BeNil())
+	Expect(simulationView.DataViewV5.RequestResponsePairs).To(HaveLen(1))
+}
+
+func TestSimulationHandler_DeletePair_ReturnsNotFoundForAnUnknownId(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	response := deletePairOnHandler(unit, "5")
+
+	Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+	Expect(stubHoverfly.DeletedId).To(Equal(""))
+
+	body, _ := ioutil.ReadAll(response.Body)
+	errorView, err := unmarshalErrorView(bytes.NewBuffer(body))
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("No pair found with index or hash 5"))
+}
@@ -0,0 +1,48 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflySimulationMatch interface {
+	PreviewMatch(RequestDetailsView) MatchPreviewView
+}
+
+// SimulationMatchHandler - finds the pair which would match a request and the response it would serve,
+// without the request being proxied or changing the cache or state
+type SimulationMatchHandler struct {
+	Hoverfly HoverflySimulationMatch
+}
+
+func (this *SimulationMatchHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Post("/api/v2/simulation/match", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Post),
+	))
+	mux.Options("/api/v2/simulation/match", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *SimulationMatchHandler) Post(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var requestView RequestDetailsView
+	err := handlers.ReadFromRequest(req, &requestView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bytes, _ := json.Marshal(this.Hoverfly.PreviewMatch(requestView))
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *SimulationMatchHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, POST")
+	handlers.WriteResponse(w, []byte(""))
+}
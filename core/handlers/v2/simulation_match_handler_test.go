@@ -0,0 +1,65 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflySimulationMatchStub struct {
+	Request RequestDetailsView
+}
+
+func (this *HoverflySimulationMatchStub) PreviewMatch(requestView RequestDetailsView) MatchPreviewView {
+	this.Request = requestView
+
+	pairIndex := 2
+	return MatchPreviewView{
+		Matched:   true,
+		PairIndex: &pairIndex,
+		Response:  &ResponseDetailsViewV5{Status: 200, Body: "preview"},
+	}
+}
+
+func Test_SimulationMatchHandler_Post_ReturnsThePreview(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationMatchStub{}
+	unit := SimulationMatchHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "", ioutil.NopCloser(bytes.NewBuffer([]byte(`{"method": "GET", "destination": "api.example.com", "path": "/orders"}`))))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	Expect(*stubHoverfly.Request.Method).To(Equal("GET"))
+	Expect(*stubHoverfly.Request.Destination).To(Equal("api.example.com"))
+	Expect(*stubHoverfly.Request.Path).To(Equal("/orders"))
+
+	var preview MatchPreviewView
+	Expect(json.Unmarshal(response.Body.Bytes(), &preview)).To(Succeed())
+	Expect(preview.Matched).To(BeTrue())
+	Expect(*preview.PairIndex).To(Equal(2))
+	Expect(preview.Response.Body).To(Equal("preview"))
+}
+
+func Test_SimulationMatchHandler_Post_ErrorsOnMalformedJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationMatchHandler{Hoverfly: &HoverflySimulationMatchStub{}}
+
+	request, err := http.NewRequest("POST", "", ioutil.NopCloser(bytes.NewBuffer([]byte(`{"method":`))))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Malformed JSON"))
+}
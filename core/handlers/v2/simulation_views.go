@@ -130,6 +130,28 @@ func validateSimulation(schemaLoader, jsonLoader gojsonschema.JSONLoader) error
 type GlobalActionsView struct {
 	Delays          []v1.ResponseDelayView          `json:"delays"`
 	DelaysLogNormal []v1.ResponseDelayLogNormalView `json:"delaysLogNormal"`
+	RateLimits      []RateLimitView                 `json:"rateLimits,omitempty"`
+	Faults          []FaultView                     `json:"faults,omitempty"`
+}
+
+// RateLimitView returns 429 Too Many Requests once limit requests matching the url pattern and http method have
+// been served from the simulation within window milliseconds
+type RateLimitView struct {
+	UrlPattern string `json:"urlPattern"`
+	HttpMethod string `json:"httpMethod,omitempty"`
+	Limit      int    `json:"limit"`
+	Window     int    `json:"window"`
+}
+
+// FaultView injects an error, connection reset or truncated body into the given probability of requests matching
+// the url pattern and http method. A seed makes the requests which are faulted reproducible.
+type FaultView struct {
+	UrlPattern  string  `json:"urlPattern"`
+	HttpMethod  string  `json:"httpMethod,omitempty"`
+	Type        string  `json:"type"`
+	Status      int     `json:"status,omitempty"`
+	Probability float64 `json:"probability"`
+	Seed        int64   `json:"seed,omitempty"`
 }
 
 type MetaView struct {
@@ -150,6 +172,8 @@ func BuildSimulationView(
 	pairViews []RequestMatcherResponsePairViewV5,
 	delayView v1.ResponseDelayPayloadView,
 	delayLogNormalView v1.ResponseDelayLogNormalPayloadView,
+	rateLimits []RateLimitView,
+	faults []FaultView,
 	variables []GlobalVariableViewV5,
 	literals []GlobalLiteralViewV5,
 	version string,
@@ -160,6 +184,8 @@ func BuildSimulationView(
 			GlobalActions: GlobalActionsView{
 				Delays:          delayView.Data,
 				DelaysLogNormal: delayLogNormalView.Data,
+				RateLimits:      rateLimits,
+				Faults:          faults,
 			},
 			GlobalVariables: variables,
 			GlobalLiterals:  literals,
@@ -235,6 +235,37 @@ func Test_NewSimulationViewFromRequestBody_CanCreateSimulationFromV1Payload(t *t
 	Expect(simulation.TimeExported).To(Equal("2017-02-23T12:43:48Z"))
 }
 
+func Test_NewSimulationViewFromRequestBody_KeepsDelaysOfV1Simulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+		"data": {
+			"pairs": [],
+			"globalActions": {
+				"delays": [
+					{
+						"urlPattern": "test-server.com",
+						"httpMethod": "POST",
+						"delay": 250
+					}
+				]
+			}
+		},
+		"meta": {
+			"schemaVersion": "v1",
+			"hoverflyVersion": "v0.11.0",
+			"timeExported": "2017-02-23T12:43:48Z"
+		}
+	}`))
+
+	Expect(err).To(BeNil())
+
+	Expect(simulation.GlobalActions.Delays).To(HaveLen(1))
+	Expect(simulation.GlobalActions.Delays[0].UrlPattern).To(Equal("test-server.com"))
+	Expect(simulation.GlobalActions.Delays[0].HttpMethod).To(Equal("POST"))
+	Expect(simulation.GlobalActions.Delays[0].Delay).To(Equal(250))
+}
+
 func Test_NewSimulationViewFromRequestBody_WontCreateSimulationFromInvalidV1Simulation(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -290,6 +321,58 @@ func Test_NewSimulationViewFromRequestBody_ReturnErrorMessagesOnInvalidSimulatio
 	Expect(simulation.GlobalActions.Delays).To(HaveLen(0))
 }
 
+func Test_NewSimulationViewFromRequestBody_ReadsPairDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+		"data": {
+			"pairs": [
+				{
+					"request": {
+						"destination": [{"matcher": "exact", "value": "test-server.com"}]
+					},
+					"response": {
+						"status": 200,
+						"delay": {"fixed": 100, "jitter": 20}
+					}
+				}
+			]
+		},
+		"meta": {
+			"schemaVersion": "v5"
+		}
+	}`))
+
+	Expect(err).To(BeNil())
+
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulation.RequestResponsePairs[0].Response.Delay).To(Equal(&v2.PairDelayOptions{Fixed: 100, Jitter: 20}))
+}
+
+func Test_NewSimulationViewFromRequestBody_WontCreateSimulationWithNegativePairDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromRequestBody([]byte(`{
+		"data": {
+			"pairs": [
+				{
+					"request": {},
+					"response": {
+						"status": 200,
+						"delay": {"fixed": 100, "jitter": -1}
+					}
+				}
+			]
+		},
+		"meta": {
+			"schemaVersion": "v5"
+		}
+	}`))
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("data.pairs.0.response.delay.jitter"))
+}
+
 func Test_NewSimulationViewFromRequestBody_WontCreateSimulationFromUnknownSchemaVersion(t *testing.T) {
 	RegisterTestingT(t)
 
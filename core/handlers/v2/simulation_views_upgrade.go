@@ -135,6 +135,9 @@ func upgradeV1(originalSimulation SimulationViewV1) SimulationViewV5 {
 	return SimulationViewV5{
 		DataViewV5{
 			RequestResponsePairs: pairs,
+			GlobalActions: GlobalActionsView{
+				Delays: originalSimulation.GlobalActions.Delays,
+			},
 		},
 		newMetaView(originalSimulation.MetaView),
 	}
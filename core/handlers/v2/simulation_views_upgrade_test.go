@@ -3,6 +3,7 @@ package v2
 import (
 	"testing"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
 	"github.com/SpectoLabs/hoverfly/core/util"
 	. "github.com/onsi/gomega"
@@ -204,6 +205,33 @@ func Test_upgradeV1_HandlesIncompleteRequest(t *testing.T) {
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.Headers).To(HaveKeyWithValue("Test", []string{"headers"}))
 }
 
+func Test_upgradeV1_KeepsGlobalDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	v1Simulation := SimulationViewV1{
+		DataViewV1{
+			RequestResponsePairViewV1: []RequestResponsePairViewV1{},
+			GlobalActions: GlobalActionsView{
+				Delays: []v1.ResponseDelayView{
+					{
+						UrlPattern: "test-server.com",
+						HttpMethod: "GET",
+						Delay:      100,
+					},
+				},
+			},
+		},
+		v1Meta,
+	}
+
+	upgradedSimulation := upgradeV1(v1Simulation)
+
+	Expect(upgradedSimulation.GlobalActions.Delays).To(HaveLen(1))
+	Expect(upgradedSimulation.GlobalActions.Delays[0].UrlPattern).To(Equal("test-server.com"))
+	Expect(upgradedSimulation.GlobalActions.Delays[0].HttpMethod).To(Equal("GET"))
+	Expect(upgradedSimulation.GlobalActions.Delays[0].Delay).To(Equal(100))
+}
+
 func Test_upgradeV1_Upgrade_UnescapesRequestQueryParameters(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -59,12 +59,33 @@ func (this ResponseDetailsView) GetRemovesState() []string { return nil }
 // Gets Headers - required for interfaces.Response
 func (this ResponseDetailsView) GetHeaders() map[string][]string { return this.Headers }
 
+// Gets OrderedHeaders - required for interfaces.Response
+func (this ResponseDetailsView) GetOrderedHeaders() [][2]string { return nil }
+
 // Gets FixedDelay - required for interfaces.Response
 func (this ResponseDetailsView) GetFixedDelay() int { return 0 }
 
 // Gets LogNormalDelay - required for interfaces.Response
 func (this ResponseDetailsView) GetLogNormalDelay() interfaces.ResponseDelay { return nil }
 
+// Gets CapturedAt - required for interfaces.Response
+func (this ResponseDetailsView) GetCapturedAt() string { return "" }
+
+// Gets LatencyMs - required for interfaces.Response
+func (this ResponseDetailsView) GetLatencyMs() int { return 0 }
+
+// Gets BodyTruncated - required for interfaces.Response
+func (this ResponseDetailsView) GetBodyTruncated() bool { return false }
+
+// Gets Delay - required for interfaces.Response
+func (this ResponseDetailsView) GetDelay() interfaces.PairDelay { return nil }
+
+// Gets Chunked - required for interfaces.Response
+func (this ResponseDetailsView) GetChunked() bool { return false }
+
+// Gets StatusText - required for interfaces.Response
+func (this ResponseDetailsView) GetStatusText() string { return "" }
+
 // RequestDetailsView is used when marshalling and unmarshalling RequestDetails
 type RequestDetailsView struct {
 	RequestType *string             `json:"requestType,omitempty"`
@@ -111,3 +132,21 @@ func (this RequestDetailsView) GetFixedDelay() int { return 0 }
 
 // Gets LogNormalDelay - required for interfaces.Response
 func (this RequestDetailsView) GetLogNormalDelay() interfaces.ResponseDelay { return nil }
+
+// Gets CapturedAt - required for interfaces.Response
+func (this RequestDetailsView) GetCapturedAt() string { return "" }
+
+// Gets LatencyMs - required for interfaces.Response
+func (this RequestDetailsView) GetLatencyMs() int { return 0 }
+
+// Gets BodyTruncated - required for interfaces.Response
+func (this RequestDetailsView) GetBodyTruncated() bool { return false }
+
+// Gets Delay - required for interfaces.Response
+func (this RequestDetailsView) GetDelay() interfaces.PairDelay { return nil }
+
+// Gets Chunked - required for interfaces.Response
+func (this RequestDetailsView) GetChunked() bool { return false }
+
+// Gets StatusText - required for interfaces.Response
+func (this RequestDetailsView) GetStatusText() string { return "" }
@@ -58,6 +58,9 @@ func (this ResponseDetailsViewV3) GetTemplated() bool { return this.Templated }
 // Gets Headers - required for interfaces.Response
 func (this ResponseDetailsViewV3) GetHeaders() map[string][]string { return this.Headers }
 
+// Gets OrderedHeaders - required for interfaces.Response
+func (this ResponseDetailsViewV3) GetOrderedHeaders() [][2]string { return nil }
+
 func (this ResponseDetailsViewV3) GetTransitionsState() map[string]string { return nil }
 
 func (this ResponseDetailsViewV3) GetRemovesState() []string { return nil }
@@ -67,3 +70,21 @@ func (this ResponseDetailsViewV3) GetFixedDelay() int { return 0 }
 
 // Gets LogNormalDelay - required for interfaces.Response
 func (this ResponseDetailsViewV3) GetLogNormalDelay() interfaces.ResponseDelay { return nil }
+
+// Gets CapturedAt - required for interfaces.Response
+func (this ResponseDetailsViewV3) GetCapturedAt() string { return "" }
+
+// Gets LatencyMs - required for interfaces.Response
+func (this ResponseDetailsViewV3) GetLatencyMs() int { return 0 }
+
+// Gets BodyTruncated - required for interfaces.Response
+func (this ResponseDetailsViewV3) GetBodyTruncated() bool { return false }
+
+// Gets Delay - required for interfaces.Response
+func (this ResponseDetailsViewV3) GetDelay() interfaces.PairDelay { return nil }
+
+// Gets Chunked - required for interfaces.Response
+func (this ResponseDetailsViewV3) GetChunked() bool { return false }
+
+// Gets StatusText - required for interfaces.Response
+func (this ResponseDetailsViewV3) GetStatusText() string { return "" }
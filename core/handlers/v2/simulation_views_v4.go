@@ -71,8 +71,29 @@ func (this ResponseDetailsViewV4) GetRemovesState() []string { return this.Remov
 // Gets Headers - required for interfaces.Response
 func (this ResponseDetailsViewV4) GetHeaders() map[string][]string { return this.Headers }
 
+// Gets OrderedHeaders - required for interfaces.Response
+func (this ResponseDetailsViewV4) GetOrderedHeaders() [][2]string { return nil }
+
 // Gets FixedDelay - required for interfaces.Response
 func (this ResponseDetailsViewV4) GetFixedDelay() int { return 0 }
 
 // Gets LogNormalDelay - required for interfaces.Response
 func (this ResponseDetailsViewV4) GetLogNormalDelay() interfaces.ResponseDelay { return nil }
+
+// Gets CapturedAt - required for interfaces.Response
+func (this ResponseDetailsViewV4) GetCapturedAt() string { return "" }
+
+// Gets LatencyMs - required for interfaces.Response
+func (this ResponseDetailsViewV4) GetLatencyMs() int { return 0 }
+
+// Gets BodyTruncated - required for interfaces.Response
+func (this ResponseDetailsViewV4) GetBodyTruncated() bool { return false }
+
+// Gets Delay - required for interfaces.Response
+func (this ResponseDetailsViewV4) GetDelay() interfaces.PairDelay { return nil }
+
+// Gets Chunked - required for interfaces.Response
+func (this ResponseDetailsViewV4) GetChunked() bool { return false }
+
+// Gets StatusText - required for interfaces.Response
+func (this ResponseDetailsViewV4) GetStatusText() string { return "" }
@@ -70,11 +70,18 @@ type ResponseDetailsViewV5 struct {
 	BodyFile         string                 `json:"bodyFile,omitempty"`
 	EncodedBody      bool                   `json:"encodedBody"`
 	Headers          map[string][]string    `json:"headers,omitempty"`
+	OrderedHeaders   [][2]string            `json:"orderedHeaders,omitempty"`
 	Templated        bool                   `json:"templated"`
 	TransitionsState map[string]string      `json:"transitionsState,omitempty"`
 	RemovesState     []string               `json:"removesState,omitempty"`
 	FixedDelay       int                    `json:"fixedDelay,omitempty"`
 	LogNormalDelay   *LogNormalDelayOptions `json:"logNormalDelay,omitempty"`
+	CapturedAt       string                 `json:"capturedAt,omitempty"`
+	LatencyMs        int                    `json:"latencyMs,omitempty"`
+	BodyTruncated    bool                   `json:"bodyTruncated,omitempty"`
+	Delay            *PairDelayOptions      `json:"delay,omitempty"`
+	Chunked          bool                   `json:"chunked,omitempty"`
+	StatusText       string                 `json:"statusText,omitempty"`
 }
 
 // Gets Status - required for interfaces.Response
@@ -100,6 +107,9 @@ func (this ResponseDetailsViewV5) GetRemovesState() []string { return this.Remov
 // Gets Headers - required for interfaces.Response
 func (this ResponseDetailsViewV5) GetHeaders() map[string][]string { return this.Headers }
 
+// Gets OrderedHeaders - required for interfaces.Response
+func (this ResponseDetailsViewV5) GetOrderedHeaders() [][2]string { return this.OrderedHeaders }
+
 // Gets FixedDelay - required for interfaces.Response
 func (this ResponseDetailsViewV5) GetFixedDelay() int { return this.FixedDelay }
 
@@ -113,6 +123,30 @@ func (this ResponseDetailsViewV5) GetLogNormalDelay() interfaces.ResponseDelay {
 	return nil
 }
 
+// Gets CapturedAt - required for interfaces.Response
+func (this ResponseDetailsViewV5) GetCapturedAt() string { return this.CapturedAt }
+
+// Gets LatencyMs - required for interfaces.Response
+func (this ResponseDetailsViewV5) GetLatencyMs() int { return this.LatencyMs }
+
+// Gets BodyTruncated - required for interfaces.Response
+func (this ResponseDetailsViewV5) GetBodyTruncated() bool { return this.BodyTruncated }
+
+// Gets Delay - required for interfaces.Response
+func (this ResponseDetailsViewV5) GetDelay() interfaces.PairDelay {
+	if this.Delay != nil {
+		return this.Delay
+	}
+
+	return nil
+}
+
+// Gets Chunked - required for interfaces.Response
+func (this ResponseDetailsViewV5) GetChunked() bool { return this.Chunked }
+
+// Gets StatusText - required for interfaces.Response
+func (this ResponseDetailsViewV5) GetStatusText() string { return this.StatusText }
+
 type LogNormalDelayOptions struct {
 	Min    int `json:"min"`
 	Max    int `json:"max"`
@@ -135,3 +169,18 @@ func (l *LogNormalDelayOptions) GetMean() int {
 func (l *LogNormalDelayOptions) GetMedian() int {
 	return l.Median
 }
+
+// PairDelayOptions delays the response of a single pair by Fixed milliseconds, plus up to Jitter milliseconds
+// chosen at random, in addition to any global delays which apply to the request
+type PairDelayOptions struct {
+	Fixed  int `json:"fixed"`
+	Jitter int `json:"jitter,omitempty"`
+}
+
+func (d *PairDelayOptions) GetFixed() int {
+	return d.Fixed
+}
+
+func (d *PairDelayOptions) GetJitter() int {
+	return d.Jitter
+}
@@ -8,6 +8,15 @@ type DestinationView struct {
 	Destination string `json:"destination"`
 }
 
+type UpstreamRouteView struct {
+	Pattern  string `json:"pattern"`
+	Upstream string `json:"upstream"`
+}
+
+type UpstreamRoutesView struct {
+	Routes []UpstreamRouteView `json:"routes"`
+}
+
 type UsageView struct {
 	Usage metrics.Stats `json:"usage"`
 }
@@ -34,10 +43,20 @@ type ModeView struct {
 }
 
 type ModeArgumentsView struct {
-	Headers            []string `json:"headersWhitelist,omitempty"`
-	MatchingStrategy   *string  `json:"matchingStrategy,omitempty"`
-	Stateful           bool     `json:"stateful,omitempty"`
-	OverwriteDuplicate bool     `json:"overwriteDuplicate,omitempty"`
+	Headers             []string `json:"headersWhitelist,omitempty"`
+	MatchingStrategy    *string  `json:"matchingStrategy,omitempty"`
+	Stateful            bool     `json:"stateful,omitempty"`
+	OverwriteDuplicate  bool     `json:"overwriteDuplicate,omitempty"`
+	OrderedHeaders      bool     `json:"orderedHeaders,omitempty"`
+	IgnoredBodyPaths    []string `json:"ignoredBodyPaths,omitempty"`
+	MaxBodySize         int      `json:"maxBodySize,omitempty"`
+	OversizedBodies     string   `json:"oversizedBodies,omitempty"`
+	RedactedHeaders     []string `json:"redactedHeaders,omitempty"`
+	RedactedQueryParams []string `json:"redactedQueryParams,omitempty"`
+	RedactedBodyPaths   []string `json:"redactedBodyPaths,omitempty"`
+	Retries             int      `json:"retries,omitempty"`
+	RetryDelay          int      `json:"retryDelay,omitempty"`
+	RetryNonIdempotent  bool     `json:"retryNonIdempotent,omitempty"`
 }
 
 type IsWebServerView struct {
@@ -84,6 +103,15 @@ type ClosestMissView struct {
 	MissedFields   []string              `json:"missedFields"`
 }
 
+// MatchPreviewView - the response Hoverfly would serve for a request, or the closest miss if no pair matches it
+type MatchPreviewView struct {
+	Matched     bool                   `json:"matched"`
+	PairIndex   *int                   `json:"pairIndex,omitempty"`
+	Response    *ResponseDetailsViewV5 `json:"response,omitempty"`
+	ClosestMiss *ClosestMissView       `json:"closestMiss,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+}
+
 type JournalView struct {
 	Journal []JournalEntryView `json:"journal"`
 	Offset  int                `json:"offset"`
@@ -138,3 +166,11 @@ type DiffFilterView struct {
 	ExcludedHeaders        []string `json:"excludedHeaders"`
 	ExcludedResponseFields []string `json:"excludedResponseFields"`
 }
+
+// MissResponseView - the response returned in simulate mode when no pair matches a request
+type MissResponseView struct {
+	Status    int                 `json:"status,omitempty"`
+	Body      string              `json:"body,omitempty"`
+	Headers   map[string][]string `json:"headers,omitempty"`
+	Templated bool                `json:"templated,omitempty"`
+}
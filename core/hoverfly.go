@@ -1,6 +1,8 @@
 package hoverfly
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/SpectoLabs/goproxy"
 	"github.com/SpectoLabs/hoverfly/core/authentication/backends"
@@ -12,15 +14,26 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/metrics"
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/SpectoLabs/hoverfly/core/requestlog"
 	"github.com/SpectoLabs/hoverfly/core/state"
 	"github.com/SpectoLabs/hoverfly/core/templating"
+	"github.com/boltdb/bolt"
 	log "github.com/sirupsen/logrus"
+	"math"
 	"net"
 	"net/http"
+	"regexp"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
+// version of Hoverfly, which release builds set with
+// -ldflags "-X github.com/SpectoLabs/hoverfly/core.version=<tag>"
+var version = "v1.5.2"
+
 // Hoverfly provides access to hoverfly - updating/starting/stopping proxy, http client and configuration, cache access
 type Hoverfly struct {
 	CacheMatcher   matching.CacheMatcher
@@ -32,9 +45,13 @@ type Hoverfly struct {
 
 	Proxy   *goproxy.ProxyHttpServer
 	SL      *StoppableListener
+	server  *http.Server
 	mu      sync.Mutex
 	version string
 
+	// proxyAddr - address the proxy is listening on, empty while it is stopped
+	proxyAddr atomic.Value
+
 	modeMap map[string]modes.Mode
 
 	state *state.State
@@ -45,6 +62,18 @@ type Hoverfly struct {
 	templator     *templating.Templator
 
 	responsesDiff map[v2.SimpleRequestDefinitionView][]v2.DiffReport
+
+	missResponse   *models.ResponseDetails
+	missResponseMu sync.RWMutex
+
+	clientAuthenticationDestination *regexp.Regexp
+	clientAuthenticationHTTP        *http.Client
+
+	requestLog *requestlog.RequestLog
+
+	// cacheDB - database the request cache and the simulation pairs are persisted to, nil when they are not
+	cacheDB   *bolt.DB
+	persistMu sync.Mutex
 }
 
 func NewHoverfly() *Hoverfly {
@@ -63,7 +92,7 @@ func NewHoverfly() *Hoverfly {
 		responsesDiff:  make(map[v2.SimpleRequestDefinitionView][]v2.DiffReport),
 	}
 
-	hoverfly.version = "v1.5.2"
+	hoverfly.version = version
 
 	log.AddHook(hoverfly.StoreLogsHook)
 
@@ -78,7 +107,7 @@ func NewHoverfly() *Hoverfly {
 
 	hoverfly.modeMap = modeMap
 
-	hoverfly.HTTP = GetDefaultHoverflyHTTPClient(hoverfly.Cfg.TLSVerification, hoverfly.Cfg.UpstreamProxy)
+	hoverfly.HTTP = GetDefaultHoverflyHTTPClient(hoverfly.Cfg.TLSVerification, hoverfly.Cfg.UpstreamProxy, hoverfly.Cfg.UpstreamRootCAs, hoverfly.Cfg.UpstreamTimeout)
 
 	return hoverfly
 }
@@ -86,14 +115,19 @@ func NewHoverfly() *Hoverfly {
 func NewHoverflyWithConfiguration(cfg *Configuration) *Hoverfly {
 	hoverfly := NewHoverfly()
 
-	var requestCache cache.FastCache
-	if !cfg.DisableCache {
-		if cfg.CacheSize > 0 {
-			requestCache, _ = cache.NewLRUCache(cfg.CacheSize)
-		} else {
-			// Backward compatibility, always set default cache if cache size is not configured
-			requestCache = cache.NewDefaultLRUCache()
-		}
+	db, err := OpenCacheDatabase(cfg)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Error("Failed to open cache database, nothing will be persisted")
+	}
+
+	requestCache, err := NewRequestCache(cfg, db)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Error("Failed to create request cache, using an in-memory cache instead")
+		requestCache = cache.NewDefaultLRUCache()
 	}
 
 	hoverfly.CacheMatcher = matching.CacheMatcher{
@@ -102,11 +136,64 @@ func NewHoverflyWithConfiguration(cfg *Configuration) *Hoverfly {
 	}
 
 	hoverfly.Cfg = cfg
-	hoverfly.HTTP = GetDefaultHoverflyHTTPClient(cfg.TLSVerification, cfg.UpstreamProxy)
+	hoverfly.HTTP = GetDefaultHoverflyHTTPClient(cfg.TLSVerification, cfg.UpstreamProxy, cfg.UpstreamRootCAs, cfg.UpstreamTimeout)
+
+	if db != nil {
+		if err := hoverfly.UseCacheDatabase(db); err != nil {
+			log.WithFields(log.Fields{
+				"error": err.Error(),
+				"path":  cfg.CacheDatabasePath,
+			}).Error("Failed to load simulation pairs from the cache database")
+		}
+	}
+
+	if err := hoverfly.LoadRequestLog(); err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+			"path":  cfg.RequestLogPath,
+		}).Error("Failed to open request log, requests will not be logged")
+	}
+
+	if cfg.RandomSeed != 0 {
+		templating.SeedRandom(cfg.RandomSeed)
+	}
 
 	return hoverfly
 }
 
+// NewRequestCache returns the request cache for the configuration. The cache is persisted to the database when one
+// is given, otherwise it is an in-memory LRU cache. Both hold up to the configured cache size. It is nil when caching
+// is disabled.
+func NewRequestCache(cfg *Configuration, db *bolt.DB) (cache.FastCache, error) {
+	if cfg.DisableCache {
+		return nil, nil
+	}
+
+	if db != nil {
+		size := cfg.CacheSize
+		if size <= 0 {
+			// the same size as the default in-memory cache
+			size = 1000
+		}
+		requestCache, err := cache.NewBoltDBFastCache(db, []byte(RequestCacheBucketName), matching.CachedResponseCodec{}, size)
+		if err != nil {
+			return nil, err
+		}
+		return requestCache, nil
+	}
+
+	if cfg.CacheSize > 0 {
+		requestCache, err := cache.NewLRUCache(cfg.CacheSize)
+		if err != nil {
+			return nil, err
+		}
+		return requestCache, nil
+	}
+
+	// Backward compatibility, always set default cache if cache size is not configured
+	return cache.NewDefaultLRUCache(), nil
+}
+
 // GetNewHoverfly returns a configured ProxyHttpServer and DBClient
 func GetNewHoverfly(cfg *Configuration, requestCache cache.FastCache, authentication backends.Authentication) *Hoverfly {
 	hoverfly := NewHoverfly()
@@ -121,7 +208,7 @@ func GetNewHoverfly(cfg *Configuration, requestCache cache.FastCache, authentica
 	}
 
 	hoverfly.Authentication = authentication
-	hoverfly.HTTP = GetDefaultHoverflyHTTPClient(cfg.TLSVerification, cfg.UpstreamProxy)
+	hoverfly.HTTP = GetDefaultHoverflyHTTPClient(cfg.TLSVerification, cfg.UpstreamProxy, cfg.UpstreamRootCAs, cfg.UpstreamTimeout)
 	hoverfly.Cfg = cfg
 
 	return hoverfly
@@ -142,6 +229,7 @@ func (hf *Hoverfly) StartProxy() error {
 
 	log.WithFields(log.Fields{
 		"destination": hf.Cfg.Destination,
+		"host":        hf.Cfg.ListenOnHost,
 		"port":        hf.Cfg.ProxyPort,
 		"mode":        hf.Cfg.GetMode(),
 	}).Info("current proxy configuration")
@@ -149,6 +237,9 @@ func (hf *Hoverfly) StartProxy() error {
 	// creating TCP listener
 	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%s", hf.Cfg.ListenOnHost, hf.Cfg.ProxyPort))
 	if err != nil {
+		if errors.Is(err, syscall.EADDRINUSE) {
+			return fmt.Errorf("Proxy port %s is already in use, stop the process using it or choose another port with -pp", hf.Cfg.ProxyPort)
+		}
 		return err
 	}
 
@@ -157,7 +248,19 @@ func (hf *Hoverfly) StartProxy() error {
 		return err
 	}
 	hf.SL = sl
-	server := http.Server{}
+	hf.proxyAddr.Store(sl.Addr().String())
+	var handler http.Handler = hf.Proxy
+	if !hf.Cfg.Webserver {
+		handler = withWebSocketPassthrough(hf, handler)
+	}
+	if hf.Cfg.AdminOnProxyPort {
+		adminApi := AdminApi{}
+		handler = withAdminOnPathPrefix(adminApi.Handler(hf), handler)
+		log.WithField("prefix", AdminPathPrefix).Info("Serving the admin API on the proxy port")
+	}
+
+	server := &http.Server{Handler: handler, ConnContext: withConnection}
+	hf.server = server
 
 	hf.Cfg.ProxyControlWG.Add(1)
 
@@ -167,17 +270,49 @@ func (hf *Hoverfly) StartProxy() error {
 			hf.Cfg.ProxyControlWG.Done()
 		}()
 		log.Info("serving proxy")
-		server.Handler = hf.Proxy
 		log.Warn(server.Serve(sl))
 	}()
 
 	return nil
 }
 
-// StopProxy - stops proxy
+// StopProxy - stops accepting new connections and gives in-flight requests up to the
+// configured grace period to complete before closing the remaining connections
 func (hf *Hoverfly) StopProxy() {
-	hf.SL.Stop()
+	if hf.server == nil {
+		return
+	}
+	hf.proxyAddr.Store("")
+
+	ctx, cancel := context.WithTimeout(context.Background(), hf.Cfg.ShutdownGracePeriod)
+	defer cancel()
+
+	if err := hf.server.Shutdown(ctx); err != nil {
+		log.WithFields(log.Fields{
+			"error":       err.Error(),
+			"gracePeriod": hf.Cfg.ShutdownGracePeriod,
+		}).Warn("Proxy requests did not complete within the grace period, closing their connections")
+		hf.server.Close()
+	}
+
 	hf.Cfg.ProxyControlWG.Wait()
+	hf.server = nil
+}
+
+// IsProxyReady - reports whether the proxy listener is accepting connections
+func (hf *Hoverfly) IsProxyReady() bool {
+	addr, _ := hf.proxyAddr.Load().(string)
+	if addr == "" {
+		return false
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+
+	return true
 }
 
 // processRequest - processes incoming requests and based on proxy state (record/playback)
@@ -197,6 +332,11 @@ func (hf *Hoverfly) processRequest(req *http.Request) *http.Response {
 	modeName := hf.Cfg.GetMode()
 	mode := hf.modeMap[modeName]
 	result, err := mode.Process(req, requestDetails)
+	if err == nil && result.Matched {
+		result = hf.applyRateLimit(req, requestDetails, result)
+		result = hf.applyFault(req, requestDetails, result)
+	}
+	hf.logRequest(requestDetails, modeName, result)
 
 	if err == nil && hf.Cfg.CORS.Enabled {
 		hf.Cfg.CORS.AddCORSHeaders(req, result.Response)
@@ -216,9 +356,82 @@ func (hf *Hoverfly) processRequest(req *http.Request) *http.Response {
 		hf.applyGlobalDelay(requestDetails)
 	}
 
+	if result.PairDelay != nil {
+		log.Debug("Applying pair delay")
+		time.Sleep(time.Duration(result.PairDelay.GenerateDelay()) * time.Millisecond)
+	}
+
 	return result.Response
 }
 
+// applyRateLimit replaces a response served from the simulation with 429 Too Many Requests when a
+// rate limit for the request has been reached, to simulate an upstream which is throttling clients
+func (hf *Hoverfly) applyRateLimit(req *http.Request, requestDetails models.RequestDetails, result modes.ProcessResult) modes.ProcessResult {
+	rateLimit := hf.Simulation.RateLimits.GetRateLimit(requestDetails)
+	if rateLimit == nil {
+		return result
+	}
+
+	allowed, retryAfter := rateLimit.Allow(time.Now())
+	if allowed {
+		return result
+	}
+
+	log.WithFields(log.Fields{
+		"urlPattern": rateLimit.UrlPattern,
+		"limit":      rateLimit.Limit,
+		"window":     rateLimit.Window,
+	}).Info("Rate limit reached, returning Too Many Requests")
+
+	response := goproxy.NewResponse(req, goproxy.ContentTypeText, http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests))
+	response.Header.Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+
+	return modes.ProcessResult{Response: response, Matched: true}
+}
+
+// LoadRequestLog - opens the file set by -request-log so that every request the proxy serves is
+// appended to it. Requests are not logged when no file is set.
+func (hf *Hoverfly) LoadRequestLog() error {
+	if hf.requestLog != nil {
+		hf.requestLog.Close()
+		hf.requestLog = nil
+	}
+	if hf.Cfg.RequestLogPath == "" {
+		return nil
+	}
+
+	requestLog, err := requestlog.NewFileRequestLog(hf.Cfg.RequestLogPath)
+	if err != nil {
+		return err
+	}
+
+	hf.requestLog = requestLog
+	return nil
+}
+
+func (hf *Hoverfly) logRequest(requestDetails models.RequestDetails, modeName string, result modes.ProcessResult) {
+	if hf.requestLog == nil || result.Response == nil {
+		return
+	}
+
+	err := hf.requestLog.Log(requestlog.Entry{
+		Time:        time.Now().UTC(),
+		Mode:        modeName,
+		Method:      requestDetails.Method,
+		Scheme:      requestDetails.Scheme,
+		Destination: requestDetails.Destination,
+		Path:        requestDetails.Path,
+		Matched:     result.Matched,
+		Status:      result.Response.StatusCode,
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+			"path":  hf.Cfg.RequestLogPath,
+		}).Error("Failed to write to the request log")
+	}
+}
+
 func (hf *Hoverfly) applyResponseDelay(result modes.ProcessResult) {
 	if result.FixedDelay > 0 {
 		time.Sleep(time.Duration(result.FixedDelay) * time.Millisecond)
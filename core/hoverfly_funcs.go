@@ -2,15 +2,19 @@ package hoverfly
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/SpectoLabs/hoverfly/core/errors"
 	v2 "github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/matching"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/metrics"
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/modes"
 	"github.com/SpectoLabs/hoverfly/core/util"
@@ -24,6 +28,12 @@ func (hf *Hoverfly) DoRequest(request *http.Request) (*http.Response, error) {
 	// We can't have this set. And it only contains "/pkg/net/http/" anyway
 	request.RequestURI = ""
 
+	if upstream := hf.Cfg.UpstreamRoutes.Find(request.URL.Host); upstream != nil {
+		request.URL.Scheme = upstream.Scheme
+		request.URL.Host = upstream.Host
+		request.Host = upstream.Host
+	}
+
 	client, err := GetHttpClient(hf, request.Host)
 	if err != nil {
 		return nil, err
@@ -48,15 +58,24 @@ func (hf *Hoverfly) DoRequest(request *http.Request) (*http.Response, error) {
 func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError) {
 	var response models.ResponseDetails
 	var cachedResponse *models.CachedResponse
+	var matchedPairIndex int
 
 	cachedResponse, cacheErr := hf.CacheMatcher.GetCachedResponse(&requestDetails)
 
+	if cacheErr == nil {
+		hf.Counter.CountEvent(metrics.CacheHit)
+	} else if hf.CacheMatcher.RequestCache != nil {
+		hf.Counter.CountEvent(metrics.CacheMiss)
+	}
+
 	// Get the cached response and return if there is a miss
 	if cacheErr == nil && cachedResponse.MatchingPair == nil {
+		hf.Counter.CountEvent(metrics.MatchMiss)
 		return nil, errors.MatchingFailedError(cachedResponse.ClosestMiss)
 		// If it's cached, use that response
 	} else if cacheErr == nil {
 		response = cachedResponse.MatchingPair.Response
+		matchedPairIndex = cachedResponse.MatchingPairIndex
 		//If it's not cached, perform matching to find a hit
 	} else {
 		mode := (hf.modeMap[modes.Simulate]).(*modes.SimulateMode)
@@ -66,7 +85,7 @@ func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.R
 
 		// Cache result
 		if result.Cacheable {
-			cachedResponse, _ = hf.CacheMatcher.SaveRequestMatcherResponsePair(requestDetails, result.Pair, result.Error)
+			cachedResponse, _ = hf.CacheMatcher.SaveRequestMatcherResponsePair(requestDetails, result.Pair, result.PairIndex, result.Error)
 		}
 
 		// If we miss, just return
@@ -79,9 +98,12 @@ func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.R
 				"method":      requestDetails.Method,
 			}).Warn("Failed to find matching request from simulation")
 
+			hf.Counter.CountEvent(metrics.MatchMiss)
 			return nil, errors.MatchingFailedError(result.Error.ClosestMiss)
 		} else {
+			hf.Counter.CountEvent(metrics.MatchHit)
 			response = result.Pair.Response
+			matchedPairIndex = result.PairIndex
 		}
 	}
 
@@ -102,6 +124,13 @@ func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.R
 			log.Warnf("Failed to applying headers templating: %s", err.Error())
 		}
 
+		responseOrderedHeaders, err := hf.applyOrderedHeadersTemplating(&requestDetails, &response, cachedResponse)
+		if err == nil {
+			response.OrderedHeaders = responseOrderedHeaders
+		} else {
+			log.Warnf("Failed to applying ordered headers templating: %s", err.Error())
+		}
+
 		responseTransitionsState, err := hf.applyTransitionsStateTemplating(&requestDetails, &response, cachedResponse)
 		if err == nil {
 			response.TransitionsState = responseTransitionsState
@@ -119,9 +148,101 @@ func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.R
 		hf.state.RemoveState(response.RemovesState)
 	}
 
+	if hf.Cfg.MatchedPairHeaders {
+		response.Headers = hf.addMatchedPairHeaders(response.Headers, matchedPairIndex)
+	}
+
 	return &response, nil
 }
 
+// PreviewMatch returns the response that would be served for the request in simulate mode, or the
+// closest miss when no pair matches it. Nothing is proxied, cached, counted or changed in the state.
+func (hf *Hoverfly) PreviewMatch(requestView v2.RequestDetailsView) v2.MatchPreviewView {
+	if requestView.Query == nil {
+		requestView.Query = util.StringToPointer("")
+	}
+	requestDetails := models.NewRequestDetailsFromRequest(requestView)
+
+	mode := (hf.modeMap[modes.Simulate]).(*modes.SimulateMode)
+	result := matching.Match(mode.MatchingStrategy, requestDetails, hf.Cfg.Webserver, hf.Simulation, hf.state)
+
+	if result.Error != nil {
+		preview := v2.MatchPreviewView{
+			Error: errors.MatchingFailedError(result.Error.ClosestMiss).Error(),
+		}
+		if result.Error.ClosestMiss != nil {
+			preview.ClosestMiss = result.Error.ClosestMiss.BuildView()
+		}
+		return preview
+	}
+
+	response := result.Pair.Response
+	if response.Templated {
+		responseBody, err := hf.applyBodyTemplating(&requestDetails, &response, nil)
+		if err == nil {
+			response.Body = responseBody
+		}
+
+		responseHeaders, err := hf.applyHeadersTemplating(&requestDetails, &response, nil)
+		if err == nil {
+			response.Headers = responseHeaders
+		}
+
+		responseOrderedHeaders, err := hf.applyOrderedHeadersTemplating(&requestDetails, &response, nil)
+		if err == nil {
+			response.OrderedHeaders = responseOrderedHeaders
+		}
+	}
+
+	responseView := response.ConvertToResponseDetailsViewV5()
+	pairIndex := result.PairIndex
+
+	return v2.MatchPreviewView{
+		Matched:   true,
+		PairIndex: &pairIndex,
+		Response:  &responseView,
+	}
+}
+
+// RenderMissResponse returns the configured response for a request which matched no pair, with its
+// body rendered if it is templated, or nil when Hoverfly's own error should be returned.
+func (hf *Hoverfly) RenderMissResponse(requestDetails models.RequestDetails) *models.ResponseDetails {
+	hf.missResponseMu.RLock()
+	defer hf.missResponseMu.RUnlock()
+
+	if hf.missResponse == nil {
+		return nil
+	}
+
+	response := *hf.missResponse
+	if response.Templated {
+		template, err := hf.templator.ParseTemplate(response.Body)
+		if err == nil {
+			response.Body, err = hf.templator.RenderTemplate(template, &requestDetails, hf.Simulation.Literals, hf.Simulation.Vars, hf.state.State)
+		}
+		if err != nil {
+			log.Warnf("Failed to applying miss response templating: %s", err.Error())
+			response.Body = hf.missResponse.Body
+		}
+	}
+
+	return &response
+}
+
+// addMatchedPairHeaders returns a copy of the headers with the position of the matched pair in
+// the simulation, so that tests can tell which pair served a request
+func (hf *Hoverfly) addMatchedPairHeaders(headers map[string][]string, matchedPairIndex int) map[string][]string {
+	withMatchedPair := map[string][]string{}
+	for key, values := range headers {
+		withMatchedPair[key] = values
+	}
+
+	withMatchedPair[modes.MatchedHeader] = []string{"true"}
+	withMatchedPair[modes.MatchedPairIndexHeader] = []string{strconv.Itoa(matchedPairIndex)}
+
+	return withMatchedPair
+}
+
 func (hf *Hoverfly) readResponseBodyFiles(pairs []v2.RequestMatcherResponsePairViewV5) v2.SimulationImportResult {
 	result := v2.SimulationImportResult{}
 
@@ -287,8 +408,47 @@ func (hf *Hoverfly) applyHeadersTemplating(requestDetails *models.RequestDetails
 	return headers, nil
 }
 
+func (hf *Hoverfly) applyOrderedHeadersTemplating(requestDetails *models.RequestDetails, response *models.ResponseDetails, cachedResponse *models.CachedResponse) ([][2]string, error) {
+	if response.OrderedHeaders == nil {
+		return nil, nil
+	}
+
+	var orderedHeadersTemplates []*raymond.Template
+	if cachedResponse != nil && cachedResponse.ResponseOrderedHeadersTemplates != nil {
+		orderedHeadersTemplates = cachedResponse.ResponseOrderedHeadersTemplates
+	} else {
+		// Parse and cache the templates of the header values, the names are never templated
+		orderedHeadersTemplates = make([]*raymond.Template, len(response.OrderedHeaders))
+		for i, header := range response.OrderedHeaders {
+			orderedHeadersTemplates[i], _ = hf.templator.ParseTemplate(header[1])
+		}
+
+		if cachedResponse != nil {
+			cachedResponse.ResponseOrderedHeadersTemplates = orderedHeadersTemplates
+		}
+	}
+
+	var err error
+	orderedHeaders := make([][2]string, len(response.OrderedHeaders))
+
+	// Render ordered headers templates
+	for i, header := range response.OrderedHeaders {
+		orderedHeaders[i][0] = header[0]
+		orderedHeaders[i][1], err = hf.templator.RenderTemplate(orderedHeadersTemplates[i], requestDetails, hf.Simulation.Literals, hf.Simulation.Vars, hf.state.State)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return orderedHeaders, nil
+}
+
 // save gets request fingerprint, extracts request body, status code and headers, then saves it to cache
 func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.ResponseDetails, modeArgs *modes.ModeArguments) error {
+	redactedRequest := redactRequest(*request, modeArgs)
+	request = &redactedRequest
+
 	body := []models.RequestFieldMatchers{
 		{
 			Matcher: matchers.Exact,
@@ -358,7 +518,12 @@ func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.Respon
 	if len(request.Query) > 0 {
 		queries = &models.QueryRequestFieldMatchers{}
 		for key, values := range request.Query {
-			queries.Add(key, getRequestMatcherForMultipleValues(values))
+			queryMatchers := getRequestMatcherForMultipleValues(values)
+			// the order a key's values are sent in does not affect matching
+			if queryMatchers[0].Matcher == matchers.Array {
+				queryMatchers[0].Config = map[string]interface{}{matchers.IgnoreOrder: true}
+			}
+			queries.Add(key, queryMatchers)
 		}
 	}
 
@@ -394,20 +559,145 @@ func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.Respon
 		},
 		Response: *response,
 	}
+
+	if !hf.Cfg.PreserveContentEncoding {
+		pair.Response = decodeResponseBody(pair.Response)
+	}
+
+	pair.Response = redactResponse(pair.Response, modeArgs)
+
+	// a streamed body is already cut at the max body size when it is read
+	if modeArgs.MaxBodySize > 0 && (pair.Response.BodyTruncated || len(pair.Response.Body) > modeArgs.MaxBodySize) {
+		if modeArgs.OversizedBodies == modes.SkipOversizedBodies {
+			log.WithFields(log.Fields{
+				"destination": request.Destination,
+				"path":        request.Path,
+				"bodySize":    len(pair.Response.Body),
+				"maxBodySize": modeArgs.MaxBodySize,
+			}).Warn("Response body is larger than the max body size, the pair was not captured")
+			return nil
+		}
+
+		pair.Response = truncateResponseBody(pair.Response, modeArgs.MaxBodySize)
+	}
+
+	if modeArgs.OrderedHeaders {
+		pair.Response = orderResponseHeaders(pair.Response)
+	}
+
 	if modeArgs.Stateful {
 		hf.Simulation.AddPairInSequence(&pair, hf.state)
+		hf.persistPairs()
 	} else if modeArgs.OverwriteDuplicate {
 		hf.Simulation.AddPairWithOverwritingDuplicate(&pair)
-	} else {
-		hf.Simulation.AddPair(&pair)
+		hf.persistPairs()
+	} else if hf.Simulation.AddPair(&pair) {
+		hf.persistPair(&pair)
 	}
 
 	return nil
 }
 
+// truncateResponseBody cuts the body at maxBodySize bytes and marks it as truncated. Content-Length is dropped,
+// as it no longer matches the body.
+func truncateResponseBody(response models.ResponseDetails, maxBodySize int) models.ResponseDetails {
+	if _, found := response.Headers["Content-Length"]; found {
+		headers := map[string][]string{}
+		for key, values := range response.Headers {
+			if key != "Content-Length" {
+				headers[key] = values
+			}
+		}
+		response.Headers = headers
+	}
+
+	if len(response.Body) > maxBodySize {
+		response.Body = response.Body[:maxBodySize]
+	}
+	response.BodyTruncated = true
+
+	return response
+}
+
+// decodeResponseBody decompresses a gzip or deflate encoded response body so that it is stored
+// as plain text. The Content-Encoding header is dropped along with Content-Length, which no
+// longer matches the body. Bodies with an unsupported or invalid encoding are left as they are.
+func decodeResponseBody(response models.ResponseDetails) models.ResponseDetails {
+	var encodings []string
+	for _, value := range response.Headers["Content-Encoding"] {
+		encodings = append(encodings, strings.Split(value, ",")...)
+	}
+	if len(encodings) == 0 {
+		return response
+	}
+
+	body := []byte(response.Body)
+	// encodings are listed in the order they were applied, so are removed in reverse
+	for i := len(encodings) - 1; i >= 0; i-- {
+		var err error
+		switch strings.ToLower(strings.TrimSpace(encodings[i])) {
+		case "gzip", "x-gzip":
+			body, err = util.DecompressGzip(body)
+		case "deflate":
+			body, err = util.DecompressDeflate(body)
+		case "identity":
+		default:
+			return response
+		}
+		// a truncated body is decoded up to where it was cut
+		if err == io.ErrUnexpectedEOF && response.BodyTruncated {
+			err = nil
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error":    err.Error(),
+				"encoding": encodings[i],
+			}).Warn("Could not decompress captured response body, storing it encoded")
+			return response
+		}
+	}
+
+	headers := map[string][]string{}
+	for key, values := range response.Headers {
+		if key != "Content-Encoding" && key != "Content-Length" {
+			headers[key] = values
+		}
+	}
+
+	response.Body = string(body)
+	response.Headers = headers
+	return response
+}
+
+// orderResponseHeaders moves the response headers into the ordered list. Header names are sorted,
+// as the HTTP client does not keep the order they were received in, but repeated headers such
+// as Set-Cookie keep the order the destination sent them in.
+func orderResponseHeaders(response models.ResponseDetails) models.ResponseDetails {
+	names := make([]string, 0, len(response.Headers))
+	for name := range response.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ordered := [][2]string{}
+	for _, name := range names {
+		for _, value := range response.Headers[name] {
+			ordered = append(ordered, [2]string{name, value})
+		}
+	}
+
+	response.OrderedHeaders = ordered
+	response.Headers = nil
+	return response
+}
+
 func (hf *Hoverfly) ApplyMiddleware(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
 	if hf.Cfg.Middleware.IsSet() {
-		return hf.Cfg.Middleware.Execute(pair)
+		result, err := hf.Cfg.Middleware.Execute(pair)
+		if err != nil {
+			hf.Counter.CountEvent(metrics.MiddlewareFailure)
+		}
+		return result, err
 	}
 
 	return pair, nil
@@ -2,9 +2,13 @@ package hoverfly
 
 import (
 	"bytes"
+	"compress/zlib"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/modes"
@@ -13,7 +17,9 @@ import (
 	v2 "github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/matching"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/metrics"
 	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/util"
 	. "github.com/onsi/gomega"
 )
 
@@ -47,6 +53,50 @@ func Test_Hoverfly_DoRequest_FailedHTTP(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 }
 
+func Test_Hoverfly_DoRequest_SendsRequestToMatchingUpstreamRoute(t *testing.T) {
+	RegisterTestingT(t)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("upstream " + r.URL.Path))
+	}))
+	defer upstream.Close()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	Expect(unit.AddUpstreamRoute(v2.UpstreamRouteView{Pattern: `^payments\.internal$`, Upstream: upstream.URL})).To(BeNil())
+
+	request, err := http.NewRequest("GET", "http://payments.internal/charges", nil)
+	Expect(err).To(BeNil())
+
+	response, err := unit.DoRequest(request)
+	Expect(err).To(BeNil())
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(body)).To(Equal("upstream /charges"))
+}
+
+func Test_Hoverfly_DoRequest_SendsRequestToOriginalHostWhenNoUpstreamRouteMatches(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("original"))
+	}))
+	defer server.Close()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	Expect(unit.AddUpstreamRoute(v2.UpstreamRouteView{Pattern: `^payments\.internal$`, Upstream: "localhost:1"})).To(BeNil())
+
+	request, err := http.NewRequest("GET", server.URL, nil)
+	Expect(err).To(BeNil())
+
+	response, err := unit.DoRequest(request)
+	Expect(err).To(BeNil())
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(body)).To(Equal("original"))
+}
+
 func Test_Hoverfly_GetResponse_CanReturnResponseFromCache(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -81,7 +131,7 @@ func Test_Hoverfly_GetResponse_CanReturnResponseFromCache(t *testing.T) {
 			Status: 200,
 			Body:   "cached response",
 		},
-	}, nil)
+	}, 0, nil)
 
 	response, err := unit.GetResponse(models.RequestDetails{
 		Destination: "somehost.com",
@@ -141,6 +191,43 @@ func Test_Hoverfly_GetResponse_CanReturnResponseFromSimulationAndNotCache(t *tes
 	Expect(response.Body).To(Equal("response body"))
 }
 
+func Test_Hoverfly_GetResponse_CountsMatcherAndCacheHitsInStats(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "somehost.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 200,
+			Body:   "response body",
+		},
+	})
+
+	requestDetails := models.RequestDetails{
+		Destination: "somehost.com",
+		Method:      "POST",
+		Scheme:      "http",
+	}
+
+	unit.GetResponse(requestDetails)
+	unit.GetResponse(requestDetails)
+
+	Expect(unit.GetStats().Matching).To(Equal(metrics.MatchingStats{
+		CacheHits:   1,
+		CacheMisses: 1,
+		MatcherHits: 1,
+		Misses:      0,
+	}))
+}
+
 func Test_Hoverfly_GetResponse_WillCacheResponseIfNotInCache(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -181,7 +268,7 @@ func Test_Hoverfly_GetResponse_WillCacheResponseIfNotInCache(t *testing.T) {
 
 	Expect(unit.CacheMatcher.RequestCache.RecordsCount()).Should(Equal(1))
 
-	cachedRequestResponsePair, found := unit.CacheMatcher.RequestCache.Get("75b4ae6efa2a3f6d3ee6b9fed4d8c8c5")
+	cachedRequestResponsePair, found := unit.CacheMatcher.RequestCache.Get("a78b546cd3df5abb776bee820447d42d")
 	Expect(found).To(BeTrue())
 
 	Expect(cachedRequestResponsePair.(*models.CachedResponse).MatchingPair.Response.Body).To(Equal("response body"))
@@ -216,7 +303,7 @@ func Test_Hoverfly_GetResponse_WillReturnCachedResponseIfHeaderMatchIsFalse(t *t
 		Response: models.ResponseDetails{
 			Body: "cached response",
 		},
-	}, nil)
+	}, 0, nil)
 
 	response, err := unit.GetResponse(requestDetails)
 	Expect(err).To(BeNil())
@@ -245,6 +332,25 @@ func Test_Hoverfly_GetResponse_WillCacheMisses(t *testing.T) {
 	Expect(cachedResponse.ClosestMiss).To(BeNil())
 }
 
+func Test_Hoverfly_GetResponse_CountsCacheAndMatcherMisses(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	requestDetails := models.RequestDetails{
+		Destination: "somehost.com",
+		Method:      "POST",
+		Scheme:      "http",
+	}
+
+	unit.GetResponse(requestDetails)
+	unit.GetResponse(requestDetails)
+
+	Expect(unit.Counter.EventCount(metrics.CacheMiss)).To(Equal(int64(1)))
+	Expect(unit.Counter.EventCount(metrics.CacheHit)).To(Equal(int64(1)))
+	Expect(unit.Counter.EventCount(metrics.MatchMiss)).To(Equal(int64(2)))
+}
+
 func Test_Hoverfly_GetResponse_WillCacheClosestMiss(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -333,7 +439,7 @@ func Test_Hoverfly_GetResponse_WillCacheTemplateIfNotInCache(t *testing.T) {
 
 	Expect(unit.CacheMatcher.RequestCache.RecordsCount()).Should(Equal(1))
 
-	cachedRequestResponsePair, found := unit.CacheMatcher.RequestCache.Get("75b4ae6efa2a3f6d3ee6b9fed4d8c8c5")
+	cachedRequestResponsePair, found := unit.CacheMatcher.RequestCache.Get("a78b546cd3df5abb776bee820447d42d")
 	Expect(found).To(BeTrue())
 
 	Expect(cachedRequestResponsePair.(*models.CachedResponse).MatchingPair.Response.Body).To(Equal("{{ randomUuid }}"))
@@ -385,7 +491,7 @@ func Test_Hoverfly_GetResponse_WillCacheHeaderTemplateIfNotInCache(t *testing.T)
 
 	Expect(unit.CacheMatcher.RequestCache.RecordsCount()).Should(Equal(1))
 
-	cachedRequestResponsePair, found := unit.CacheMatcher.RequestCache.Get("75b4ae6efa2a3f6d3ee6b9fed4d8c8c5")
+	cachedRequestResponsePair, found := unit.CacheMatcher.RequestCache.Get("a78b546cd3df5abb776bee820447d42d")
 	Expect(found).To(BeTrue())
 
 	Expect(cachedRequestResponsePair.(*models.CachedResponse).MatchingPair.Response.Headers["X-Image-Id"][0]).To(Equal("{{ randomInteger }}"))
@@ -444,7 +550,7 @@ func Test_Hoverfly_GetResponse_WillCacheTransitionStateTemplateIfNotInCache(t *t
 
 	Expect(unit.CacheMatcher.RequestCache.RecordsCount()).Should(Equal(1))
 
-	cachedRequestResponsePair, found := unit.CacheMatcher.RequestCache.Get("18349e17236c980b2e2e9ee6ea084028")
+	cachedRequestResponsePair, found := unit.CacheMatcher.RequestCache.Get("b3f21df8dc5c67e43caa67f0cef69ddc")
 	Expect(found).To(BeTrue())
 
 	Expect(cachedRequestResponsePair.(*models.CachedResponse).MatchingPair.Response.TransitionsState["status"]).To(Equal("{{ Request.QueryParam.status }}"))
@@ -495,12 +601,161 @@ func Test_Hoverfly_GetResponse_ShouldReturnEmptyTextIfResponseTemplateIsNotRende
 
 	Expect(unit.CacheMatcher.RequestCache.RecordsCount()).Should(Equal(1))
 
-	cachedRequestResponsePair, found := unit.CacheMatcher.RequestCache.Get("75b4ae6efa2a3f6d3ee6b9fed4d8c8c5")
+	cachedRequestResponsePair, found := unit.CacheMatcher.RequestCache.Get("a78b546cd3df5abb776bee820447d42d")
 	Expect(found).To(BeTrue())
 
 	Expect(cachedRequestResponsePair.(*models.CachedResponse).MatchingPair.Response.Body).To(Equal("hello {{ unknownFunc }}"))
 }
 
+func Test_Hoverfly_GetResponse_TemplatesLocationHeader(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "somehost.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status:    201,
+			Templated: true,
+			Headers: map[string][]string{
+				"Location":     {"/orders/{{ randomUuid }}"},
+				"Content-Type": {"application/json"},
+			},
+		},
+	})
+
+	response, err := unit.GetResponse(models.RequestDetails{
+		Destination: "somehost.com",
+	})
+
+	Expect(err).To(BeNil())
+	Expect(response.Headers["Location"][0]).To(MatchRegexp(`^/orders/[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`))
+	Expect(response.Headers["Content-Type"]).To(Equal([]string{"application/json"}))
+}
+
+func Test_Hoverfly_GetResponse_DoesNotTemplateHeadersOfNonTemplatedPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "somehost.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 201,
+			Headers: map[string][]string{
+				"Location": {"/orders/{{ randomUuid }}"},
+			},
+			OrderedHeaders: [][2]string{
+				{"X-Order", "{{ randomUuid }}"},
+			},
+		},
+	})
+
+	response, err := unit.GetResponse(models.RequestDetails{
+		Destination: "somehost.com",
+	})
+
+	Expect(err).To(BeNil())
+	Expect(response.Headers["Location"]).To(Equal([]string{"/orders/{{ randomUuid }}"}))
+	Expect(response.OrderedHeaders).To(Equal([][2]string{{"X-Order", "{{ randomUuid }}"}}))
+}
+
+func Test_Hoverfly_GetResponse_TemplatesOrderedHeaders(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "somehost.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status:    201,
+			Templated: true,
+			OrderedHeaders: [][2]string{
+				{"Location", "/orders/{{ Request.Path.[0] }}"},
+				{"X-Literal", "literal"},
+			},
+		},
+	})
+
+	requestDetails := models.RequestDetails{
+		Destination: "somehost.com",
+		Path:        "/1234",
+	}
+
+	for i := 0; i < 2; i++ {
+		response, err := unit.GetResponse(requestDetails)
+
+		Expect(err).To(BeNil())
+		Expect(response.OrderedHeaders).To(Equal([][2]string{
+			{"Location", "/orders/1234"},
+			{"X-Literal", "literal"},
+		}))
+	}
+
+	cachedResponse, found := unit.CacheMatcher.RequestCache.Get(requestDetails.Hash())
+	Expect(found).To(BeTrue())
+
+	Expect(cachedResponse.(*models.CachedResponse).ResponseOrderedHeadersTemplates).To(HaveLen(2))
+	Expect(cachedResponse.(*models.CachedResponse).MatchingPair.Response.OrderedHeaders[0][1]).To(Equal("/orders/{{ Request.Path.[0] }}"))
+}
+
+func Test_Hoverfly_GetResponse_EchoesRequestHeaderIntoResponseHeaderAndBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "somehost.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status:    200,
+			Body:      `{"requestId": "{{ Request.Header "X-Request-Id" }}"}`,
+			Templated: true,
+			Headers: map[string][]string{
+				"X-Request-Id": {`{{ Request.Header "X-Request-Id" }}`},
+			},
+		},
+	})
+
+	response, err := unit.GetResponse(models.RequestDetails{
+		Destination: "somehost.com",
+		Headers: map[string][]string{
+			"X-Request-Id": {"1234", "5678"},
+		},
+	})
+
+	Expect(err).To(BeNil())
+	Expect(response.Body).To(Equal(`{"requestId": "1234"}`))
+	Expect(response.Headers["X-Request-Id"]).To(Equal([]string{"1234"}))
+}
+
 func Test_Hoverfly_GetResponse_TransitioningBetweenStatesWhenSimulating(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -752,6 +1007,100 @@ func Test_Hoverfly_GetResponse_TransitioningBetweenStatesWhenSimulating(t *testi
 	Expect(string(response.Body)).To(Equal(`empty`))
 }
 
+func Test_Hoverfly_GetResponse_CanTemplateResponseFromStateCapturedInPreviousRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := `{
+		"data": {
+			"pairs": [{
+					"request": {
+						"path": [
+							{
+								"matcher": "exact",
+								"value": "/orders"
+							}
+						],
+						"method": [
+							{
+								"matcher": "exact",
+								"value": "POST"
+							}
+						]
+					},
+					"response": {
+						"status": 201,
+						"body": "created",
+						"templated": true,
+						"transitionsState": {
+							"order:id": "{{ jsonPath Request.Body '$.id' }}"
+						}
+					}
+				},
+				{
+					"request": {
+						"path": [
+							{
+								"matcher": "exact",
+								"value": "/orders"
+							}
+						],
+						"method": [
+							{
+								"matcher": "exact",
+								"value": "GET"
+							}
+						]
+					},
+					"response": {
+						"status": 200,
+						"body": "{\"id\": \"{{ state 'order:id' 'none' }}\"}",
+						"templated": true
+					}
+				}
+			],
+			"globalActions": {
+				"delays": []
+			}
+		},
+		"meta": {
+			"schemaVersion": "v5",
+			"hoverflyVersion": "v0.10.2",
+			"timeExported": "2017-02-23T12:43:48Z"
+		}
+	}`
+
+	v5 := &v2.SimulationViewV5{}
+
+	json.Unmarshal([]byte(simulation), v5)
+
+	hoverfly := NewHoverfly()
+	hoverfly.CacheMatcher = matching.CacheMatcher{
+		RequestCache: cache.NewDefaultLRUCache(),
+	}
+	hoverfly.PutSimulation(*v5)
+
+	hoverfly.SetModeWithArguments(v2.ModeView{Mode: "simulate"})
+
+	response, _ := hoverfly.GetResponse(models.RequestDetails{
+		Method: "GET",
+		Path:   "/orders",
+	})
+	Expect(string(response.Body)).To(Equal(`{"id": "none"}`))
+
+	response, _ = hoverfly.GetResponse(models.RequestDetails{
+		Method: "POST",
+		Path:   "/orders",
+		Body:   `{"id": "abc-123"}`,
+	})
+	Expect(string(response.Body)).To(Equal(`created`))
+
+	response, _ = hoverfly.GetResponse(models.RequestDetails{
+		Method: "GET",
+		Path:   "/orders",
+	})
+	Expect(string(response.Body)).To(Equal(`{"id": "abc-123"}`))
+}
+
 func Test_Hoverfly_GetResponse_GetNotRecordedRequest(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -770,7 +1119,202 @@ func Test_Hoverfly_GetResponse_GetNotRecordedRequest(t *testing.T) {
 	Expect(response).To(BeNil())
 }
 
-func Test_Hoverfly_Save_SavesRequestAndResponseToSimulation(t *testing.T) {
+func Test_Hoverfly_GetResponse_AddsMatchedPairHeadersWhenEnabled(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{MatchedPairHeaders: true})
+
+	for _, path := range []string{"/first", "/second"} {
+		unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+			RequestMatcher: models.RequestMatcher{
+				Path: []models.RequestFieldMatchers{
+					{
+						Matcher: matchers.Exact,
+						Value:   path,
+					},
+				},
+			},
+			Response: models.ResponseDetails{
+				Status:  200,
+				Body:    path,
+				Headers: map[string][]string{"Content-Type": {"text/plain"}},
+			},
+		})
+	}
+
+	// the second request is served from the cache
+	for i := 0; i < 2; i++ {
+		response, err := unit.GetResponse(models.RequestDetails{
+			Path: "/second",
+		})
+		Expect(err).To(BeNil())
+
+		Expect(response.Body).To(Equal("/second"))
+		Expect(response.Headers).To(HaveKeyWithValue("Hoverfly-Matched", []string{"true"}))
+		Expect(response.Headers).To(HaveKeyWithValue("Hoverfly-Pair-Index", []string{"1"}))
+		Expect(response.Headers).To(HaveKeyWithValue("Content-Type", []string{"text/plain"}))
+	}
+
+	Expect(unit.Simulation.GetMatchingPairs()[1].Response.Headers).To(Equal(map[string][]string{
+		"Content-Type": {"text/plain"},
+	}))
+}
+
+func Test_Hoverfly_GetResponse_MatchedPairIndexIsThePairChosenWhenMatchersAreEqual(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{MatchedPairHeaders: true})
+
+	paths := []string{"/orders/*", "/orders/*", "/*/1"}
+	for i, destination := range []string{"other.com", "test.com", "test.com"} {
+		unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+			RequestMatcher: models.RequestMatcher{
+				Destination: []models.RequestFieldMatchers{
+					{
+						Matcher: matchers.Exact,
+						Value:   destination,
+					},
+				},
+				Path: []models.RequestFieldMatchers{
+					{
+						Matcher: matchers.Glob,
+						Value:   paths[i],
+					},
+				},
+				Method: []models.RequestFieldMatchers{
+					{
+						Matcher: matchers.Exact,
+						Value:   "GET",
+					},
+				},
+			},
+			Response: models.ResponseDetails{
+				Status: 200,
+				Body:   strconv.Itoa(i),
+			},
+		})
+	}
+
+	// the second request is served from the cache
+	for i := 0; i < 2; i++ {
+		response, err := unit.GetResponse(models.RequestDetails{
+			Destination: "test.com",
+			Path:        "/orders/1",
+			Method:      "GET",
+		})
+		Expect(err).To(BeNil())
+
+		Expect(response.Body).To(Equal("2"))
+		Expect(response.Headers).To(HaveKeyWithValue("Hoverfly-Pair-Index", []string{"2"}))
+	}
+}
+
+func Test_Hoverfly_GetResponse_DoesNotAddMatchedPairHeadersByDefault(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/first",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 200,
+		},
+	})
+
+	response, err := unit.GetResponse(models.RequestDetails{
+		Path: "/first",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(response.Headers).ToNot(HaveKey("Hoverfly-Matched"))
+	Expect(response.Headers).ToNot(HaveKey("Hoverfly-Pair-Index"))
+}
+
+func Test_Hoverfly_PreviewMatch_ReturnsTheTemplatedResponseWithoutChangingCacheOrState(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/orders",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status:    201,
+			Body:      "order {{ Request.QueryParam.id }}",
+			Templated: true,
+			TransitionsState: map[string]string{
+				"orders": "created",
+			},
+		},
+	})
+
+	preview := unit.PreviewMatch(v2.RequestDetailsView{
+		Path:  util.StringToPointer("/orders"),
+		Query: util.StringToPointer("id=7"),
+	})
+
+	Expect(preview.Matched).To(BeTrue())
+	Expect(*preview.PairIndex).To(Equal(0))
+	Expect(preview.Response.Status).To(Equal(201))
+	Expect(preview.Response.Body).To(Equal("order 7"))
+	Expect(preview.ClosestMiss).To(BeNil())
+
+	Expect(unit.CacheMatcher.RequestCache.RecordsCount()).Should(Equal(0))
+	Expect(unit.state.State).ToNot(HaveKey("orders"))
+}
+
+func Test_Hoverfly_PreviewMatch_ReturnsTheClosestMissWhenNoPairMatches(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/orders",
+				},
+			},
+			Method: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "POST",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 201,
+		},
+	})
+
+	preview := unit.PreviewMatch(v2.RequestDetailsView{
+		Path:   util.StringToPointer("/orders"),
+		Method: util.StringToPointer("GET"),
+	})
+
+	Expect(preview.Matched).To(BeFalse())
+	Expect(preview.PairIndex).To(BeNil())
+	Expect(preview.Response).To(BeNil())
+	Expect(preview.Error).To(ContainSubstring("Could not find a match for request"))
+	Expect(preview.ClosestMiss.MissedFields).To(ConsistOf("method"))
+	Expect(preview.ClosestMiss.Response.Status).To(Equal(201))
+}
+
+func Test_Hoverfly_Save_SavesRequestAndResponseToSimulation(t *testing.T) {
 	RegisterTestingT(t)
 
 	unit := NewHoverflyWithConfiguration(&Configuration{})
@@ -821,6 +1365,224 @@ func Test_Hoverfly_Save_SavesRequestAndResponseToSimulation(t *testing.T) {
 	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Status).To(Equal(200))
 }
 
+func Test_Hoverfly_Save_DecompressesDeflateResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	writer.Write([]byte("testresponsebody"))
+	writer.Close()
+
+	_ = unit.Save(&models.RequestDetails{
+		Destination: "testdestination",
+		Method:      "GET",
+		Path:        "/testpath",
+		Scheme:      "http",
+	}, &models.ResponseDetails{
+		Body: compressed.String(),
+		Headers: map[string][]string{
+			"Content-Encoding": {"deflate"},
+			"Content-Length":   {"24"},
+			"Content-Type":     {"text/plain"},
+		},
+		Status: 200,
+	}, &modes.ModeArguments{})
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Body).To(Equal("testresponsebody"))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Headers).To(Equal(map[string][]string{
+		"Content-Type": {"text/plain"},
+	}))
+}
+
+func Test_Hoverfly_Save_TruncatesBodyLargerThanMaxBodySize(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	_ = unit.Save(&models.RequestDetails{
+		Destination: "testdestination",
+		Method:      "GET",
+		Path:        "/testpath",
+		Scheme:      "http",
+	}, &models.ResponseDetails{
+		Body: "testresponsebody",
+		Headers: map[string][]string{
+			"Content-Length": {"16"},
+			"Content-Type":   {"text/plain"},
+		},
+		Status: 200,
+	}, &modes.ModeArguments{MaxBodySize: 4})
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Body).To(Equal("test"))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.BodyTruncated).To(BeTrue())
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Headers).To(Equal(map[string][]string{
+		"Content-Type": {"text/plain"},
+	}))
+}
+
+func Test_Hoverfly_Save_KeepsBodyWithinMaxBodySize(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	_ = unit.Save(&models.RequestDetails{
+		Destination: "testdestination",
+		Method:      "GET",
+		Path:        "/testpath",
+		Scheme:      "http",
+	}, &models.ResponseDetails{
+		Body:   "testresponsebody",
+		Status: 200,
+	}, &modes.ModeArguments{MaxBodySize: 16})
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Body).To(Equal("testresponsebody"))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.BodyTruncated).To(BeFalse())
+}
+
+func Test_Hoverfly_Save_SkipsBodyLargerThanMaxBodySize(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.Save(&models.RequestDetails{
+		Destination: "testdestination",
+		Method:      "GET",
+		Path:        "/testpath",
+		Scheme:      "http",
+	}, &models.ResponseDetails{
+		Body:   "testresponsebody",
+		Status: 200,
+	}, &modes.ModeArguments{MaxBodySize: 4, OversizedBodies: modes.SkipOversizedBodies})
+
+	Expect(err).To(BeNil())
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(0))
+}
+
+func Test_Hoverfly_Save_SkipsStreamedBodyWhichWasTruncated(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.Save(&models.RequestDetails{
+		Destination: "testdestination",
+		Method:      "GET",
+		Path:        "/testpath",
+		Scheme:      "http",
+	}, &models.ResponseDetails{
+		Body:          "test",
+		BodyTruncated: true,
+		Status:        200,
+	}, &modes.ModeArguments{MaxBodySize: 4, OversizedBodies: modes.SkipOversizedBodies})
+
+	Expect(err).To(BeNil())
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(0))
+}
+
+func Test_Hoverfly_Save_DecodesTruncatedGzipBodyUpToWhereItWasCut(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	compressed, err := util.CompressGzip([]byte(strings.Repeat("0123456789", 1000)))
+	Expect(err).To(BeNil())
+
+	_ = unit.Save(&models.RequestDetails{
+		Destination: "testdestination",
+		Method:      "GET",
+		Path:        "/testpath",
+		Scheme:      "http",
+	}, &models.ResponseDetails{
+		Body:          string(compressed[:len(compressed)-10]),
+		BodyTruncated: true,
+		Headers: map[string][]string{
+			"Content-Encoding": {"gzip"},
+		},
+		Status: 200,
+	}, &modes.ModeArguments{MaxBodySize: len(compressed) - 10})
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Body).To(HavePrefix("0123456789"))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.BodyTruncated).To(BeTrue())
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Headers).To(BeEmpty())
+}
+
+func Test_Hoverfly_Save_RedactsHeadersAndBodyFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	request := &models.RequestDetails{
+		Destination: "testdestination",
+		Method:      "POST",
+		Path:        "/login",
+		Scheme:      "http",
+		Query:       map[string][]string{"token": {"secret"}, "page": {"1"}},
+		Body:        `{"username":"bob","password":"hunter2"}`,
+		Headers: map[string][]string{
+			"Authorization": {"Bearer abc"},
+			"Content-Type":  {"application/json"},
+		},
+	}
+	response := &models.ResponseDetails{
+		Body: `{"user":{"name":"bob","password":"hunter2"}}`,
+		Headers: map[string][]string{
+			"Authorization": {"Bearer def"},
+		},
+		Status: 200,
+	}
+
+	err := unit.Save(request, response, &modes.ModeArguments{
+		Headers:             []string{"*"},
+		RedactedHeaders:     []string{"authorization"},
+		RedactedQueryParams: []string{"token"},
+		RedactedBodyPaths:   []string{"$.password", "user.password"},
+	})
+	Expect(err).To(BeNil())
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	pair := unit.Simulation.GetMatchingPairs()[0]
+
+	Expect(pair.RequestMatcher.Headers["Authorization"][0].Value).To(Equal("REDACTED"))
+	Expect(pair.RequestMatcher.Headers["Content-Type"][0].Value).To(Equal("application/json"))
+	Expect(pair.RequestMatcher.Query.Get("token")[0].Value).To(Equal("REDACTED"))
+	Expect(pair.RequestMatcher.Query.Get("page")[0].Value).To(Equal("1"))
+	Expect(pair.RequestMatcher.Body[0].Value).To(Equal(`{"password":"REDACTED","username":"bob"}`))
+
+	Expect(pair.Response.Headers["Authorization"]).To(Equal([]string{"REDACTED"}))
+	Expect(pair.Response.Body).To(Equal(`{"user":{"name":"bob","password":"REDACTED"}}`))
+
+	// the request and response passed on to the client are left as they are
+	Expect(request.Headers["Authorization"]).To(Equal([]string{"Bearer abc"}))
+	Expect(request.Body).To(Equal(`{"username":"bob","password":"hunter2"}`))
+	Expect(response.Headers["Authorization"]).To(Equal([]string{"Bearer def"}))
+}
+
+func Test_Hoverfly_Save_KeepsResponseWithUnsupportedEncoding(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	_ = unit.Save(&models.RequestDetails{
+		Destination: "testdestination",
+		Method:      "GET",
+		Path:        "/testpath",
+		Scheme:      "http",
+	}, &models.ResponseDetails{
+		Body:    "brotli bytes",
+		Headers: map[string][]string{"Content-Encoding": {"br"}},
+		Status:  200,
+	}, &modes.ModeArguments{})
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Body).To(Equal("brotli bytes"))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Headers).To(HaveKeyWithValue("Content-Encoding", []string{"br"}))
+}
+
 func Test_Hoverfly_Save_SavesRequestContainsMultiValueQuery(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -844,6 +1606,7 @@ func Test_Hoverfly_Save_SavesRequestContainsMultiValueQuery(t *testing.T) {
 		{
 			Matcher: matchers.Array,
 			Value:   expectedValues[:],
+			Config:  map[string]interface{}{matchers.IgnoreOrder: true},
 		},
 	}))
 }
@@ -1135,3 +1898,147 @@ func Test_Hoverfly_Save_CanOverwriteExistingDuplicatedPair(t *testing.T) {
 
 	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Status).To(Equal(200))
 }
+
+func Test_Hoverfly_GetResponse_MatchesDestinationsRegardlessOfCaseTrailingDotAndDefaultPort(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Destination: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "example.com",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   "captured",
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v5",
+		},
+	})
+
+	for _, url := range []string{"http://example.com:80/", "http://Example.com/", "http://example.com./", "https://example.com:443/"} {
+		request, _ := http.NewRequest("GET", url, nil)
+		requestDetails, err := models.NewRequestDetailsFromHttpRequest(request)
+		Expect(err).To(BeNil())
+
+		response, err := unit.GetResponse(requestDetails)
+		Expect(err).To(BeNil(), url)
+		Expect(response.Body).To(Equal("captured"))
+	}
+
+	request, _ := http.NewRequest("GET", "http://example.com:8080/", nil)
+	requestDetails, _ := models.NewRequestDetailsFromHttpRequest(request)
+	_, err := unit.GetResponse(requestDetails)
+	Expect(err).ToNot(BeNil())
+}
+
+func Test_Hoverfly_GetResponse_NormalizesExactDestinationsOfImportedPairs(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Destination: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "Example.com.:443",
+							},
+						},
+						Scheme: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "https",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   "imported",
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v5",
+		},
+	})
+
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Destination[0].Value).To(Equal("example.com"))
+
+	request, _ := http.NewRequest("GET", "https://example.com/", nil)
+	requestDetails, _ := models.NewRequestDetailsFromHttpRequest(request)
+	response, err := unit.GetResponse(requestDetails)
+	Expect(err).To(BeNil())
+	Expect(response.Body).To(Equal("imported"))
+}
+
+func Test_Hoverfly_GetResponse_MatchesCapturedQueriesWhateverTheOrderOfTheirValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	captured, _ := http.NewRequest("GET", "http://test.com/?a=1&id=1&id=2", nil)
+	capturedDetails, _ := models.NewRequestDetailsFromHttpRequest(captured)
+	Expect(unit.Save(&capturedDetails, &models.ResponseDetails{Status: 200, Body: "captured"}, &modes.ModeArguments{})).To(Succeed())
+
+	for _, url := range []string{"http://test.com/?id=2&a=1&id=1", "http://test.com/?id=1&id=2&a=1"} {
+		request, _ := http.NewRequest("GET", url, nil)
+		requestDetails, _ := models.NewRequestDetailsFromHttpRequest(request)
+
+		response, err := unit.GetResponse(requestDetails)
+		Expect(err).To(BeNil(), url)
+		Expect(response.Body).To(Equal("captured"))
+	}
+}
+
+func Test_Hoverfly_GetResponse_MatchesQueryStringsWhateverTheirOrderAndEncoding(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						DeprecatedQuery: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "q=hello%20world&b=2",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   "matched",
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v5",
+		},
+	})
+
+	for _, url := range []string{"http://test.com/?b=2&q=hello+world", "http://test.com/?q=hello%20world&b=2", "http://test.com/?%62=2&q=hello+world"} {
+		request, _ := http.NewRequest("GET", url, nil)
+		requestDetails, _ := models.NewRequestDetailsFromHttpRequest(request)
+
+		response, err := unit.GetResponse(requestDetails)
+		Expect(err).To(BeNil(), url)
+		Expect(response.Body).To(Equal("matched"))
+	}
+}
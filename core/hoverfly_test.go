@@ -2,19 +2,28 @@ package hoverfly
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/SpectoLabs/hoverfly/core/cors"
 	"github.com/SpectoLabs/hoverfly/core/modes"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/authentication/backends"
 	"github.com/SpectoLabs/hoverfly/core/cache"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/metrics"
 	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/requestlog"
+	"github.com/SpectoLabs/hoverfly/core/util"
 	. "github.com/onsi/gomega"
 )
 
@@ -106,6 +115,184 @@ func Test_NewHoverflyWithConfiguration_DoesNotCreateCacheIfCfgIsDisabled(t *test
 	Expect(unit.CacheMatcher.RequestCache).To(BeNil())
 }
 
+func Test_NewRequestCache_ReturnsLRUCacheByDefault(t *testing.T) {
+	RegisterTestingT(t)
+
+	requestCache, err := NewRequestCache(&Configuration{CacheSize: 10}, nil)
+	Expect(err).To(BeNil())
+	Expect(requestCache).To(BeAssignableToTypeOf(&cache.LRUFastCache{}))
+}
+
+func Test_NewRequestCache_ReturnsNilWhenCacheIsDisabled(t *testing.T) {
+	RegisterTestingT(t)
+
+	requestCache, err := NewRequestCache(&Configuration{DisableCache: true}, testDB)
+	Expect(err).To(BeNil())
+	Expect(requestCache).To(BeNil())
+}
+
+func Test_NewRequestCache_LimitsThePersistedCacheToTheCacheSize(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer os.Remove("request_cache_size_test.db")
+
+	db, err := OpenCacheDatabase(&Configuration{CacheDatabasePath: "request_cache_size_test.db"})
+	Expect(err).To(BeNil())
+	defer db.Close()
+
+	requestCache, err := NewRequestCache(&Configuration{CacheSize: 2}, db)
+	Expect(err).To(BeNil())
+	Expect(requestCache).To(BeAssignableToTypeOf(&cache.BoltDBFastCache{}))
+
+	for _, key := range []string{"key1", "key2", "key3"} {
+		Expect(requestCache.Set(key, &models.CachedResponse{})).To(Succeed())
+	}
+
+	count, err := requestCache.RecordsCount()
+	Expect(err).To(BeNil())
+	Expect(count).To(Equal(2))
+}
+
+func Test_OpenCacheDatabase_ReturnsNilWhenNoPathIsSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	db, err := OpenCacheDatabase(&Configuration{})
+	Expect(err).To(BeNil())
+	Expect(db).To(BeNil())
+}
+
+func Test_OpenCacheDatabase_ReturnsErrorWhenDatabaseCannotBeOpened(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := OpenCacheDatabase(&Configuration{CacheDatabasePath: "/non-existent-dir/cache.db"})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Unable to open cache database /non-existent-dir/cache.db"))
+}
+
+func Test_NewHoverflyWithConfiguration_PersistsRequestCacheWhenDatabasePathIsSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer os.Remove("request_cache_test.db")
+
+	unit := NewHoverflyWithConfiguration(&Configuration{CacheDatabasePath: "request_cache_test.db"})
+	Expect(unit.CacheMatcher.RequestCache).To(BeAssignableToTypeOf(&cache.BoltDBFastCache{}))
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: "exact",
+					Value:   "somehost.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 200,
+			Body:   "persisted body",
+		},
+	})
+
+	requestDetails := models.RequestDetails{
+		Destination: "somehost.com",
+		Method:      "GET",
+		Scheme:      "http",
+	}
+
+	unit.GetResponse(requestDetails)
+	Expect(unit.CloseCacheDatabase()).To(Succeed())
+
+	reopened := NewHoverflyWithConfiguration(&Configuration{CacheDatabasePath: "request_cache_test.db"})
+	defer reopened.CloseCacheDatabase()
+
+	response, matchingErr := reopened.GetResponse(requestDetails)
+	Expect(matchingErr).To(BeNil())
+	Expect(response.Body).To(Equal("persisted body"))
+	Expect(reopened.Counter.EventCount(metrics.CacheHit)).To(Equal(int64(1)))
+
+	Expect(reopened.CacheMatcher.FlushCache()).To(Succeed())
+
+	count, err := reopened.CacheMatcher.RequestCache.RecordsCount()
+	Expect(err).To(BeNil())
+	Expect(count).To(Equal(0))
+}
+
+func Test_NewHoverflyWithConfiguration_LoadsCapturedPairsPersistedByAnEarlierRun(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer os.Remove("simulation_pairs_test.db")
+
+	unit := NewHoverflyWithConfiguration(&Configuration{CacheDatabasePath: "simulation_pairs_test.db"})
+
+	for _, path := range []string{"/one", "/two"} {
+		Expect(unit.Save(&models.RequestDetails{
+			Destination: "somehost.com",
+			Method:      "GET",
+			Path:        path,
+			Scheme:      "http",
+		}, &models.ResponseDetails{
+			Status: 200,
+			Body:   "captured " + path,
+		}, &modes.ModeArguments{})).To(Succeed())
+	}
+	Expect(unit.CloseCacheDatabase()).To(Succeed())
+
+	reopened := NewHoverflyWithConfiguration(&Configuration{CacheDatabasePath: "simulation_pairs_test.db"})
+	defer reopened.CloseCacheDatabase()
+
+	pairs := reopened.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[0].RequestMatcher.Path[0].Value).To(Equal("/one"))
+	Expect(pairs[0].Response.Body).To(Equal("captured /one"))
+	Expect(pairs[1].RequestMatcher.Path[0].Value).To(Equal("/two"))
+	Expect(pairs[1].Response.Body).To(Equal("captured /two"))
+}
+
+func Test_NewHoverflyWithConfiguration_LoadsThePersistedPairsAfterTheSimulationIsChanged(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer os.Remove("simulation_changes_test.db")
+
+	unit := NewHoverflyWithConfiguration(&Configuration{CacheDatabasePath: "simulation_changes_test.db"})
+
+	simulation := v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Path: []v2.MatcherViewV5{{Matcher: "exact", Value: "/imported"}},
+					},
+					Response: v2.ResponseDetailsViewV5{Status: 200, Body: "imported"},
+				},
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Path: []v2.MatcherViewV5{{Matcher: "exact", Value: "/deleted"}},
+					},
+					Response: v2.ResponseDetailsViewV5{Status: 200, Body: "deleted"},
+				},
+			},
+		},
+		MetaView: v2.MetaView{SchemaVersion: "v5"},
+	}
+
+	Expect(unit.PutSimulation(simulation).GetError()).To(BeNil())
+	Expect(unit.DeleteSimulationPair("1")).To(Succeed())
+	Expect(unit.CloseCacheDatabase()).To(Succeed())
+
+	reopened := NewHoverflyWithConfiguration(&Configuration{CacheDatabasePath: "simulation_changes_test.db"})
+
+	pairs := reopened.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].Response.Body).To(Equal("imported"))
+
+	reopened.DeleteSimulation()
+	Expect(reopened.CloseCacheDatabase()).To(Succeed())
+
+	emptied := NewHoverflyWithConfiguration(&Configuration{CacheDatabasePath: "simulation_changes_test.db"})
+	defer emptied.CloseCacheDatabase()
+
+	Expect(emptied.Simulation.GetMatchingPairs()).To(BeEmpty())
+}
+
 func TestGetNewHoverflyCheckConfig(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -161,6 +348,129 @@ func Test_Hoverfly_processRequest_CaptureModeReturnsResponseAndSavesIt(t *testin
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
 }
 
+func gzipTestTools(body string) (*httptest.Server, *Hoverfly) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		compressed, _ := util.CompressGzip([]byte(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		w.Write(compressed)
+	}))
+
+	cfg := InitSettings()
+	unit := GetNewHoverfly(cfg, cache.NewDefaultLRUCache(), nil)
+	unit.HTTP = &http.Client{Transport: &http.Transport{
+		Proxy: func(req *http.Request) (*url.URL, error) {
+			return url.Parse(server.URL)
+		},
+	}}
+
+	return server, unit
+}
+
+func Test_Hoverfly_processRequest_CaptureModeDecompressesGzipResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := gzipTestTools(`{"message": "here"}`)
+	defer server.Close()
+
+	r, err := http.NewRequest("GET", "http://somehost.com", nil)
+	Expect(err).To(BeNil())
+	r.Header.Set("Accept-Encoding", "gzip")
+
+	unit.Cfg.SetMode("capture")
+	resp := unit.processRequest(r)
+
+	Expect(resp).ToNot(BeNil())
+	Expect(resp.Header.Get("Content-Encoding")).To(Equal("gzip"))
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].Response.Body).To(Equal(`{"message": "here"}`))
+	Expect(pairs[0].Response.Headers).ToNot(HaveKey("Content-Encoding"))
+	Expect(pairs[0].Response.Headers).ToNot(HaveKey("Content-Length"))
+	Expect(pairs[0].Response.Headers).To(HaveKeyWithValue("Content-Type", []string{"application/json"}))
+
+	unit.Cfg.SetMode("simulate")
+	simulated := unit.processRequest(r)
+
+	Expect(simulated.StatusCode).To(Equal(http.StatusOK))
+	Expect(simulated.Header.Get("Content-Encoding")).To(Equal(""))
+	body, err := ioutil.ReadAll(simulated.Body)
+	Expect(err).To(BeNil())
+	Expect(string(body)).To(Equal(`{"message": "here"}`))
+}
+
+func Test_Hoverfly_processRequest_CaptureModePreservesGzipResponseWhenConfigured(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := gzipTestTools(`{"message": "here"}`)
+	defer server.Close()
+	unit.Cfg.PreserveContentEncoding = true
+
+	r, err := http.NewRequest("GET", "http://somehost.com", nil)
+	Expect(err).To(BeNil())
+	r.Header.Set("Accept-Encoding", "gzip")
+
+	unit.Cfg.SetMode("capture")
+	unit.processRequest(r)
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].Response.Headers).To(HaveKeyWithValue("Content-Encoding", []string{"gzip"}))
+
+	decompressed, err := util.DecompressGzip([]byte(pairs[0].Response.Body))
+	Expect(err).To(BeNil())
+	Expect(string(decompressed)).To(Equal(`{"message": "here"}`))
+}
+
+func Test_Hoverfly_processRequest_CaptureModeCanRecordOrderedResponseHeaders(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Set-Cookie", "session=abc")
+		w.Header().Add("Set-Cookie", "theme=dark")
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("cookies"))
+	}))
+	defer server.Close()
+
+	unit := GetNewHoverfly(InitSettings(), cache.NewDefaultLRUCache(), nil)
+	unit.HTTP = &http.Client{Transport: &http.Transport{
+		Proxy: func(req *http.Request) (*url.URL, error) {
+			return url.Parse(server.URL)
+		},
+	}}
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode:      "capture",
+		Arguments: v2.ModeArgumentsView{OrderedHeaders: true},
+	})).To(BeNil())
+
+	r, err := http.NewRequest("GET", "http://somehost.com", nil)
+	Expect(err).To(BeNil())
+	unit.processRequest(r)
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].Response.Headers).To(BeNil())
+	Expect(pairs[0].Response.OrderedHeaders).To(ContainElement([2]string{"Content-Type", "text/plain"}))
+
+	var cookies []string
+	for _, header := range pairs[0].Response.OrderedHeaders {
+		if header[0] == "Set-Cookie" {
+			cookies = append(cookies, header[1])
+		}
+	}
+	Expect(cookies).To(Equal([]string{"session=abc", "theme=dark"}))
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{Mode: "simulate"})).To(BeNil())
+	simulated := unit.processRequest(r)
+
+	Expect(simulated.Header["Set-Cookie"]).To(Equal([]string{"session=abc", "theme=dark"}))
+	Expect(simulated.Header.Get("Content-Type")).To(Equal("text/plain"))
+}
+
 func Test_Hoverfly_processRequest_CanSimulateRequest(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -228,6 +538,119 @@ func Test_Hoverfly_processRequest_CanSpyRequest(t *testing.T) {
 	Expect(resp.Header).To(HaveKeyWithValue("Hoverfly", []string{"Was-Here", "Forwarded"}))
 }
 
+func Test_Hoverfly_processRequest_WritesMatchedAndMissedRequestsToTheRequestLog(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(201, `{'message': 'here'}`)
+	defer server.Close()
+
+	buffer := &bytes.Buffer{}
+	unit.requestLog = requestlog.NewRequestLog(buffer)
+
+	unit.Cfg.SetMode("capture")
+	unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/captured", nil))
+
+	unit.Cfg.SetMode("simulate")
+	unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/captured", nil))
+	unit.processRequest(httptest.NewRequest("POST", "http://somehost.com/missed", nil))
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	Expect(lines).To(HaveLen(3))
+
+	var entries []requestlog.Entry
+	for _, line := range lines {
+		var entry requestlog.Entry
+		Expect(json.Unmarshal([]byte(line), &entry)).To(Succeed())
+		entries = append(entries, entry)
+	}
+
+	Expect(entries[0].Mode).To(Equal("capture"))
+	Expect(entries[0].Matched).To(BeFalse())
+	Expect(entries[0].Status).To(Equal(http.StatusCreated))
+
+	Expect(entries[1].Mode).To(Equal("simulate"))
+	Expect(entries[1].Method).To(Equal("GET"))
+	Expect(entries[1].Scheme).To(Equal("http"))
+	Expect(entries[1].Destination).To(Equal("somehost.com"))
+	Expect(entries[1].Path).To(Equal("/captured"))
+	Expect(entries[1].Matched).To(BeTrue())
+	Expect(entries[1].Status).To(Equal(http.StatusCreated))
+
+	Expect(entries[2].Method).To(Equal("POST"))
+	Expect(entries[2].Path).To(Equal("/missed"))
+	Expect(entries[2].Matched).To(BeFalse())
+	Expect(entries[2].Status).To(Equal(http.StatusBadGateway))
+}
+
+func Test_Hoverfly_processRequest_ThrottlesMatchedRequestsWithARateLimitAndResumesAfterTheWindow(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(201, `{'message': 'here'}`)
+	defer server.Close()
+
+	unit.Cfg.SetMode("capture")
+	unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/throttled", nil))
+
+	Expect(unit.SetRateLimits([]v2.RateLimitView{{UrlPattern: "somehost.com/throttled", Limit: 2, Window: 200}})).To(Succeed())
+	unit.Cfg.SetMode("simulate")
+
+	Expect(unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/throttled", nil)).StatusCode).To(Equal(http.StatusCreated))
+	Expect(unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/throttled", nil)).StatusCode).To(Equal(http.StatusCreated))
+
+	throttled := unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/throttled", nil))
+	Expect(throttled.StatusCode).To(Equal(http.StatusTooManyRequests))
+	Expect(throttled.Header.Get("Retry-After")).To(Equal("1"))
+
+	time.Sleep(250 * time.Millisecond)
+
+	Expect(unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/throttled", nil)).StatusCode).To(Equal(http.StatusCreated))
+}
+
+func Test_Hoverfly_processRequest_DoesNotCountMissesTowardsARateLimit(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(201, `{'message': 'here'}`)
+	defer server.Close()
+
+	unit.Cfg.SetMode("capture")
+	unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/throttled", nil))
+
+	Expect(unit.SetRateLimits([]v2.RateLimitView{{UrlPattern: "somehost.com", Limit: 1, Window: 60000}})).To(Succeed())
+	unit.Cfg.SetMode("simulate")
+
+	Expect(unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/missed", nil)).StatusCode).To(Equal(http.StatusBadGateway))
+	Expect(unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/throttled", nil)).StatusCode).To(Equal(http.StatusCreated))
+	Expect(unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/throttled", nil)).StatusCode).To(Equal(http.StatusTooManyRequests))
+}
+
+func Test_Hoverfly_LoadRequestLog_DoesNothingWithoutAPath(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.LoadRequestLog()).To(Succeed())
+	Expect(unit.requestLog).To(BeNil())
+}
+
+func Test_Hoverfly_LoadRequestLog_OpensTheRequestLogFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	file, err := ioutil.TempFile("", "request-log")
+	Expect(err).To(BeNil())
+	file.Close()
+	defer os.Remove(file.Name())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{RequestLogPath: file.Name()})
+	Expect(unit.requestLog).ToNot(BeNil())
+
+	unit.Cfg.SetMode("simulate")
+	unit.processRequest(httptest.NewRequest("GET", "http://somehost.com/path", nil))
+
+	contents, err := ioutil.ReadFile(file.Name())
+	Expect(err).To(BeNil())
+	Expect(string(contents)).To(ContainSubstring(`"path":"/path","matched":false,"status":502}`))
+}
+
 func Test_Hoverfly_processRequest_CanUseMiddlewareToSynthesizeResponse(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -352,6 +775,47 @@ func Test_Hoverfly_processRequest_DelayAppliedToSuccessfulSimulateRequest(t *tes
 	Expect(stubLogNormal.gotDelays, Equal(1))
 }
 
+func Test_Hoverfly_processRequest_PairDelayAppliedWithGlobalDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(201, `{'message': 'here'}`)
+	defer server.Close()
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: "exact",
+					Value:   "somehost.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 200,
+			Delay:  &models.ResponseDetailsDelay{Fixed: 100},
+		},
+	})
+
+	r, err := http.NewRequest("GET", "http://somehost.com", nil)
+	Expect(err).To(BeNil())
+
+	unit.Cfg.SetMode("simulate")
+
+	stub := ResponseDelayListStub{}
+	unit.Simulation.ResponseDelays = &stub
+	stubLogNormal := ResponseDelayLogNormalListStub{}
+	unit.Simulation.ResponseDelaysLogNormal = &stubLogNormal
+
+	start := time.Now()
+	newResp := unit.processRequest(r)
+
+	Expect(newResp.StatusCode).To(Equal(http.StatusOK))
+	Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+
+	Expect(stub.gotDelays).To(Equal(1))
+	Expect(stubLogNormal.gotDelays).To(Equal(1))
+}
+
 func Test_Hoverfly_processRequest_DelayNotAppliedToFailedSimulateRequest(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -759,3 +1223,155 @@ func Test_Hoverfly_StartProxy_StartProxyWOPort(t *testing.T) {
 	err := unit.StartProxy()
 	Expect(err).ToNot(BeNil())
 }
+
+func Test_Hoverfly_StartProxy_BindsToListenOnHost(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.ListenOnHost = "127.0.0.1"
+	unit.Cfg.ProxyPort = "0"
+
+	err := unit.StartProxy()
+	Expect(err).To(BeNil())
+	defer unit.StopProxy()
+
+	host, _, err := net.SplitHostPort(unit.SL.Addr().String())
+	Expect(err).To(BeNil())
+	Expect(host).To(Equal("127.0.0.1"))
+}
+
+func Test_Hoverfly_IsProxyReady_OnlyWhileTheProxyIsRunning(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.ListenOnHost = "127.0.0.1"
+	unit.Cfg.ProxyPort = "0"
+
+	Expect(unit.IsProxyReady()).To(BeFalse())
+
+	err := unit.StartProxy()
+	Expect(err).To(BeNil())
+
+	Expect(unit.IsProxyReady()).To(BeTrue())
+
+	unit.StopProxy()
+
+	Expect(unit.IsProxyReady()).To(BeFalse())
+}
+
+func Test_Hoverfly_IsProxyReady_CanBeCalledWhileTheProxyIsRestarted(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.ListenOnHost = "127.0.0.1"
+	unit.Cfg.ProxyPort = "0"
+	unit.Cfg.ShutdownGracePeriod = time.Second
+
+	Expect(unit.StartProxy()).To(BeNil())
+	defer unit.StopProxy()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 5; i++ {
+			unit.SetDestination("example.com")
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			Expect(unit.IsProxyReady()).To(BeTrue())
+			return
+		default:
+			unit.IsProxyReady()
+		}
+	}
+}
+
+func startSlowCaptureProxy(upstreamDelay, gracePeriod time.Duration) (*httptest.Server, *Hoverfly, *http.Client) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(upstreamDelay)
+		w.Write([]byte("slow response"))
+	}))
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.ListenOnHost = "127.0.0.1"
+	unit.Cfg.ProxyPort = "0"
+	unit.Cfg.Destination = "."
+	unit.Cfg.ShutdownGracePeriod = gracePeriod
+	unit.Cfg.SetMode("capture")
+	unit.HTTP = &http.Client{Transport: &http.Transport{
+		Proxy: func(req *http.Request) (*url.URL, error) {
+			return url.Parse(upstream.URL)
+		},
+	}}
+
+	Expect(unit.StartProxy()).To(BeNil())
+
+	proxyUrl, _ := url.Parse("http://" + unit.SL.Addr().String())
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyUrl)}}
+
+	return upstream, unit, client
+}
+
+func Test_Hoverfly_StopProxy_LetsInFlightRequestsComplete(t *testing.T) {
+	RegisterTestingT(t)
+
+	upstream, unit, client := startSlowCaptureProxy(500*time.Millisecond, 5*time.Second)
+	defer upstream.Close()
+
+	result := make(chan string)
+	go func() {
+		response, err := client.Get("http://slow.com")
+		if err != nil {
+			result <- err.Error()
+			return
+		}
+		body, _ := ioutil.ReadAll(response.Body)
+		result <- string(body)
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	unit.StopProxy()
+
+	Expect(<-result).To(Equal("slow response"))
+}
+
+func Test_Hoverfly_StopProxy_ClosesRequestsStillRunningAfterTheGracePeriod(t *testing.T) {
+	RegisterTestingT(t)
+
+	upstream, unit, client := startSlowCaptureProxy(time.Second, 100*time.Millisecond)
+	defer upstream.Close()
+
+	result := make(chan error)
+	go func() {
+		_, err := client.Get("http://slow.com")
+		result <- err
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	start := time.Now()
+	unit.StopProxy()
+
+	Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+	Expect(<-result).ToNot(BeNil())
+}
+
+func Test_Hoverfly_StartProxy_ReturnsClearErrorWhenPortIsInUse(t *testing.T) {
+	RegisterTestingT(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	defer listener.Close()
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.ListenOnHost = "127.0.0.1"
+	unit.Cfg.ProxyPort = port
+
+	err = unit.StartProxy()
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Proxy port " + port + " is already in use, stop the process using it or choose another port with -pp"))
+}
@@ -0,0 +1,292 @@
+package hoverfly
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/certs"
+	. "github.com/onsi/gomega"
+)
+
+// newAuthenticatingProxy - a fake upstream proxy which only forwards requests carrying
+// the expected basic Proxy-Authorization header, and answers them itself
+func newAuthenticatingProxy(username, password string) *httptest.Server {
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Proxy-Authorization") != expected {
+			w.Header().Set("Proxy-Authenticate", `Basic realm="proxy"`)
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+
+		w.Write([]byte("proxied " + r.URL.String()))
+	}))
+}
+
+func Test_GetDefaultHoverflyHTTPClient_SendsUpstreamProxyCredentials(t *testing.T) {
+	RegisterTestingT(t)
+
+	proxy := newAuthenticatingProxy("user", "secret")
+	defer proxy.Close()
+
+	unit := GetDefaultHoverflyHTTPClient(true, "user:secret@"+strings.TrimPrefix(proxy.URL, "http://"), nil, 0)
+
+	response, err := unit.Get("http://test.com/path")
+	Expect(err).To(BeNil())
+	defer response.Body.Close()
+
+	Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(body)).To(Equal("proxied http://test.com/path"))
+}
+
+func Test_GetDefaultHoverflyHTTPClient_TimesOutSlowDestinations(t *testing.T) {
+	RegisterTestingT(t)
+
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		w.Write([]byte("too late"))
+	}))
+	defer slowServer.Close()
+
+	unit := GetDefaultHoverflyHTTPClient(true, "", nil, 50*time.Millisecond)
+
+	_, err := unit.Get(slowServer.URL)
+	Expect(err).ToNot(BeNil())
+	Expect(err.(net.Error).Timeout()).To(BeTrue())
+}
+
+func Test_GetDefaultHoverflyHTTPClient_FailsProxyAuthenticationWithoutCredentials(t *testing.T) {
+	RegisterTestingT(t)
+
+	proxy := newAuthenticatingProxy("user", "secret")
+	defer proxy.Close()
+
+	unit := GetDefaultHoverflyHTTPClient(true, proxy.URL, nil, 0)
+
+	response, err := unit.Get("http://test.com/path")
+	Expect(err).To(BeNil())
+	defer response.Body.Close()
+
+	Expect(response.StatusCode).To(Equal(http.StatusProxyAuthRequired))
+}
+
+// newClientCertificatePEM - a self signed certificate and key for a client to present
+func newClientCertificatePEM() (string, string) {
+	cert, key, err := certs.NewCertificatePair("client.hoverfly.io", "Hoverfly", time.Hour)
+	Expect(err).To(BeNil())
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	return string(certPEM), string(keyPEM)
+}
+
+// newMutualTLSServer - a server which refuses connections that do not present a client certificate
+func newMutualTLSServer() *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello " + r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+
+	return server
+}
+
+func Test_GetHttpClient_PresentsClientCertificateToMatchingDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := newMutualTLSServer()
+	defer server.Close()
+
+	certPEM, keyPEM := newClientCertificatePEM()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{
+		ClientAuthenticationDestination: "127.0.0.1",
+		ClientAuthenticationClientCert:  certPEM,
+		ClientAuthenticationClientKey:   keyPEM,
+	})
+	Expect(unit.LoadClientAuthentication()).To(BeNil())
+
+	client, err := GetHttpClient(unit, strings.TrimPrefix(server.URL, "https://"))
+	Expect(err).To(BeNil())
+
+	response, err := client.Get(server.URL)
+	Expect(err).To(BeNil())
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(body)).To(Equal("hello client.hoverfly.io"))
+}
+
+func Test_GetHttpClient_DoesNotPresentClientCertificateToOtherDestinations(t *testing.T) {
+	RegisterTestingT(t)
+
+	certPEM, keyPEM := newClientCertificatePEM()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{
+		ClientAuthenticationDestination: "secure.hoverfly.io",
+		ClientAuthenticationClientCert:  certPEM,
+		ClientAuthenticationClientKey:   keyPEM,
+	})
+	Expect(unit.LoadClientAuthentication()).To(BeNil())
+
+	client, err := GetHttpClient(unit, "hoverfly.io")
+	Expect(err).To(BeNil())
+	Expect(client).To(Equal(unit.HTTP))
+}
+
+func Test_GetClientAuthenticationHTTPClient_LoadsCertificateAndKeyFromFiles(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "hoverfly-client-auth")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	certPEM, keyPEM := newClientCertificatePEM()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	Expect(ioutil.WriteFile(certFile, []byte(certPEM), 0600)).To(BeNil())
+	Expect(ioutil.WriteFile(keyFile, []byte(keyPEM), 0600)).To(BeNil())
+
+	client, err := GetClientAuthenticationHTTPClient(&Configuration{
+		ClientAuthenticationClientCert: certFile,
+		ClientAuthenticationClientKey:  keyFile,
+		ClientAuthenticationCACert:     certFile,
+	})
+	Expect(err).To(BeNil())
+
+	tlsConfig := client.Transport.(*http.Transport).TLSClientConfig
+	Expect(tlsConfig.Certificates).To(HaveLen(1))
+	Expect(tlsConfig.RootCAs).ToNot(BeNil())
+	Expect(tlsConfig.InsecureSkipVerify).To(BeFalse())
+}
+
+func Test_GetClientAuthenticationHTTPClient_ErrorsWhenCertificateCannotBeLoaded(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, keyPEM := newClientCertificatePEM()
+
+	_, err := GetClientAuthenticationHTTPClient(&Configuration{
+		ClientAuthenticationClientCert: "/does/not/exist.pem",
+		ClientAuthenticationClientKey:  keyPEM,
+	})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Unable to load client certs file"))
+}
+
+func Test_GetClientAuthenticationHTTPClient_ErrorsWhenCertificateAndKeyDoNotMatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	certPEM, _ := newClientCertificatePEM()
+	_, otherKeyPEM := newClientCertificatePEM()
+
+	_, err := GetClientAuthenticationHTTPClient(&Configuration{
+		ClientAuthenticationClientCert: certPEM,
+		ClientAuthenticationClientKey:  otherKeyPEM,
+	})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Unable to load client certs file"))
+}
+
+func Test_Hoverfly_LoadClientAuthentication_ErrorsOnInvalidDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	certPEM, keyPEM := newClientCertificatePEM()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{
+		ClientAuthenticationDestination: "[",
+		ClientAuthenticationClientCert:  certPEM,
+		ClientAuthenticationClientKey:   keyPEM,
+	})
+
+	err := unit.LoadClientAuthentication()
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Client authentication destination is not a valid regular expression"))
+
+	_, err = GetHttpClient(unit, "hoverfly.io")
+	Expect(err).ToNot(BeNil())
+}
+
+// writeCABundle - writes the server's self signed certificate to a PEM file to be trusted as a CA
+func writeCABundle(dir string, server *httptest.Server) string {
+	bundle := filepath.Join(dir, "ca-bundle.pem")
+	Expect(ioutil.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600)).To(BeNil())
+
+	return bundle
+}
+
+func Test_GetDefaultHoverflyHTTPClient_TrustsCustomRootCAs(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("verified"))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "hoverfly-ca-bundle")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	rootCAs, err := LoadCABundle(writeCABundle(dir, server))
+	Expect(err).To(BeNil())
+
+	unit := GetDefaultHoverflyHTTPClient(true, "", rootCAs, 0)
+	Expect(unit.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify).To(BeFalse())
+
+	response, err := unit.Get(server.URL)
+	Expect(err).To(BeNil())
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(body)).To(Equal("verified"))
+}
+
+func Test_GetDefaultHoverflyHTTPClient_RejectsUnknownCAWithoutCustomRootCAs(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	unit := GetDefaultHoverflyHTTPClient(true, "", nil, 0)
+
+	_, err := unit.Get(server.URL)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("certificate"))
+}
+
+func Test_LoadCABundle_ErrorsWhenFileCannotBeRead(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := LoadCABundle("/does/not/exist.pem")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Unable to load CA bundle"))
+}
+
+func Test_LoadCABundle_ErrorsWhenFileHasNoCertificates(t *testing.T) {
+	RegisterTestingT(t)
+
+	bundle, err := ioutil.TempFile("", "hoverfly-ca-bundle")
+	Expect(err).To(BeNil())
+	defer os.Remove(bundle.Name())
+
+	bundle.WriteString("not a certificate")
+	bundle.Close()
+
+	_, err = LoadCABundle(bundle.Name())
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("no PEM encoded certificates found"))
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"path/filepath"
 	"strconv"
 
 	"github.com/SpectoLabs/hoverfly/core/delay"
@@ -23,6 +24,13 @@ import (
 // or returns an error
 func (hf *Hoverfly) Import(uri string) error {
 
+	if util.IsObjectStorageURI(uri) {
+		log.WithFields(log.Fields{
+			"importFrom": uri,
+		}).Info("Object storage")
+		return hf.ImportFromObjectStorage(uri)
+	}
+
 	// assuming file URI is URL:
 	if util.IsURL(uri) {
 		log.WithFields(log.Fields{
@@ -48,6 +56,48 @@ func (hf *Hoverfly) Import(uri string) error {
 	return fmt.Errorf("Failed to import payloads, given file '%s' does not exist", uri)
 }
 
+// ImportFromDirectory imports the named simulation file from the given directory. When no name
+// is given, the most recently modified JSON file in the directory is imported, so that a
+// restarted Hoverfly can pick up the last saved simulation.
+func (hf *Hoverfly) ImportFromDirectory(directory, name string) error {
+	if name == "" {
+		latest, err := latestSimulationFile(directory)
+		if err != nil {
+			return err
+		}
+		return hf.Import(latest)
+	}
+
+	if path.Ext(name) != ".json" {
+		name = name + ".json"
+	}
+
+	return hf.Import(filepath.Join(directory, name))
+}
+
+func latestSimulationFile(directory string) (string, error) {
+	entries, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read simulation directory %s. Got error: %s", directory, err.Error())
+	}
+
+	var latest os.FileInfo
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		if latest == nil || entry.ModTime().After(latest.ModTime()) {
+			latest = entry
+		}
+	}
+
+	if latest == nil {
+		return "", fmt.Errorf("No simulation files found in directory %s", directory)
+	}
+
+	return filepath.Join(directory, latest.Name()), nil
+}
+
 func exists(path string) (bool, error) {
 	_, err := os.Stat(path)
 	if err == nil {
@@ -107,6 +157,24 @@ func (hf *Hoverfly) ImportFromURL(url string) error {
 	return hf.PutSimulation(simulation).GetError()
 }
 
+// ImportFromObjectStorage - downloads a simulation from an s3:// or gs:// uri, using credentials from the
+// environment, and imports it
+func (hf *Hoverfly) ImportFromObjectStorage(uri string) error {
+	body, err := util.FetchObject(uri)
+	if err != nil {
+		return err
+	}
+
+	var simulation v2.SimulationViewV5
+
+	err = json.Unmarshal(body, &simulation)
+	if err != nil {
+		return fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
+	}
+
+	return hf.PutSimulation(simulation).GetError()
+}
+
 // importRequestResponsePairViews along with custom data - a function to save given pairs into the database. custom data is loaded before request/response pair so that in case someone access it, it ll be able to render
 func (hf *Hoverfly) importRequestResponsePairViewsWithCustomData(pairViews []v2.RequestMatcherResponsePairViewV5, literals []v2.GlobalLiteralViewV5, variables []v2.GlobalVariableViewV5) v2.SimulationImportResult {
 	importResult := v2.SimulationImportResult{}
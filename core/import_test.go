@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/cache"
 	v2 "github.com/SpectoLabs/hoverfly/core/handlers/v2"
@@ -110,6 +111,56 @@ func TestImportFromDiskWrongJson(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 }
 
+func TestImportFromDirectory_ImportsNamedSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.ImportFromDirectory("../examples/simulations", "hoverfly.io")
+	Expect(err).To(BeNil())
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(2))
+}
+
+func TestImportFromDirectory_ImportsMostRecentSimulationWhenNameIsEmpty(t *testing.T) {
+	RegisterTestingT(t)
+
+	directory, err := ioutil.TempDir("", "hoverfly-simulations")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(directory)
+
+	simulation, err := ioutil.ReadFile(hoverflyIoSimulationPath)
+	Expect(err).To(BeNil())
+
+	Expect(ioutil.WriteFile(directory+"/older.json", []byte(`{"data": {}}`), 0644)).To(BeNil())
+	Expect(ioutil.WriteFile(directory+"/notes.txt", []byte(`not a simulation`), 0644)).To(BeNil())
+	Expect(ioutil.WriteFile(directory+"/latest.json", simulation, 0644)).To(BeNil())
+
+	lastHour := time.Now().Add(-time.Hour)
+	Expect(os.Chtimes(directory+"/older.json", lastHour, lastHour)).To(BeNil())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err = unit.ImportFromDirectory(directory, "")
+	Expect(err).To(BeNil())
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(2))
+}
+
+func TestImportFromDirectory_ReturnsErrorWhenDirectoryHasNoSimulations(t *testing.T) {
+	RegisterTestingT(t)
+
+	directory, err := ioutil.TempDir("", "hoverfly-simulations")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(directory)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err = unit.ImportFromDirectory(directory, "")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("No simulation files found in directory " + directory))
+}
+
 func TestImportFromURL(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -130,6 +181,30 @@ func TestImportFromURL(t *testing.T) {
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(2))
 }
 
+func TestImportFromObjectStorage(t *testing.T) {
+	RegisterTestingT(t)
+
+	pairFileBytes, err := ioutil.ReadFile(hoverflyIoSimulationPath)
+	Expect(err).To(BeNil())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Expect(r.URL.Path).To(Equal("/simulations/hoverfly.json"))
+		w.Write(pairFileBytes)
+	}))
+	defer server.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err = unit.Import("s3://simulations/hoverfly.json")
+	Expect(err).To(BeNil())
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(2))
+}
+
 func TestImportFromURLRedirect(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -143,7 +218,7 @@ func TestImportFromURLRedirect(t *testing.T) {
 	server, unit := testTools(200, string(pairFileBytes))
 	defer server.Close()
 
-	unit.HTTP = GetDefaultHoverflyHTTPClient(false, "")
+	unit.HTTP = GetDefaultHoverflyHTTPClient(false, "", nil, 0)
 
 	redirectServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Location", server.URL)
@@ -23,6 +23,11 @@ type ResponseDelay interface {
 	GetMean() int
 }
 
+type PairDelay interface {
+	GetFixed() int
+	GetJitter() int
+}
+
 type Response interface {
 	GetStatus() int
 	GetBody() string
@@ -30,8 +35,15 @@ type Response interface {
 	GetEncodedBody() bool
 	GetTemplated() bool
 	GetHeaders() map[string][]string
+	GetOrderedHeaders() [][2]string
 	GetTransitionsState() map[string]string
 	GetRemovesState() []string
 	GetFixedDelay() int
 	GetLogNormalDelay() ResponseDelay
+	GetCapturedAt() string
+	GetLatencyMs() int
+	GetBodyTruncated() bool
+	GetDelay() PairDelay
+	GetChunked() bool
+	GetStatusText() string
 }
@@ -48,14 +48,22 @@ func (this *Journal) NewEntry(request *http.Request, response *http.Response, mo
 
 	payloadRequest, _ := models.NewRequestDetailsFromHttpRequest(request)
 
-	respBody, _ := util.GetResponseBody(response)
-
 	payloadResponse := &models.ResponseDetails{
 		Status:  response.StatusCode,
-		Body:    respBody,
 		Headers: response.Header,
 	}
 
+	// a streamed body is not read into memory here, the entry gets the body once it has been sent to the client
+	if streamedBody, ok := response.Body.(*util.StreamedBody); ok {
+		streamedBody.OnComplete(func(body []byte, truncated bool) {
+			this.mutex.Lock()
+			payloadResponse.Body = string(body)
+			this.mutex.Unlock()
+		})
+	} else {
+		payloadResponse.Body, _ = util.GetResponseBody(response)
+	}
+
 	this.mutex.Lock()
 	if len(this.entries) >= this.EntryLimit {
 		this.entries = append(this.entries[:0], this.entries[1:]...)
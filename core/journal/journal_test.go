@@ -11,6 +11,7 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/journal"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/util"
 	. "github.com/onsi/gomega"
 )
 
@@ -70,6 +71,34 @@ func Test_Journal_NewEntry_AddsJournalEntryToEntries(t *testing.T) {
 	Expect(entries[0].Latency).To(BeNumerically("<", 1))
 }
 
+func Test_Journal_NewEntry_GetsStreamedBodyOnceItHasBeenRead(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := journal.NewJournal()
+
+	request, _ := http.NewRequest("GET", "http://hoverfly.io", nil)
+
+	response := &http.Response{
+		StatusCode: 200,
+		Body:       util.NewStreamedBody(ioutil.NopCloser(bytes.NewBufferString("streamed body")), -1, 0),
+	}
+
+	err := unit.NewEntry(request, response, "capture", time.Now())
+	Expect(err).To(BeNil())
+
+	journalView, err := unit.GetEntries(0, 25, nil, nil, "")
+	Expect(err).To(BeNil())
+	Expect(journalView.Journal[0].Response.Body).To(Equal(""))
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(body)).To(Equal("streamed body"))
+
+	journalView, err = unit.GetEntries(0, 25, nil, nil, "")
+	Expect(err).To(BeNil())
+	Expect(journalView.Journal[0].Response.Body).To(Equal("streamed body"))
+}
+
 func Test_Journal_NewEntry_RespectsEntryLimit(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -1,6 +1,8 @@
 package matching_test
 
 import (
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/matching"
@@ -25,7 +27,69 @@ var bodyMatchingTests = []bodyMatchingTest{
 		toMatch: models.RequestDetails{
 			Body: "foo",
 		},
-		equals: BeTrue(),
+		equals:      BeTrue(),
+		matchEquals: Equal(0),
+	},
+	{
+		name:     "NilMatchesEmptyBody",
+		matchers: nil,
+		toMatch: models.RequestDetails{
+			Body: "",
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(0),
+	},
+	{
+		name: "MatchesTrueWithEmptyMatchOnEmptyBody",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Empty,
+			},
+		},
+		toMatch: models.RequestDetails{
+			Body: "",
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(2),
+	},
+	{
+		name: "MatchesFalseWithEmptyMatchOnBody",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Empty,
+			},
+		},
+		toMatch: models.RequestDetails{
+			Body: "foo",
+		},
+		equals: BeFalse(),
+	},
+	{
+		name: "MatchesTrueWithExactMatchOfEmptyStringOnEmptyBody",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   "",
+			},
+		},
+		toMatch: models.RequestDetails{
+			Body: "",
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(2),
+	},
+	{
+		name: "MatchesFalseWithExactMatchOfEmptyStringOnBody",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   "",
+			},
+		},
+		toMatch: models.RequestDetails{
+			Body: "foo",
+		},
+		equals: BeFalse(),
 	},
 	{
 		name: "MatchesTrueWithJsonMatch",
@@ -93,6 +157,107 @@ var bodyMatchingTests = []bodyMatchingTest{
 		},
 		equals: BeFalse(),
 	},
+	{
+		name: "MatchesTrueWithFormMatchOnOneOfManyValues",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: "form",
+				Value: map[string][]models.RequestFieldMatchers{
+					"scope": {
+						{
+							Matcher: matchers.Exact,
+							Value:   "write",
+						},
+					},
+				},
+			},
+		},
+		toMatch: models.RequestDetails{
+			FormData: map[string][]string{"scope": {"read", "write"}},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(2),
+	},
+	{
+		name: "MatchesTrueWithFormArrayMatchOnAllValues",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: "form",
+				Value: map[string][]models.RequestFieldMatchers{
+					"scope": {
+						{
+							Matcher: matchers.Array,
+							Value:   []interface{}{"read", "write"},
+						},
+					},
+				},
+			},
+		},
+		toMatch: models.RequestDetails{
+			FormData: map[string][]string{"scope": {"read", "write"}},
+		},
+		equals: BeTrue(),
+	},
+	{
+		name: "MatchesFalseWithFormMatchWhenFieldIsAbsent",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: "form",
+				Value: map[string][]models.RequestFieldMatchers{
+					"name": {
+						{
+							Matcher: matchers.Exact,
+							Value:   "foo",
+						},
+					},
+				},
+			},
+		},
+		toMatch: models.RequestDetails{
+			FormData: map[string][]string{"other": {"foo"}},
+		},
+		equals:      BeFalse(),
+		matchEquals: Equal(0),
+	},
+	{
+		name: "MatchesFalseWithOtherBodyMatcherAlongsideFormMatch",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: "form",
+				Value: map[string][]models.RequestFieldMatchers{
+					"name": {
+						{
+							Matcher: matchers.Exact,
+							Value:   "foo",
+						},
+					},
+				},
+			},
+			{
+				Matcher: matchers.Glob,
+				Value:   "*debug=true*",
+			},
+		},
+		toMatch: models.RequestDetails{
+			Body:     "name=foo",
+			FormData: map[string][]string{"name": {"foo"}},
+		},
+		equals: BeFalse(),
+	},
+	{
+		name: "MatchesFalseWithInvalidFormMatcherValue",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: "form",
+				Value:   "name=foo",
+			},
+		},
+		toMatch: models.RequestDetails{
+			Body:     "name=foo",
+			FormData: map[string][]string{"name": {"foo"}},
+		},
+		equals: BeFalse(),
+	},
 }
 
 func Test_BodyMatching(t *testing.T) {
@@ -108,3 +273,42 @@ func Test_BodyMatching(t *testing.T) {
 	}
 
 }
+
+func Test_BodyMatching_FormMatchIgnoresFieldOrderAndEncoding(t *testing.T) {
+	RegisterTestingT(t)
+
+	formMatchers := []models.RequestFieldMatchers{
+		{
+			Matcher: "form",
+			Value: map[string][]models.RequestFieldMatchers{
+				"name": {
+					{
+						Matcher: matchers.Exact,
+						Value:   "John Doe",
+					},
+				},
+				"email": {
+					{
+						Matcher: matchers.Exact,
+						Value:   "john@example.com",
+					},
+				},
+			},
+		},
+	}
+
+	for _, body := range []string{
+		"name=John+Doe&email=john%40example.com",
+		"email=john@example.com&name=John%20Doe",
+		"csrf=abc&email=john%40example.com&name=John+Doe",
+	} {
+		request, err := http.NewRequest("POST", "http://hoverfly.io/login", strings.NewReader(body))
+		Expect(err).To(BeNil())
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		requestDetails, err := models.NewRequestDetailsFromHttpRequest(request)
+		Expect(err).To(BeNil())
+
+		Expect(matching.BodyMatching(formMatchers, requestDetails).Matched).To(BeTrue(), body)
+	}
+}
@@ -7,29 +7,38 @@ import (
 func BodyMatching(fields []models.RequestFieldMatchers, req models.RequestDetails) *FieldMatch {
 
 	matched := true
-	hasForm := false
 	var score int
 
 	if len(fields) == 0 {
 		return &FieldMatch{
 			Matched: matched,
-			Score:   1,
+			Score:   0,
 		}
 	}
 
+	var bodyFields []models.RequestFieldMatchers
 	for _, field := range fields {
-		if field.Matcher == "form" {
-			hasForm = true
-			formMatchers := field.Value.(map[string][]models.RequestFieldMatchers)
-			formMatched := processFormMatcher(formMatchers, req.FormData)
-			if !formMatched.Matched {
-				matched = false
-			}
-			score += formMatched.Score
+		if field.Matcher != "form" {
+			bodyFields = append(bodyFields, field)
+			continue
+		}
+
+		formMatchers, ok := field.Value.(map[string][]models.RequestFieldMatchers)
+		if !ok {
+			matched = false
+			continue
 		}
+
+		formMatched := processFormMatcher(formMatchers, req.FormData)
+		if !formMatched.Matched {
+			matched = false
+		}
+		score += formMatched.Score
 	}
-	if !hasForm {
-		bodyMatched := FieldMatcher(fields, req.Body)
+
+	// other matchers on the body, eg. a glob alongside a form matcher, match the raw body
+	if len(bodyFields) > 0 {
+		bodyMatched := FieldMatcher(bodyFields, req.Body)
 		if !bodyMatched.Matched {
 			matched = false
 		}
@@ -52,7 +61,7 @@ func processFormMatcher(formFields map[string][]models.RequestFieldMatchers, for
 			matched = false
 			continue
 		}
-		formMatched := FieldMatcher(formMatchers, formValue[0])
+		formMatched := MultiValueFieldMatcher(formMatchers, formValue)
 		if !formMatched.Matched {
 			matched = false
 		}
@@ -8,6 +8,8 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+var logger = log.WithField("component", "matching")
+
 type CacheMatcher struct {
 	Webserver    bool
 	RequestCache cache.FastCache
@@ -19,7 +21,7 @@ func (this *CacheMatcher) GetCachedResponse(req *models.RequestDetails) (*models
 		return nil, errors.NoCacheSetError()
 	}
 
-	log.Debug("Checking cache for request")
+	logger.Debug("Checking cache for request")
 
 	var key string
 
@@ -32,7 +34,7 @@ func (this *CacheMatcher) GetCachedResponse(req *models.RequestDetails) (*models
 	cachedResponse, found := this.RequestCache.Get(key)
 
 	if !found {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"key":         key,
 			"query":       req.Query,
 			"path":        req.Path,
@@ -43,7 +45,7 @@ func (this *CacheMatcher) GetCachedResponse(req *models.RequestDetails) (*models
 		return nil, errors.RecordedRequestNotInCacheError()
 	}
 
-	log.WithFields(log.Fields{
+	logger.WithFields(log.Fields{
 		"key":         key,
 		"path":        req.Path,
 		"rawQuery":    req.Query,
@@ -95,7 +97,7 @@ func (this *CacheMatcher) GetAllResponses() (v2.CacheView, error) {
 }
 
 // TODO: This would be easier to reason about if we had two methods, "CacheHit" and "CacheHit" in order to reduce bloating
-func (this *CacheMatcher) SaveRequestMatcherResponsePair(request models.RequestDetails, pair *models.RequestMatcherResponsePair, matchError *models.MatchError) (*models.CachedResponse, error) {
+func (this *CacheMatcher) SaveRequestMatcherResponsePair(request models.RequestDetails, pair *models.RequestMatcherResponsePair, pairIndex int, matchError *models.MatchError) (*models.CachedResponse, error) {
 	if this.RequestCache == nil {
 		return nil, errors.NoCacheSetError()
 	}
@@ -108,7 +110,7 @@ func (this *CacheMatcher) SaveRequestMatcherResponsePair(request models.RequestD
 		key = request.Hash()
 	}
 
-	log.WithFields(log.Fields{
+	logger.WithFields(log.Fields{
 		"path":          request.Path,
 		"rawQuery":      request.Query,
 		"requestMethod": request.Method,
@@ -118,8 +120,9 @@ func (this *CacheMatcher) SaveRequestMatcherResponsePair(request models.RequestD
 	}).Debug("Saving response to cache")
 
 	cachedResponse := models.CachedResponse{
-		Request:      request,
-		MatchingPair: pair,
+		Request:           request,
+		MatchingPair:      pair,
+		MatchingPairIndex: pairIndex,
 	}
 
 	if matchError != nil {
@@ -138,25 +141,51 @@ func (this *CacheMatcher) FlushCache() error {
 	return this.RequestCache.DeleteData()
 }
 
+// FlushCacheForDestination removes only the cached responses for requests to the destination,
+// so that they are matched against the simulation again while the rest of the cache is kept
+func (this *CacheMatcher) FlushCacheForDestination(destination string) error {
+	if this.RequestCache == nil {
+		return errors.NoCacheSetError()
+	}
+
+	entries, err := this.RequestCache.GetAllEntries()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range entries {
+		cachedResponse := value.(*models.CachedResponse)
+		if cachedResponse.Request.Destination != destination {
+			continue
+		}
+
+		if err := this.RequestCache.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (this *CacheMatcher) PreloadCache(simulation *models.Simulation) error {
 
 	if this.RequestCache == nil {
 		return errors.NoCacheSetError()
 	}
 	cacheRequestCount := 0
-	for _, pair := range simulation.GetMatchingPairs() {
+	for i, pair := range simulation.GetMatchingPairs() {
 
 		if requestDetails := pair.RequestMatcher.ToEagerlyCacheable(); requestDetails != nil {
 			pairCopy := pair
-			this.SaveRequestMatcherResponsePair(*requestDetails, &pairCopy, nil)
+			this.SaveRequestMatcherResponsePair(*requestDetails, &pairCopy, i, nil)
 			cacheRequestCount = cacheRequestCount + 1
 		}
 	}
 
 	if cacheRequestCount > 0 && cacheRequestCount == len(simulation.GetMatchingPairs()) {
-		log.Info("cache preloaded completely")
+		logger.Info("cache preloaded completely")
 	} else if cacheRequestCount > 0 {
-		log.Info("cache preloaded partially")
+		logger.Info("cache preloaded partially")
 	}
 
 	return nil
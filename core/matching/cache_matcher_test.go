@@ -31,7 +31,7 @@ func Test_CacheMatcher_SaveRequestMatcherResponsePair_WillReturnErrorIfCacheIsNi
 	RegisterTestingT(t)
 	unit := matching.CacheMatcher{}
 
-	cachedResponse, err := unit.SaveRequestMatcherResponsePair(models.RequestDetails{}, nil, nil)
+	cachedResponse, err := unit.SaveRequestMatcherResponsePair(models.RequestDetails{}, nil, -1, nil)
 	Expect(err).ToNot(BeNil())
 	Expect(err.Error()).To(Equal("No cache set"))
 	Expect(cachedResponse).To(BeNil())
@@ -44,7 +44,7 @@ func Test_CacheMatcher_SaveRequestMatcherResponsePair_CanSaveNilPairs(t *testing
 		RequestCache: cache.NewDefaultLRUCache(),
 	}
 
-	cachedResponse, err := unit.SaveRequestMatcherResponsePair(models.RequestDetails{}, nil, nil)
+	cachedResponse, err := unit.SaveRequestMatcherResponsePair(models.RequestDetails{}, nil, -1, nil)
 	Expect(err).To(BeNil())
 
 	Expect(cachedResponse.MatchingPair).To(BeNil())
@@ -59,6 +59,39 @@ func Test_CacheMatcher_FlushCache_WillReturnErrorIfCacheIsNil(t *testing.T) {
 	Expect(err.Error()).To(Equal("No cache set"))
 }
 
+func Test_CacheMatcher_FlushCacheForDestination_WillReturnErrorIfCacheIsNil(t *testing.T) {
+	RegisterTestingT(t)
+	unit := matching.CacheMatcher{}
+
+	err := unit.FlushCacheForDestination("api.example.com")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("No cache set"))
+}
+
+func Test_CacheMatcher_FlushCacheForDestination_OnlyRemovesResponsesForTheDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := matching.CacheMatcher{
+		RequestCache: cache.NewDefaultLRUCache(),
+	}
+
+	unit.SaveRequestMatcherResponsePair(models.RequestDetails{Destination: "api.example.com", Path: "/one"}, nil, -1, nil)
+	unit.SaveRequestMatcherResponsePair(models.RequestDetails{Destination: "api.example.com", Path: "/two"}, nil, -1, nil)
+	unit.SaveRequestMatcherResponsePair(models.RequestDetails{Destination: "other.example.com", Path: "/one"}, nil, -1, nil)
+
+	Expect(unit.FlushCacheForDestination("api.example.com")).To(Succeed())
+
+	count, err := unit.RequestCache.RecordsCount()
+	Expect(err).To(BeNil())
+	Expect(count).To(Equal(1))
+
+	_, cacheErr := unit.GetCachedResponse(&models.RequestDetails{Destination: "other.example.com", Path: "/one"})
+	Expect(cacheErr).To(BeNil())
+
+	_, cacheErr = unit.GetCachedResponse(&models.RequestDetails{Destination: "api.example.com", Path: "/one"})
+	Expect(cacheErr).ToNot(BeNil())
+}
+
 func Test_CacheMatcher_PreloadCache_WillReturnErrorIfCacheIsNil(t *testing.T) {
 	RegisterTestingT(t)
 	unit := matching.CacheMatcher{}
@@ -0,0 +1,59 @@
+package matching
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v2 "github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+)
+
+// CachedResponseCodec encodes cached responses so that the request cache can be persisted.
+// Compiled templates are not stored, they are compiled again when the response is next used.
+type CachedResponseCodec struct{}
+
+type persistedCachedResponse struct {
+	Request           models.RequestDetails                `json:"request"`
+	MatchingPair      *v2.RequestMatcherResponsePairViewV5 `json:"matchingPair,omitempty"`
+	MatchingPairIndex int                                  `json:"matchingPairIndex"`
+	ClosestMiss       *models.ClosestMiss                  `json:"closestMiss,omitempty"`
+}
+
+func (CachedResponseCodec) Encode(value interface{}) ([]byte, error) {
+	cachedResponse, ok := value.(*models.CachedResponse)
+	if !ok {
+		return nil, fmt.Errorf("Cannot encode %T as a cached response", value)
+	}
+
+	persisted := persistedCachedResponse{
+		Request:           cachedResponse.Request,
+		MatchingPairIndex: cachedResponse.MatchingPairIndex,
+		ClosestMiss:       cachedResponse.ClosestMiss,
+	}
+
+	if cachedResponse.MatchingPair != nil {
+		pairView := cachedResponse.MatchingPair.BuildView()
+		persisted.MatchingPair = &pairView
+	}
+
+	return json.Marshal(persisted)
+}
+
+func (CachedResponseCodec) Decode(data []byte) (interface{}, error) {
+	var persisted persistedCachedResponse
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return nil, err
+	}
+
+	cachedResponse := &models.CachedResponse{
+		Request:           persisted.Request,
+		MatchingPairIndex: persisted.MatchingPairIndex,
+		ClosestMiss:       persisted.ClosestMiss,
+	}
+
+	if persisted.MatchingPair != nil {
+		cachedResponse.MatchingPair = models.NewRequestMatcherResponsePairFromView(persisted.MatchingPair)
+	}
+
+	return cachedResponse, nil
+}
@@ -0,0 +1,87 @@
+package matching_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+func Test_CachedResponseCodec_RoundTripsMatchingPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := matching.CachedResponseCodec{}
+
+	cachedResponse := &models.CachedResponse{
+		Request: models.RequestDetails{
+			Method:      "GET",
+			Destination: "test.com",
+			Query:       map[string][]string{"a": {"1"}},
+		},
+		MatchingPair: &models.RequestMatcherResponsePair{
+			RequestMatcher: models.RequestMatcher{
+				Destination: []models.RequestFieldMatchers{
+					{
+						Matcher: matchers.Exact,
+						Value:   "test.com",
+					},
+				},
+			},
+			Response: models.ResponseDetails{
+				Status:  200,
+				Body:    "body",
+				Headers: map[string][]string{"Header": {"value"}},
+			},
+		},
+		MatchingPairIndex: 3,
+	}
+
+	data, err := unit.Encode(cachedResponse)
+	Expect(err).To(BeNil())
+
+	decoded, err := unit.Decode(data)
+	Expect(err).To(BeNil())
+
+	result := decoded.(*models.CachedResponse)
+	Expect(result.Request).To(Equal(cachedResponse.Request))
+	Expect(result.ClosestMiss).To(BeNil())
+	Expect(result.MatchingPair.RequestMatcher.Destination).To(Equal(cachedResponse.MatchingPair.RequestMatcher.Destination))
+	Expect(result.MatchingPairIndex).To(Equal(3))
+	Expect(result.MatchingPair.Response.Status).To(Equal(200))
+	Expect(result.MatchingPair.Response.Body).To(Equal("body"))
+	Expect(result.MatchingPair.Response.Headers).To(Equal(map[string][]string{"Header": {"value"}}))
+}
+
+func Test_CachedResponseCodec_RoundTripsMiss(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := matching.CachedResponseCodec{}
+
+	cachedResponse := &models.CachedResponse{
+		Request: models.RequestDetails{
+			Method: "GET",
+		},
+		ClosestMiss: &models.ClosestMiss{
+			MissedFields: []string{"method"},
+		},
+	}
+
+	data, err := unit.Encode(cachedResponse)
+	Expect(err).To(BeNil())
+
+	decoded, err := unit.Decode(data)
+	Expect(err).To(BeNil())
+
+	result := decoded.(*models.CachedResponse)
+	Expect(result.MatchingPair).To(BeNil())
+	Expect(result.ClosestMiss.MissedFields).To(Equal([]string{"method"}))
+}
+
+func Test_CachedResponseCodec_Encode_RejectsOtherValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := matching.CachedResponseCodec{}.Encode("not a cached response")
+	Expect(err).To(MatchError("Cannot encode string as a cached response"))
+}
@@ -17,7 +17,7 @@ func FieldMatcher(fields []models.RequestFieldMatchers, toMatch string) *FieldMa
 
 	for _, field := range fields {
 		if isMatching(field, toMatch) {
-			if field.Matcher == matchers.Exact || (field.Matcher == matchers.Array && field.Config == nil) {
+			if field.Matcher == matchers.Exact || field.Matcher == matchers.Empty || (field.Matcher == matchers.Array && field.Config == nil) {
 				fieldMatch.Score = fieldMatch.Score + 2
 			} else {
 				fieldMatch.Score = fieldMatch.Score + 1
@@ -30,6 +30,25 @@ func FieldMatcher(fields []models.RequestFieldMatchers, toMatch string) *FieldMa
 	return fieldMatch
 }
 
+// MultiValueFieldMatcher - matches all of a header's or form field's values joined with ";", which
+// is how they are captured and how the array matcher compares them. A field with more than one
+// value, eg. Accept sent more than once, also matches when any single value does, so a glob such
+// as "application/*" does not have to account for the other values.
+func MultiValueFieldMatcher(matchers []models.RequestFieldMatchers, values []string) *FieldMatch {
+	fieldMatch := FieldMatcher(matchers, strings.Join(values, ";"))
+	if fieldMatch.Matched || len(values) < 2 {
+		return fieldMatch
+	}
+
+	for _, value := range values {
+		if valueMatch := FieldMatcher(matchers, value); valueMatch.Matched {
+			return valueMatch
+		}
+	}
+
+	return fieldMatch
+}
+
 func isMatching(field models.RequestFieldMatchers, toMatch string) bool {
 	currentMatcher := field
 	actual := toMatch
@@ -68,3 +87,31 @@ type FieldMatch struct {
 	Matched bool
 	Score   int
 }
+
+// RepeatedValueFieldMatcher - matches the values of a query parameter sent more than once, eg. ?id=1&id=2&id=3.
+// Each matcher must match either all of the values joined with ";", as captured, or any one of them, so the
+// order of the values does not matter and matchers for "1" and "3" match as long as both values are present.
+func RepeatedValueFieldMatcher(fields []models.RequestFieldMatchers, values []string) *FieldMatch {
+	joined := strings.Join(values, ";")
+	if len(values) < 2 {
+		return FieldMatcher(fields, joined)
+	}
+
+	fieldMatch := &FieldMatch{Matched: true}
+	for _, field := range fields {
+		field := []models.RequestFieldMatchers{field}
+
+		valueMatch := FieldMatcher(field, joined)
+		for i := 0; !valueMatch.Matched && i < len(values); i++ {
+			valueMatch = FieldMatcher(field, values[i])
+		}
+
+		if valueMatch.Matched {
+			fieldMatch.Score += valueMatch.Score
+		} else {
+			fieldMatch.Matched = false
+		}
+	}
+
+	return fieldMatch
+}
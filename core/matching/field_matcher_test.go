@@ -193,6 +193,40 @@ var fieldMatcherTests = []fieldMatcherTest{
 		toMatch: `<document><details>{"name":"Test", "id":"12345"}</details></document>`,
 		equals:  BeTrue(),
 	},
+	{
+		name: "MatcherChainingWithNumericComparison",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: "jsonpath",
+				Value:   "$.amount",
+				DoMatch: &models.RequestFieldMatchers{
+					Matcher: "greaterthan",
+					Value:   1000.0,
+					DoMatch: &models.RequestFieldMatchers{
+						Matcher: "lessthan",
+						Value:   5000.0,
+					},
+				},
+			},
+		},
+		toMatch: `{"amount": 1500, "currency": "GBP"}`,
+		equals:  BeTrue(),
+	},
+	{
+		name: "MatcherChainingWithNumericComparisonOutOfRange",
+		matchers: []models.RequestFieldMatchers{
+			{
+				Matcher: "jsonpath",
+				Value:   "$.amount",
+				DoMatch: &models.RequestFieldMatchers{
+					Matcher: "greaterthan",
+					Value:   1000.0,
+				},
+			},
+		},
+		toMatch: `{"amount": 999.5, "currency": "GBP"}`,
+		equals:  BeFalse(),
+	},
 	{
 		name: "TestJwtMatcher",
 		matchers: []models.RequestFieldMatchers{
@@ -11,6 +11,7 @@ type FirstMatchStrategy struct {
 	matchedOnAllButHeadersAtLeastOnce bool
 	matchedOnAllButStateAtLeastOnce   bool
 	matchingPair                      *models.RequestMatcherResponsePair
+	matchingPairIndex                 int
 }
 
 func (s *FirstMatchStrategy) PreMatching() {
@@ -34,7 +35,7 @@ func (s *FirstMatchStrategy) Matching(fieldMatch *FieldMatch, field string) {
 	}
 }
 
-func (s *FirstMatchStrategy) PostMatching(req models.RequestDetails, requestMatcher models.RequestMatcher, matchingPair models.RequestMatcherResponsePair, state map[string]string) *MatchingResult {
+func (s *FirstMatchStrategy) PostMatching(req models.RequestDetails, requestMatcher models.RequestMatcher, matchingPair models.RequestMatcherResponsePair, pairIndex int, state map[string]string) *MatchingResult {
 	if s.matchedOnAllButHeaders {
 		s.matchedOnAllButHeadersAtLeastOnce = true
 	}
@@ -44,6 +45,7 @@ func (s *FirstMatchStrategy) PostMatching(req models.RequestDetails, requestMatc
 	}
 	if s.matched && s.matchingPair == nil {
 		s.matchingPair = &matchingPair
+		s.matchingPairIndex = pairIndex
 		return s.Result()
 	}
 
@@ -62,6 +64,7 @@ func (s *FirstMatchStrategy) Result() *MatchingResult {
 
 		return &MatchingResult{
 			Pair:      s.matchingPair,
+			PairIndex: s.matchingPairIndex,
 			Error:     nil,
 			Cacheable: isCacheable(s.matchingPair, s.matchedOnAllButHeadersAtLeastOnce, s.matchedOnAllButStateAtLeastOnce),
 		}
@@ -69,6 +72,7 @@ func (s *FirstMatchStrategy) Result() *MatchingResult {
 
 	return &MatchingResult{
 		Pair:      nil,
+		PairIndex: -1,
 		Error:     models.NewMatchError("No match found"),
 		Cacheable: isCacheable(nil, s.matchedOnAllButHeadersAtLeastOnce, s.matchedOnAllButStateAtLeastOnce),
 	}
@@ -530,7 +530,7 @@ func Test_FirstMatchStrategy_RequestMatchersCanUseGlobsOnSchemeAndBeMatched(t *t
 			Scheme: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Glob,
-					Value:   "H*",
+					Value:   "h*",
 				},
 			},
 		},
@@ -3,41 +3,41 @@ package matching
 import (
 	"strings"
 
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
 	"github.com/SpectoLabs/hoverfly/core/models"
 )
 
 func HeaderMatching(requestMatcher models.RequestMatcher, toMatch map[string][]string) *FieldMatch {
 
-	// // Make everything lowercase, as headers are case insensitive
-	// for requestHeaderKey, requestHeaderValues := range toMatch {
-	// 	delete(toMatch, requestHeaderKey)
-	// 	toMatch[strings.ToLower(requestHeaderKey)] = requestHeaderValues
-	// }
-
 	matched := true
 	var score int
 
-	requestMatcherHeadersWithMatchers := requestMatcher.Headers
+	// Make everything lowercase, as headers are case insensitive
+	toMatchWithLowerCaseKeys := make(map[string][]string)
+	for key, value := range toMatch {
+		toMatchWithLowerCaseKeys[strings.ToLower(key)] = append(toMatchWithLowerCaseKeys[strings.ToLower(key)], value...)
+	}
 
-	for matcherHeaderKey, matcherHeaderValue := range requestMatcherHeadersWithMatchers {
-		// Make everything lowercase, as headers are case insensitive
-		toMatchWithLowerCaseKeys := make(map[string][]string)
-		for key, value := range toMatch {
-			toMatchWithLowerCaseKeys[strings.ToLower(key)] = value
+	for matcherHeaderKey, matcherHeaderValue := range requestMatcher.Headers {
+		toMatchHeaderValues, found := toMatchWithLowerCaseKeys[strings.ToLower(matcherHeaderKey)]
+		if requiresAbsence(matcherHeaderValue) {
+			if found {
+				matched = false
+			} else {
+				score += 2
+			}
+			continue
 		}
-		matcherHeaderValueMatched := false
 
-		toMatchHeaderValues, found := toMatchWithLowerCaseKeys[strings.ToLower(matcherHeaderKey)]
 		if !found {
 			matched = false
 			continue
 		}
 
-		fieldMatch := FieldMatcher(matcherHeaderValue, strings.Join(toMatchHeaderValues, ";"))
-		matcherHeaderValueMatched = fieldMatch.Matched
+		fieldMatch := MultiValueFieldMatcher(matcherHeaderValue, toMatchHeaderValues)
 		score += fieldMatch.Score
 
-		if !matcherHeaderValueMatched {
+		if !fieldMatch.Matched {
 			matched = false
 		}
 	}
@@ -47,3 +47,14 @@ func HeaderMatching(requestMatcher models.RequestMatcher, toMatch map[string][]s
 		Score:   score,
 	}
 }
+
+// requiresAbsence reports whether a header is matched with the absent matcher, in which case the
+// request must not have the header, whatever other matchers are given for it
+func requiresAbsence(fieldMatchers []models.RequestFieldMatchers) bool {
+	for _, fieldMatcher := range fieldMatchers {
+		if strings.ToLower(fieldMatcher.Matcher) == matchers.Absent {
+			return true
+		}
+	}
+	return false
+}
@@ -160,6 +160,168 @@ var tests = []headerMatchingTest{
 		equals:      BeFalse(),
 		matchEquals: Equal(0),
 	},
+	{
+		name: "headersWithMatchers glob matches one of many values",
+		headers: map[string][]models.RequestFieldMatchers{
+			"Accept": {
+				{
+					Matcher: matchers.Glob,
+					Value:   "application/*",
+				},
+			},
+		},
+		toMatchHeaders: map[string][]string{
+			"Accept": {"text/html", "application/json"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(1),
+	},
+	{
+		name: "headersWithMatchers exact matches one of many values",
+		headers: map[string][]models.RequestFieldMatchers{
+			"Accept": {
+				{
+					Matcher: matchers.Exact,
+					Value:   "application/json",
+				},
+			},
+		},
+		toMatchHeaders: map[string][]string{
+			"Accept": {"text/html", "application/json"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(2),
+	},
+	{
+		name: "headersWithMatchers glob matches none of many values",
+		headers: map[string][]models.RequestFieldMatchers{
+			"Accept": {
+				{
+					Matcher: matchers.Glob,
+					Value:   "image/*",
+				},
+			},
+		},
+		toMatchHeaders: map[string][]string{
+			"Accept": {"text/html", "application/json"},
+		},
+		equals:      BeFalse(),
+		matchEquals: Equal(0),
+	},
+	{
+		name: "headersWithMatchers array matches all values",
+		headers: map[string][]models.RequestFieldMatchers{
+			"Accept": {
+				{
+					Matcher: matchers.Array,
+					Value:   []interface{}{"text/html", "application/json"},
+				},
+			},
+		},
+		toMatchHeaders: map[string][]string{
+			"Accept": {"text/html", "application/json"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(2),
+	},
+	{
+		name: "headersWithMatchers array fails when a value is missing",
+		headers: map[string][]models.RequestFieldMatchers{
+			"Accept": {
+				{
+					Matcher: matchers.Array,
+					Value:   []interface{}{"text/html", "application/json"},
+				},
+			},
+		},
+		toMatchHeaders: map[string][]string{
+			"Accept": {"application/json"},
+		},
+		equals:      BeFalse(),
+		matchEquals: Equal(0),
+	},
+	{
+		name: "headersWithMatchers glob on authorization prefix with case insensitive name",
+		headers: map[string][]models.RequestFieldMatchers{
+			"authorization": {
+				{
+					Matcher: matchers.Glob,
+					Value:   "Bearer *",
+				},
+			},
+		},
+		toMatchHeaders: map[string][]string{
+			"Authorization": {"Bearer abc.def.ghi"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(1),
+	},
+	{
+		name: "headersWithMatchers absent when header is not present",
+		headers: map[string][]models.RequestFieldMatchers{
+			"Authorization": {
+				{
+					Matcher: matchers.Absent,
+				},
+			},
+		},
+		toMatchHeaders: map[string][]string{
+			"Accept": {"application/json"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(2),
+	},
+	{
+		name: "headersWithMatchers absent fails when header is present",
+		headers: map[string][]models.RequestFieldMatchers{
+			"Authorization": {
+				{
+					Matcher: matchers.Absent,
+				},
+			},
+		},
+		toMatchHeaders: map[string][]string{
+			"authorization": {"Bearer abc.def.ghi"},
+		},
+		equals:      BeFalse(),
+		matchEquals: Equal(0),
+	},
+	{
+		name: "headersWithMatchers absent fails when header is present but empty",
+		headers: map[string][]models.RequestFieldMatchers{
+			"Authorization": {
+				{
+					Matcher: matchers.Absent,
+				},
+			},
+		},
+		toMatchHeaders: map[string][]string{
+			"Authorization": {""},
+		},
+		equals:      BeFalse(),
+		matchEquals: Equal(0),
+	},
+	{
+		name: "headersWithMatchers absent with other headers present",
+		headers: map[string][]models.RequestFieldMatchers{
+			"Authorization": {
+				{
+					Matcher: matchers.Absent,
+				},
+			},
+			"Accept": {
+				{
+					Matcher: matchers.Exact,
+					Value:   "application/json",
+				},
+			},
+		},
+		toMatchHeaders: map[string][]string{
+			"Accept": {"application/json"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(4),
+	},
 }
 
 func Test_HeaderMatching(t *testing.T) {
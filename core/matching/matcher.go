@@ -16,7 +16,9 @@ func Match(strongestMatch string, req models.RequestDetails, webserver bool, sim
 }
 
 type MatchingResult struct {
-	Pair      *models.RequestMatcherResponsePair
+	Pair *models.RequestMatcherResponsePair
+	// PairIndex - position of the matched pair in the simulation, -1 when there is no match
+	PairIndex int
 	Error     *models.MatchError
 	Cacheable bool
 }
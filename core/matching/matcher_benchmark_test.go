@@ -0,0 +1,68 @@
+package matching_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/state"
+)
+
+// Compares matching against a large simulation where destinations use exact matchers, so only the pairs for the
+// request's destination are checked, with one where they use glob matchers, so every pair is checked
+func BenchmarkMatchLargeSimulation(b *testing.B) {
+	benchmarks := []struct {
+		name               string
+		destinationMatcher string
+	}{
+		{"Exact destinations", matchers.Exact},
+		{"Glob destinations", matchers.Glob},
+	}
+
+	for _, bm := range benchmarks {
+		simulation := models.NewSimulation()
+		for i := 0; i < 5000; i++ {
+			simulation.AddPairWithoutCheck(&models.RequestMatcherResponsePair{
+				RequestMatcher: models.RequestMatcher{
+					Destination: []models.RequestFieldMatchers{
+						{
+							Matcher: bm.destinationMatcher,
+							Value:   fmt.Sprintf("host-%d.com", i%100),
+						},
+					},
+					Path: []models.RequestFieldMatchers{
+						{
+							Matcher: matchers.Exact,
+							Value:   fmt.Sprintf("/path/%d", i),
+						},
+					},
+					Method: []models.RequestFieldMatchers{
+						{
+							Matcher: matchers.Exact,
+							Value:   "GET",
+						},
+					},
+				},
+				Response: models.ResponseDetails{Status: 200},
+			})
+		}
+
+		request := models.RequestDetails{
+			Method:      "GET",
+			Destination: "host-42.com",
+			Path:        "/path/4942",
+		}
+		matchingState := &state.State{State: map[string]string{}}
+
+		b.Run(bm.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				result := matching.Match("strongest", request, false, simulation, matchingState)
+				if result.Pair == nil {
+					b.Fatal("expected a match")
+				}
+			}
+		})
+	}
+}
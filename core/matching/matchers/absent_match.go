@@ -0,0 +1,9 @@
+package matchers
+
+var Absent = "absent"
+
+// AbsentMatch never matches a value, as there is nothing to match when the field is absent. On a header, it
+// makes the header matcher pass only when the request does not have the header at all, and fail when it does.
+func AbsentMatch(match interface{}, toMatch string) bool {
+	return false
+}
@@ -0,0 +1,16 @@
+package matchers_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_AbsentMatch_MatchesFalseForAnyValue(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.AbsentMatch(nil, "")).To(BeFalse())
+	Expect(matchers.AbsentMatch(nil, "Bearer token")).To(BeFalse())
+	Expect(matchers.AbsentMatch("anything", "anything")).To(BeFalse())
+}
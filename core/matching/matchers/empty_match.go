@@ -0,0 +1,9 @@
+package matchers
+
+var Empty = "empty"
+
+// EmptyMatch matches only when the value being matched is empty. The matcher value is
+// ignored, so it can be used to require that a request has no body at all.
+func EmptyMatch(match interface{}, toMatch string) bool {
+	return len(toMatch) == 0
+}
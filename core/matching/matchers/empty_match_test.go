@@ -0,0 +1,28 @@
+package matchers_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_EmptyMatch_MatchesTrueWhenStringToMatchIsEmpty(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.EmptyMatch(nil, "")).To(BeTrue())
+}
+
+func Test_EmptyMatch_MatchesFalseWhenStringToMatchHasContent(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.EmptyMatch(nil, `{"event": "push"}`)).To(BeFalse())
+	Expect(matchers.EmptyMatch(nil, " ")).To(BeFalse())
+}
+
+func Test_EmptyMatch_IgnoresMatcherValue(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.EmptyMatch("anything", "")).To(BeTrue())
+	Expect(matchers.EmptyMatch("anything", "anything")).To(BeFalse())
+}
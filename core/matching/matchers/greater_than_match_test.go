@@ -0,0 +1,41 @@
+package matchers_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_GreaterThanMatch_MatchesFalseWithIncorrectDataType(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.GreaterThanMatch(true, "1")).To(BeFalse())
+}
+
+func Test_GreaterThanMatch_MatchesFalseWhenStringToMatchIsNotANumber(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.GreaterThanMatch(1000.0, "test")).To(BeFalse())
+}
+
+func Test_GreaterThanMatch_MatchesTrueWhenStringToMatchIsGreater(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.GreaterThanMatch(1000.0, "1500")).To(BeTrue())
+	Expect(matchers.GreaterThanMatch(1000.0, "1000.01")).To(BeTrue())
+}
+
+func Test_GreaterThanMatch_MatchesFalseWhenStringToMatchIsEqualOrLess(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.GreaterThanMatch(1000.0, "1000")).To(BeFalse())
+	Expect(matchers.GreaterThanMatch(1000.0, "-1")).To(BeFalse())
+}
+
+func Test_GreaterThanMatch_AcceptsMatcherValueAsString(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.GreaterThanMatch("1000", "1500")).To(BeTrue())
+	Expect(matchers.GreaterThanMatch("not-a-number", "1500")).To(BeFalse())
+}
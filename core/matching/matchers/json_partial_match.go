@@ -20,13 +20,15 @@ func JsonPartialMatch(match interface{}, toMatch string) bool {
 		return false
 	}
 
-	actual, ok := toMatchType.(map[string]interface{})
 	var allNodes []interface{}
-	if ok {
+	switch actual := toMatchType.(type) {
+	case map[string]interface{}:
 		allNodes = getAllNodesFromMap(actual)
-	} else {
-		actual := toMatchType.([]interface{})
+	case []interface{}:
 		allNodes = getAllNodesFromArray(actual)
+	default:
+		// a scalar JSON value cannot contain a partial object or array
+		return false
 	}
 
 	for _, node := range allNodes {
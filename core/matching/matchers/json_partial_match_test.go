@@ -70,6 +70,14 @@ func Test_JsonPartialMatch_MatchesFalseInvalidJson(t *testing.T) {
 	Expect(matchers.JsonPartialMatch(`{"test":{"json":true,"minified":true}}`, `{"test":{"json":true,"minified":}}`)).To(BeFalse())
 }
 
+func Test_JsonPartialMatch_MatchesFalseWithScalarJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonPartialMatch(`{"test": true}`, `5`)).To(BeFalse())
+	Expect(matchers.JsonPartialMatch(`{"test": true}`, `"test"`)).To(BeFalse())
+	Expect(matchers.JsonPartialMatch(`{"test": true}`, `null`)).To(BeFalse())
+}
+
 func Test_JsonPartialMatch_MatchesTrueDeep(t *testing.T) {
 	RegisterTestingT(t)
 
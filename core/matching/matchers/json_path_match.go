@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 
-	log "github.com/sirupsen/logrus"
 	"k8s.io/client-go/util/jsonpath"
 )
 
@@ -31,13 +30,13 @@ func JsonPathExecution(matchString, toMatch string) (string, error) {
 
 	err := jsonPath.Parse(matchString)
 	if err != nil {
-		log.Errorf("Failed to parse json path query %s: %s", matchString, err.Error())
+		logger.Errorf("Failed to parse json path query %s: %s", matchString, err.Error())
 		return "", err
 	}
 
 	var data interface{}
 	if err := json.Unmarshal([]byte(toMatch), &data); err != nil {
-		log.Errorf("Failed to unmarshal body to JSON: %s", err.Error())
+		logger.Errorf("Failed to unmarshal body to JSON: %s", err.Error())
 		return "", err
 	}
 
@@ -45,7 +44,7 @@ func JsonPathExecution(matchString, toMatch string) (string, error) {
 
 	err = jsonPath.Execute(buf, data)
 	if err != nil {
-		log.Errorf("err to execute json path match: %s", err.Error())
+		logger.Errorf("err to execute json path match: %s", err.Error())
 		return "", err
 	}
 
@@ -5,8 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-
-	log "github.com/sirupsen/logrus"
 )
 
 var JWT = "jwt"
@@ -15,7 +13,7 @@ func JwtMatcher(data interface{}, toMatch string) bool {
 
 	jwt, err := ParseJWT(toMatch)
 	if err != nil {
-		log.Errorf("Error occurred while fetching jwt token %s", err.Error())
+		logger.Errorf("Error occurred while fetching jwt token %s", err.Error())
 		return false
 	}
 	return JsonPartialMatch(data, jwt)
@@ -47,7 +45,7 @@ func GetDecodedJsonData(str string) (interface{}, error) {
 
 	strByteArr, err := base64.RawURLEncoding.DecodeString(str)
 	if err != nil {
-		log.Errorf("Error occurred while decoding jwt part %s", str)
+		logger.Errorf("Error occurred while decoding jwt part %s", str)
 		return nil, err
 	}
 	var jsonData interface{}
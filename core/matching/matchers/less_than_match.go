@@ -0,0 +1,19 @@
+package matchers
+
+import "github.com/SpectoLabs/hoverfly/core/util"
+
+var LessThan = "lessthan"
+
+func LessThanMatch(match interface{}, toMatch string) bool {
+	matchNumber, ok := util.ToNumber(match)
+	if !ok {
+		return false
+	}
+
+	toMatchNumber, ok := util.ToNumber(toMatch)
+	if !ok {
+		return false
+	}
+
+	return toMatchNumber < matchNumber
+}
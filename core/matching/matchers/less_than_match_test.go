@@ -0,0 +1,40 @@
+package matchers_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_LessThanMatch_MatchesFalseWithIncorrectDataType(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.LessThanMatch(true, "1")).To(BeFalse())
+}
+
+func Test_LessThanMatch_MatchesFalseWhenStringToMatchIsNotANumber(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.LessThanMatch(1000.0, "test")).To(BeFalse())
+}
+
+func Test_LessThanMatch_MatchesTrueWhenStringToMatchIsLess(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.LessThanMatch(1000.0, "999.99")).To(BeTrue())
+	Expect(matchers.LessThanMatch(1000.0, "-1500")).To(BeTrue())
+}
+
+func Test_LessThanMatch_MatchesFalseWhenStringToMatchIsEqualOrGreater(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.LessThanMatch(1000.0, "1000")).To(BeFalse())
+	Expect(matchers.LessThanMatch(1000.0, "1001")).To(BeFalse())
+}
+
+func Test_LessThanMatch_AcceptsMatcherValueAsString(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.LessThanMatch("1000", "500")).To(BeTrue())
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"reflect"
 
-	log "github.com/sirupsen/logrus"
 	"k8s.io/client-go/util/jsonpath"
 )
 
@@ -21,19 +20,19 @@ func JsonPathMatcherValueGenerator(match interface{}, toMatch string) string {
 
 	err := jsonPath.Parse(matchString)
 	if err != nil {
-		log.Errorf("Failed to parse json path query %s: %s", matchString, err.Error())
+		logger.Errorf("Failed to parse json path query %s: %s", matchString, err.Error())
 		return ""
 	}
 
 	var data interface{}
 	if err := json.Unmarshal([]byte(toMatch), &data); err != nil {
-		log.Errorf("Failed to unmarshal body to JSON: %s", err.Error())
+		logger.Errorf("Failed to unmarshal body to JSON: %s", err.Error())
 		return ""
 	}
 
 	results, err := jsonPath.FindResults(data)
 	if err != nil {
-		log.Errorf("err to execute json path match: %s", err.Error())
+		logger.Errorf("err to execute json path match: %s", err.Error())
 		return ""
 	}
 
@@ -53,7 +52,7 @@ func getResult(results [][]reflect.Value) string {
 		}
 		bytes, err := json.Marshal(allResultsInterface[0])
 		if err != nil {
-			log.Errorf("err to marshal %s", err.Error())
+			logger.Errorf("err to marshal %s", err.Error())
 			return ""
 		}
 		return string(bytes)
@@ -61,7 +60,7 @@ func getResult(results [][]reflect.Value) string {
 
 	bytes, err := json.Marshal(allResultsInterface)
 	if err != nil {
-		log.Errorf("err to marshal %s", err.Error())
+		logger.Errorf("err to marshal %s", err.Error())
 		return ""
 	}
 	return string(bytes)
@@ -71,7 +70,7 @@ func XPathMatchValueGenerator(match interface{}, toMatch string) string {
 
 	results, err := XpathExecution(match.(string), toMatch)
 	if err != nil {
-		log.Errorf("Failed to generate xpath value: %s", err.Error())
+		logger.Errorf("Failed to generate xpath value: %s", err.Error())
 		return ""
 	}
 	return results.String()
@@ -82,7 +81,7 @@ func JwtMatchValueGenerator(match interface{}, toMatch string) string {
 	if jwt, err := ParseJWT(toMatch); err == nil {
 		return jwt
 	} else {
-		log.Errorf("Failed to parse JWT %s", err.Error())
+		logger.Errorf("Failed to parse JWT %s", err.Error())
 		return ""
 	}
 }
@@ -1,5 +1,11 @@
 package matchers
 
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+var logger = log.WithField("component", "matching")
+
 type MatcherFunc func(data interface{}, toMatch string) bool
 
 type MatcherFuncWithConfig func(data interface{}, toMatch string, config map[string]interface{}) bool
@@ -60,6 +66,22 @@ var Matchers = map[string]MatcherDetails{
 		MatcherFunction:     JwtMatcher,
 		MatchValueGenerator: JwtMatchValueGenerator,
 	},
+	GreaterThan: {
+		MatcherFunction:     GreaterThanMatch,
+		MatchValueGenerator: IdentityValueGenerator,
+	},
+	LessThan: {
+		MatcherFunction:     LessThanMatch,
+		MatchValueGenerator: IdentityValueGenerator,
+	},
+	Empty: {
+		MatcherFunction:     EmptyMatch,
+		MatchValueGenerator: nil,
+	},
+	Absent: {
+		MatcherFunction:     AbsentMatch,
+		MatchValueGenerator: nil,
+	},
 }
 
 type MatcherDetails struct {
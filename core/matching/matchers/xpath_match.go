@@ -7,7 +7,6 @@ import (
 	"github.com/ChrisTrenkamp/xsel/grammar"
 	"github.com/ChrisTrenkamp/xsel/parser"
 	"github.com/ChrisTrenkamp/xsel/store"
-	log "github.com/sirupsen/logrus"
 )
 
 var Xpath = "xpath"
@@ -42,7 +41,7 @@ func XpathExecution(matchString, toMatch string) (exec.Result, error) {
 
 	results, err := exec.Exec(cursor, &xpath, contextSettings)
 	if err != nil {
-		log.Errorf("Failed to execute xpath match: %s", err.Error())
+		logger.Errorf("Failed to execute xpath match: %s", err.Error())
 		return nil, err
 	}
 
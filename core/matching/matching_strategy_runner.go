@@ -9,7 +9,7 @@ import (
 type MatchingStrategy interface {
 	PreMatching()
 	Matching(*FieldMatch, string)
-	PostMatching(models.RequestDetails, models.RequestMatcher, models.RequestMatcherResponsePair, map[string]string) *MatchingResult
+	PostMatching(models.RequestDetails, models.RequestMatcher, models.RequestMatcherResponsePair, int, map[string]string) *MatchingResult
 	Result() *MatchingResult
 }
 
@@ -17,13 +17,35 @@ func MatchingStrategyRunner(req models.RequestDetails, webserver bool, simulatio
 	state.RWMutex.RLock()
 	copyState := util.CopyMap(state.State)
 	state.RWMutex.RUnlock()
-	for _, matchingPair := range simulation.GetMatchingPairs() {
+
+	candidates, positions, skippedPairs := simulation.GetCandidatePairs(req.Destination, req.Method, webserver)
+	result := runMatchingStrategy(req, webserver, candidates, positions, copyState, strategy)
+
+	// Skipped pairs cannot match, but on a miss they are checked too so that the closest miss is the same
+	if result.Pair == nil && skippedPairs {
+		result = runMatchingStrategy(req, webserver, simulation.GetMatchingPairs(), nil, copyState, strategy)
+	}
+
+	return result
+}
+
+// runMatchingStrategy matches the request against the pairs, which are at the given positions in the simulation, or
+// in simulation order when positions is nil
+func runMatchingStrategy(req models.RequestDetails, webserver bool, pairs []models.RequestMatcherResponsePair, positions []int, copyState map[string]string, strategy MatchingStrategy) *MatchingResult {
+	for i, matchingPair := range pairs {
+		pairIndex := i
+		if positions != nil {
+			pairIndex = positions[i]
+		}
+
 		requestMatcher := matchingPair.RequestMatcher
 		strategy.PreMatching()
 
 		strategy.Matching(BodyMatching(requestMatcher.Body, req), "body")
 
 		if !webserver {
+			strategy.Matching(FieldMatcher(requestMatcher.Scheme, req.Scheme), "scheme")
+
 			strategy.Matching(FieldMatcher(requestMatcher.Destination, req.Destination), "destination")
 		}
 
@@ -39,7 +61,7 @@ func MatchingStrategyRunner(req models.RequestDetails, webserver bool, simulatio
 
 		strategy.Matching(StateMatcher(copyState, requestMatcher.RequiresState), "state")
 
-		if result := strategy.PostMatching(req, requestMatcher, matchingPair, copyState); result != nil {
+		if result := strategy.PostMatching(req, requestMatcher, matchingPair, pairIndex, copyState); result != nil {
 			return result
 		}
 	}
@@ -45,7 +45,7 @@ func QueryMatching(requestMatcher models.RequestMatcher, toMatch map[string][]st
 			continue
 		}
 
-		fieldMatch := FieldMatcher(matcherQueryValue, strings.Join(toMatchQueryValues, ";"))
+		fieldMatch := RepeatedValueFieldMatcher(matcherQueryValue, toMatchQueryValues)
 		matcherHeaderValueMatched = fieldMatch.Matched
 		score += fieldMatch.Score
 
@@ -165,6 +165,159 @@ var queryMatchingTests = []queryMatchingTest{
 		equals:      BeTrue(),
 		matchEquals: Equal(1),
 	},
+	{
+		name: "ignores query parameters without a matcher",
+		queriesWithMatchers: &models.QueryRequestFieldMatchers{
+			"status": {
+				{
+					Matcher: matchers.Exact,
+					Value:   "active",
+				},
+			},
+		},
+		toMatchQueries: map[string][]string{
+			"page":   {"2"},
+			"status": {"active"},
+			"sort":   {"name"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(2),
+	},
+	{
+		name: "fails when the matched query parameter is absent",
+		queriesWithMatchers: &models.QueryRequestFieldMatchers{
+			"status": {
+				{
+					Matcher: matchers.Exact,
+					Value:   "active",
+				},
+			},
+		},
+		toMatchQueries: map[string][]string{
+			"page": {"2"},
+		},
+		equals:      BeFalse(),
+		matchEquals: Equal(0),
+	},
+	{
+		name: "empty matcher matches a query parameter without a value",
+		queriesWithMatchers: &models.QueryRequestFieldMatchers{
+			"debug": {
+				{
+					Matcher: matchers.Empty,
+					Value:   "",
+				},
+			},
+		},
+		toMatchQueries: map[string][]string{
+			"debug":  {""},
+			"status": {"active"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(2),
+	},
+	{
+		name: "repeated key matches an exact value which is one of its values",
+		queriesWithMatchers: &models.QueryRequestFieldMatchers{
+			"id": {
+				{
+					Matcher: matchers.Exact,
+					Value:   "2",
+				},
+			},
+		},
+		toMatchQueries: map[string][]string{
+			"id": {"1", "2", "3"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(2),
+	},
+	{
+		name: "repeated key matches when all of the exact values are present in any order",
+		queriesWithMatchers: &models.QueryRequestFieldMatchers{
+			"id": {
+				{
+					Matcher: matchers.Exact,
+					Value:   "1",
+				},
+				{
+					Matcher: matchers.Exact,
+					Value:   "3",
+				},
+			},
+		},
+		toMatchQueries: map[string][]string{
+			"id": {"3", "2", "1"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(4),
+	},
+	{
+		name: "repeated key fails when one of the exact values is missing",
+		queriesWithMatchers: &models.QueryRequestFieldMatchers{
+			"id": {
+				{
+					Matcher: matchers.Exact,
+					Value:   "1",
+				},
+				{
+					Matcher: matchers.Exact,
+					Value:   "4",
+				},
+			},
+		},
+		toMatchQueries: map[string][]string{
+			"id": {"1", "2", "3"},
+		},
+		equals: BeFalse(),
+	},
+	{
+		name: "repeated key still matches all of its values joined",
+		queriesWithMatchers: &models.QueryRequestFieldMatchers{
+			"id": {
+				{
+					Matcher: matchers.Exact,
+					Value:   "1;2;3",
+				},
+			},
+		},
+		toMatchQueries: map[string][]string{
+			"id": {"1", "2", "3"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(2),
+	},
+	{
+		name: "repeated key matches a glob which matches one of its values",
+		queriesWithMatchers: &models.QueryRequestFieldMatchers{
+			"tag": {
+				{
+					Matcher: matchers.Glob,
+					Value:   "beta-*",
+				},
+			},
+		},
+		toMatchQueries: map[string][]string{
+			"tag": {"stable", "beta-2"},
+		},
+		equals:      BeTrue(),
+		matchEquals: Equal(1),
+	},
+	{
+		name: "repeated key fails when a glob matches none of its values",
+		queriesWithMatchers: &models.QueryRequestFieldMatchers{
+			"tag": {
+				{
+					Matcher: matchers.Glob,
+					Value:   "beta-*",
+				},
+			},
+		},
+		toMatchQueries: map[string][]string{
+			"tag": {"stable", "alpha-1"},
+		},
+		equals: BeFalse(),
+	},
 }
 
 func Test_QueryMatching(t *testing.T) {
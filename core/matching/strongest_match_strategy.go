@@ -16,6 +16,7 @@ type StrongestMatchStrategy struct {
 	closestMiss                       *models.ClosestMiss
 	missedFields                      []string
 	requestMatch                      *models.RequestMatcherResponsePair
+	requestMatchIndex                 int
 }
 
 func (s *StrongestMatchStrategy) PreMatching() {
@@ -40,7 +41,7 @@ func (s *StrongestMatchStrategy) Matching(fieldMatch *FieldMatch, field string)
 	s.score += fieldMatch.Score
 }
 
-func (s *StrongestMatchStrategy) PostMatching(req models.RequestDetails, requestMatcher models.RequestMatcher, matchingPair models.RequestMatcherResponsePair, state map[string]string) *MatchingResult {
+func (s *StrongestMatchStrategy) PostMatching(req models.RequestDetails, requestMatcher models.RequestMatcher, matchingPair models.RequestMatcherResponsePair, pairIndex int, state map[string]string) *MatchingResult {
 	// This only counts if there was actually a matcher for headers
 	if s.matchedOnAllButHeaders && requestMatcher.Headers != nil && len(requestMatcher.Headers) > 0 {
 		s.matchedOnAllButHeadersAtLeastOnce = true
@@ -56,6 +57,7 @@ func (s *StrongestMatchStrategy) PostMatching(req models.RequestDetails, request
 			RequestMatcher: requestMatcher,
 			Response:       matchingPair.Response,
 		}
+		s.requestMatchIndex = pairIndex
 		s.strongestMatchScore = s.score
 		s.closestMiss = nil
 	} else if s.matched == false && s.requestMatch == nil && s.score >= s.closestMissScore {
@@ -76,12 +78,15 @@ func (s *StrongestMatchStrategy) PostMatching(req models.RequestDetails, request
 func (s *StrongestMatchStrategy) Result() *MatchingResult {
 	cacheable := isCacheable(s.requestMatch, s.matchedOnAllButHeadersAtLeastOnce, s.matchedOnAllButStateAtLeastOnce)
 	var err *models.MatchError
+	pairIndex := s.requestMatchIndex
 	if s.requestMatch == nil {
 		err = models.NewMatchErrorWithClosestMiss(s.closestMiss, "No match found")
+		pairIndex = -1
 	}
 
 	return &MatchingResult{
 		Pair:      s.requestMatch,
+		PairIndex: pairIndex,
 		Error:     err,
 		Cacheable: cacheable,
 	}
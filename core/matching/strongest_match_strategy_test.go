@@ -60,6 +60,214 @@ func Test_ClosestRequestMatcherRequestMatcher_RequestMatchersShouldMatchOnBody(t
 	Expect(result.Pair.Response.Body).To(Equal("request matched"))
 }
 
+func Test_ClosestRequestMatcherRequestMatcher_EmptyMatcherDistinguishesRequestsWithoutBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := models.NewSimulation()
+
+	simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Method: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "POST",
+				},
+			},
+			Body: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Empty,
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Body: "no body",
+		},
+	})
+
+	simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Method: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "POST",
+				},
+			},
+			Body: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Glob,
+					Value:   "*",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Body: "any body",
+		},
+	})
+
+	result := matching.MatchingStrategyRunner(models.RequestDetails{
+		Method: "POST",
+	}, false, simulation, &state.State{State: map[string]string{}}, &matching.StrongestMatchStrategy{})
+
+	Expect(result.Error).To(BeNil())
+	Expect(result.Pair.Response.Body).To(Equal("no body"))
+
+	result = matching.MatchingStrategyRunner(models.RequestDetails{
+		Method: "POST",
+		Body:   `{"event": "push"}`,
+	}, false, simulation, &state.State{State: map[string]string{}}, &matching.StrongestMatchStrategy{})
+
+	Expect(result.Error).To(BeNil())
+	Expect(result.Pair.Response.Body).To(Equal("any body"))
+}
+
+func Test_ClosestRequestMatcherRequestMatcher_SpecificMatchBeatsGenericMatchRegardlessOfOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	specific := models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/orders",
+				},
+			},
+			Body: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.JsonPartial,
+					Value:   `{"type": "express"}`,
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Body: "specific",
+		},
+	}
+
+	generic := models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/orders",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Body: "generic",
+		},
+	}
+
+	request := models.RequestDetails{
+		Path: "/orders",
+		Body: `{"type": "express", "items": 2}`,
+	}
+
+	for _, pairs := range [][]models.RequestMatcherResponsePair{{specific, generic}, {generic, specific}} {
+		simulation := models.NewSimulation()
+		for i := range pairs {
+			simulation.AddPair(&pairs[i])
+		}
+
+		result := matching.MatchingStrategyRunner(request, false, simulation, &state.State{State: map[string]string{}}, &matching.StrongestMatchStrategy{})
+
+		Expect(result.Error).To(BeNil())
+		Expect(result.Pair.Response.Body).To(Equal("specific"))
+	}
+}
+
+func Test_ClosestRequestMatcherRequestMatcher_PicksLastPairWhenScoresAreEqual(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := models.NewSimulation()
+
+	// the globs are different so that the pairs are not duplicates, but they all match and score the same
+	for _, path := range []string{"/orders/*", "/*/1", "*/1"} {
+		simulation.AddPair(&models.RequestMatcherResponsePair{
+			RequestMatcher: models.RequestMatcher{
+				Path: []models.RequestFieldMatchers{
+					{
+						Matcher: matchers.Glob,
+						Value:   path,
+					},
+				},
+			},
+			Response: models.ResponseDetails{
+				Body: path,
+			},
+		})
+	}
+
+	Expect(simulation.GetMatchingPairs()).To(HaveLen(3))
+
+	result := matching.MatchingStrategyRunner(models.RequestDetails{
+		Path: "/orders/1",
+	}, false, simulation, &state.State{State: map[string]string{}}, &matching.StrongestMatchStrategy{})
+
+	Expect(result.Error).To(BeNil())
+	Expect(result.Pair.Response.Body).To(Equal("*/1"))
+	Expect(result.PairIndex).To(Equal(2))
+}
+
+func Test_ClosestRequestMatcherRequestMatcher_AbsentMatcherDistinguishesRequestsWithoutHeader(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := models.NewSimulation()
+
+	simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/account",
+				},
+			},
+			Headers: map[string][]models.RequestFieldMatchers{
+				"Authorization": {
+					{
+						Matcher: matchers.Absent,
+					},
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 401,
+			Body:   "unauthenticated",
+		},
+	})
+
+	simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/account",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 200,
+			Body:   "account",
+		},
+	})
+
+	result := matching.MatchingStrategyRunner(models.RequestDetails{
+		Path: "/account",
+	}, false, simulation, &state.State{State: map[string]string{}}, &matching.StrongestMatchStrategy{})
+
+	Expect(result.Error).To(BeNil())
+	Expect(result.Pair.Response.Body).To(Equal("unauthenticated"))
+
+	result = matching.MatchingStrategyRunner(models.RequestDetails{
+		Path: "/account",
+		Headers: map[string][]string{
+			"Authorization": {"Bearer abc.def.ghi"},
+		},
+	}, false, simulation, &state.State{State: map[string]string{}}, &matching.StrongestMatchStrategy{})
+
+	Expect(result.Error).To(BeNil())
+	Expect(result.Pair.Response.Body).To(Equal("account"))
+}
+
 func Test_ClosestRequestMatcherRequestMatcher_ReturnResponseWhenAllHeadersMatch(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -498,7 +706,7 @@ func Test_ClosestRequestMatcherRequestMatcher_RequestMatchersCanUseGlobsOnScheme
 			Scheme: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Glob,
-					Value:   "*.com",
+					Value:   "h*",
 				},
 			},
 		},
@@ -518,6 +726,67 @@ func Test_ClosestRequestMatcherRequestMatcher_RequestMatchersCanUseGlobsOnScheme
 	Expect(result.Pair.Response.Body).To(Equal("request matched"))
 }
 
+func Test_ClosestRequestMatcherRequestMatcher_GlobSchemeMatcherMatchesHttpAndHttps(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := models.NewSimulation()
+
+	simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Scheme: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Glob,
+					Value:   "*",
+				},
+			},
+		},
+		Response: testResponse,
+	})
+
+	for _, scheme := range []string{"http", "https"} {
+		request := models.RequestDetails{
+			Method:      "GET",
+			Destination: "testhost.com",
+			Scheme:      scheme,
+			Path:        "/api/1",
+		}
+
+		result := matching.MatchingStrategyRunner(request, false, simulation, &state.State{State: map[string]string{}}, &matching.StrongestMatchStrategy{})
+		Expect(result.Error).To(BeNil())
+
+		Expect(result.Pair.Response.Body).To(Equal("request matched"))
+	}
+}
+
+func Test_ClosestRequestMatcherRequestMatcher_ExactSchemeMatcherDoesNotMatchAnotherScheme(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := models.NewSimulation()
+
+	simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Scheme: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "http",
+				},
+			},
+		},
+		Response: testResponse,
+	})
+
+	request := models.RequestDetails{
+		Method:      "GET",
+		Destination: "testhost.com",
+		Scheme:      "https",
+		Path:        "/api/1",
+	}
+
+	result := matching.MatchingStrategyRunner(request, false, simulation, &state.State{State: map[string]string{}}, &matching.StrongestMatchStrategy{})
+	Expect(result.Pair).To(BeNil())
+	Expect(result.Error.ClosestMiss.MissedFields).To(ConsistOf("scheme"))
+}
+
 func Test_ClosestRequestMatcherRequestMatcher_RequestMatchersCanUseGlobsOnHeadersAndBeMatched(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -1311,8 +1580,7 @@ func Test_ShouldReturnFieldsMissedInClosestMiss(t *testing.T) {
 	Expect(result.Error).ToNot(BeNil())
 	Expect(result.Pair).To(BeNil())
 	Expect(result.Error.ClosestMiss).ToNot(BeNil())
-	//TODO: Scheme matching?
-	Expect(result.Error.ClosestMiss.MissedFields).To(ConsistOf(`body`, `path`, `query`))
+	Expect(result.Error.ClosestMiss.MissedFields).To(ConsistOf(`body`, `scheme`, `path`, `query`))
 }
 
 func Test_ShouldReturnFieldsMissedInClosestMissAgain(t *testing.T) {
@@ -1373,8 +1641,9 @@ func Test_ShouldReturnFieldsMissedInClosestMissAgain(t *testing.T) {
 	})
 
 	r := models.RequestDetails{
-		Body: "hit",
-		Path: "hit",
+		Body:   "hit",
+		Path:   "hit",
+		Scheme: "hit",
 		Query: map[string][]string{
 			"hit": {""},
 		},
@@ -1385,7 +1654,6 @@ func Test_ShouldReturnFieldsMissedInClosestMissAgain(t *testing.T) {
 	Expect(result.Error).ToNot(BeNil())
 	Expect(result.Pair).To(BeNil())
 	Expect(result.Error.ClosestMiss).ToNot(BeNil())
-	//TODO: Scheme matching?
 	Expect(result.Error.ClosestMiss.MissedFields).To(ConsistOf(`method`, `destination`, `headers`))
 }
 
@@ -2074,3 +2342,63 @@ func Test_StrongestMatch__ShouldBeCacheableIfMatchedOnEverythingApartFromStateZe
 	Expect(result.Error).ToNot(BeNil())
 	Expect(result.Cacheable).To(BeTrue())
 }
+
+func Test_ClosestRequestMatcherRequestMatcher_ClosestMissCanBeForAnotherDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := models.NewSimulation()
+
+	simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "other.com",
+				},
+			},
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/path",
+				},
+			},
+			Method: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "GET",
+				},
+			},
+		},
+		Response: testResponse,
+	})
+
+	simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "somehost.com",
+				},
+			},
+			Method: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "POST",
+				},
+			},
+		},
+		Response: testResponse,
+	})
+
+	r := models.RequestDetails{
+		Method:      "GET",
+		Destination: "somehost.com",
+		Path:        "/path",
+	}
+	result := matching.MatchingStrategyRunner(r, false, simulation, &state.State{State: map[string]string{}}, &matching.StrongestMatchStrategy{})
+
+	Expect(result.Pair).To(BeNil())
+	Expect(result.Error.ClosestMiss).ToNot(BeNil())
+	Expect(result.Error.ClosestMiss.RequestMatcher.Destination[0].Value).To(Equal("other.com"))
+	Expect(result.Error.ClosestMiss.MissedFields).To(Equal([]string{"destination"}))
+}
@@ -1,17 +1,48 @@
 package metrics
 
 import (
+	"fmt"
+	"io"
+	"sort"
+
 	"github.com/rcrowley/go-metrics"
 	log "github.com/sirupsen/logrus"
 
 	"time"
 )
 
+// Events counted alongside the requests per mode
+const (
+	CacheHit          = "cache_hit"
+	CacheMiss         = "cache_miss"
+	MatchHit          = "match_hit"
+	MatchMiss         = "match_miss"
+	MiddlewareFailure = "middleware_failure"
+)
+
+type eventMetric struct {
+	event string
+	name  string
+	help  string
+}
+
+// eventMetrics lists the Prometheus name and help text of each event, in exposition order
+var eventMetrics = []eventMetric{
+	{CacheHit, "hoverfly_cache_hits_total", "Requests answered from the request cache."},
+	{CacheMiss, "hoverfly_cache_misses_total", "Requests not found in the request cache."},
+	{MatchHit, "hoverfly_matcher_hits_total", "Requests matched by a request matcher rather than the request cache."},
+	{MatchMiss, "hoverfly_matcher_misses_total", "Requests that did not match any request matcher."},
+	{MiddlewareFailure, "hoverfly_middleware_failures_total", "Middleware executions that returned an error."},
+}
+
 // CounterByMode - container for mode counters, registry and flush interval
 type CounterByMode struct {
 	Counters      map[string]metrics.Counter
 	registry      metrics.Registry
 	flushInterval time.Duration
+
+	// events are kept out of the registry so that Flush only reports the modes
+	events map[string]metrics.Counter
 }
 
 // NewModeCounter - returns new counter instance
@@ -26,10 +57,16 @@ func NewModeCounter(modes []string) *CounterByMode {
 		registry.GetOrRegister(v, counter)
 	}
 
+	events := make(map[string]metrics.Counter)
+	for _, v := range eventMetrics {
+		events[v.event] = metrics.NewCounter()
+	}
+
 	c := &CounterByMode{
 		Counters:      counters,
 		registry:      registry,
 		flushInterval: 5 * time.Second,
+		events:        events,
 	}
 
 	log.Debug("new counter created, registration successful")
@@ -42,6 +79,47 @@ func (c *CounterByMode) Count(mode string) {
 	c.Counters[mode].Inc(1)
 }
 
+// CountEvent - counts an event such as a cache hit or a middleware failure
+func (c *CounterByMode) CountEvent(event string) {
+	if counter, ok := c.events[event]; ok {
+		counter.Inc(1)
+	}
+}
+
+// EventCount - returns the number of times an event has been counted
+func (c *CounterByMode) EventCount(event string) int64 {
+	if counter, ok := c.events[event]; ok {
+		return counter.Count()
+	}
+	return 0
+}
+
+// WritePrometheus writes the counters in the Prometheus text exposition format
+func (c *CounterByMode) WritePrometheus(w io.Writer) error {
+	modes := make([]string, 0, len(c.Counters))
+	for mode := range c.Counters {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+
+	if _, err := fmt.Fprint(w, "# HELP hoverfly_requests_total Requests processed by Hoverfly, by mode.\n# TYPE hoverfly_requests_total counter\n"); err != nil {
+		return err
+	}
+	for _, mode := range modes {
+		if _, err := fmt.Fprintf(w, "hoverfly_requests_total{mode=%q} %d\n", mode, c.Counters[mode].Count()); err != nil {
+			return err
+		}
+	}
+
+	for _, metric := range eventMetrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", metric.name, metric.help, metric.name, metric.name, c.EventCount(metric.event)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Init initializes logging
 func (c *CounterByMode) Init() {
 	go func() {
@@ -57,6 +135,15 @@ type Stats struct {
 	Counters    map[string]int64   `json:"counters"`
 	Gauges      map[string]int64   `json:"gauges,omitempty"`
 	GaugesFloat map[string]float64 `json:"gaugesFloat,omitempty"`
+	Matching    MatchingStats      `json:"matching"`
+}
+
+// MatchingStats - holds how requests were answered in simulate, spy and diff mode
+type MatchingStats struct {
+	CacheHits   int64 `json:"cacheHits"`
+	CacheMisses int64 `json:"cacheMisses"`
+	MatcherHits int64 `json:"matcherHits"`
+	Misses      int64 `json:"misses"`
 }
 
 // Flush gets current metrics from stats registry
@@ -80,5 +167,11 @@ func (c *CounterByMode) Flush() (h Stats) {
 	h.Counters = counters
 	h.Gauges = gauges
 	h.GaugesFloat = gaugesFloat
+	h.Matching = MatchingStats{
+		CacheHits:   c.EventCount(CacheHit),
+		CacheMisses: c.EventCount(CacheMiss),
+		MatcherHits: c.EventCount(MatchHit),
+		Misses:      c.EventCount(MatchMiss),
+	}
 	return
 }
@@ -1,6 +1,7 @@
 package metrics_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/metrics"
@@ -30,3 +31,78 @@ func TestFlush(t *testing.T) {
 
 	Expect(count).To(Equal(int64(1)))
 }
+
+func TestCountEvent(t *testing.T) {
+	RegisterTestingT(t)
+	counter := metrics.NewModeCounter([]string{"name"})
+
+	counter.CountEvent(metrics.CacheHit)
+	counter.CountEvent(metrics.CacheHit)
+	counter.CountEvent("unknown")
+
+	Expect(counter.EventCount(metrics.CacheHit)).To(Equal(int64(2)))
+	Expect(counter.EventCount(metrics.CacheMiss)).To(Equal(int64(0)))
+	Expect(counter.EventCount("unknown")).To(Equal(int64(0)))
+}
+
+func TestFlushDoesNotIncludeEventsInCounters(t *testing.T) {
+	RegisterTestingT(t)
+	counter := metrics.NewModeCounter([]string{"name"})
+
+	counter.CountEvent(metrics.MatchMiss)
+
+	Expect(counter.Flush().Counters).To(Equal(map[string]int64{"name": 0}))
+}
+
+func TestFlushIncludesMatchingStats(t *testing.T) {
+	RegisterTestingT(t)
+	counter := metrics.NewModeCounter([]string{"name"})
+
+	counter.CountEvent(metrics.CacheHit)
+	counter.CountEvent(metrics.CacheMiss)
+	counter.CountEvent(metrics.CacheMiss)
+	counter.CountEvent(metrics.MatchHit)
+	counter.CountEvent(metrics.MatchMiss)
+
+	Expect(counter.Flush().Matching).To(Equal(metrics.MatchingStats{
+		CacheHits:   1,
+		CacheMisses: 2,
+		MatcherHits: 1,
+		Misses:      1,
+	}))
+}
+
+func TestWritePrometheus(t *testing.T) {
+	RegisterTestingT(t)
+	counter := metrics.NewModeCounter([]string{"simulate", "capture"})
+
+	counter.Count("simulate")
+	counter.Count("simulate")
+	counter.Count("capture")
+	counter.CountEvent(metrics.CacheMiss)
+	counter.CountEvent(metrics.MiddlewareFailure)
+
+	var buffer bytes.Buffer
+	Expect(counter.WritePrometheus(&buffer)).To(Succeed())
+
+	Expect(buffer.String()).To(Equal(`# HELP hoverfly_requests_total Requests processed by Hoverfly, by mode.
+# TYPE hoverfly_requests_total counter
+hoverfly_requests_total{mode="capture"} 1
+hoverfly_requests_total{mode="simulate"} 2
+# HELP hoverfly_cache_hits_total Requests answered from the request cache.
+# TYPE hoverfly_cache_hits_total counter
+hoverfly_cache_hits_total 0
+# HELP hoverfly_cache_misses_total Requests not found in the request cache.
+# TYPE hoverfly_cache_misses_total counter
+hoverfly_cache_misses_total 1
+# HELP hoverfly_matcher_hits_total Requests matched by a request matcher rather than the request cache.
+# TYPE hoverfly_matcher_hits_total counter
+hoverfly_matcher_hits_total 0
+# HELP hoverfly_matcher_misses_total Requests that did not match any request matcher.
+# TYPE hoverfly_matcher_misses_total counter
+hoverfly_matcher_misses_total 0
+# HELP hoverfly_middleware_failures_total Middleware executions that returned an error.
+# TYPE hoverfly_middleware_failures_total counter
+hoverfly_middleware_failures_total 1
+`))
+}
@@ -3,7 +3,9 @@ package middleware
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"os/exec"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/models"
 	log "github.com/sirupsen/logrus"
@@ -26,7 +28,7 @@ func (this Middleware) executeMiddlewareLocally(pair models.RequestResponsePair)
 		}
 	}
 
-	log.WithFields(log.Fields{
+	logger.WithFields(log.Fields{
 		"command": this.toString(),
 		"stdin":   string(pairViewBytes),
 	}).Info("Preparing to execute local middleware")
@@ -40,7 +42,7 @@ func (this Middleware) executeMiddlewareLocally(pair models.RequestResponsePair)
 	middlewareCommand.Stderr = &stderr
 
 	if err := middlewareCommand.Start(); err != nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"sdtdout": string(stdout.Bytes()),
 			"sdtderr": string(stderr.Bytes()),
 			"error":   err.Error(),
@@ -55,8 +57,35 @@ func (this Middleware) executeMiddlewareLocally(pair models.RequestResponsePair)
 		}
 	}
 
-	if err := middlewareCommand.Wait(); err != nil {
-		log.WithFields(log.Fields{
+	var timeout <-chan time.Time
+	if this.Timeout > 0 {
+		timer := time.NewTimer(this.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	finished := make(chan error, 1)
+	go func() {
+		finished <- middlewareCommand.Wait()
+	}()
+
+	select {
+	case err = <-finished:
+	case <-timeout:
+		middlewareCommand.Process.Kill()
+		logger.WithFields(log.Fields{
+			"command": this.toString(),
+			"timeout": this.Timeout.String(),
+		}).Error("Middleware timed out")
+		return pair, &MiddlewareError{
+			Message: fmt.Sprintf("Middleware timed out after %s", this.Timeout),
+			Command: this.toString(),
+			Stdin:   string(pairViewBytes),
+		}
+	}
+
+	if err != nil {
+		logger.WithFields(log.Fields{
 			"command": this.toString(),
 			"stdin":   string(pairViewBytes),
 			"sdtdout": string(stdout.Bytes()),
@@ -75,7 +104,7 @@ func (this Middleware) executeMiddlewareLocally(pair models.RequestResponsePair)
 
 	// log stderr, middleware executed successfully
 	if len(stderr.Bytes()) > 0 {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"sdtderr": string(stderr.Bytes()),
 		}).Info("Information from middleware")
 	}
@@ -96,7 +125,7 @@ func (this Middleware) executeMiddlewareLocally(pair models.RequestResponsePair)
 			}
 		} else {
 			if log.GetLevel() == log.DebugLevel {
-				log.WithFields(log.Fields{
+				logger.WithFields(log.Fields{
 					"middleware": this.toString(),
 					"payload":    string(stdout.Bytes()),
 				}).Debug("payload after modifications")
@@ -105,7 +134,7 @@ func (this Middleware) executeMiddlewareLocally(pair models.RequestResponsePair)
 			return models.NewRequestResponsePairFromRequestResponsePairView(newPairView), nil
 		}
 	} else {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"stdout": string(stdout.Bytes()),
 		}).Warn("No response from middleware.")
 	}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"testing"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/models"
 	. "github.com/onsi/gomega"
@@ -73,3 +74,45 @@ func TestReflectBody(t *testing.T) {
 	Expect(newPair.Request.Method).To(Equal(req.Method))
 	Expect(newPair.Request.Destination).To(Equal(req.Destination))
 }
+
+func Test_Middleware_executeMiddlewareLocally_ReturnsOriginalPairWhenMiddlewareTimesOut(t *testing.T) {
+	RegisterTestingT(t)
+
+	originalPair := models.RequestResponsePair{
+		Response: models.ResponseDetails{Status: 201, Body: "original body"},
+		Request:  models.RequestDetails{Path: "/", Method: "GET", Destination: "hostname-x"},
+	}
+
+	unit := &Middleware{Timeout: 200 * time.Millisecond}
+
+	Expect(unit.SetBinary("sh")).To(BeNil())
+	Expect(unit.SetScript("sleep 5")).To(BeNil())
+	defer unit.DeleteScript()
+
+	start := time.Now()
+	newPair, err := unit.executeMiddlewareLocally(originalPair)
+
+	Expect(time.Since(start)).To(BeNumerically("<", 2*time.Second))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Middleware timed out after 200ms"))
+	Expect(newPair).To(Equal(originalPair))
+}
+
+func Test_Middleware_executeMiddlewareLocally_WaitsForMiddlewareFinishingWithinTheTimeout(t *testing.T) {
+	RegisterTestingT(t)
+
+	originalPair := models.RequestResponsePair{
+		Response: models.ResponseDetails{Status: 201, Body: "original body"},
+	}
+
+	unit := &Middleware{Timeout: 5 * time.Second}
+
+	Expect(unit.SetBinary("sh")).To(BeNil())
+	Expect(unit.SetScript("sleep 0.1")).To(BeNil())
+	defer unit.DeleteScript()
+
+	newPair, err := unit.executeMiddlewareLocally(originalPair)
+
+	Expect(err).To(BeNil())
+	Expect(newPair).To(Equal(originalPair))
+}
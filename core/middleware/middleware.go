@@ -4,17 +4,30 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"io/ioutil"
 
 	"github.com/SpectoLabs/hoverfly/core/errors"
 	"github.com/SpectoLabs/hoverfly/core/models"
+	log "github.com/sirupsen/logrus"
 )
 
+var logger = log.WithField("component", "middleware")
+
 type Middleware struct {
 	Binary string
 	Script *os.File
 	Remote string
+
+	// Timeout is how long middleware is given to process a pair before the original pair is
+	// returned with an error. A zero timeout waits for the middleware indefinitely.
+	Timeout time.Duration
+
+	// RemoteAttempts is how many times a payload is sent to remote middleware before giving up,
+	// waiting RemoteRetryDelay before the first retry and doubling the wait after each one
+	RemoteAttempts   int
+	RemoteRetryDelay time.Duration
 }
 
 func ConvertToNewMiddleware(middleware string) (*Middleware, error) {
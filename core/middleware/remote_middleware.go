@@ -7,6 +7,7 @@ import (
 
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/models"
 	log "github.com/sirupsen/logrus"
@@ -21,24 +22,46 @@ func (this Middleware) executeMiddlewareRemotely(pair models.RequestResponsePair
 		}
 	}
 
-	req, err := http.NewRequest("POST", this.Remote, bytes.NewBuffer(pairViewBytes))
-	if err != nil {
-		log.WithFields(log.Fields{
-			"error": err.Error(),
-		}).Error("Error when building request to remote middleware")
-		return pair, &MiddlewareError{
-			OriginalError: err,
-			Message:       "Error when building request to remote middleware: ",
-			Url:           this.Remote,
-			Stdin:         string(pairViewBytes),
+	client := &http.Client{Timeout: this.Timeout}
+
+	var req *http.Request
+	var resp *http.Response
+	for attempt := 1; ; attempt++ {
+		req, err = http.NewRequest("POST", this.Remote, bytes.NewBuffer(pairViewBytes))
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"error": err.Error(),
+			}).Error("Error when building request to remote middleware")
+			return pair, &MiddlewareError{
+				OriginalError: err,
+				Message:       "Error when building request to remote middleware: ",
+				Url:           this.Remote,
+				Stdin:         string(pairViewBytes),
+			}
+		}
+
+		req.Header.Add("Content-Type", "application/json")
+
+		resp, err = client.Do(req)
+		if err == nil && resp.StatusCode == 200 || attempt >= this.RemoteAttempts {
+			break
 		}
-	}
 
-	req.Header.Add("Content-Type", "application/json")
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		// exponential backoff, doubling the delay after each failed attempt
+		delay := this.RemoteRetryDelay * time.Duration(1<<uint(attempt-1))
+		logger.WithFields(log.Fields{
+			"attempt": attempt,
+			"delay":   delay.String(),
+		}).Warn("Remote middleware did not process payload, retrying")
+		time.Sleep(delay)
+	}
 
-	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"error": err.Error(),
 		}).Error("Error when communicating with remote middleware")
 		return pair, &MiddlewareError{
@@ -50,7 +73,7 @@ func (this Middleware) executeMiddlewareRemotely(pair models.RequestResponsePair
 	}
 
 	if resp.StatusCode != 200 {
-		log.Error("Remote middleware did not process payload")
+		logger.Error("Remote middleware did not process payload")
 		return pair, &MiddlewareError{
 			OriginalError: err,
 			Message:       fmt.Sprintf("Error when communicating with remote middleware: received %d", resp.StatusCode),
@@ -64,7 +87,7 @@ func (this Middleware) executeMiddlewareRemotely(pair models.RequestResponsePair
 		returnedPairViewBytes = []byte(" ")
 	}
 	if err != nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"error": err.Error(),
 		}).Error("Error when process response from remote middleware")
 		return pair, &MiddlewareError{
@@ -80,7 +103,7 @@ func (this Middleware) executeMiddlewareRemotely(pair models.RequestResponsePair
 
 	err = json.Unmarshal(returnedPairViewBytes, &newPairView)
 	if err != nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"error": err.Error(),
 		}).Error("Error when trying to serialize response from remote middleware")
 		return pair, &MiddlewareError{
@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/gorilla/mux"
@@ -127,3 +129,121 @@ func Test_Middleware_executeMiddlewareRemotely_ReturnsError_WebsiteIsUnreachable
 
 	Expect(untouchedPair).To(Equal(originalPair))
 }
+
+func Test_Middleware_executeMiddlewareRemotely_ReturnsOriginalPairWhenMiddlewareTimesOut(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Second)
+		processHandlerOkay(w, r)
+	}))
+	defer server.Close()
+
+	originalPair := models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Body: "Normal body",
+		},
+	}
+
+	unit := &Middleware{Timeout: 100 * time.Millisecond}
+	unit.Remote = server.URL
+
+	newPair, err := unit.executeMiddlewareRemotely(originalPair)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Error when communicating with remote middleware"))
+	Expect(err.Error()).To(ContainSubstring("Client.Timeout exceeded"))
+	Expect(newPair).To(Equal(originalPair))
+}
+
+func failingThenOkayHandler(failures int) (http.HandlerFunc, *int) {
+	attempts := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		processHandlerOkay(w, r)
+	}, &attempts
+}
+
+func Test_Middleware_executeMiddlewareRemotely_RetriesUntilMiddlewareProcessesPayload(t *testing.T) {
+	RegisterTestingT(t)
+
+	handler, attempts := failingThenOkayHandler(2)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	originalPair := models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Body: "Normal body",
+		},
+	}
+
+	unit := &Middleware{RemoteAttempts: 3, RemoteRetryDelay: time.Millisecond}
+	unit.Remote = server.URL
+
+	newPair, err := unit.executeMiddlewareRemotely(originalPair)
+	Expect(err).To(BeNil())
+
+	Expect(*attempts).To(Equal(3))
+	Expect(newPair.Response.Body).To(Equal("You got straight up messed with"))
+}
+
+func Test_Middleware_executeMiddlewareRemotely_ReturnsOriginalPairWhenAllRetriesFail(t *testing.T) {
+	RegisterTestingT(t)
+
+	handler, attempts := failingThenOkayHandler(2)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	originalPair := models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Body: "Normal body",
+		},
+	}
+
+	unit := &Middleware{RemoteAttempts: 2, RemoteRetryDelay: time.Millisecond}
+	unit.Remote = server.URL
+
+	newPair, err := unit.executeMiddlewareRemotely(originalPair)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Error when communicating with remote middleware: received 503"))
+
+	Expect(*attempts).To(Equal(2))
+	Expect(newPair).To(Equal(originalPair))
+}
+
+func Test_Middleware_executeMiddlewareRemotely_DoesNotRetryByDefault(t *testing.T) {
+	RegisterTestingT(t)
+
+	handler, attempts := failingThenOkayHandler(1)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	unit := &Middleware{}
+	unit.Remote = server.URL
+
+	_, err := unit.executeMiddlewareRemotely(models.RequestResponsePair{})
+	Expect(err).ToNot(BeNil())
+
+	Expect(*attempts).To(Equal(1))
+}
+
+func Test_Middleware_executeMiddlewareRemotely_RetriesWhenMiddlewareCannotBeReached(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(processHandlerOkay))
+	server.Close()
+
+	unit := &Middleware{RemoteAttempts: 3, RemoteRetryDelay: 10 * time.Millisecond}
+	unit.Remote = server.URL
+
+	start := time.Now()
+	_, err := unit.executeMiddlewareRemotely(models.RequestResponsePair{})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Error when communicating with remote middleware"))
+
+	// waits 10ms then 20ms between the three attempts
+	Expect(time.Since(start)).To(BeNumerically(">=", 30*time.Millisecond))
+}
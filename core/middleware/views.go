@@ -59,6 +59,9 @@ type ResponseDetailsView struct {
 	Headers        map[string][]string       `json:"headers"`
 	FixedDelay     int                       `json:"fixedDelay"`
 	LogNormalDelay *v2.LogNormalDelayOptions `json:"logNormalDelay"`
+	Delay          *v2.PairDelayOptions      `json:"delay,omitempty"`
+	Chunked        bool                      `json:"chunked,omitempty"`
+	StatusText     string                    `json:"statusText,omitempty"`
 }
 
 func (this ResponseDetailsView) GetStatus() int { return this.Status }
@@ -79,6 +82,8 @@ func (this ResponseDetailsView) GetRemovesState() []string { return nil }
 
 func (this ResponseDetailsView) GetHeaders() map[string][]string { return this.Headers }
 
+func (this ResponseDetailsView) GetOrderedHeaders() [][2]string { return nil }
+
 func (this ResponseDetailsView) GetFixedDelay() int { return this.FixedDelay }
 
 // The trick here to return nil with the right type to compare later.
@@ -89,3 +94,21 @@ func (this ResponseDetailsView) GetLogNormalDelay() interfaces.ResponseDelay {
 
 	return nil
 }
+
+func (this ResponseDetailsView) GetCapturedAt() string { return "" }
+
+func (this ResponseDetailsView) GetLatencyMs() int { return 0 }
+
+func (this ResponseDetailsView) GetBodyTruncated() bool { return false }
+
+func (this ResponseDetailsView) GetDelay() interfaces.PairDelay {
+	if this.Delay != nil {
+		return this.Delay
+	}
+
+	return nil
+}
+
+func (this ResponseDetailsView) GetChunked() bool { return this.Chunked }
+
+func (this ResponseDetailsView) GetStatusText() string { return this.StatusText }
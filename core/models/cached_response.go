@@ -5,10 +5,12 @@ import (
 )
 
 type CachedResponse struct {
-	Request                  RequestDetails
-	MatchingPair             *RequestMatcherResponsePair
-	ClosestMiss              *ClosestMiss
-	ResponseStateTemplates   map[string]*raymond.Template
-	ResponseTemplate         *raymond.Template
-	ResponseHeadersTemplates map[string][]*raymond.Template
+	Request                         RequestDetails
+	MatchingPair                    *RequestMatcherResponsePair
+	MatchingPairIndex               int
+	ClosestMiss                     *ClosestMiss
+	ResponseStateTemplates          map[string]*raymond.Template
+	ResponseTemplate                *raymond.Template
+	ResponseHeadersTemplates        map[string][]*raymond.Template
+	ResponseOrderedHeadersTemplates []*raymond.Template
 }
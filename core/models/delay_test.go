@@ -55,6 +55,23 @@ func TestErrprIfDelayNotSet(t *testing.T) {
 	Expect(err).To(Not(BeNil()))
 }
 
+func TestErrorIfDelayNegative(t *testing.T) {
+	RegisterTestingT(t)
+
+	jsonConf := `
+	{
+		"data": [{
+				"urlPattern": ".",
+				"delay": -1
+			}]
+	}`
+	var responseDelayJson v1.ResponseDelayPayloadView
+	json.Unmarshal([]byte(jsonConf), &responseDelayJson)
+	err := models.ValidateResponseDelayPayload(responseDelayJson)
+	Expect(err).To(Not(BeNil()))
+	Expect(err.Error()).To(ContainSubstring("Delay cannot be negative"))
+}
+
 func TestHostPatternMustBeAValidRegexPattern(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+)
+
+// Fault types which can be injected into responses served from the simulation
+const (
+	FaultError    = "error"
+	FaultReset    = "reset"
+	FaultTruncate = "truncate"
+)
+
+// Fault injects a fault into a percentage of requests matching the url pattern and method. When a seed is set
+// the faulted requests are the same every time the simulation is imported.
+type Fault struct {
+	UrlPattern  string
+	HttpMethod  string
+	Type        string
+	Status      int
+	Probability float64
+	Seed        int64
+
+	pattern *regexp.Regexp
+	random  *rand.Rand
+	mutex   sync.Mutex
+}
+
+type FaultList []*Fault
+
+func ValidateFaults(faults []v2.FaultView) error {
+	for _, fault := range faults {
+		if fault.UrlPattern == "" {
+			return fmt.Errorf("Config error - fault needs a urlPattern in: %v", fault)
+		}
+		if _, err := regexp.Compile(fault.UrlPattern); err != nil {
+			return fmt.Errorf("Config error - invalid fault pattern: %s", fault.UrlPattern)
+		}
+		if fault.Type != FaultError && fault.Type != FaultReset && fault.Type != FaultTruncate {
+			return fmt.Errorf("Config error - fault type must be one of %s, %s or %s in: %v", FaultError, FaultReset, FaultTruncate, fault)
+		}
+		if fault.Probability <= 0 || fault.Probability > 1 {
+			return fmt.Errorf("Config error - fault probability must be greater than 0 and at most 1 in: %v", fault)
+		}
+		if fault.Status != 0 && (fault.Type != FaultError || fault.Status < 500 || fault.Status > 599) {
+			return fmt.Errorf("Config error - fault status must be a 5xx status for an error fault in: %v", fault)
+		}
+	}
+	return nil
+}
+
+func ImportFaults(faults []v2.FaultView) FaultList {
+	faultList := FaultList{}
+	for _, fault := range faults {
+		seed := fault.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+
+		faultList = append(faultList, &Fault{
+			UrlPattern:  fault.UrlPattern,
+			HttpMethod:  fault.HttpMethod,
+			Type:        fault.Type,
+			Status:      fault.Status,
+			Probability: fault.Probability,
+			Seed:        fault.Seed,
+			pattern:     regexp.MustCompile(fault.UrlPattern),
+			random:      rand.New(rand.NewSource(seed)),
+		})
+	}
+	return faultList
+}
+
+// GetFault returns the first fault which applies to the request
+func (this FaultList) GetFault(request RequestDetails) *Fault {
+	for _, fault := range this {
+		if fault.pattern.MatchString(request.Destination+request.Path) &&
+			(fault.HttpMethod == "" || strings.EqualFold(fault.HttpMethod, request.Method)) {
+			return fault
+		}
+	}
+	return nil
+}
+
+// ShouldInject decides whether to inject the fault into a request, with the configured probability
+func (this *Fault) ShouldInject() bool {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	return this.random.Float64() < this.Probability
+}
+
+// GetStatus returns the status of the response for an error fault, which is 500 unless set
+func (this *Fault) GetStatus() int {
+	if this.Status == 0 {
+		return 500
+	}
+	return this.Status
+}
+
+func (this FaultList) ConvertToFaultsView() []v2.FaultView {
+	views := []v2.FaultView{}
+	for _, fault := range this {
+		views = append(views, v2.FaultView{
+			UrlPattern:  fault.UrlPattern,
+			HttpMethod:  fault.HttpMethod,
+			Type:        fault.Type,
+			Status:      fault.Status,
+			Probability: fault.Probability,
+			Seed:        fault.Seed,
+		})
+	}
+	return views
+}
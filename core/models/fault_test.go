@@ -0,0 +1,96 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+func Test_ValidateFaults_AcceptsValidFaults(t *testing.T) {
+	RegisterTestingT(t)
+
+	err := models.ValidateFaults([]v2.FaultView{
+		{UrlPattern: ".", Type: models.FaultError, Status: 503, Probability: 0.5},
+		{UrlPattern: ".", Type: models.FaultReset, Probability: 1},
+		{UrlPattern: ".", Type: models.FaultTruncate, Probability: 0.1, Seed: 42},
+	})
+	Expect(err).To(BeNil())
+}
+
+func Test_ValidateFaults_ErrorsOnInvalidFaults(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(models.ValidateFaults([]v2.FaultView{{Type: models.FaultError, Probability: 1}})).ToNot(BeNil())
+	Expect(models.ValidateFaults([]v2.FaultView{{UrlPattern: "[", Type: models.FaultError, Probability: 1}})).ToNot(BeNil())
+	Expect(models.ValidateFaults([]v2.FaultView{{UrlPattern: ".", Type: "explode", Probability: 1}})).ToNot(BeNil())
+	Expect(models.ValidateFaults([]v2.FaultView{{UrlPattern: ".", Type: models.FaultError}})).ToNot(BeNil())
+	Expect(models.ValidateFaults([]v2.FaultView{{UrlPattern: ".", Type: models.FaultError, Probability: 1.5}})).ToNot(BeNil())
+	Expect(models.ValidateFaults([]v2.FaultView{{UrlPattern: ".", Type: models.FaultError, Status: 404, Probability: 1}})).ToNot(BeNil())
+	Expect(models.ValidateFaults([]v2.FaultView{{UrlPattern: ".", Type: models.FaultReset, Status: 500, Probability: 1}})).ToNot(BeNil())
+}
+
+func Test_FaultList_GetFault_MatchesUrlPatternAndMethod(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.ImportFaults([]v2.FaultView{
+		{UrlPattern: "hoverfly\\.io/api", HttpMethod: "POST", Type: models.FaultError, Probability: 1},
+		{UrlPattern: "hoverfly\\.io/other", Type: models.FaultReset, Probability: 1},
+	})
+
+	Expect(unit.GetFault(models.RequestDetails{Method: "POST", Destination: "hoverfly.io", Path: "/api"})).To(Equal(unit[0]))
+	Expect(unit.GetFault(models.RequestDetails{Method: "GET", Destination: "hoverfly.io", Path: "/api"})).To(BeNil())
+	Expect(unit.GetFault(models.RequestDetails{Method: "GET", Destination: "hoverfly.io", Path: "/other"})).To(Equal(unit[1]))
+}
+
+func Test_Fault_ShouldInject_AlwaysInjectsWithAProbabilityOfOne(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.ImportFaults([]v2.FaultView{{UrlPattern: ".", Type: models.FaultError, Probability: 1}})[0]
+
+	for i := 0; i < 100; i++ {
+		Expect(unit.ShouldInject()).To(BeTrue())
+	}
+}
+
+func Test_Fault_ShouldInject_IsReproducibleWithASeed(t *testing.T) {
+	RegisterTestingT(t)
+
+	view := []v2.FaultView{{UrlPattern: ".", Type: models.FaultError, Probability: 0.5, Seed: 42}}
+	first := models.ImportFaults(view)[0]
+	second := models.ImportFaults(view)[0]
+
+	injected := 0
+	for i := 0; i < 100; i++ {
+		inject := first.ShouldInject()
+		Expect(second.ShouldInject()).To(Equal(inject))
+		if inject {
+			injected++
+		}
+	}
+
+	Expect(injected).To(BeNumerically(">", 0))
+	Expect(injected).To(BeNumerically("<", 100))
+}
+
+func Test_Fault_GetStatus_DefaultsTo500(t *testing.T) {
+	RegisterTestingT(t)
+
+	faults := models.ImportFaults([]v2.FaultView{
+		{UrlPattern: ".", Type: models.FaultError, Probability: 1},
+		{UrlPattern: ".", Type: models.FaultError, Status: 503, Probability: 1},
+	})
+
+	Expect(faults[0].GetStatus()).To(Equal(500))
+	Expect(faults[1].GetStatus()).To(Equal(503))
+}
+
+func Test_FaultList_ConvertToFaultsView(t *testing.T) {
+	RegisterTestingT(t)
+
+	views := []v2.FaultView{{UrlPattern: ".", HttpMethod: "GET", Type: models.FaultTruncate, Probability: 0.25, Seed: 7}}
+
+	Expect(models.ImportFaults(views).ConvertToFaultsView()).To(Equal(views))
+	Expect(models.FaultList{}.ConvertToFaultsView()).To(BeEmpty())
+}
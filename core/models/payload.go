@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"sort"
 	"strings"
+	"time"
 
 	v2 "github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/interfaces"
@@ -100,7 +102,7 @@ func NewRequestDetailsFromHttpRequest(req *http.Request) (RequestDetails, error)
 	requestDetails := RequestDetails{
 		Path:        urlPath,
 		Method:      req.Method,
-		Destination: strings.ToLower(req.Host),
+		Destination: util.NormalizeDestination(req.Host, scheme),
 		Scheme:      scheme,
 		Query:       req.URL.Query(),
 		Body:        reqBody,
@@ -164,6 +166,7 @@ func (r *RequestDetails) concatenate(withHost bool) string {
 	var buffer bytes.Buffer
 
 	if withHost {
+		buffer.WriteString(r.Scheme)
 		buffer.WriteString(r.Destination)
 	}
 	if len(r.FormData) > 0 {
@@ -199,6 +202,20 @@ type ResponseDetailsLogNormal struct {
 	Median int
 }
 
+type ResponseDetailsDelay struct {
+	Fixed  int
+	Jitter int
+}
+
+// GenerateDelay - returns the fixed delay plus a random jitter of up to Jitter milliseconds
+func (this ResponseDetailsDelay) GenerateDelay() int {
+	if this.Jitter <= 0 {
+		return this.Fixed
+	}
+
+	return this.Fixed + rand.Intn(this.Jitter+1)
+}
+
 // ResponseDetails structure hold response body from external service, body is not decoded and is supposed
 // to be bytes, however headers should provide all required information for later decoding
 // by the client.
@@ -207,11 +224,23 @@ type ResponseDetails struct {
 	Body             string
 	BodyFile         string
 	Headers          map[string][]string
+	OrderedHeaders   [][2]string
 	Templated        bool
 	TransitionsState map[string]string
 	RemovesState     []string
 	FixedDelay       int
 	LogNormalDelay   *ResponseDetailsLogNormal
+	// CapturedAt and LatencyMs are recorded by capture mode and are never used for matching
+	CapturedAt time.Time
+	LatencyMs  int
+	// BodyTruncated is set by capture mode when the body was cut at its maximum body size
+	BodyTruncated bool
+	// Delay is applied when this pair is matched, in addition to any global delays
+	Delay *ResponseDetailsDelay
+	// Chunked responses are sent with Transfer-Encoding: chunked instead of a Content-Length
+	Chunked bool
+	// StatusText is the reason phrase sent with the status code, the standard one is used when it is empty
+	StatusText string
 }
 
 func NewResponseDetailsFromResponse(data interfaces.Response) ResponseDetails {
@@ -227,10 +256,19 @@ func NewResponseDetailsFromResponse(data interfaces.Response) ResponseDetails {
 		Body:             body,
 		BodyFile:         data.GetBodyFile(),
 		Headers:          data.GetHeaders(),
+		OrderedHeaders:   data.GetOrderedHeaders(),
 		Templated:        data.GetTemplated(),
 		TransitionsState: data.GetTransitionsState(),
 		RemovesState:     data.GetRemovesState(),
 		FixedDelay:       data.GetFixedDelay(),
+		LatencyMs:        data.GetLatencyMs(),
+		BodyTruncated:    data.GetBodyTruncated(),
+		Chunked:          data.GetChunked(),
+		StatusText:       data.GetStatusText(),
+	}
+
+	if capturedAt, err := time.Parse(time.RFC3339Nano, data.GetCapturedAt()); err == nil {
+		details.CapturedAt = capturedAt
 	}
 
 	if d := data.GetLogNormalDelay(); d != nil {
@@ -242,6 +280,13 @@ func NewResponseDetailsFromResponse(data interfaces.Response) ResponseDetails {
 		}
 	}
 
+	if d := data.GetDelay(); d != nil {
+		details.Delay = &ResponseDetailsDelay{
+			Fixed:  d.GetFixed(),
+			Jitter: d.GetJitter(),
+		}
+	}
+
 	return details
 }
 
@@ -309,11 +354,20 @@ func (r *ResponseDetails) ConvertToResponseDetailsViewV5() v2.ResponseDetailsVie
 		Body:             body,
 		BodyFile:         r.BodyFile,
 		Headers:          r.Headers,
+		OrderedHeaders:   r.OrderedHeaders,
 		EncodedBody:      needsEncoding,
 		Templated:        r.Templated,
 		RemovesState:     r.RemovesState,
 		TransitionsState: r.TransitionsState,
 		FixedDelay:       r.FixedDelay,
+		LatencyMs:        r.LatencyMs,
+		BodyTruncated:    r.BodyTruncated,
+		Chunked:          r.Chunked,
+		StatusText:       r.StatusText,
+	}
+
+	if !r.CapturedAt.IsZero() {
+		view.CapturedAt = r.CapturedAt.Format(time.RFC3339Nano)
 	}
 
 	if r.LogNormalDelay != nil {
@@ -325,6 +379,13 @@ func (r *ResponseDetails) ConvertToResponseDetailsViewV5() v2.ResponseDetailsVie
 		}
 	}
 
+	if r.Delay != nil {
+		view.Delay = &v2.PairDelayOptions{
+			Fixed:  r.Delay.Fixed,
+			Jitter: r.Delay.Jitter,
+		}
+	}
+
 	return view
 }
 
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"net/http"
 	"net/url"
@@ -108,6 +109,97 @@ func TestResponseDetails_ConvertToResponseDetailsView_WithImageBody(t *testing.T
 	}))
 }
 
+func TestResponseDetails_ConvertToResponseDetailsViewV5_IncludesCaptureMetadata(t *testing.T) {
+	RegisterTestingT(t)
+
+	capturedAt := time.Date(2018, 1, 2, 3, 4, 5, 600000000, time.UTC)
+	original := models.ResponseDetails{Status: 200, Body: "hello", CapturedAt: capturedAt, LatencyMs: 42}
+
+	view := original.ConvertToResponseDetailsViewV5()
+
+	Expect(view.CapturedAt).To(Equal("2018-01-02T03:04:05.6Z"))
+	Expect(view.LatencyMs).To(Equal(42))
+
+	roundTripped := models.NewResponseDetailsFromResponse(view)
+
+	Expect(roundTripped.CapturedAt.Equal(capturedAt)).To(BeTrue())
+	Expect(roundTripped.LatencyMs).To(Equal(42))
+}
+
+func TestResponseDetails_ConvertToResponseDetailsViewV5_OmitsCaptureMetadataWhenNotCaptured(t *testing.T) {
+	RegisterTestingT(t)
+
+	original := models.ResponseDetails{Status: 200, Body: "hello"}
+
+	view := original.ConvertToResponseDetailsViewV5()
+
+	Expect(view.CapturedAt).To(BeEmpty())
+	Expect(view.LatencyMs).To(Equal(0))
+	Expect(models.NewResponseDetailsFromResponse(view).CapturedAt.IsZero()).To(BeTrue())
+}
+
+func TestResponseDetails_ConvertToResponseDetailsViewV5_IncludesPairDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	original := models.ResponseDetails{Status: 200, Delay: &models.ResponseDetailsDelay{Fixed: 100, Jitter: 20}}
+
+	view := original.ConvertToResponseDetailsViewV5()
+
+	Expect(view.Delay).To(Equal(&v2.PairDelayOptions{Fixed: 100, Jitter: 20}))
+	Expect(models.NewResponseDetailsFromResponse(view).Delay).To(Equal(&models.ResponseDetailsDelay{Fixed: 100, Jitter: 20}))
+}
+
+func TestResponseDetails_ConvertToResponseDetailsViewV5_OmitsPairDelayWhenNotSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	original := models.ResponseDetails{Status: 200}
+
+	view := original.ConvertToResponseDetailsViewV5()
+
+	Expect(view.Delay).To(BeNil())
+	Expect(models.NewResponseDetailsFromResponse(view).Delay).To(BeNil())
+}
+
+func TestResponseDetails_ConvertToResponseDetailsViewV5_IncludesChunked(t *testing.T) {
+	RegisterTestingT(t)
+
+	original := models.ResponseDetails{Status: 200, Chunked: true}
+
+	view := original.ConvertToResponseDetailsViewV5()
+
+	Expect(view.Chunked).To(BeTrue())
+	Expect(models.NewResponseDetailsFromResponse(view).Chunked).To(BeTrue())
+}
+
+func TestResponseDetails_ConvertToResponseDetailsViewV5_IncludesStatusText(t *testing.T) {
+	RegisterTestingT(t)
+
+	original := models.ResponseDetails{Status: 200, StatusText: "Custom Reason"}
+
+	view := original.ConvertToResponseDetailsViewV5()
+
+	Expect(view.StatusText).To(Equal("Custom Reason"))
+	Expect(models.NewResponseDetailsFromResponse(view).StatusText).To(Equal("Custom Reason"))
+}
+
+func TestResponseDetailsDelay_GenerateDelay_ReturnsFixedDelayWithoutJitter(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(models.ResponseDetailsDelay{Fixed: 100}.GenerateDelay()).To(Equal(100))
+}
+
+func TestResponseDetailsDelay_GenerateDelay_AddsJitterToFixedDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.ResponseDetailsDelay{Fixed: 100, Jitter: 10}
+
+	for i := 0; i < 100; i++ {
+		delay := unit.GenerateDelay()
+		Expect(delay).To(BeNumerically(">=", 100))
+		Expect(delay).To(BeNumerically("<=", 110))
+	}
+}
+
 func TestRequestResponsePair_ConvertToRequestResponsePairView_WithPlainTextResponse(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -229,6 +321,23 @@ func Test_NewRequestDetailsFromHttpRequest_SortsQueryString(t *testing.T) {
 	Expect(requestDetails.QueryString()).To(Equal("a=a&a=b"))
 }
 
+func Test_NewRequestDetailsFromHttpRequest_NormalizesDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	for url, destination := range map[string]string{
+		"http://Example.com.:80/":   "example.com",
+		"https://example.com:443/":  "example.com",
+		"http://example.com:443/":   "example.com:443",
+		"http://localhost:8500/api": "localhost:8500",
+	} {
+		request, _ := http.NewRequest("GET", url, nil)
+		requestDetails, err := models.NewRequestDetailsFromHttpRequest(request)
+		Expect(err).To(BeNil())
+
+		Expect(requestDetails.Destination).To(Equal(destination), url)
+	}
+}
+
 func Test_NewRequestDetailsFromHttpRequest_WithFormDataHavingNonEmptyBody(t *testing.T) {
 	RegisterTestingT(t)
 	form := url.Values{}
@@ -394,7 +503,7 @@ func Test_RequestDetails_Hash_ItHashes(t *testing.T) {
 
 	hashedUnit := unit.Hash()
 
-	Expect(hashedUnit).To(Equal("70c4fd58c2db41298071ea0446af0793"))
+	Expect(hashedUnit).To(Equal("b2087f150b8392b88806f19c48350cbe"))
 }
 
 func Test_RequestDetails_Hash_TheHashIgnoresHeaders(t *testing.T) {
@@ -413,7 +522,7 @@ func Test_RequestDetails_Hash_TheHashIgnoresHeaders(t *testing.T) {
 
 	hashedUnit := unit.Hash()
 
-	Expect(hashedUnit).To(Equal("70c4fd58c2db41298071ea0446af0793"))
+	Expect(hashedUnit).To(Equal("b2087f150b8392b88806f19c48350cbe"))
 }
 
 func Test_RequestDetails_Hash_TheHashIncludesTheBody(t *testing.T) {
@@ -432,7 +541,7 @@ func Test_RequestDetails_Hash_TheHashIncludesTheBody(t *testing.T) {
 
 	hashedUnit := unit.Hash()
 
-	Expect(hashedUnit).To(Equal("51834bfe5334158be38ef5209f2b8e29"))
+	Expect(hashedUnit).To(Equal("d439ffb1467ec362a2cff2a2777f6c71"))
 }
 
 func Test_RequestDetails_QueryString_ConvertsMapToString(t *testing.T) {
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+)
+
+// RateLimit simulates upstream throttling. Once Limit requests matching the url pattern and method have been
+// served from the simulation within Window milliseconds, further requests are throttled until the window ends.
+type RateLimit struct {
+	UrlPattern string
+	HttpMethod string
+	Limit      int
+	Window     int
+
+	pattern     *regexp.Regexp
+	windowStart time.Time
+	count       int
+	mutex       sync.Mutex
+}
+
+type RateLimitList []*RateLimit
+
+func ValidateRateLimits(rateLimits []v2.RateLimitView) error {
+	for _, rateLimit := range rateLimits {
+		if rateLimit.UrlPattern == "" || rateLimit.Limit <= 0 || rateLimit.Window <= 0 {
+			return fmt.Errorf("Config error - rate limit needs a urlPattern and a limit and window greater than 0 in: %v", rateLimit)
+		}
+		if _, err := regexp.Compile(rateLimit.UrlPattern); err != nil {
+			return fmt.Errorf("Config error - invalid rate limit pattern: %s", rateLimit.UrlPattern)
+		}
+	}
+	return nil
+}
+
+func ImportRateLimits(rateLimits []v2.RateLimitView) RateLimitList {
+	rateLimitList := RateLimitList{}
+	for _, rateLimit := range rateLimits {
+		rateLimitList = append(rateLimitList, &RateLimit{
+			UrlPattern: rateLimit.UrlPattern,
+			HttpMethod: rateLimit.HttpMethod,
+			Limit:      rateLimit.Limit,
+			Window:     rateLimit.Window,
+			pattern:    regexp.MustCompile(rateLimit.UrlPattern),
+		})
+	}
+	return rateLimitList
+}
+
+// GetRateLimit returns the first rate limit which applies to the request
+func (this RateLimitList) GetRateLimit(request RequestDetails) *RateLimit {
+	for _, rateLimit := range this {
+		if rateLimit.pattern.MatchString(request.Destination+request.Path) &&
+			(rateLimit.HttpMethod == "" || strings.EqualFold(rateLimit.HttpMethod, request.Method)) {
+			return rateLimit
+		}
+	}
+	return nil
+}
+
+// Allow counts a request made at the given time. When the limit has been reached within the current window it
+// returns false, along with how long remains until the window ends and requests are allowed again. The count
+// starts again with the first request after the window has ended.
+func (this *RateLimit) Allow(now time.Time) (bool, time.Duration) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	window := time.Duration(this.Window) * time.Millisecond
+	if this.windowStart.IsZero() || !now.Before(this.windowStart.Add(window)) {
+		this.windowStart = now
+		this.count = 0
+	}
+
+	if this.count >= this.Limit {
+		return false, this.windowStart.Add(window).Sub(now)
+	}
+
+	this.count++
+	return true, 0
+}
+
+func (this RateLimitList) ConvertToRateLimitsView() []v2.RateLimitView {
+	views := []v2.RateLimitView{}
+	for _, rateLimit := range this {
+		views = append(views, v2.RateLimitView{
+			UrlPattern: rateLimit.UrlPattern,
+			HttpMethod: rateLimit.HttpMethod,
+			Limit:      rateLimit.Limit,
+			Window:     rateLimit.Window,
+		})
+	}
+	return views
+}
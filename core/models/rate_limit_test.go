@@ -0,0 +1,93 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+func Test_ValidateRateLimits_AcceptsAValidRateLimit(t *testing.T) {
+	RegisterTestingT(t)
+
+	err := models.ValidateRateLimits([]v2.RateLimitView{
+		{UrlPattern: "hoverfly\\.io/api", HttpMethod: "GET", Limit: 2, Window: 1000},
+	})
+	Expect(err).To(BeNil())
+}
+
+func Test_ValidateRateLimits_ErrorsOnMissingOrInvalidValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(models.ValidateRateLimits([]v2.RateLimitView{{Limit: 2, Window: 1000}})).ToNot(BeNil())
+	Expect(models.ValidateRateLimits([]v2.RateLimitView{{UrlPattern: ".", Window: 1000}})).ToNot(BeNil())
+	Expect(models.ValidateRateLimits([]v2.RateLimitView{{UrlPattern: ".", Limit: 2}})).ToNot(BeNil())
+	Expect(models.ValidateRateLimits([]v2.RateLimitView{{UrlPattern: ".", Limit: -1, Window: 1000}})).ToNot(BeNil())
+	Expect(models.ValidateRateLimits([]v2.RateLimitView{{UrlPattern: "[", Limit: 2, Window: 1000}})).ToNot(BeNil())
+}
+
+func Test_RateLimitList_GetRateLimit_MatchesUrlPatternAndMethod(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.ImportRateLimits([]v2.RateLimitView{
+		{UrlPattern: "hoverfly\\.io/api", HttpMethod: "POST", Limit: 1, Window: 1000},
+		{UrlPattern: "hoverfly\\.io/other", Limit: 2, Window: 1000},
+	})
+
+	Expect(unit.GetRateLimit(models.RequestDetails{Method: "post", Destination: "hoverfly.io", Path: "/api"})).To(Equal(unit[0]))
+	Expect(unit.GetRateLimit(models.RequestDetails{Method: "GET", Destination: "hoverfly.io", Path: "/api"})).To(BeNil())
+	Expect(unit.GetRateLimit(models.RequestDetails{Method: "GET", Destination: "hoverfly.io", Path: "/other"})).To(Equal(unit[1]))
+	Expect(unit.GetRateLimit(models.RequestDetails{Method: "GET", Destination: "specto.io", Path: "/other"})).To(BeNil())
+}
+
+func Test_RateLimit_Allow_ThrottlesOnceTheLimitIsReachedAndResumesAfterTheWindow(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.ImportRateLimits([]v2.RateLimitView{{UrlPattern: ".", Limit: 2, Window: 1000}})[0]
+	start := time.Date(2018, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	allowed, _ := unit.Allow(start)
+	Expect(allowed).To(BeTrue())
+	allowed, _ = unit.Allow(start.Add(100 * time.Millisecond))
+	Expect(allowed).To(BeTrue())
+
+	allowed, retryAfter := unit.Allow(start.Add(400 * time.Millisecond))
+	Expect(allowed).To(BeFalse())
+	Expect(retryAfter).To(Equal(600 * time.Millisecond))
+
+	allowed, _ = unit.Allow(start.Add(999 * time.Millisecond))
+	Expect(allowed).To(BeFalse())
+
+	allowed, _ = unit.Allow(start.Add(1000 * time.Millisecond))
+	Expect(allowed).To(BeTrue())
+	allowed, _ = unit.Allow(start.Add(1500 * time.Millisecond))
+	Expect(allowed).To(BeTrue())
+	allowed, _ = unit.Allow(start.Add(1600 * time.Millisecond))
+	Expect(allowed).To(BeFalse())
+}
+
+func Test_RateLimit_Allow_ResetsTheCountAfterAnIdleWindow(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.ImportRateLimits([]v2.RateLimitView{{UrlPattern: ".", Limit: 1, Window: 1000}})[0]
+	start := time.Date(2018, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	allowed, _ := unit.Allow(start)
+	Expect(allowed).To(BeTrue())
+
+	allowed, _ = unit.Allow(start.Add(10 * time.Second))
+	Expect(allowed).To(BeTrue())
+	allowed, _ = unit.Allow(start.Add(10*time.Second + time.Millisecond))
+	Expect(allowed).To(BeFalse())
+}
+
+func Test_RateLimitList_ConvertToRateLimitsView(t *testing.T) {
+	RegisterTestingT(t)
+
+	views := []v2.RateLimitView{{UrlPattern: ".", HttpMethod: "GET", Limit: 1, Window: 1000}}
+
+	Expect(models.ImportRateLimits(views).ConvertToRateLimitsView()).To(Equal(views))
+	Expect(models.RateLimitList{}.ConvertToRateLimitsView()).To(BeEmpty())
+}
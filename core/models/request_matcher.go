@@ -1,8 +1,11 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/url"
+	"strings"
 
 	v2 "github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
@@ -151,17 +154,28 @@ type RequestMatcherResponsePair struct {
 func NewRequestMatcherResponsePairFromView(view *v2.RequestMatcherResponsePairViewV5) *RequestMatcherResponsePair {
 	for i, matcher := range view.RequestMatcher.DeprecatedQuery {
 		if matcher.Matcher == matchers.Exact {
-			sortedQuery := util.SortQueryString(matcher.Value.(string))
+			sortedQuery := util.NormalizeQueryString(matcher.Value.(string))
 			view.RequestMatcher.DeprecatedQuery[i].Value = sortedQuery
 		}
 	}
 
+	// exact destinations are normalized in the same way as the destination of requests, on the
+	// converted matchers so that the view is left as it was given
+	scheme := NewRequestFieldMatchersFromView(view.RequestMatcher.Scheme)
+	schemeValue, _ := exactMatcherValue(scheme)
+	destination := NewRequestFieldMatchersFromView(view.RequestMatcher.Destination)
+	for i, matcher := range destination {
+		if value, ok := matcher.Value.(string); ok && strings.ToLower(matcher.Matcher) == matchers.Exact {
+			destination[i].Value = util.NormalizeDestination(value, strings.ToLower(schemeValue))
+		}
+	}
+
 	return &RequestMatcherResponsePair{
 		RequestMatcher: RequestMatcher{
 			Path:            NewRequestFieldMatchersFromView(view.RequestMatcher.Path),
 			Method:          NewRequestFieldMatchersFromView(view.RequestMatcher.Method),
-			Destination:     NewRequestFieldMatchersFromView(view.RequestMatcher.Destination),
-			Scheme:          NewRequestFieldMatchersFromView(view.RequestMatcher.Scheme),
+			Destination:     destination,
+			Scheme:          scheme,
 			DeprecatedQuery: NewRequestFieldMatchersFromView(view.RequestMatcher.DeprecatedQuery),
 			Body:            NewRequestFieldMatchersFromView(view.RequestMatcher.Body),
 			Headers:         NewRequestFieldMatchersFromMapView(view.RequestMatcher.Headers),
@@ -172,6 +186,14 @@ func NewRequestMatcherResponsePairFromView(view *v2.RequestMatcherResponsePairVi
 	}
 }
 
+// Hash identifies a pair by its request matcher. It is calculated from the request matcher's
+// view, so it stays the same when the simulation is exported and imported again.
+func (this *RequestMatcherResponsePair) Hash() string {
+	requestMatcherJson, _ := json.Marshal(this.BuildView().RequestMatcher)
+	hash := sha256.Sum256(requestMatcherJson)
+	return hex.EncodeToString(hash[:8])
+}
+
 func (this *RequestMatcherResponsePair) BuildView() v2.RequestMatcherResponsePairViewV5 {
 
 	var path, method, destination, scheme, query, body []v2.MatcherViewV5
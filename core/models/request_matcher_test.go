@@ -235,6 +235,29 @@ func Test_NewRequestMatcherResponsePairFromView_SortsDeprecatedQuery(t *testing.
 	Expect(unit.RequestMatcher.DeprecatedQuery[0].Value).To(Equal("a=a&b=b"))
 }
 
+func Test_NewRequestMatcherResponsePairFromView_NormalizesExactDestinationWithoutChangingTheView(t *testing.T) {
+	RegisterTestingT(t)
+
+	view := &v2.RequestMatcherResponsePairViewV5{
+		RequestMatcher: v2.RequestMatcherViewV5{
+			Destination: []v2.MatcherViewV5{
+				{
+					Matcher: matchers.Exact,
+					Value:   "Test.com.:80",
+				},
+			},
+		},
+		Response: v2.ResponseDetailsViewV5{
+			Body: "body",
+		},
+	}
+
+	unit := models.NewRequestMatcherResponsePairFromView(view)
+
+	Expect(unit.RequestMatcher.Destination[0].Value).To(Equal("test.com"))
+	Expect(view.RequestMatcher.Destination[0].Value).To(Equal("Test.com.:80"))
+}
+
 func Test_NewRequestMatcherResponsePairFromView_StoresTemplated(t *testing.T) {
 	RegisterTestingT(t)
 
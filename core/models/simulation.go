@@ -13,9 +13,12 @@ type Simulation struct {
 	matchingPairs           []RequestMatcherResponsePair
 	ResponseDelays          ResponseDelays
 	ResponseDelaysLogNormal ResponseDelaysLogNormal
+	RateLimits              RateLimitList
+	Faults                  FaultList
 	Vars                    *Variables
 	Literals                *Literals
 	RWMutex                 sync.RWMutex
+	index                   *pairIndex
 }
 
 func NewSimulation() *Simulation {
@@ -24,6 +27,8 @@ func NewSimulation() *Simulation {
 		matchingPairs:           []RequestMatcherResponsePair{},
 		ResponseDelays:          &ResponseDelayList{},
 		ResponseDelaysLogNormal: &ResponseDelayLogNormalList{},
+		RateLimits:              RateLimitList{},
+		Faults:                  FaultList{},
 		Literals:                &Literals{},
 		Vars:                    &Variables{},
 	}
@@ -40,7 +45,7 @@ func (this *Simulation) AddPair(pair *RequestMatcherResponsePair) bool {
 		}
 	}
 	if !duplicate {
-		this.matchingPairs = append(this.matchingPairs, *pair)
+		this.appendPair(pair)
 	}
 	this.RWMutex.Unlock()
 	return !duplicate
@@ -53,12 +58,14 @@ func (this *Simulation) AddPairWithOverwritingDuplicate(pair *RequestMatcherResp
 	for i, savedPair := range this.matchingPairs {
 		duplicate = reflect.DeepEqual(pair.RequestMatcher, savedPair.RequestMatcher)
 		if duplicate {
-			this.matchingPairs[i] = *pair
+			pairs := this.copyMatchingPairs()
+			pairs[i] = *pair
+			this.matchingPairs = pairs
 			break
 		}
 	}
 	if !duplicate {
-		this.matchingPairs = append(this.matchingPairs, *pair)
+		this.appendPair(pair)
 	}
 	this.RWMutex.Unlock()
 	return !duplicate
@@ -66,7 +73,7 @@ func (this *Simulation) AddPairWithOverwritingDuplicate(pair *RequestMatcherResp
 
 func (this *Simulation) AddPairWithoutCheck(pair *RequestMatcherResponsePair) {
 	this.RWMutex.Lock()
-	this.matchingPairs = append(this.matchingPairs, *pair)
+	this.appendPair(pair)
 	this.RWMutex.Unlock()
 }
 
@@ -91,13 +98,9 @@ func (this *Simulation) AddPairInSequence(pair *RequestMatcherResponsePair, stat
 		if duplicate {
 			counter = counter + 1
 
-			if savedPair.RequestMatcher.RequiresState == nil {
-				savedPair.RequestMatcher.RequiresState = map[string]string{}
-			}
-
-			if savedPair.Response.TransitionsState == nil {
-				savedPair.Response.TransitionsState = map[string]string{}
-			}
+			// the state maps are shared with pairs already returned by GetMatchingPairs, so they are copied
+			savedPair.RequestMatcher.RequiresState = copyStateMap(savedPair.RequestMatcher.RequiresState)
+			savedPair.Response.TransitionsState = copyStateMap(savedPair.Response.TransitionsState)
 
 			if pair.RequestMatcher.RequiresState == nil {
 				pair.RequestMatcher.RequiresState = map[string]string{}
@@ -127,18 +130,50 @@ func (this *Simulation) AddPairInSequence(pair *RequestMatcherResponsePair, stat
 		}
 	}
 
-	for i, updatedPair := range updates {
-		this.matchingPairs[i] = updatedPair
+	if len(updates) > 0 {
+		pairs := this.copyMatchingPairs()
+		for i, updatedPair := range updates {
+			pairs[i] = updatedPair
+		}
+		this.matchingPairs = pairs
 	}
 
 	if counter != 0 {
 		pair.RequestMatcher.RequiresState[sequenceKey] = strconv.Itoa(counter + 1)
 	}
 
-	this.matchingPairs = append(this.matchingPairs, *pair)
+	this.appendPair(pair)
 	this.RWMutex.Unlock()
 }
 
+// appendPair must be called with the lock held
+func (this *Simulation) appendPair(pair *RequestMatcherResponsePair) {
+	this.matchingPairs = append(this.matchingPairs, *pair)
+	if this.index == nil {
+		this.index = newPairIndex(this.matchingPairs)
+	} else {
+		this.index.add(len(this.matchingPairs)-1, pair)
+	}
+}
+
+// copyMatchingPairs must be called with the lock held. Pairs are replaced in a copy rather than in place, as
+// callers of GetMatchingPairs may still be reading the old slice.
+func (this *Simulation) copyMatchingPairs() []RequestMatcherResponsePair {
+	pairs := make([]RequestMatcherResponsePair, len(this.matchingPairs))
+	copy(pairs, this.matchingPairs)
+	return pairs
+}
+
+func copyStateMap(original map[string]string) map[string]string {
+	copied := make(map[string]string, len(original))
+	for key, value := range original {
+		copied[key] = value
+	}
+	return copied
+}
+
+// GetMatchingPairs returns the current pairs. The slice is never modified in place, so it is safe to read
+// while the simulation is being changed.
 func (this *Simulation) GetMatchingPairs() []RequestMatcherResponsePair {
 	this.RWMutex.RLock()
 	pairs := this.matchingPairs
@@ -146,10 +181,54 @@ func (this *Simulation) GetMatchingPairs() []RequestMatcherResponsePair {
 	return pairs
 }
 
+// GetCandidatePairs returns, in order, the pairs which could match a request with the destination and method,
+// skipping pairs whose exact destination or method matchers are for something else, along with their positions
+// in the simulation. The positions are nil when every pair is returned. The destination is not used when
+// ignoreDestination is set. The boolean is true when some pairs were skipped.
+func (this *Simulation) GetCandidatePairs(destination, method string, ignoreDestination bool) ([]RequestMatcherResponsePair, []int, bool) {
+	this.RWMutex.RLock()
+	defer this.RWMutex.RUnlock()
+
+	if this.index == nil {
+		return this.matchingPairs, nil, false
+	}
+
+	positions := this.index.candidates(destination, method, ignoreDestination)
+	if len(positions) == len(this.matchingPairs) {
+		return this.matchingPairs, nil, false
+	}
+
+	pairs := make([]RequestMatcherResponsePair, len(positions))
+	for i, position := range positions {
+		pairs[i] = this.matchingPairs[position]
+	}
+
+	return pairs, positions, true
+}
+
+// DeletePair removes the pair at the given index, returning false if there is no such pair
+func (this *Simulation) DeletePair(index int) bool {
+	this.RWMutex.Lock()
+	defer this.RWMutex.Unlock()
+
+	if index < 0 || index >= len(this.matchingPairs) {
+		return false
+	}
+
+	// a new slice is built, as callers of GetMatchingPairs may still hold the old one
+	pairs := make([]RequestMatcherResponsePair, 0, len(this.matchingPairs)-1)
+	pairs = append(pairs, this.matchingPairs[:index]...)
+	this.matchingPairs = append(pairs, this.matchingPairs[index+1:]...)
+	this.index = newPairIndex(this.matchingPairs)
+
+	return true
+}
+
 func (this *Simulation) DeleteMatchingPairsAlongWithCustomData() {
 	var pairs []RequestMatcherResponsePair
 	this.RWMutex.Lock()
 	this.matchingPairs = pairs
+	this.index = nil
 	this.Literals = &Literals{}
 	this.Vars = &Variables{}
 	this.RWMutex.Unlock()
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+)
+
+// pairIndex finds the pairs which could match a request without checking every pair. Pairs are indexed by the
+// value of their exact destination and method matchers. Pairs using any other matcher for a field, such as a glob
+// or regex, are indexed under "any" for that field and are always candidates.
+type pairIndex struct {
+	byDestinationAndMethod map[pairIndexKey][]int
+	byMethod               map[pairIndexKey][]int
+}
+
+type pairIndexKey struct {
+	destination    string
+	anyDestination bool
+	method         string
+	anyMethod      bool
+}
+
+func newPairIndex(pairs []RequestMatcherResponsePair) *pairIndex {
+	index := &pairIndex{
+		byDestinationAndMethod: map[pairIndexKey][]int{},
+		byMethod:               map[pairIndexKey][]int{},
+	}
+
+	for i := range pairs {
+		index.add(i, &pairs[i])
+	}
+
+	return index
+}
+
+// add must be given the position of the pair, pairs are added in order so each list of positions stays sorted
+func (this *pairIndex) add(position int, pair *RequestMatcherResponsePair) {
+	destination, anyDestination := exactMatcherValue(pair.RequestMatcher.Destination)
+	method, anyMethod := exactMatcherValue(pair.RequestMatcher.Method)
+
+	key := pairIndexKey{
+		destination:    destination,
+		anyDestination: anyDestination,
+		method:         method,
+		anyMethod:      anyMethod,
+	}
+	this.byDestinationAndMethod[key] = append(this.byDestinationAndMethod[key], position)
+
+	methodKey := pairIndexKey{method: method, anyMethod: anyMethod}
+	this.byMethod[methodKey] = append(this.byMethod[methodKey], position)
+}
+
+// candidates returns the positions, in order, of the pairs which could match the destination and method.
+// The destination is not used when ignoreDestination is set, as in webserver mode.
+func (this *pairIndex) candidates(destination, method string, ignoreDestination bool) []int {
+	var lists [][]int
+	if ignoreDestination {
+		lists = [][]int{
+			this.byMethod[pairIndexKey{method: method}],
+			this.byMethod[pairIndexKey{anyMethod: true}],
+		}
+	} else {
+		lists = [][]int{
+			this.byDestinationAndMethod[pairIndexKey{destination: destination, method: method}],
+			this.byDestinationAndMethod[pairIndexKey{destination: destination, anyMethod: true}],
+			this.byDestinationAndMethod[pairIndexKey{anyDestination: true, method: method}],
+			this.byDestinationAndMethod[pairIndexKey{anyDestination: true, anyMethod: true}],
+		}
+	}
+
+	var positions []int
+	for _, list := range lists {
+		positions = append(positions, list...)
+	}
+	sort.Ints(positions)
+
+	return positions
+}
+
+// exactMatcherValue returns the value a field must be equal to, or true if any value could match
+func exactMatcherValue(fieldMatchers []RequestFieldMatchers) (string, bool) {
+	for _, fieldMatcher := range fieldMatchers {
+		if strings.ToLower(fieldMatcher.Matcher) != matchers.Exact || fieldMatcher.Config != nil || fieldMatcher.DoMatch != nil {
+			continue
+		}
+		if value, ok := fieldMatcher.Value.(string); ok {
+			return value, false
+		}
+	}
+
+	return "", true
+}
@@ -0,0 +1,104 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+func newIndexTestPair(destinationMatcher, destination, method, body string) *models.RequestMatcherResponsePair {
+	pair := &models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: destinationMatcher,
+					Value:   destination,
+				},
+			},
+		},
+		Response: models.ResponseDetails{Body: body},
+	}
+	if method != "" {
+		pair.RequestMatcher.Method = []models.RequestFieldMatchers{
+			{
+				Matcher: matchers.Exact,
+				Value:   method,
+			},
+		}
+	}
+	return pair
+}
+
+func candidateBodies(pairs []models.RequestMatcherResponsePair) []string {
+	bodies := []string{}
+	for _, pair := range pairs {
+		bodies = append(bodies, pair.Response.Body)
+	}
+	return bodies
+}
+
+func Test_Simulation_GetCandidatePairs_SkipsPairsForOtherDestinationsAndMethods(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddPair(newIndexTestPair(matchers.Exact, "one.com", "GET", "one GET"))
+	unit.AddPair(newIndexTestPair(matchers.Exact, "two.com", "GET", "two GET"))
+	unit.AddPair(newIndexTestPair(matchers.Glob, "*.com", "POST", "glob POST"))
+	unit.AddPair(newIndexTestPair(matchers.Exact, "one.com", "POST", "one POST"))
+	unit.AddPair(newIndexTestPair(matchers.Glob, "*.com", "", "glob any method"))
+	unit.AddPair(newIndexTestPair(matchers.Exact, "one.com", "", "one any method"))
+
+	pairs, positions, skipped := unit.GetCandidatePairs("one.com", "POST", false)
+	Expect(skipped).To(BeTrue())
+	Expect(candidateBodies(pairs)).To(Equal([]string{"glob POST", "one POST", "glob any method", "one any method"}))
+	Expect(positions).To(Equal([]int{2, 3, 4, 5}))
+}
+
+func Test_Simulation_GetCandidatePairs_IgnoresDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddPair(newIndexTestPair(matchers.Exact, "one.com", "GET", "one GET"))
+	unit.AddPair(newIndexTestPair(matchers.Exact, "two.com", "POST", "two POST"))
+	unit.AddPair(newIndexTestPair(matchers.Exact, "two.com", "GET", "two GET"))
+
+	pairs, positions, skipped := unit.GetCandidatePairs("unknown", "GET", true)
+	Expect(skipped).To(BeTrue())
+	Expect(candidateBodies(pairs)).To(Equal([]string{"one GET", "two GET"}))
+	Expect(positions).To(Equal([]int{0, 2}))
+}
+
+func Test_Simulation_GetCandidatePairs_ReturnsAllPairsWhenNoneAreSkipped(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddPair(newIndexTestPair(matchers.Regex, "one", "", "regex"))
+	unit.AddPair(newIndexTestPair(matchers.Exact, "one.com", "", "exact"))
+
+	pairs, positions, skipped := unit.GetCandidatePairs("one.com", "GET", false)
+	Expect(skipped).To(BeFalse())
+	Expect(candidateBodies(pairs)).To(Equal([]string{"regex", "exact"}))
+	Expect(positions).To(BeNil())
+}
+
+func Test_Simulation_GetCandidatePairs_IsUpdatedWhenPairsAreDeleted(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddPair(newIndexTestPair(matchers.Exact, "one.com", "", "first"))
+	unit.AddPair(newIndexTestPair(matchers.Exact, "two.com", "", "second"))
+	unit.AddPair(newIndexTestPair(matchers.Exact, "one.com", "GET", "third"))
+
+	unit.DeletePair(0)
+
+	pairs, _, _ := unit.GetCandidatePairs("one.com", "GET", false)
+	Expect(candidateBodies(pairs)).To(Equal([]string{"third"}))
+
+	unit.DeleteMatchingPairsAlongWithCustomData()
+	unit.AddPair(newIndexTestPair(matchers.Exact, "one.com", "", "new"))
+
+	pairs, _, _ = unit.GetCandidatePairs("one.com", "GET", false)
+	Expect(candidateBodies(pairs)).To(Equal([]string{"new"}))
+}
@@ -1,6 +1,8 @@
 package models_test
 
 import (
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
@@ -685,3 +687,170 @@ func Test_Simulation_DeleteMatchingPairs(t *testing.T) {
 
 	Expect(unit.GetMatchingPairs()).To(HaveLen(0))
 }
+
+func Test_Simulation_DeletePair_RemovesThePairAtTheIndex(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	for _, destination := range []string{"one", "two", "three"} {
+		unit.AddPair(&models.RequestMatcherResponsePair{
+			RequestMatcher: models.RequestMatcher{
+				Destination: []models.RequestFieldMatchers{
+					{
+						Matcher: matchers.Exact,
+						Value:   destination,
+					},
+				},
+			},
+		})
+	}
+
+	before := unit.GetMatchingPairs()
+
+	Expect(unit.DeletePair(1)).To(BeTrue())
+
+	Expect(unit.GetMatchingPairs()).To(HaveLen(2))
+	Expect(unit.GetMatchingPairs()[0].RequestMatcher.Destination[0].Value).To(Equal("one"))
+	Expect(unit.GetMatchingPairs()[1].RequestMatcher.Destination[0].Value).To(Equal("three"))
+
+	// pairs previously returned are not modified
+	Expect(before).To(HaveLen(3))
+	Expect(before[1].RequestMatcher.Destination[0].Value).To(Equal("two"))
+}
+
+func Test_Simulation_DeletePair_ReturnsFalseForAnIndexOutOfRange(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddPair(&models.RequestMatcherResponsePair{})
+
+	Expect(unit.DeletePair(-1)).To(BeFalse())
+	Expect(unit.DeletePair(1)).To(BeFalse())
+	Expect(unit.GetMatchingPairs()).To(HaveLen(1))
+}
+
+func Test_Simulation_AddPairWithOverwritingDuplicate_DoesNotModifyPairsPreviouslyReturned(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "test.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{Body: "old"},
+	})
+
+	before := unit.GetMatchingPairs()
+
+	unit.AddPairWithOverwritingDuplicate(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "test.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{Body: "new"},
+	})
+
+	Expect(unit.GetMatchingPairs()[0].Response.Body).To(Equal("new"))
+	Expect(before[0].Response.Body).To(Equal("old"))
+}
+
+func Test_Simulation_AddPairInSequence_DoesNotModifyPairsPreviouslyReturned(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	pairState := state.NewState()
+
+	newPair := func() *models.RequestMatcherResponsePair {
+		return &models.RequestMatcherResponsePair{
+			RequestMatcher: models.RequestMatcher{
+				Destination: []models.RequestFieldMatchers{
+					{
+						Matcher: matchers.Exact,
+						Value:   "test.com",
+					},
+				},
+				RequiresState: map[string]string{},
+			},
+			Response: models.ResponseDetails{
+				TransitionsState: map[string]string{},
+			},
+		}
+	}
+
+	unit.AddPairInSequence(newPair(), pairState)
+
+	before := unit.GetMatchingPairs()
+
+	unit.AddPairInSequence(newPair(), pairState)
+
+	Expect(unit.GetMatchingPairs()[0].RequestMatcher.RequiresState).To(HaveKeyWithValue("sequence:1", "1"))
+	Expect(before[0].RequestMatcher.RequiresState).To(BeEmpty())
+	Expect(before[0].Response.TransitionsState).To(BeEmpty())
+}
+
+// Run with -race to detect unsynchronised access
+func Test_Simulation_CanBeModifiedAndReadConcurrently(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	pairState := state.NewState()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+
+		go func(i int) {
+			defer wg.Done()
+			unit.AddPair(&models.RequestMatcherResponsePair{
+				RequestMatcher: models.RequestMatcher{
+					Destination: []models.RequestFieldMatchers{
+						{
+							Matcher: matchers.Exact,
+							Value:   strconv.Itoa(i),
+						},
+					},
+				},
+			})
+		}(i)
+
+		go func() {
+			defer wg.Done()
+			unit.AddPairInSequence(&models.RequestMatcherResponsePair{
+				RequestMatcher: models.RequestMatcher{
+					Destination: []models.RequestFieldMatchers{
+						{
+							Matcher: matchers.Exact,
+							Value:   "sequenced",
+						},
+					},
+				},
+			}, pairState)
+		}()
+
+		go func() {
+			defer wg.Done()
+			for _, pair := range unit.GetMatchingPairs() {
+				for key, value := range pair.RequestMatcher.RequiresState {
+					_ = key + value
+				}
+				for key, value := range pair.Response.TransitionsState {
+					_ = key + value
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	Expect(unit.GetMatchingPairs()).To(HaveLen(20))
+}
@@ -2,8 +2,13 @@ package modes
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/util"
@@ -27,9 +32,18 @@ func (this *CaptureMode) View() v2.ModeView {
 	return v2.ModeView{
 		Mode: Capture,
 		Arguments: v2.ModeArgumentsView{
-			Headers:            this.Arguments.Headers,
-			Stateful:           this.Arguments.Stateful,
-			OverwriteDuplicate: this.Arguments.OverwriteDuplicate,
+			Headers:             this.Arguments.Headers,
+			Stateful:            this.Arguments.Stateful,
+			OverwriteDuplicate:  this.Arguments.OverwriteDuplicate,
+			OrderedHeaders:      this.Arguments.OrderedHeaders,
+			MaxBodySize:         this.Arguments.MaxBodySize,
+			OversizedBodies:     this.Arguments.OversizedBodies,
+			RedactedHeaders:     this.Arguments.RedactedHeaders,
+			RedactedQueryParams: this.Arguments.RedactedQueryParams,
+			RedactedBodyPaths:   this.Arguments.RedactedBodyPaths,
+			Retries:             this.Arguments.Retries,
+			RetryDelay:          int(this.Arguments.RetryDelay / time.Millisecond),
+			RetryNonIdempotent:  this.Arguments.RetryNonIdempotent,
 		},
 	}
 }
@@ -59,28 +73,88 @@ func (this CaptureMode) Process(request *http.Request, details models.RequestDet
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when preparing request for pass through", Capture)
 	}
 
+	capturedAt := time.Now()
 	response, err := this.Hoverfly.DoRequest(modifiedRequest)
+	for attempt := 1; err != nil && this.isRetryable(pair.Request.Method, err, attempt); attempt++ {
+		// exponential backoff, doubling the delay after each failed attempt
+		delay := this.Arguments.RetryDelay * time.Duration(1<<uint(attempt-1))
+		log.WithFields(log.Fields{
+			"error":   err.Error(),
+			"attempt": attempt,
+			"delay":   delay.String(),
+			"request": GetRequestLogFields(&pair.Request),
+		}).Warn("Request to the intended destination failed, retrying")
+		time.Sleep(delay)
+
+		// the body of the previous request has been read, so it is sent from a new request
+		modifiedRequest, _ = ReconstructRequest(pair)
+		capturedAt = time.Now()
+		response, err = this.Hoverfly.DoRequest(modifiedRequest)
+	}
+	latency := time.Since(capturedAt)
 	if err != nil {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when forwarding the request to the intended destination", Capture)
 	}
 
-	respBody, _ := util.GetResponseBody(response)
+	if this.Arguments.Headers == nil {
+		this.Arguments.Headers = []string{}
+	}
+
+	if streamsBody(response, this.Arguments.MaxBodySize) {
+		streamedBody := util.NewStreamedBody(response.Body, response.ContentLength, this.Arguments.MaxBodySize)
+		streamedBody.OnComplete(func(body []byte, truncated bool) {
+			responseObj := &models.ResponseDetails{
+				Status:        response.StatusCode,
+				StatusText:    util.GetResponseStatusText(response),
+				Body:          string(body),
+				Headers:       util.GetResponseHeaders(response),
+				CapturedAt:    capturedAt.UTC(),
+				LatencyMs:     int(latency / time.Millisecond),
+				BodyTruncated: truncated,
+			}
+
+			// the response is already on its way to the client, so failing to save it can only be logged
+			if err := this.save(&pair, responseObj); err != nil {
+				log.WithFields(log.Fields{
+					"error":   err.Error(),
+					"request": GetRequestLogFields(&pair.Request),
+				}).Error("There was an error when saving request and response")
+			}
+		})
+		response.Body = streamedBody
+
+		return newProcessResult(response, pair.Response.FixedDelay, pair.Response.LogNormalDelay), nil
+	}
+
+	respBody, err := util.GetResponseBody(response)
+	if err != nil {
+		return ReturnErrorAndLog(request, err, &pair, "There was an error when reading the response from the intended destination", Capture)
+	}
 	respHeaders := util.GetResponseHeaders(response)
 
 	responseObj := &models.ResponseDetails{
-		Status:  response.StatusCode,
-		Body:    respBody,
-		Headers: respHeaders,
+		Status:     response.StatusCode,
+		StatusText: util.GetResponseStatusText(response),
+		Body:       respBody,
+		Headers:    respHeaders,
+		CapturedAt: capturedAt.UTC(),
+		LatencyMs:  int(latency / time.Millisecond),
 	}
 
-	if this.Arguments.Headers == nil {
-		this.Arguments.Headers = []string{}
+	err = this.save(&pair, responseObj)
+	if err != nil {
+		return ReturnErrorAndLog(request, err, &pair, "There was an error when saving request and response", Capture)
 	}
 
+	return newProcessResult(response, pair.Response.FixedDelay, pair.Response.LogNormalDelay), nil
+}
+
+// save stores the response captured for the request
+func (this CaptureMode) save(pair *models.RequestResponsePair, response *models.ResponseDetails) error {
 	// saving response body with request/response meta to cache
-	err = this.Hoverfly.Save(&pair.Request, responseObj, &this.Arguments)
+	err := this.Hoverfly.Save(&pair.Request, response, &this.Arguments)
 	if err != nil {
-		return ReturnErrorAndLog(request, err, &pair, "There was an error when saving request and response", Capture)
+		return err
 	}
 
 	log.WithFields(log.Fields{
@@ -89,5 +163,39 @@ func (this CaptureMode) Process(request *http.Request, details models.RequestDet
 		"response": GetResponseLogFields(&pair.Response),
 	}).Info("request and response captured")
 
-	return newProcessResult(response, pair.Response.FixedDelay, pair.Response.LogNormalDelay), nil
+	return nil
+}
+
+// isRetryable is true when a request which failed with err can be sent again. Only connection errors, where
+// the destination closed or refused the connection, are retried, and only for idempotent methods unless
+// retrying other methods is enabled.
+func (this CaptureMode) isRetryable(method string, err error, attempt int) bool {
+	if attempt > this.Arguments.Retries {
+		return false
+	}
+
+	if !this.Arguments.RetryNonIdempotent && !isIdempotent(method) {
+		return false
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func isIdempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// streamsBody is true when the response body is sent to the client as it is read from the destination,
+// instead of being read into memory first. That is the case when its length is not known, as with a chunked
+// or streaming response, or when it is larger than the max body size stored in capture mode.
+func streamsBody(response *http.Response, maxBodySize int) bool {
+	if response.Body == nil || response.Body == http.NoBody {
+		return false
+	}
+	return response.ContentLength < 0 || maxBodySize > 0 && response.ContentLength > int64(maxBodySize)
 }
@@ -3,9 +3,14 @@ package modes_test
 import (
 	"bytes"
 	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/modes"
@@ -31,9 +36,27 @@ func (this hoverflyCaptureStub) DoRequest(request *http.Request) (*http.Response
 		return nil, errors.New("Could not reach error.com")
 	}
 
+	if request.Host == "slow.com" {
+		time.Sleep(20 * time.Millisecond)
+	}
+
 	response.StatusCode = 200
 	response.Body = ioutil.NopCloser(bytes.NewBufferString("test"))
 
+	if request.Host == "chunked.com" {
+		// a chunked response has no content length
+		response.ContentLength = -1
+		response.Body = ioutil.NopCloser(bytes.NewBufferString(strings.Repeat("chunk", 1024*1024)))
+	}
+
+	if request.Host == "reason.com" {
+		response.Status = "200 Custom Reason"
+	}
+
+	if request.Host == "timeout.com" {
+		response.Body = ioutil.NopCloser(io.MultiReader(bytes.NewBufferString("partial"), timeoutReader{}))
+	}
+
 	if request.Host == "trailer.com" {
 		response.Header = make(http.Header)
 		response.Header.Set("Content-Type", "application/json")
@@ -45,6 +68,34 @@ func (this hoverflyCaptureStub) DoRequest(request *http.Request) (*http.Response
 	return response, nil
 }
 
+// timeoutError - the error the HTTP client returns when a request has taken longer than its timeout
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "Client.Timeout exceeded while reading body" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+type timeoutReader struct{}
+
+func (timeoutReader) Read(p []byte) (int, error) {
+	return 0, timeoutError{}
+}
+
+// hoverflyCaptureFlakyStub - fails the first requests with a connection reset, as a flaky destination would
+type hoverflyCaptureFlakyStub struct {
+	hoverflyCaptureStub
+	Failures int
+	Requests int
+}
+
+func (this *hoverflyCaptureFlakyStub) DoRequest(request *http.Request) (*http.Response, error) {
+	this.Requests++
+	if this.Requests <= this.Failures {
+		return nil, &url.Error{Op: request.Method, URL: request.URL.String(), Err: syscall.ECONNRESET}
+	}
+	return this.hoverflyCaptureStub.DoRequest(request)
+}
+
 // Save - Stub implementation of modes.HoverflyCapture interface
 func (this *hoverflyCaptureStub) Save(request *models.RequestDetails, response *models.ResponseDetails, modeArgs *modes.ModeArguments) error {
 	this.SavedRequest = request
@@ -100,6 +151,53 @@ func Test_CaptureMode_WhenGivenARequestItWillMakeTheRequestAndSaveIt(t *testing.
 	Expect(hoverflyStub.SavedResponse.Body).To(Equal("test"))
 }
 
+func Test_CaptureMode_RecordsCapturedAtAndLatency(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureStub{}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	requestDetails := models.RequestDetails{
+		Scheme:      "http",
+		Destination: "slow.com",
+	}
+
+	request, _ := http.NewRequest("GET", "http://slow.com", nil)
+
+	before := time.Now()
+	_, err := unit.Process(request, requestDetails)
+	Expect(err).To(BeNil())
+
+	Expect(hoverflyStub.SavedResponse.CapturedAt).To(BeTemporally(">=", before.Truncate(time.Second)))
+	Expect(hoverflyStub.SavedResponse.CapturedAt).To(BeTemporally("<=", time.Now()))
+	Expect(hoverflyStub.SavedResponse.LatencyMs).To(BeNumerically(">=", 20))
+}
+
+func Test_CaptureMode_SavesANonStandardReasonPhrase(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureStub{}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	requestDetails := models.RequestDetails{
+		Scheme:      "http",
+		Destination: "reason.com",
+	}
+
+	request, _ := http.NewRequest("GET", "http://reason.com", nil)
+
+	_, err := unit.Process(request, requestDetails)
+	Expect(err).To(BeNil())
+
+	Expect(hoverflyStub.SavedResponse.StatusText).To(Equal("Custom Reason"))
+}
+
 func Test_CaptureMode_IfHeadersArgumentNotSet_CallsSaveWithEmptyList(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -236,3 +334,193 @@ func Test_CaptureMode_SavesResponseTrailersIfPresent(t *testing.T) {
 	Expect(hoverflyStub.SavedResponse.Headers["X-Streaming-Error"]).To(ConsistOf("Connection closed"))
 	Expect(hoverflyStub.SavedResponse.Headers["X-Bin-Id"]).To(ConsistOf("xyz"))
 }
+
+func Test_CaptureMode_StreamsLargeChunkedResponseAndSavesItOnceItHasBeenRead(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureStub{}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+	unit.SetArguments(modes.ModeArguments{
+		MaxBodySize: 1024,
+	})
+
+	request, err := http.NewRequest("GET", "http://chunked.com", nil)
+	Expect(err).To(BeNil())
+
+	result, err := unit.Process(request, models.RequestDetails{
+		Scheme:      "http",
+		Destination: "chunked.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(hoverflyStub.SavedResponse).To(BeNil())
+
+	body, err := ioutil.ReadAll(result.Response.Body)
+	Expect(err).To(BeNil())
+	Expect(body).To(HaveLen(5 * 1024 * 1024))
+
+	Expect(hoverflyStub.SavedRequest.Destination).To(Equal("chunked.com"))
+	Expect(hoverflyStub.SavedResponse.Status).To(Equal(200))
+	Expect(hoverflyStub.SavedResponse.Body).To(Equal(strings.Repeat("chunk", 1024)[:1024]))
+	Expect(hoverflyStub.SavedResponse.BodyTruncated).To(BeTrue())
+}
+
+func Test_CaptureMode_ReturnsGatewayTimeoutAndDoesNotSaveWhenTheResponseTimesOut(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureStub{}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	request, err := http.NewRequest("GET", "http://timeout.com", nil)
+	Expect(err).To(BeNil())
+
+	result, err := unit.Process(request, models.RequestDetails{
+		Scheme:      "http",
+		Destination: "timeout.com",
+	})
+	Expect(err).ToNot(BeNil())
+
+	Expect(result.Response.StatusCode).To(Equal(http.StatusGatewayTimeout))
+	Expect(hoverflyStub.SavedResponse).To(BeNil())
+}
+
+func Test_CaptureMode_RetriesIdempotentRequestsWhichFailWithAConnectionError(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureFlakyStub{Failures: 2}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+	unit.SetArguments(modes.ModeArguments{
+		Retries:    2,
+		RetryDelay: time.Millisecond,
+	})
+
+	request, err := http.NewRequest("GET", "http://flaky.com", nil)
+	Expect(err).To(BeNil())
+
+	result, err := unit.Process(request, models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "flaky.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(result.Response.StatusCode).To(Equal(200))
+	Expect(hoverflyStub.Requests).To(Equal(3))
+	Expect(hoverflyStub.SavedResponse.Body).To(Equal("test"))
+}
+
+func Test_CaptureMode_GivesUpAfterTheRetries(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureFlakyStub{Failures: 3}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+	unit.SetArguments(modes.ModeArguments{
+		Retries: 2,
+	})
+
+	request, err := http.NewRequest("GET", "http://flaky.com", nil)
+	Expect(err).To(BeNil())
+
+	result, err := unit.Process(request, models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "flaky.com",
+	})
+	Expect(err).ToNot(BeNil())
+
+	Expect(result.Response.StatusCode).To(Equal(http.StatusBadGateway))
+	Expect(hoverflyStub.Requests).To(Equal(3))
+	Expect(hoverflyStub.SavedResponse).To(BeNil())
+}
+
+func Test_CaptureMode_DoesNotRetryNonIdempotentRequestsByDefault(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureFlakyStub{Failures: 1}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+	unit.SetArguments(modes.ModeArguments{
+		Retries: 2,
+	})
+
+	request, err := http.NewRequest("POST", "http://flaky.com", nil)
+	Expect(err).To(BeNil())
+
+	_, err = unit.Process(request, models.RequestDetails{
+		Method:      "POST",
+		Scheme:      "http",
+		Destination: "flaky.com",
+	})
+	Expect(err).ToNot(BeNil())
+
+	Expect(hoverflyStub.Requests).To(Equal(1))
+}
+
+func Test_CaptureMode_RetriesNonIdempotentRequestsWhenEnabled(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureFlakyStub{Failures: 1}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+	unit.SetArguments(modes.ModeArguments{
+		Retries:            2,
+		RetryNonIdempotent: true,
+	})
+
+	request, err := http.NewRequest("POST", "http://flaky.com", nil)
+	Expect(err).To(BeNil())
+
+	_, err = unit.Process(request, models.RequestDetails{
+		Method:      "POST",
+		Scheme:      "http",
+		Destination: "flaky.com",
+		Body:        "sent twice",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(hoverflyStub.Requests).To(Equal(2))
+	Expect(hoverflyStub.SavedRequest.Body).To(Equal("sent twice"))
+}
+
+func Test_CaptureMode_DoesNotRetryOtherErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureStub{}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+	unit.SetArguments(modes.ModeArguments{
+		Retries:    2,
+		RetryDelay: time.Second,
+	})
+
+	request, err := http.NewRequest("GET", "http://error.com", nil)
+	Expect(err).To(BeNil())
+
+	start := time.Now()
+	_, err = unit.Process(request, models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "error.com",
+	})
+	Expect(err).ToNot(BeNil())
+
+	Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+}
@@ -16,6 +16,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -35,7 +36,8 @@ func (this *DiffMode) View() v2.ModeView {
 	return v2.ModeView{
 		Mode: Diff,
 		Arguments: v2.ModeArgumentsView{
-			Headers: this.Arguments.Headers,
+			Headers:          this.Arguments.Headers,
+			IgnoredBodyPaths: this.Arguments.IgnoredBodyPaths,
 		},
 	}
 }
@@ -126,7 +128,7 @@ func (this *DiffMode) headerDiff(expected map[string][]string, actual map[string
 	for k := range expected {
 		shouldContinue := false
 		for _, header := range headersBlacklist {
-			if k == header || header == "*" {
+			if strings.EqualFold(k, header) || header == "*" {
 				shouldContinue = true
 			}
 		}
@@ -203,6 +205,9 @@ func (this *DiffMode) JsonDiff(prefix string, expected map[string]interface{}, a
 	same := true
 	for k := range expected {
 		param := prefix + "/" + k
+		if this.isIgnoredBodyPath(param) {
+			continue
+		}
 		if _, ok := actual[k]; !ok {
 			this.addEntry(param, expected[k], nil)
 			same = false
@@ -232,6 +237,21 @@ func (this *DiffMode) JsonDiff(prefix string, expected map[string]interface{}, a
 	return same
 }
 
+// isIgnoredBodyPath - checks whether a body field, or one of the objects containing it, should be left out of the diff.
+// Ignored paths can be given as "$.meta.requestId", "meta.requestId" or "body/meta/requestId".
+func (this *DiffMode) isIgnoredBodyPath(param string) bool {
+	for _, path := range this.Arguments.IgnoredBodyPaths {
+		path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+		if !strings.HasPrefix(path, "body/") {
+			path = "body/" + strings.Replace(path, ".", "/", -1)
+		}
+		if param == path || strings.HasPrefix(param, path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 func decompress(body []byte, encodings []string) ([]byte, error) {
 	var err error
 	var reader io.ReadCloser
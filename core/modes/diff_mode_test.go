@@ -286,6 +286,48 @@ func Test_DiffMode_BlacklistlistTwoHeaders(t *testing.T) {
 		v2.DiffReportEntry{Field: "body", Expected: "simulated", Actual: "actual"}))
 }
 
+func Test_DiffMode_BlacklistHeaderIgnoresCase(t *testing.T) {
+	RegisterTestingT(t)
+
+	//given
+	unit := &DiffMode{
+		Hoverfly: hoverflyDiffStub{},
+		Arguments: ModeArguments{
+			Headers: []string{
+				"Source",
+			},
+		},
+	}
+
+	request := models.RequestDetails{
+		Scheme:      "http",
+		Destination: "positive-match-with-different-response.com",
+	}
+
+	// when
+	_, err := unit.Process(nil, request)
+
+	// then
+	Expect(err).To(BeNil())
+	Expect(unit.DiffReport.DiffEntries).To(ConsistOf(
+		v2.DiffReportEntry{Field: "header/header", Expected: "[simulated]", Actual: "[actual]"},
+		v2.DiffReportEntry{Field: "body", Expected: "simulated", Actual: "actual"}))
+}
+
+func Test_DiffMode_View_IncludesIgnoredBodyPaths(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &DiffMode{
+		Arguments: ModeArguments{
+			Headers:          []string{"Date"},
+			IgnoredBodyPaths: []string{"$.meta.requestId"},
+		},
+	}
+
+	Expect(unit.View().Arguments.Headers).To(Equal([]string{"Date"}))
+	Expect(unit.View().Arguments.IgnoredBodyPaths).To(Equal([]string{"$.meta.requestId"}))
+}
+
 func Test_DiffMode_WhenGivenANonMatchingRequestDiffIsEmpty(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -420,3 +462,75 @@ func Test_JsonDiff_WhenSameThenReturnsTrue(t *testing.T) {
 	Expect(result).To(Equal(true))
 	Expect(len(diffMode.DiffReport.DiffEntries)).To(Equal(0))
 }
+
+func Test_JsonDiff_IgnoresConfiguredBodyPaths(t *testing.T) {
+	RegisterTestingT(t)
+
+	expected := []byte(`{
+	"foo": "bar",
+	"requestId": "1",
+	"meta": {
+		"timestamp": "10:00",
+		"nested": {
+			"baz": "boo"
+		}
+	},
+	"other": {
+		"baz": "boo"
+	}}`)
+	actual := []byte(`{
+	"foo": "baz",
+	"requestId": "2",
+	"meta": {
+		"timestamp": "10:01",
+		"nested": {
+			"baz": "bar"
+		}
+	},
+	"other": {
+		"baz": "bar"
+	}}`)
+
+	var jsonExpected interface{}
+	var jsonActual interface{}
+	_ = json.Unmarshal(expected, &jsonExpected)
+	_ = json.Unmarshal(actual, &jsonActual)
+
+	diffMode := DiffMode{
+		DiffReport: v2.DiffReport{},
+		Arguments: ModeArguments{
+			IgnoredBodyPaths: []string{"requestId", "$.meta", "body/other/baz"},
+		},
+	}
+
+	// when
+	result := diffMode.JsonDiff("body", jsonExpected.(map[string]interface{}), jsonActual.(map[string]interface{}))
+
+	// then
+	Expect(result).To(Equal(false))
+	Expect(diffMode.DiffReport.DiffEntries).To(ConsistOf(
+		v2.DiffReportEntry{Field: "body/foo", Expected: "bar", Actual: "baz"}))
+}
+
+func Test_JsonDiff_WhenOnlyIgnoredBodyPathsDifferThenReturnsTrue(t *testing.T) {
+	RegisterTestingT(t)
+
+	var jsonExpected interface{}
+	var jsonActual interface{}
+	_ = json.Unmarshal([]byte(`{"foo": "bar", "meta": {"requestId": "1"}}`), &jsonExpected)
+	_ = json.Unmarshal([]byte(`{"foo": "bar", "meta": {"requestId": "2"}}`), &jsonActual)
+
+	diffMode := DiffMode{
+		DiffReport: v2.DiffReport{},
+		Arguments: ModeArguments{
+			IgnoredBodyPaths: []string{"$.meta.requestId"},
+		},
+	}
+
+	// when
+	result := diffMode.JsonDiff("body", jsonExpected.(map[string]interface{}), jsonActual.(map[string]interface{}))
+
+	// then
+	Expect(result).To(Equal(true))
+	Expect(diffMode.DiffReport.DiffEntries).To(BeEmpty())
+}
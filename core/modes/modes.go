@@ -6,9 +6,12 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/util"
 	log "github.com/sirupsen/logrus"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/SpectoLabs/goproxy"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
@@ -45,12 +48,38 @@ type ModeArguments struct {
 	MatchingStrategy   *string
 	Stateful           bool
 	OverwriteDuplicate bool
+	OrderedHeaders     bool
+	IgnoredBodyPaths   []string
+	// MaxBodySize is the largest response body, in bytes, stored in capture mode. 0 means there is no limit
+	MaxBodySize int
+	// OversizedBodies is what capture mode does with larger bodies, "truncate" (the default) or "skip"
+	OversizedBodies string
+	// RedactedHeaders, RedactedQueryParams and RedactedBodyPaths are replaced with a placeholder before
+	// capture mode stores a pair
+	RedactedHeaders     []string
+	RedactedQueryParams []string
+	RedactedBodyPaths   []string
+	// Retries is how many times capture mode retries a request which failed with a connection error, waiting
+	// RetryDelay before the first retry and doubling the wait after each one. Only idempotent methods are
+	// retried unless RetryNonIdempotent is set
+	Retries            int
+	RetryDelay         time.Duration
+	RetryNonIdempotent bool
 }
 
+const (
+	TruncateOversizedBodies = "truncate"
+	SkipOversizedBodies     = "skip"
+)
+
 type ProcessResult struct {
 	Response       *http.Response
 	FixedDelay     int
 	LogNormalDelay *models.ResponseDetailsLogNormal
+	// PairDelay is the delay of the pair the response was served from, applied in addition to any global delays
+	PairDelay *models.ResponseDetailsDelay
+	// Matched is true when the response was served from a pair in the simulation
+	Matched bool
 }
 
 func (p ProcessResult) IsResponseDelayable() bool {
@@ -97,6 +126,10 @@ func ReconstructRequest(pair models.RequestResponsePair) (*http.Request, error)
 
 	newRequest.Method = pair.Request.Method
 	newRequest.Header = pair.Request.Headers
+	// middleware can drop the headers, but the HTTP client will not send a request without them
+	if newRequest.Header == nil {
+		newRequest.Header = http.Header{}
+	}
 
 	if pair.Request.GetRawQuery() == "" {
 		// rawQuery is empty if middleware is applied, as unexported fields are not marshal, hence re-encoding of the query params is needed here
@@ -119,12 +152,19 @@ func ReconstructResponse(request *http.Request, pair models.RequestResponsePair)
 	response.Body = ioutil.NopCloser(strings.NewReader(pair.Response.Body))
 	response.StatusCode = pair.Response.Status
 	response.Status = http.StatusText(pair.Response.Status)
+	if pair.Response.StatusText != "" {
+		response.Status = pair.Response.StatusText
+	}
 
 	headers := make(http.Header)
 
+	for _, header := range pair.Response.OrderedHeaders {
+		headers.Add(header[0], header[1])
+	}
+
 	// Make copy to prevent modifying the simulation
 	for k, v := range pair.Response.Headers {
-		headers[k] = v
+		headers[k] = append(headers[k], v...)
 	}
 
 	if keys, present := headers["Trailer"]; present {
@@ -138,6 +178,14 @@ func ReconstructResponse(request *http.Request, pair models.RequestResponsePair)
 
 	response.Header = headers
 
+	if pair.Response.Chunked {
+		// A ContentLength of -1 tells the server not to set a Content-Length, so the body is streamed in chunks
+		response.ContentLength = -1
+		response.TransferEncoding = []string{"chunked"}
+		response.Header.Del("Content-Length")
+		response.Header.Set("Transfer-Encoding", "chunked")
+	}
+
 	if response.ContentLength > 0 && response.Header.Get("Content-Length") == "" && response.Header.Get("Transfer-Encoding") == "" {
 		response.Header.Set("Content-Length", fmt.Sprintf("%v", response.ContentLength))
 	}
@@ -145,6 +193,82 @@ func ReconstructResponse(request *http.Request, pair models.RequestResponsePair)
 	return response
 }
 
+// CompressResponse gzips the response body when the request advertises gzip support in
+// its Accept-Encoding header. Responses which are empty or already encoded are left untouched.
+func CompressResponse(request *http.Request, response *http.Response) error {
+	if !acceptsGzip(request) || response.Header.Get("Content-Encoding") != "" {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if len(body) > 0 {
+		compressed, err := util.CompressGzip(body)
+		if err != nil {
+			response.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+			return err
+		}
+		body = compressed
+		response.Header.Set("Content-Encoding", "gzip")
+		addVaryAcceptEncoding(response.Header)
+		if response.ContentLength >= 0 {
+			response.Header.Set("Content-Length", fmt.Sprintf("%v", len(body)))
+		}
+	}
+
+	// Chunked responses keep a ContentLength of -1 so that they are still streamed in chunks
+	if response.ContentLength >= 0 {
+		response.ContentLength = int64(len(body))
+	}
+	response.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+
+	return nil
+}
+
+// addVaryAcceptEncoding tells caches that the response depends on the Accept-Encoding of the
+// request, keeping any other fields the response already varies on
+func addVaryAcceptEncoding(header http.Header) {
+	for _, value := range header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), "Accept-Encoding") {
+				return
+			}
+		}
+	}
+
+	header.Add("Vary", "Accept-Encoding")
+}
+
+func acceptsGzip(request *http.Request) bool {
+	if request == nil {
+		return false
+	}
+
+	for _, value := range request.Header.Values("Accept-Encoding") {
+		for _, coding := range strings.Split(value, ",") {
+			parts := strings.Split(coding, ";")
+			if strings.ToLower(strings.TrimSpace(parts[0])) != "gzip" {
+				continue
+			}
+			// a quality of zero means the client explicitly refuses gzip
+			for _, parameter := range parts[1:] {
+				parameter = strings.TrimSpace(parameter)
+				if strings.HasPrefix(parameter, "q=") {
+					if quality, err := strconv.ParseFloat(parameter[2:], 64); err == nil && quality == 0 {
+						return false
+					}
+				}
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
 func GetRequestLogFields(request *models.RequestDetails) *logrus.Fields {
 	if request == nil {
 		return &log.Fields{
@@ -188,8 +312,14 @@ func ReturnErrorAndLog(request *http.Request, err error, pair *models.RequestRes
 	return ErrorResponse(request, err, msg), err
 }
 
+// ErrorResponse - a 502 Bad Gateway response for the error, or 504 Gateway Timeout when the error is a timeout
 func ErrorResponse(req *http.Request, err error, msg string) ProcessResult {
+	status := http.StatusBadGateway
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		status = http.StatusGatewayTimeout
+	}
+
 	return newProcessResult(goproxy.NewResponse(req,
-		goproxy.ContentTypeText, http.StatusBadGateway,
+		goproxy.ContentTypeText, status,
 		fmt.Sprintf("Hoverfly Error!\n\n%s\n\nGot error: %s", msg, err.Error())), 0, nil)
 }
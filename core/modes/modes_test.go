@@ -147,6 +147,40 @@ func Test_ReconstructResponse_ReturnsAResponseWithCorrectStatus(t *testing.T) {
 	Expect(response.Status).To(Equal("Not Found"))
 }
 
+func Test_ReconstructResponse_ReturnsAResponseWithTheStandardStatusText(t *testing.T) {
+	RegisterTestingT(t)
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+
+	pair := models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Status: 418,
+		},
+	}
+
+	response := modes.ReconstructResponse(req, pair)
+
+	Expect(response.Status).To(Equal("I'm a teapot"))
+}
+
+func Test_ReconstructResponse_ReturnsAResponseWithTheStatusTextOfThePair(t *testing.T) {
+	RegisterTestingT(t)
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+
+	pair := models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Status:     418,
+			StatusText: "Custom Reason",
+		},
+	}
+
+	response := modes.ReconstructResponse(req, pair)
+
+	Expect(response.StatusCode).To(Equal(418))
+	Expect(response.Status).To(Equal("Custom Reason"))
+}
+
 func Test_ReconstructResponse_ReturnsAResponseWithBody(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -182,6 +216,31 @@ func Test_ReconstructResponse_ReturnsAResponseWithCorrectContentLength(t *testin
 	Expect(response.ContentLength).To(Equal(int64(9)))
 }
 
+func Test_ReconstructResponse_WritesOrderedHeadersInOrderBeforeHeaders(t *testing.T) {
+	RegisterTestingT(t)
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+
+	pair := models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			OrderedHeaders: [][2]string{
+				{"Set-Cookie", "session=abc"},
+				{"Content-Type", "text/plain"},
+				{"Set-Cookie", "theme=dark"},
+			},
+			Headers: map[string][]string{
+				"Set-Cookie": {"lang=en"},
+			},
+		},
+	}
+
+	response := modes.ReconstructResponse(req, pair)
+
+	Expect(response.Header["Set-Cookie"]).To(Equal([]string{"session=abc", "theme=dark", "lang=en"}))
+	Expect(response.Header.Get("Content-Type")).To(Equal("text/plain"))
+	Expect(pair.Response.Headers["Set-Cookie"]).To(Equal([]string{"lang=en"}))
+}
+
 func Test_ReconstructResponse_ReturnEmptyBodyWithCorrectContentLength(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -235,6 +294,97 @@ func Test_ReconstructResponse_DoesNotSetContentLengthHeaderIfTransferEncodingHea
 	Expect(response.Header.Get("Content-Length")).To(Equal(""))
 }
 
+func Test_ReconstructResponse_ChunkedResponseHasNoContentLength(t *testing.T) {
+	RegisterTestingT(t)
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+
+	pair := models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Body: "test body",
+			Headers: map[string][]string{
+				"Content-Length": {"9"},
+			},
+			Chunked: true,
+		},
+	}
+
+	response := modes.ReconstructResponse(req, pair)
+
+	Expect(response.ContentLength).To(Equal(int64(-1)))
+	Expect(response.TransferEncoding).To(Equal([]string{"chunked"}))
+	Expect(response.Header.Get("Content-Length")).To(Equal(""))
+	Expect(response.Header.Get("Transfer-Encoding")).To(Equal("chunked"))
+
+	body, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(body)).To(Equal("test body"))
+}
+
+func Test_CompressResponse_KeepsChunkedResponseWithoutContentLength(t *testing.T) {
+	RegisterTestingT(t)
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	pair := models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Body:    "test body",
+			Chunked: true,
+		},
+	}
+
+	response := modes.ReconstructResponse(req, pair)
+
+	Expect(modes.CompressResponse(req, response)).To(Succeed())
+
+	Expect(response.ContentLength).To(Equal(int64(-1)))
+	Expect(response.Header.Get("Content-Length")).To(Equal(""))
+	Expect(response.Header.Get("Content-Encoding")).To(Equal("gzip"))
+}
+
+func Test_CompressResponse_AddsAcceptEncodingToVary(t *testing.T) {
+	RegisterTestingT(t)
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	pair := models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Body: "test body",
+			Headers: map[string][]string{
+				"Vary": {"Origin"},
+			},
+		},
+	}
+
+	response := modes.ReconstructResponse(req, pair)
+
+	Expect(modes.CompressResponse(req, response)).To(Succeed())
+	Expect(response.Header.Values("Vary")).To(Equal([]string{"Origin", "Accept-Encoding"}))
+
+	response = modes.ReconstructResponse(req, pair)
+	response.Header.Set("Vary", "Origin, accept-encoding")
+
+	Expect(modes.CompressResponse(req, response)).To(Succeed())
+	Expect(response.Header.Values("Vary")).To(Equal([]string{"Origin, accept-encoding"}))
+}
+
+func Test_CompressResponse_DoesNotAddVaryWhenTheBodyIsNotCompressed(t *testing.T) {
+	RegisterTestingT(t)
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+
+	response := modes.ReconstructResponse(req, models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Body: "test body",
+		},
+	})
+
+	Expect(modes.CompressResponse(req, response)).To(Succeed())
+	Expect(response.Header).ToNot(HaveKey("Vary"))
+}
+
 func Test_ReconstructResponse_DoesNotChangeContentLengthHeaderIfPresent(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -419,6 +569,14 @@ func Test_errorResponse_ShouldAlwaysBeABadGatway(t *testing.T) {
 	Expect(result.Response.StatusCode).To(Equal(http.StatusBadGateway))
 }
 
+func Test_errorResponse_ShouldBeAGatewayTimeoutWhenTheErrorIsATimeout(t *testing.T) {
+	RegisterTestingT(t)
+
+	result := modes.ErrorResponse(&http.Request{}, timeoutError{}, "An error was got")
+
+	Expect(result.Response.StatusCode).To(Equal(http.StatusGatewayTimeout))
+}
+
 func Test_errorResponse_ShouldAlwaysIncludeBothMessageAndErrorInResponseBody(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -123,3 +123,102 @@ func Test_ModifyMode_WillErrorWhenMiddlewareFails(t *testing.T) {
 	Expect(string(responseBody)).To(ContainSubstring("There was an error when executing middleware"))
 	Expect(string(responseBody)).To(ContainSubstring("middleware-error"))
 }
+
+type hoverflyModifyRecordingStub struct {
+	middleware func(models.RequestResponsePair) models.RequestResponsePair
+	forwarded  *http.Request
+	body       string
+}
+
+func (this *hoverflyModifyRecordingStub) DoRequest(request *http.Request) (*http.Response, error) {
+	if request.Header == nil {
+		return nil, errors.New("http: nil Request.Header")
+	}
+
+	body, _ := ioutil.ReadAll(request.Body)
+	this.forwarded = request
+	this.body = string(body)
+
+	return &http.Response{
+		StatusCode: 200,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("upstream")),
+	}, nil
+}
+
+func (this *hoverflyModifyRecordingStub) ApplyMiddleware(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
+	if pair.Response.Status != 0 {
+		return pair, nil
+	}
+	return this.middleware(pair), nil
+}
+
+func Test_ModifyMode_ForwardsTheRequestModifiedByMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyModifyRecordingStub{
+		middleware: func(pair models.RequestResponsePair) models.RequestResponsePair {
+			pair.Request.Destination = "rewritten.com:8080"
+			pair.Request.Path = "/rewritten"
+			pair.Request.Method = "POST"
+			pair.Request.Body = "rewritten body"
+			pair.Request.Headers = map[string][]string{"X-Rewritten": {"true"}}
+			return pair
+		},
+	}
+
+	unit := &modes.ModifyMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	requestDetails := models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "original.com",
+		Path:        "/original",
+		Headers:     map[string][]string{"X-Original": {"true"}},
+	}
+
+	request, err := http.NewRequest("GET", "http://original.com/original", nil)
+	Expect(err).To(BeNil())
+
+	result, err := unit.Process(request, requestDetails)
+	Expect(err).To(BeNil())
+	Expect(result.Response.StatusCode).To(Equal(200))
+
+	Expect(hoverflyStub.forwarded.Method).To(Equal("POST"))
+	Expect(hoverflyStub.forwarded.Host).To(Equal("rewritten.com:8080"))
+	Expect(hoverflyStub.forwarded.URL.String()).To(Equal("http://rewritten.com:8080/rewritten"))
+	Expect(hoverflyStub.forwarded.Header).To(Equal(http.Header{"X-Rewritten": {"true"}}))
+	Expect(hoverflyStub.body).To(Equal("rewritten body"))
+}
+
+func Test_ModifyMode_ForwardsTheRequestWhenMiddlewareRemovesAllHeaders(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyModifyRecordingStub{
+		middleware: func(pair models.RequestResponsePair) models.RequestResponsePair {
+			pair.Request.Headers = nil
+			return pair
+		},
+	}
+
+	unit := &modes.ModifyMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	requestDetails := models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "original.com",
+		Headers:     map[string][]string{"X-Original": {"true"}},
+	}
+
+	request, err := http.NewRequest("GET", "http://original.com", nil)
+	Expect(err).To(BeNil())
+
+	result, err := unit.Process(request, requestDetails)
+	Expect(err).To(BeNil())
+	Expect(result.Response.StatusCode).To(Equal(200))
+	Expect(hoverflyStub.forwarded.Header).To(BeEmpty())
+}
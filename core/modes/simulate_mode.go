@@ -2,15 +2,27 @@ package modes
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/SpectoLabs/hoverfly/core/errors"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/models"
 )
 
+// ClosestMissFieldsHeader lists the fields which did not match on the closest pair when a request is not matched
+const ClosestMissFieldsHeader = "Hoverfly-Closest-Miss-Fields"
+
+// MatchedHeader and MatchedPairIndexHeader are added to simulated responses when matched pair headers are enabled
+const (
+	MatchedHeader          = "Hoverfly-Matched"
+	MatchedPairIndexHeader = "Hoverfly-Pair-Index"
+)
+
 type HoverflySimulate interface {
 	GetResponse(models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError)
 	ApplyMiddleware(models.RequestResponsePair) (models.RequestResponsePair, error)
+	IsResponseCompressionEnabled() bool
+	RenderMissResponse(models.RequestDetails) *models.ResponseDetails
 }
 
 type SimulateMode struct {
@@ -44,7 +56,15 @@ func (this SimulateMode) Process(request *http.Request, details models.RequestDe
 	response, matchingErr := this.Hoverfly.GetResponse(details)
 
 	if matchingErr != nil {
-		return ReturnErrorAndLog(request, matchingErr, &pair, "There was an error when matching", Simulate)
+		result, err := ReturnErrorAndLog(request, matchingErr, &pair, "There was an error when matching", Simulate)
+		if missResponse := this.Hoverfly.RenderMissResponse(details); missResponse != nil {
+			pair.Response = *missResponse
+			result = newProcessResult(ReconstructResponse(request, pair), 0, nil)
+		}
+		if matchingErr.ClosestMiss != nil {
+			result.Response.Header.Set(ClosestMissFieldsHeader, strings.Join(matchingErr.ClosestMiss.MissedFields, ","))
+		}
+		return result, err
 	}
 
 	pair.Response = *response
@@ -54,9 +74,20 @@ func (this SimulateMode) Process(request *http.Request, details models.RequestDe
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Simulate)
 	}
 
-	return newProcessResult(
-		ReconstructResponse(request, pair),
+	simulatedResponse := ReconstructResponse(request, pair)
+	if this.Hoverfly.IsResponseCompressionEnabled() {
+		if err := CompressResponse(request, simulatedResponse); err != nil {
+			return ReturnErrorAndLog(request, err, &pair, "There was an error when compressing the response", Simulate)
+		}
+	}
+
+	result := newProcessResult(
+		simulatedResponse,
 		pair.Response.FixedDelay,
 		pair.Response.LogNormalDelay,
-	), nil
+	)
+	result.PairDelay = pair.Response.Delay
+	result.Matched = true
+
+	return result, nil
 }
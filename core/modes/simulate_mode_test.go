@@ -9,16 +9,35 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/errors"
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/SpectoLabs/hoverfly/core/util"
 	. "github.com/onsi/gomega"
 )
 
-type hoverflySimulateStub struct{}
+type hoverflySimulateStub struct {
+	compressResponses bool
+	missResponse      *models.ResponseDetails
+}
 
 func (this hoverflySimulateStub) GetResponse(requestDetails models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError) {
 	if requestDetails.Destination == "positive-match.com" {
 		return &models.ResponseDetails{
 			Status: 200,
 		}, nil
+	} else if requestDetails.Destination == "body-match.com" {
+		return &models.ResponseDetails{
+			Status:  200,
+			Body:    "plain text body",
+			Headers: map[string][]string{"Content-Type": {"text/plain"}},
+		}, nil
+	} else if requestDetails.Destination == "delayed-match.com" {
+		return &models.ResponseDetails{
+			Status: 200,
+			Delay:  &models.ResponseDetailsDelay{Fixed: 100, Jitter: 10},
+		}, nil
+	} else if requestDetails.Destination == "closest-miss.com" {
+		return nil, errors.MatchingFailedError(&models.ClosestMiss{
+			MissedFields: []string{"method", "body"},
+		})
 	} else {
 		return nil, &errors.HoverflyError{
 			Message: "matching-error",
@@ -33,6 +52,14 @@ func (this hoverflySimulateStub) ApplyMiddleware(pair models.RequestResponsePair
 	return pair, nil
 }
 
+func (this hoverflySimulateStub) IsResponseCompressionEnabled() bool {
+	return this.compressResponses
+}
+
+func (this hoverflySimulateStub) RenderMissResponse(requestDetails models.RequestDetails) *models.ResponseDetails {
+	return this.missResponse
+}
+
 func Test_SimulateMode_WhenGivenAMatchingRequestItReturnsTheCorrectResponse(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -50,6 +77,21 @@ func Test_SimulateMode_WhenGivenAMatchingRequestItReturnsTheCorrectResponse(t *t
 	Expect(result.Response.StatusCode).To(Equal(200))
 }
 
+func Test_SimulateMode_WhenGivenAMatchingRequestItReturnsThePairDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SimulateMode{
+		Hoverfly: hoverflySimulateStub{},
+	}
+
+	result, err := unit.Process(nil, models.RequestDetails{
+		Destination: "delayed-match.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(result.PairDelay).To(Equal(&models.ResponseDetailsDelay{Fixed: 100, Jitter: 10}))
+}
+
 func Test_SimulateMode_WhenGivenANonMatchingRequestItReturnsAnError(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -73,6 +115,73 @@ func Test_SimulateMode_WhenGivenANonMatchingRequestItReturnsAnError(t *testing.T
 	Expect(string(responseBody)).To(ContainSubstring("matching-error"))
 }
 
+func Test_SimulateMode_WhenGivenANonMatchingRequestItReturnsTheClosestMissFieldsInAHeader(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SimulateMode{
+		Hoverfly: hoverflySimulateStub{},
+	}
+
+	request := models.RequestDetails{
+		Destination: "closest-miss.com",
+	}
+
+	result, err := unit.Process(&http.Request{}, request)
+	Expect(err).ToNot(BeNil())
+
+	Expect(result.Response.StatusCode).To(Equal(http.StatusBadGateway))
+	Expect(result.Response.Header.Get("Hoverfly-Closest-Miss-Fields")).To(Equal("method,body"))
+
+	responseBody, err := ioutil.ReadAll(result.Response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(string(responseBody)).To(ContainSubstring("But it did not match on the following fields"))
+}
+
+func Test_SimulateMode_WhenGivenANonMatchingRequestItReturnsTheMissResponseWhenSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SimulateMode{
+		Hoverfly: hoverflySimulateStub{
+			missResponse: &models.ResponseDetails{
+				Status:  http.StatusNotFound,
+				Body:    "no such thing",
+				Headers: map[string][]string{"Content-Type": {"text/plain"}},
+			},
+		},
+	}
+
+	request := models.RequestDetails{
+		Destination: "closest-miss.com",
+	}
+
+	result, err := unit.Process(&http.Request{}, request)
+	Expect(err).ToNot(BeNil())
+
+	Expect(result.Response.StatusCode).To(Equal(http.StatusNotFound))
+	Expect(result.Response.Header.Get("Content-Type")).To(Equal("text/plain"))
+	Expect(result.Response.Header.Get("Hoverfly-Closest-Miss-Fields")).To(Equal("method,body"))
+
+	responseBody, err := ioutil.ReadAll(result.Response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(string(responseBody)).To(Equal("no such thing"))
+}
+
+func Test_SimulateMode_WhenGivenANonMatchingRequestWithoutClosestMissItDoesNotSetTheHeader(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SimulateMode{
+		Hoverfly: hoverflySimulateStub{},
+	}
+
+	result, _ := unit.Process(&http.Request{}, models.RequestDetails{
+		Destination: "negative-match.com",
+	})
+
+	Expect(result.Response.Header).ToNot(HaveKey("Hoverfly-Closest-Miss-Fields"))
+}
+
 func Test_SimulateMode_WhenGivenAMatchingRequesAndMiddlewareFaislItReturnsAnError(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -96,3 +205,78 @@ func Test_SimulateMode_WhenGivenAMatchingRequesAndMiddlewareFaislItReturnsAnErro
 	Expect(string(responseBody)).To(ContainSubstring("There was an error when executing middleware"))
 	Expect(string(responseBody)).To(ContainSubstring("middleware-error"))
 }
+
+func Test_SimulateMode_WhenCompressionIsEnabledItGzipsTheResponseIfTheClientAcceptsGzip(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SimulateMode{
+		Hoverfly: hoverflySimulateStub{compressResponses: true},
+	}
+
+	request, _ := http.NewRequest("GET", "http://body-match.com", nil)
+	request.Header.Set("Accept-Encoding", "deflate, gzip;q=0.8")
+
+	result, err := unit.Process(request, models.RequestDetails{
+		Destination: "body-match.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(result.Response.Header.Get("Content-Encoding")).To(Equal("gzip"))
+	Expect(result.Response.Header.Get("Vary")).To(Equal("Accept-Encoding"))
+	Expect(result.Response.Header.Get("Content-Type")).To(Equal("text/plain"))
+
+	responseBody, err := ioutil.ReadAll(result.Response.Body)
+	Expect(err).To(BeNil())
+	Expect(result.Response.ContentLength).To(Equal(int64(len(responseBody))))
+	Expect(result.Response.Header.Get("Content-Length")).To(Equal(fmt.Sprint(len(responseBody))))
+
+	decompressed, err := util.DecompressGzip(responseBody)
+	Expect(err).To(BeNil())
+	Expect(string(decompressed)).To(Equal("plain text body"))
+}
+
+func Test_SimulateMode_WhenCompressionIsEnabledItDoesNotGzipIfTheClientDoesNotAcceptGzip(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SimulateMode{
+		Hoverfly: hoverflySimulateStub{compressResponses: true},
+	}
+
+	for _, acceptEncoding := range []string{"", "deflate", "gzip;q=0"} {
+		request, _ := http.NewRequest("GET", "http://body-match.com", nil)
+		request.Header.Set("Accept-Encoding", acceptEncoding)
+
+		result, err := unit.Process(request, models.RequestDetails{
+			Destination: "body-match.com",
+		})
+		Expect(err).To(BeNil())
+
+		Expect(result.Response.Header).ToNot(HaveKey("Content-Encoding"))
+
+		responseBody, err := ioutil.ReadAll(result.Response.Body)
+		Expect(err).To(BeNil())
+		Expect(string(responseBody)).To(Equal("plain text body"))
+	}
+}
+
+func Test_SimulateMode_WhenCompressionIsDisabledItDoesNotGzipTheResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SimulateMode{
+		Hoverfly: hoverflySimulateStub{},
+	}
+
+	request, _ := http.NewRequest("GET", "http://body-match.com", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+
+	result, err := unit.Process(request, models.RequestDetails{
+		Destination: "body-match.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(result.Response.Header).ToNot(HaveKey("Content-Encoding"))
+
+	responseBody, err := ioutil.ReadAll(result.Response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(responseBody)).To(Equal("plain text body"))
+}
@@ -69,9 +69,13 @@ func (this SpyMode) Process(request *http.Request, details models.RequestDetails
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Spy)
 	}
 
-	return newProcessResult(
+	result := newProcessResult(
 		ReconstructResponse(request, pair),
 		pair.Response.FixedDelay,
 		pair.Response.LogNormalDelay,
-	), nil
+	)
+	result.PairDelay = pair.Response.Delay
+	result.Matched = true
+
+	return result, nil
 }
@@ -2,6 +2,7 @@ package hoverfly
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/base64"
 	"io/ioutil"
 	"net/http"
@@ -22,6 +23,22 @@ import (
 
 var ProxyAuthorizationHeader string
 
+// LoadCACertificate - loads the CA certificate and private key used to sign MITM certificates. Each can be
+// the PEM encoded certificate or key itself, or the path to a file containing it.
+func LoadCACertificate(cert, key string) (tls.Certificate, error) {
+	certPEM, err := readPEM(cert)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	keyPEM, err := readPEM(key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
 // Creates goproxy.ProxyHttpServer and configures it to be used as a proxy for Hoverfly
 // goproxy is given handlers that use the Hoverfly request processing
 func NewProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
@@ -32,19 +49,7 @@ func NewProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
 
 	if hoverfly.Cfg.AuthEnabled {
 		log.Info("Enabling proxy authentication")
-		proxyBasicAndBearer(proxy, "hoverfly", func(user, password string) bool {
-
-			proxyUser := &backends.User{
-				Username: user,
-				Password: password,
-			}
-
-			responseStatus, _ := authentication.Login(proxyUser, hoverfly.Authentication, nil, 0)
-
-			return responseStatus == http.StatusOK
-		}, func(headerToken string) bool {
-			return authentication.IsJwtTokenValid(headerToken, hoverfly.Authentication, hoverfly.Cfg.SecretKey, hoverfly.Cfg.JWTExpirationDelta)
-		})
+		proxyBasicAndBearer(proxy, "hoverfly", hoverfly.mitmConnect(), hoverfly.isValidProxyUser, hoverfly.isValidProxyToken)
 	}
 
 	proxy.OnRequest(matchesFilter(hoverfly.Cfg.Destination)).
@@ -52,12 +57,16 @@ func NewProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
 			if hoverfly.Cfg.PlainHttpTunneling && !strings.HasSuffix(host, ":443") {
 				return goproxy.HTTPMitmConnect, host
 			}
-			return goproxy.MitmConnect, host
+			return hoverfly.mitmConnect(), host
 		}))
 
 	// processing connections
 	proxy.OnRequest(matchesFilter(hoverfly.Cfg.Destination)).DoFunc(
 		func(r *http.Request, ctx *goproxy.ProxyCtx) (*http.Request, *http.Response) {
+			// WebSocket connections over TLS are passed through by goproxy, plain ones by withWebSocketPassthrough
+			if isWebSocketUpgrade(r) {
+				return r, nil
+			}
 			startTime := time.Now()
 			resp := hoverfly.processRequest(r)
 			hoverfly.Journal.NewEntry(r, resp, hoverfly.Cfg.Mode, startTime)
@@ -81,6 +90,9 @@ func NewProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
 	// intercepts response
 	proxy.OnResponse(matchesFilter(hoverfly.Cfg.Destination)).DoFunc(
 		func(resp *http.Response, ctx *goproxy.ProxyCtx) *http.Response {
+			if resp != nil && ctx.Req != nil && isWebSocketUpgrade(ctx.Req) {
+				hoverfly.recordWebSocketHandshake(ctx.Req, resp)
+			}
 			hoverfly.Counter.Count(hoverfly.Cfg.GetMode())
 			return resp
 		})
@@ -151,6 +163,21 @@ func NewWebserverProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
 	return proxy
 }
 
+func (hf *Hoverfly) isValidProxyUser(user, password string) bool {
+	proxyUser := &backends.User{
+		Username: user,
+		Password: password,
+	}
+
+	responseStatus, _ := authentication.Login(proxyUser, hf.Authentication, nil, 0, hf.Cfg.FailedLoginLimit, hf.Cfg.FailedLoginLockout)
+
+	return responseStatus == http.StatusOK
+}
+
+func (hf *Hoverfly) isValidProxyToken(headerToken string) bool {
+	return authentication.IsJwtTokenValid(headerToken, hf.Authentication, hf.Cfg.SecretKey, hf.Cfg.JWTExpirationDelta)
+}
+
 func unauthorizedError(request *http.Request, realm, message string) *http.Response {
 	response := auth.BasicUnauthorized(request, realm)
 	response.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(message)))
@@ -159,7 +186,7 @@ func unauthorizedError(request *http.Request, realm, message string) *http.Respo
 	return response
 }
 
-func proxyBasicAndBearer(proxy *goproxy.ProxyHttpServer, realm string, basicFunc func(user, passwd string) bool, bearerFunc func(token string) bool) {
+func proxyBasicAndBearer(proxy *goproxy.ProxyHttpServer, realm string, mitmConnect *goproxy.ConnectAction, basicFunc func(user, passwd string) bool, bearerFunc func(token string) bool) {
 
 	proxy.OnRequest().Do(goproxy.FuncReqHandler(func(req *http.Request, ctx *goproxy.ProxyCtx) (*http.Request, *http.Response) {
 		if strings.HasSuffix(req.URL.Host, ":443") {
@@ -178,7 +205,7 @@ func proxyBasicAndBearer(proxy *goproxy.ProxyHttpServer, realm string, basicFunc
 			ctx.Resp = unauthorizedError(ctx.Req, realm, err.Error())
 			return goproxy.RejectConnect, host
 		}
-		return goproxy.MitmConnect, host
+		return mitmConnect, host
 	}))
 }
 
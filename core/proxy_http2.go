@@ -0,0 +1,89 @@
+package hoverfly
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/SpectoLabs/goproxy"
+	log "github.com/sirupsen/logrus"
+)
+
+// mitmConnect - returns the action used to intercept HTTPS connections. goproxy only speaks HTTP/1.1 to clients,
+// so when HTTP/2 is enabled the connection is hijacked and served by a net/http server which negotiates it with ALPN.
+func (hf *Hoverfly) mitmConnect() *goproxy.ConnectAction {
+	if !hf.Cfg.HTTP2 {
+		return goproxy.MitmConnect
+	}
+	return &goproxy.ConnectAction{Action: goproxy.ConnectHijack, Hijack: hf.serveMitmConnection}
+}
+
+func (hf *Hoverfly) serveMitmConnection(connect *http.Request, client net.Conn, ctx *goproxy.ProxyCtx) {
+	host := connect.URL.Host
+
+	tlsConfig, err := goproxy.TLSConfigFromCA(&goproxy.GoproxyCa)(host, ctx)
+	if err != nil {
+		io.WriteString(client, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+		client.Close()
+		return
+	}
+	tlsConfig.NextProtos = []string{"h2", "http/1.1"}
+
+	if _, err := io.WriteString(client, "HTTP/1.0 200 OK\r\n\r\n"); err != nil {
+		client.Close()
+		return
+	}
+
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.URL.Scheme = "https"
+			r.URL.Host = host
+			r.RemoteAddr = connect.RemoteAddr
+
+			if isWebSocketUpgrade(r) {
+				hf.passthroughWebSocket(w, r)
+				return
+			}
+			ctx.Proxy.ServeHTTP(w, r)
+		}),
+		ConnContext: withConnection,
+	}
+
+	go func() {
+		if err := server.Serve(newSingleConnListener(tls.Server(client, tlsConfig))); err != nil && err != io.EOF {
+			log.WithField("error", err.Error()).Warn("Could not serve intercepted HTTPS connection")
+		}
+	}()
+}
+
+// singleConnListener - hands a single connection to http.Server.Serve. Serve returns once the
+// connection has been accepted, but carries on serving it until the client closes it.
+type singleConnListener struct {
+	conn net.Conn
+	once sync.Once
+}
+
+func newSingleConnListener(conn net.Conn) *singleConnListener {
+	return &singleConnListener{conn: conn}
+}
+
+func (l *singleConnListener) Accept() (net.Conn, error) {
+	var conn net.Conn
+	l.once.Do(func() {
+		conn = l.conn
+	})
+	if conn == nil {
+		return nil, io.EOF
+	}
+	return conn, nil
+}
+
+func (l *singleConnListener) Close() error {
+	return nil
+}
+
+func (l *singleConnListener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
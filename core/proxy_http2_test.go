@@ -0,0 +1,94 @@
+package hoverfly
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+func startSimulatingProxy(http2 bool) (*Hoverfly, *http.Client) {
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.ListenOnHost = "127.0.0.1"
+	unit.Cfg.ProxyPort = "0"
+	unit.Cfg.Destination = "."
+	unit.Cfg.ProxyAuthorizationHeader = "Proxy-Authorization"
+	unit.Cfg.HTTP2 = http2
+	unit.Cfg.SetMode("simulate")
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "test.com",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 200,
+			Body:   "simulated",
+		},
+	})
+
+	Expect(unit.StartProxy()).To(BeNil())
+
+	proxyUrl, _ := url.Parse("http://" + unit.SL.Addr().String())
+	client := &http.Client{Transport: &http.Transport{
+		Proxy:             http.ProxyURL(proxyUrl),
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		ForceAttemptHTTP2: true,
+	}}
+
+	return unit, client
+}
+
+func Test_Hoverfly_StartProxy_NegotiatesHTTP2OverInterceptedConnectionsWhenEnabled(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, client := startSimulatingProxy(true)
+	defer unit.StopProxy()
+
+	response, err := client.Get("https://test.com/path")
+	Expect(err).To(BeNil())
+	Expect(response.ProtoMajor).To(Equal(2))
+	Expect(response.StatusCode).To(Equal(200))
+
+	body, _ := ioutil.ReadAll(response.Body)
+	Expect(string(body)).To(Equal("simulated"))
+}
+
+func Test_Hoverfly_StartProxy_UsesHTTP1OverInterceptedConnectionsByDefault(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, client := startSimulatingProxy(false)
+	defer unit.StopProxy()
+
+	response, err := client.Get("https://test.com/path")
+	Expect(err).To(BeNil())
+	Expect(response.ProtoMajor).To(Equal(1))
+
+	body, _ := ioutil.ReadAll(response.Body)
+	Expect(string(body)).To(Equal("simulated"))
+}
+
+func Test_Hoverfly_StartProxy_ServesHTTP1ClientsOverInterceptedConnectionsWhenHTTP2IsEnabled(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, client := startSimulatingProxy(true)
+	defer unit.StopProxy()
+	client.Transport.(*http.Transport).ForceAttemptHTTP2 = false
+	client.Transport.(*http.Transport).TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+
+	response, err := client.Get("https://test.com/path")
+	Expect(err).To(BeNil())
+	Expect(response.ProtoMajor).To(Equal(1))
+
+	body, _ := ioutil.ReadAll(response.Body)
+	Expect(string(body)).To(Equal("simulated"))
+}
@@ -1,13 +1,19 @@
 package hoverfly
 
 import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"bufio"
 	"net"
 
+	"github.com/SpectoLabs/hoverfly/core/certs"
 	. "github.com/onsi/gomega"
 
 	"net/http/httptest"
@@ -311,3 +317,59 @@ func Test_matchesFilter_ShouldGetHostNameFromRequest(t *testing.T) {
 	}, nil)
 	Expect(httpResult).To(BeTrue())
 }
+
+func testCACertificatePEM() (string, string) {
+	cert, key, err := certs.NewCertificatePair("test.ca", "Test Authority", time.Hour)
+	Expect(err).To(BeNil())
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	keyPEM := pem.EncodeToMemory(certs.PemBlockForKey(key))
+
+	return string(certPEM), string(keyPEM)
+}
+
+func Test_LoadCACertificate_LoadsPEM(t *testing.T) {
+	RegisterTestingT(t)
+
+	certPEM, keyPEM := testCACertificatePEM()
+
+	certificate, err := LoadCACertificate(certPEM, keyPEM)
+	Expect(err).To(BeNil())
+
+	parsed, err := x509.ParseCertificate(certificate.Certificate[0])
+	Expect(err).To(BeNil())
+	Expect(parsed.Subject.CommonName).To(Equal("test.ca"))
+}
+
+func Test_LoadCACertificate_LoadsFiles(t *testing.T) {
+	RegisterTestingT(t)
+
+	certPEM, keyPEM := testCACertificatePEM()
+
+	dir := t.TempDir()
+	Expect(ioutil.WriteFile(filepath.Join(dir, "cert.pem"), []byte(certPEM), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "key.pem"), []byte(keyPEM), 0600)).To(Succeed())
+
+	certificate, err := LoadCACertificate(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"))
+	Expect(err).To(BeNil())
+	Expect(certificate.Certificate).To(HaveLen(1))
+}
+
+func Test_LoadCACertificate_ErrorsWhenKeyDoesNotMatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	certPEM, _ := testCACertificatePEM()
+	_, otherKeyPEM := testCACertificatePEM()
+
+	_, err := LoadCACertificate(certPEM, otherKeyPEM)
+	Expect(err).ToNot(BeNil())
+}
+
+func Test_LoadCACertificate_ErrorsWhenFileDoesNotExist(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, keyPEM := testCACertificatePEM()
+
+	_, err := LoadCACertificate("does-not-exist.pem", keyPEM)
+	Expect(err).ToNot(BeNil())
+}
@@ -0,0 +1,145 @@
+package hoverfly
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+)
+
+// RedactedValue - replaces the value of every redacted header, query param and body field in captured pairs
+const RedactedValue = "REDACTED"
+
+// redactRequest returns a copy of the request with the redacted headers, query params and JSON body fields
+// replaced, so that they are never stored in the simulation
+func redactRequest(request models.RequestDetails, modeArgs *modes.ModeArguments) models.RequestDetails {
+	request.Headers = redactHeaders(request.Headers, modeArgs.RedactedHeaders)
+
+	if len(modeArgs.RedactedQueryParams) > 0 && len(request.Query) > 0 {
+		query := map[string][]string{}
+		for key, values := range request.Query {
+			if containsString(modeArgs.RedactedQueryParams, key) {
+				values = redactValues(values)
+			}
+			query[key] = values
+		}
+		request.Query = query
+	}
+
+	if len(modeArgs.RedactedBodyPaths) > 0 && len(request.FormData) > 0 {
+		form := map[string][]string{}
+		for key, values := range request.FormData {
+			if containsString(modeArgs.RedactedBodyPaths, key) || containsString(modeArgs.RedactedBodyPaths, "$."+key) {
+				values = redactValues(values)
+			}
+			form[key] = values
+		}
+		request.FormData = form
+	}
+
+	request.Body = redactJsonBody(request.Body, modeArgs.RedactedBodyPaths)
+
+	return request
+}
+
+// redactResponse returns a copy of the response with the redacted headers and JSON body fields replaced
+func redactResponse(response models.ResponseDetails, modeArgs *modes.ModeArguments) models.ResponseDetails {
+	response.Headers = redactHeaders(response.Headers, modeArgs.RedactedHeaders)
+	response.Body = redactJsonBody(response.Body, modeArgs.RedactedBodyPaths)
+
+	return response
+}
+
+func redactHeaders(headers map[string][]string, names []string) map[string][]string {
+	if len(names) == 0 || len(headers) == 0 {
+		return headers
+	}
+
+	redacted := map[string][]string{}
+	for key, values := range headers {
+		for _, name := range names {
+			if strings.EqualFold(key, name) {
+				values = redactValues(values)
+				break
+			}
+		}
+		redacted[key] = values
+	}
+	return redacted
+}
+
+func redactValues(values []string) []string {
+	redacted := make([]string, len(values))
+	for i := range values {
+		redacted[i] = RedactedValue
+	}
+	return redacted
+}
+
+// redactJsonBody replaces the fields at the given paths, eg. "$.user.password" or "user.password", when the
+// body is JSON. Fields inside arrays are redacted in every element. Other bodies are returned as they are.
+func redactJsonBody(body string, paths []string) string {
+	if len(paths) == 0 || body == "" {
+		return body
+	}
+
+	var data interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return body
+	}
+
+	redacted := false
+	for _, path := range paths {
+		path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+		if path == "" {
+			continue
+		}
+		if redactJsonPath(data, strings.Split(path, ".")) {
+			redacted = true
+		}
+	}
+
+	if !redacted {
+		return body
+	}
+
+	redactedBody, err := json.Marshal(data)
+	if err != nil {
+		return body
+	}
+	return string(redactedBody)
+}
+
+func redactJsonPath(data interface{}, keys []string) bool {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		field, found := value[keys[0]]
+		if !found {
+			return false
+		}
+		if len(keys) == 1 {
+			value[keys[0]] = RedactedValue
+			return true
+		}
+		return redactJsonPath(field, keys[1:])
+	case []interface{}:
+		redacted := false
+		for _, element := range value {
+			if redactJsonPath(element, keys) {
+				redacted = true
+			}
+		}
+		return redacted
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package hoverfly
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	. "github.com/onsi/gomega"
+)
+
+func Test_redactJsonBody_RedactsFieldsInEveryArrayElement(t *testing.T) {
+	RegisterTestingT(t)
+
+	body := redactJsonBody(`{"users":[{"name":"a","token":"1"},{"name":"b","token":"2"}]}`, []string{"$.users.token"})
+
+	Expect(body).To(Equal(`{"users":[{"name":"a","token":"REDACTED"},{"name":"b","token":"REDACTED"}]}`))
+}
+
+func Test_redactJsonBody_RedactsWholeObjects(t *testing.T) {
+	RegisterTestingT(t)
+
+	body := redactJsonBody(`{"card":{"number":"4111","expiry":"01/30"},"amount":10}`, []string{"card"})
+
+	Expect(body).To(Equal(`{"amount":10,"card":"REDACTED"}`))
+}
+
+func Test_redactJsonBody_LeavesBodyAsItIsWhenNothingIsRedacted(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(redactJsonBody(`{ "name": "bob" }`, []string{"$.password"})).To(Equal(`{ "name": "bob" }`))
+	Expect(redactJsonBody(`password=hunter2`, []string{"$.password"})).To(Equal(`password=hunter2`))
+}
+
+func Test_redactRequest_RedactsFormFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	request := redactRequest(models.RequestDetails{
+		FormData: map[string][]string{
+			"username": {"bob"},
+			"password": {"hunter2"},
+		},
+	}, &modes.ModeArguments{
+		RedactedBodyPaths: []string{"$.password"},
+	})
+
+	Expect(request.FormData).To(Equal(map[string][]string{
+		"username": {"bob"},
+		"password": {"REDACTED"},
+	}))
+}
+
+func Test_redactRequest_DoesNothingWithoutRedactions(t *testing.T) {
+	RegisterTestingT(t)
+
+	original := models.RequestDetails{
+		Query:   map[string][]string{"token": {"secret"}},
+		Headers: map[string][]string{"Authorization": {"Bearer abc"}},
+		Body:    `{"password":"hunter2"}`,
+	}
+
+	Expect(redactRequest(original, &modes.ModeArguments{})).To(Equal(original))
+}
@@ -0,0 +1,65 @@
+package requestlog
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Entry is a single served request in the request log
+type Entry struct {
+	Time        time.Time `json:"time"`
+	Mode        string    `json:"mode"`
+	Method      string    `json:"method"`
+	Scheme      string    `json:"scheme"`
+	Destination string    `json:"destination"`
+	Path        string    `json:"path"`
+	Matched     bool      `json:"matched"`
+	Status      int       `json:"status"`
+}
+
+// RequestLog appends an entry per served request as line-delimited JSON. Each entry is written with a
+// single write so that the log can be rotated by truncating or moving the file.
+type RequestLog struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+func NewRequestLog(writer io.Writer) *RequestLog {
+	return &RequestLog{
+		writer: writer,
+	}
+}
+
+// NewFileRequestLog opens the file in append mode, creating it if it does not exist
+func NewFileRequestLog(path string) (*RequestLog, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRequestLog(file), nil
+}
+
+func (this *RequestLog) Log(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	_, err = this.writer.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the underlying writer if it can be closed
+func (this *RequestLog) Close() error {
+	if closer, ok := this.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package requestlog_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/requestlog"
+	. "github.com/onsi/gomega"
+)
+
+func Test_RequestLog_Log_WritesAnEntryAsOneLineOfJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	buffer := &bytes.Buffer{}
+	unit := requestlog.NewRequestLog(buffer)
+
+	err := unit.Log(requestlog.Entry{
+		Time:        time.Date(2018, 1, 1, 10, 0, 0, 0, time.UTC),
+		Mode:        "simulate",
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "hoverfly.io",
+		Path:        "/path",
+		Matched:     true,
+		Status:      200,
+	})
+	Expect(err).To(BeNil())
+
+	Expect(buffer.String()).To(Equal(`{"time":"2018-01-01T10:00:00Z","mode":"simulate","method":"GET","scheme":"http","destination":"hoverfly.io","path":"/path","matched":true,"status":200}` + "\n"))
+}
+
+func Test_RequestLog_Log_AppendsEntries(t *testing.T) {
+	RegisterTestingT(t)
+
+	buffer := &bytes.Buffer{}
+	unit := requestlog.NewRequestLog(buffer)
+
+	Expect(unit.Log(requestlog.Entry{Path: "/one"})).To(Succeed())
+	Expect(unit.Log(requestlog.Entry{Path: "/two", Status: 502})).To(Succeed())
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	Expect(lines).To(HaveLen(2))
+	Expect(lines[0]).To(ContainSubstring(`"path":"/one"`))
+	Expect(lines[1]).To(ContainSubstring(`"path":"/two"`))
+	Expect(lines[1]).To(ContainSubstring(`"matched":false,"status":502`))
+}
+
+func Test_NewFileRequestLog_AppendsToExistingFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "requestlog")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "requests.log")
+	Expect(ioutil.WriteFile(path, []byte("{}\n"), 0644)).To(Succeed())
+
+	unit, err := requestlog.NewFileRequestLog(path)
+	Expect(err).To(BeNil())
+
+	Expect(unit.Log(requestlog.Entry{Path: "/path"})).To(Succeed())
+	Expect(unit.Close()).To(Succeed())
+
+	contents, err := ioutil.ReadFile(path)
+	Expect(err).To(BeNil())
+	Expect(string(contents)).To(HavePrefix("{}\n"))
+	Expect(string(contents)).To(ContainSubstring(`"path":"/path"`))
+}
+
+func Test_NewFileRequestLog_ErrorsWhenFileCannotBeOpened(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := requestlog.NewFileRequestLog(filepath.Join("does", "not", "exist", "requests.log"))
+	Expect(err).ToNot(BeNil())
+}
@@ -1,10 +1,12 @@
 package hoverfly
 
 import (
+	"crypto/x509"
 	"github.com/SpectoLabs/hoverfly/core/cors"
 	"os"
 	"strconv"
 	"sync"
+	"time"
 
 	"strings"
 
@@ -23,18 +25,29 @@ type Configuration struct {
 	DatabasePath string
 	Webserver    bool
 
+	AdminOnProxyPort bool
+
 	TLSVerification bool
+	// UpstreamRootCAs - the CAs trusted when verifying real servers, the system roots if nil
+	UpstreamRootCAs *x509.CertPool
 
 	UpstreamProxy string
-	PACFile       []byte
+	// UpstreamTimeout - time given to a destination to respond, including sending the body, 0 is no timeout
+	UpstreamTimeout time.Duration
+	UpstreamRoutes  UpstreamRoutes
+	PACFile         []byte
 
 	Verbose bool
 
-	DisableCache bool
-	CacheSize    int
+	DisableCache      bool
+	CacheSize         int
+	CacheDatabasePath string
 
 	SecretKey          []byte
 	JWTExpirationDelta int
+	JWTRefreshWindow   int
+	FailedLoginLimit   int
+	FailedLoginLockout time.Duration
 	AuthEnabled        bool
 
 	ProxyAuthorizationHeader string
@@ -42,8 +55,21 @@ type Configuration struct {
 	PlainHttpTunneling bool
 	CORS               cors.Configs
 
+	// HTTP2 - negotiates HTTP/2 with clients over intercepted HTTPS connections
+	HTTP2 bool
+
 	NoImportCheck bool
 
+	PreserveContentEncoding bool
+	CompressResponses       bool
+	MatchedPairHeaders      bool
+
+	// RandomSeed - seeds the random template helpers so that templated responses are reproducible, 0 leaves them unseeded
+	RandomSeed int64
+
+	// RequestLogPath - file which every served request is appended to as line-delimited JSON
+	RequestLogPath string
+
 	ClientAuthenticationDestination string
 	ClientAuthenticationClientCert  string
 	ClientAuthenticationClientKey   string
@@ -52,7 +78,8 @@ type Configuration struct {
 	ResponsesBodyFilesPath           string
 	ResponsesBodyFilesAllowedOrigins []string
 
-	ProxyControlWG sync.WaitGroup
+	ShutdownGracePeriod time.Duration
+	ProxyControlWG      sync.WaitGroup
 
 	mu sync.Mutex
 }
@@ -87,13 +114,28 @@ const DefaultAdminPort = "8888"
 
 const DefaultListenOnHost = "127.0.0.1"
 
+// DefaultShutdownGracePeriod - default time given to in-flight proxy requests to complete when stopping the proxy
+const DefaultShutdownGracePeriod = 5 * time.Second
+
+// DefaultUpstreamTimeout - default time given to a destination to respond to a request
+const DefaultUpstreamTimeout = 30 * time.Second
+
 // DefaultDatabasePath - default database name that will be created
 // or used by Hoverfly
 const DefaultDatabasePath = "requests.db"
 
-// DefaultJWTExpirationDelta - default token expiration if environment variable is no provided
+// DefaultJWTExpirationDelta - default token expiration in seconds if environment variable is no provided
 const DefaultJWTExpirationDelta = 1 * 24 * 60 * 60
 
+// DefaultJWTRefreshWindow - default seconds after a token expires during which it can still be refreshed
+const DefaultJWTRefreshWindow = 60 * 60
+
+// DefaultFailedLoginLimit - default number of failed logins after which a username is locked out
+const DefaultFailedLoginLimit = 3
+
+// DefaultFailedLoginLockout - default time a username is locked out for after too many failed logins
+const DefaultFailedLoginLockout = 10 * time.Minute
+
 // Environment variables
 const (
 	// TODO Should use naming convention for environment variables
@@ -138,10 +180,12 @@ func InitSettings() *Configuration {
 	}
 
 	appConfig.ListenOnHost = DefaultListenOnHost
+	appConfig.ShutdownGracePeriod = DefaultShutdownGracePeriod
+	appConfig.UpstreamTimeout = DefaultUpstreamTimeout
 
 	// getting external proxy
 	if os.Getenv(HoverflyUpstreamProxyPortEV) != "" {
-		appConfig.UpstreamProxy = os.Getenv(HoverflyUpstreamProxyPortEV)
+		appConfig.SetUpstreamProxy(os.Getenv(HoverflyUpstreamProxyPortEV))
 	} else {
 		appConfig.UpstreamProxy = ""
 	}
@@ -169,11 +213,19 @@ func InitSettings() *Configuration {
 			}).Fatal("failed to get token exipration delta, using default value")
 			exp = DefaultJWTExpirationDelta
 		}
+		if exp <= 0 {
+			log.WithFields(log.Fields{
+				"HoverflyTokenExpiration": os.Getenv(HoverflyTokenExpirationEV),
+			}).Fatal("token expiration must be a positive number of seconds")
+		}
 		appConfig.JWTExpirationDelta = exp
 
 	} else {
 		appConfig.JWTExpirationDelta = DefaultJWTExpirationDelta
 	}
+	appConfig.JWTRefreshWindow = DefaultJWTRefreshWindow
+	appConfig.FailedLoginLimit = DefaultFailedLoginLimit
+	appConfig.FailedLoginLockout = DefaultFailedLoginLockout
 
 	if os.Getenv(HoverflyAuthEnabledEV) == "true" {
 		appConfig.AuthEnabled = true
@@ -67,3 +67,29 @@ func (hook StoreLogsHook) GetLogs(limit int, from *time.Time) ([]*logrus.Entry,
 		return hook.Entries[entriesLength-limit:], nil
 	}
 }
+
+// GetFilteredLogs returns the most recent logs at the given level or more
+// severe, optionally restricted to a component. Filtering is applied before
+// the limit so that a limit always returns matching entries.
+func (hook StoreLogsHook) GetFilteredLogs(limit int, from *time.Time, level logrus.Level, component string) ([]*logrus.Entry, error) {
+	if hook.LogsLimit == 0 {
+		return []*logrus.Entry{}, fmt.Errorf("Logs disabled")
+	}
+
+	entries := []*logrus.Entry{}
+	for i := len(hook.Entries) - 1; i >= 0 && len(entries) < limit; i-- {
+		entry := hook.Entries[i]
+		if from != nil && !entry.Time.After(*from) {
+			continue
+		}
+		if entry.Level > level {
+			continue
+		}
+		if component != "" && entry.Data["component"] != component {
+			continue
+		}
+		entries = append([]*logrus.Entry{entry}, entries...)
+	}
+
+	return entries, nil
+}
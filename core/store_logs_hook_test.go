@@ -175,3 +175,64 @@ func Test_StoreLogsHook_GetLogs_FilteredByFromDateTimeAndLimit(t *testing.T) {
 	Expect(logs[1].Time).To(BeTemporally("==", time.Date(2017, 6, 14, 10, 0, 3, 0, time.Local), expectPrecision))
 
 }
+
+func Test_StoreLogsHook_GetFilteredLogs_ReturnsLogsAtLevelOrMoreSevere(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewStoreLogsHook()
+
+	unit.Fire(&logrus.Entry{Level: logrus.ErrorLevel, Message: "error"})
+	unit.Fire(&logrus.Entry{Level: logrus.InfoLevel, Message: "info"})
+	unit.Fire(&logrus.Entry{Level: logrus.WarnLevel, Message: "warn"})
+	unit.Fire(&logrus.Entry{Level: logrus.DebugLevel, Message: "debug"})
+
+	logs, err := unit.GetFilteredLogs(100, nil, logrus.WarnLevel, "")
+	Expect(err).To(BeNil())
+
+	Expect(logs).To(HaveLen(2))
+	Expect(logs[0].Message).To(Equal("error"))
+	Expect(logs[1].Message).To(Equal("warn"))
+}
+
+func Test_StoreLogsHook_GetFilteredLogs_ReturnsLogsForComponent(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewStoreLogsHook()
+
+	unit.Fire(&logrus.Entry{Level: logrus.InfoLevel, Message: "matching", Data: logrus.Fields{"component": "matching"}})
+	unit.Fire(&logrus.Entry{Level: logrus.InfoLevel, Message: "middleware", Data: logrus.Fields{"component": "middleware"}})
+	unit.Fire(&logrus.Entry{Level: logrus.InfoLevel, Message: "none"})
+
+	logs, err := unit.GetFilteredLogs(100, nil, logrus.DebugLevel, "matching")
+	Expect(err).To(BeNil())
+
+	Expect(logs).To(HaveLen(1))
+	Expect(logs[0].Message).To(Equal("matching"))
+}
+
+func Test_StoreLogsHook_GetFilteredLogs_AppliesLimitAfterFiltering(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewStoreLogsHook()
+
+	unit.Fire(&logrus.Entry{Level: logrus.ErrorLevel, Message: "error-0"})
+	unit.Fire(&logrus.Entry{Level: logrus.ErrorLevel, Message: "error-1"})
+	unit.Fire(&logrus.Entry{Level: logrus.InfoLevel, Message: "info-0"})
+	unit.Fire(&logrus.Entry{Level: logrus.InfoLevel, Message: "info-1"})
+
+	logs, err := unit.GetFilteredLogs(1, nil, logrus.ErrorLevel, "")
+	Expect(err).To(BeNil())
+
+	Expect(logs).To(HaveLen(1))
+	Expect(logs[0].Message).To(Equal("error-1"))
+}
+
+func Test_StoreLogsHook_GetFilteredLogs_ErrorsWhenLogsAreDisabled(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewStoreLogsHook()
+	unit.LogsLimit = 0
+
+	_, err := unit.GetFilteredLogs(100, nil, logrus.DebugLevel, "")
+	Expect(err).ToNot(BeNil())
+}
@@ -14,7 +14,6 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/util"
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/icrowley/fake"
-	log "github.com/sirupsen/logrus"
 )
 
 const defaultDateTimeFormat = "2006-01-02T15:04:05Z07:00"
@@ -26,6 +25,14 @@ type templateHelpers struct {
 
 func (t templateHelpers) nowHelper(offset string, format string) string {
 	now := t.now()
+
+	// Allow the layout to be given before the offset, eg. {{ now '2006-01-02' '+3h' }}
+	if _, err := ParseDuration(offset); err != nil && offset != "" {
+		if _, err := ParseDuration(format); err == nil {
+			offset, format = format, offset
+		}
+	}
+
 	if offset != "" {
 		duration, err := ParseDuration(offset)
 		if err == nil {
@@ -42,6 +49,10 @@ func (t templateHelpers) nowHelper(offset string, format string) string {
 		formatted = strconv.FormatInt(now.UnixNano()/1000000, 10)
 	} else {
 		formatted = now.UTC().Format(format)
+		// A layout without any time elements is returned unchanged, so fall back to the default format
+		if formatted == format {
+			formatted = now.UTC().Format(defaultDateTimeFormat)
+		}
 	}
 
 	return formatted
@@ -87,6 +98,30 @@ func (t templateHelpers) randomIPv6() string {
 	return fake.IPv6()
 }
 
+func (t templateHelpers) randomFirstName() string {
+	return fake.FirstName()
+}
+
+func (t templateHelpers) randomLastName() string {
+	return fake.LastName()
+}
+
+func (t templateHelpers) randomFullName() string {
+	return fake.FullName()
+}
+
+func (t templateHelpers) randomStreetAddress() string {
+	return fake.StreetAddress()
+}
+
+func (t templateHelpers) randomCity() string {
+	return fake.City()
+}
+
+func (t templateHelpers) randomPhone() string {
+	return fake.Phone()
+}
+
 func (t templateHelpers) randomUuid() string {
 	return uuid.New()
 }
@@ -97,6 +132,79 @@ func (t templateHelpers) requestBody(queryType, query string, options *raymond.O
 	return fetchFromRequestBody(queryType, query, toMatch)
 }
 
+// requestHeader returns the first value of the request header with the given name, ignoring its case, or an
+// empty string if the request does not have that header.
+func (t templateHelpers) requestHeader(name string, options *raymond.Options) string {
+	request, ok := options.Value("request").(Request)
+	if !ok {
+		return ""
+	}
+
+	for key, values := range request.Header {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
+
+// jsonPathHelper extracts a value from the source, such as the request body, using a JSONPath
+// query. An empty string is returned when the source is not JSON or the query does not match.
+func (t templateHelpers) jsonPathHelper(source, query string) string {
+	if query == "" {
+		return ""
+	}
+
+	return jsonPath(query, source)
+}
+
+// queryParam returns the first value of the named query parameter of the request, or an
+// empty string if it is not present.
+func (t templateHelpers) queryParam(name string, options *raymond.Options) string {
+	request, ok := options.Value("request").(Request)
+	if !ok {
+		return ""
+	}
+
+	if values := request.QueryParam[name]; len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// pathParam returns the path segment at the given zero based index, or an empty string if
+// the path does not have that many segments.
+func (t templateHelpers) pathParam(index int, options *raymond.Options) string {
+	request, ok := options.Value("request").(Request)
+	if !ok {
+		return ""
+	}
+
+	if index < 0 || index >= len(request.Path) {
+		return ""
+	}
+
+	return request.Path[index]
+}
+
+// stateHelper returns the value stored in state under the given key, or the default value if
+// the key has not been set. This allows keys which are not valid template paths, such as
+// "sequence:1", to be referenced.
+func (t templateHelpers) stateHelper(key string, defaultValue string, options *raymond.Options) string {
+	state, ok := options.Value("state").(map[string]string)
+	if !ok {
+		return defaultValue
+	}
+
+	if value, exists := state[key]; exists {
+		return value
+	}
+
+	return defaultValue
+}
+
 func fetchFromRequestBody(queryType, query, toMatch string) string {
 
 	if queryType == "jsonpath" {
@@ -104,7 +212,7 @@ func fetchFromRequestBody(queryType, query, toMatch string) string {
 	} else if queryType == "xpath" {
 		return xPath(query, toMatch)
 	}
-	log.Errorf("Unknown query type \"%s\" for templating Request.Body", queryType)
+	logger.Errorf("Unknown query type \"%s\" for templating Request.Body", queryType)
 	return ""
 }
 
@@ -130,6 +238,78 @@ func (t templateHelpers) replace(target, oldValue, newValue string) string {
 	return strings.Replace(target, oldValue, newValue, -1)
 }
 
+func (t templateHelpers) add(a, b interface{}) string {
+	return arithmetic(a, b, func(x, y float64) float64 { return x + y })
+}
+
+func (t templateHelpers) subtract(a, b interface{}) string {
+	return arithmetic(a, b, func(x, y float64) float64 { return x - y })
+}
+
+func (t templateHelpers) multiply(a, b interface{}) string {
+	return arithmetic(a, b, func(x, y float64) float64 { return x * y })
+}
+
+func (t templateHelpers) divide(a, b interface{}) string {
+	if divisor, ok := util.ToNumber(b); !ok || divisor == 0 {
+		return ""
+	}
+	return arithmetic(a, b, func(x, y float64) float64 { return x / y })
+}
+
+// equals returns true when both arguments have the same text, so that it can be used to branch with
+// {{#if (eq (Request.QueryParam "tier") "gold") }}
+func (t templateHelpers) equals(a, b interface{}) bool {
+	return toText(a) == toText(b)
+}
+
+func (t templateHelpers) notEquals(a, b interface{}) bool {
+	return !t.equals(a, b)
+}
+
+// contains returns true when the text contains the value, or when a list, such as all the values of a
+// query parameter, has an item equal to it.
+func (t templateHelpers) contains(target, value interface{}) bool {
+	if items, ok := target.([]string); ok {
+		for _, item := range items {
+			if item == toText(value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return strings.Contains(toText(target), toText(value))
+}
+
+// arithmetic applies the operation to both arguments once converted to numbers, returning an
+// empty string if either of them is not numeric. Whole results are formatted without decimals.
+func arithmetic(a, b interface{}, operation func(float64, float64) float64) string {
+	x, ok := util.ToNumber(a)
+	if !ok {
+		return ""
+	}
+
+	y, ok := util.ToNumber(b)
+	if !ok {
+		return ""
+	}
+
+	return strconv.FormatFloat(operation(x, y), 'f', -1, 64)
+}
+
+// toText returns the value as it would be rendered, except for lists which give their first item
+func toText(value interface{}) string {
+	if items, ok := value.([]string); ok {
+		if len(items) == 0 {
+			return ""
+		}
+		return items[0]
+	}
+
+	return raymond.Str(value)
+}
+
 func prepareJsonPathQuery(query string) string {
 	if query[0:1] != "{" && query[len(query)-1:] != "}" {
 		query = fmt.Sprintf("{%s}", query)
@@ -73,7 +73,24 @@ func Test_now_withInvalidFormat(t *testing.T) {
 
 	unit := templateHelpers{now: testNow}
 
-	Expect(unit.nowHelper("", "dog")).To(Equal("dog"))
+	Expect(unit.nowHelper("", "dog")).To(Equal("2018-01-01T00:00:00Z"))
+}
+
+func Test_now_withHoursOffsetAndRFC1123Format(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{now: testNow}
+
+	Expect(unit.nowHelper("+3h", "Mon, 02 Jan 2006 15:04:05 MST")).To(Equal("Mon, 01 Jan 2018 03:00:00 UTC"))
+}
+
+func Test_now_withFormatBeforeOffset(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{now: testNow}
+
+	Expect(unit.nowHelper("2006-01-02", "+3d")).To(Equal("2018-01-04"))
+	Expect(unit.nowHelper("2006-01-02 15:04", "-30m")).To(Equal("2017-12-31 23:30"))
 }
 
 func Test_replace(t *testing.T) {
@@ -91,3 +108,79 @@ func Test_faker(t *testing.T) {
 
 	Expect(unit.faker("JobTitle")[0].String()).To(Not(BeEmpty()))
 }
+
+func Test_randomNameAndAddressHelpers_returnNonEmptyValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{}
+
+	Expect(unit.randomFirstName()).ToNot(BeEmpty())
+	Expect(unit.randomLastName()).ToNot(BeEmpty())
+	Expect(unit.randomFullName()).ToNot(BeEmpty())
+	Expect(unit.randomStreetAddress()).ToNot(BeEmpty())
+	Expect(unit.randomCity()).ToNot(BeEmpty())
+	Expect(unit.randomPhone()).ToNot(BeEmpty())
+}
+
+func Test_randomUuid_generatesVersion4UUID(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{}
+
+	Expect(unit.randomUuid()).To(MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`))
+}
+
+func Test_randomUuid_generatesDifferentValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{}
+
+	Expect(unit.randomUuid()).ToNot(Equal(unit.randomUuid()))
+}
+
+func Test_add(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{}
+
+	Expect(unit.add(1, 2)).To(Equal("3"))
+	Expect(unit.add("1.5", 2)).To(Equal("3.5"))
+	Expect(unit.add("one", 2)).To(Equal(""))
+}
+
+func Test_subtract(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{}
+
+	Expect(unit.subtract(10, "4")).To(Equal("6"))
+	Expect(unit.subtract(1.5, 2)).To(Equal("-0.5"))
+}
+
+func Test_multiply(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{}
+
+	Expect(unit.multiply("3", 10)).To(Equal("30"))
+	Expect(unit.multiply(2.5, "0.5")).To(Equal("1.25"))
+	Expect(unit.multiply([]string{"4"}, 2)).To(Equal("8"))
+}
+
+func Test_divide(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{}
+
+	Expect(unit.divide(10, 4)).To(Equal("2.5"))
+	Expect(unit.divide("9", "3")).To(Equal("3"))
+}
+
+func Test_divide_byZeroReturnsEmptyString(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := templateHelpers{}
+
+	Expect(unit.divide(10, 0)).To(Equal(""))
+	Expect(unit.divide(10, "0.0")).To(Equal(""))
+}
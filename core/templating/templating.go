@@ -9,13 +9,33 @@ import (
 	"github.com/brianvoe/gofakeit/v6"
 
 	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/util"
 	"github.com/aymerick/raymond"
+	"github.com/aymerick/raymond/lexer"
+	"github.com/icrowley/fake"
+	"github.com/pborman/uuid"
 
 	log "github.com/sirupsen/logrus"
 )
 
 const REQUEST_BODY_HELPER = "requestBody"
 
+var logger = log.WithField("component", "templating")
+
+// requestValueCalls maps the Request fields which can be called with a name, eg. {{ Request.QueryParam "page" }}, to
+// the fields which render them. raymond would otherwise render the whole map, as they are also used as paths to the
+// values, eg. {{ Request.QueryParam.page }}
+var requestValueCalls = map[string]string{
+	"QueryParam": "QueryParamValue",
+	"Header":     "HeaderValue",
+}
+
+// requestValueArguments maps the Request fields which are rendered with a call, to the fields holding the value they
+// stand for when they are passed to a helper, eg. {{ jsonPath Request.Body "$.id" }}
+var requestValueArguments = map[string]string{
+	"Body": "RawBody",
+}
+
 type TemplatingData struct {
 	Request         Request
 	State           map[string]string
@@ -26,13 +46,20 @@ type TemplatingData struct {
 
 type Request struct {
 	QueryParam map[string][]string
-	Header     map[string][]string
-	Path       []string
-	Scheme     string
-	Body       func(queryType, query string, options *raymond.Options) string
-	FormData   map[string][]string
-	body       string
-	Method     string
+	// QueryParamValue - the first value of the named query parameter, which is how {{ Request.QueryParam "name" }} is rendered
+	QueryParamValue func(name string, options *raymond.Options) string
+	Header          map[string][]string
+	// HeaderValue - the first value of the named header, which is how {{ Request.Header "name" }} is rendered
+	HeaderValue func(name string, options *raymond.Options) string
+	Path        []string
+	PathParam   func(index int, options *raymond.Options) string
+	Scheme      string
+	Body        func(queryType, query string, options *raymond.Options) string
+	// RawBody - the request body as received, so that it can be passed to helpers such as jsonPath
+	RawBody  string
+	FormData map[string][]string
+	body     string
+	Method   string
 }
 
 type Templator struct {
@@ -41,10 +68,34 @@ type Templator struct {
 
 var helpersRegistered = false
 
+// fakerSource - shared by every templator, as the helpers are only registered with raymond once
+var fakerSource = gofakeit.New(0)
+
+// SeedRandom - seeds every source of random data used by the template helpers, so that a templated simulation
+// renders the same output on every run. A seed of 0 restores the default unseeded behaviour.
+func SeedRandom(seed int64) {
+	util.SeedRandom(seed)
+	if seed == 0 {
+		fake.Seed(time.Now().UnixNano())
+		fakerSource.Rand.Seed(time.Now().UnixNano())
+		uuid.SetRand(nil)
+		return
+	}
+	fake.Seed(seed)
+	fakerSource.Rand.Seed(seed)
+	uuid.SetRand(randomReader{})
+}
+
+type randomReader struct{}
+
+func (randomReader) Read(p []byte) (int, error) {
+	return util.RandomBytes(p)
+}
+
 func NewTemplator() *Templator {
 	t := templateHelpers{
 		now:         time.Now,
-		fakerSource: gofakeit.New(0),
+		fakerSource: fakerSource,
 	}
 	helperMethodMap := make(map[string]interface{})
 	if !helpersRegistered {
@@ -59,10 +110,25 @@ func NewTemplator() *Templator {
 		helperMethodMap["randomEmail"] = t.randomEmail
 		helperMethodMap["randomIPv4"] = t.randomIPv4
 		helperMethodMap["randomIPv6"] = t.randomIPv6
+		helperMethodMap["randomFirstName"] = t.randomFirstName
+		helperMethodMap["randomLastName"] = t.randomLastName
+		helperMethodMap["randomFullName"] = t.randomFullName
+		helperMethodMap["randomStreetAddress"] = t.randomStreetAddress
+		helperMethodMap["randomCity"] = t.randomCity
+		helperMethodMap["randomPhone"] = t.randomPhone
 		helperMethodMap["randomUuid"] = t.randomUuid
 		helperMethodMap["replace"] = t.replace
 		helperMethodMap["faker"] = t.faker
 		helperMethodMap["requestBody"] = t.requestBody
+		helperMethodMap["jsonPath"] = t.jsonPathHelper
+		helperMethodMap["state"] = t.stateHelper
+		helperMethodMap["add"] = t.add
+		helperMethodMap["subtract"] = t.subtract
+		helperMethodMap["multiply"] = t.multiply
+		helperMethodMap["divide"] = t.divide
+		helperMethodMap["eq"] = t.equals
+		helperMethodMap["ne"] = t.notEquals
+		helperMethodMap["contains"] = t.contains
 
 		raymond.RegisterHelpers(helperMethodMap)
 		helpersRegistered = true
@@ -75,7 +141,53 @@ func NewTemplator() *Templator {
 
 func (*Templator) ParseTemplate(responseBody string) (*raymond.Template, error) {
 
-	return raymond.Parse(responseBody)
+	return raymond.Parse(rewriteRequestValueCalls(responseBody))
+}
+
+// rewriteRequestValueCalls rewrites calls such as {{ Request.QueryParam "page" }}, and arguments such as
+// {{ jsonPath Request.Body "$.id" }}, to the fields which render them. Only the tokens inside mustaches are rewritten.
+func rewriteRequestValueCalls(source string) string {
+	var tokens []lexer.Token
+	scanner := lexer.Scan(source)
+	for {
+		token := scanner.NextToken()
+		if token.Kind == lexer.TokenEOF || token.Kind == lexer.TokenError {
+			break
+		}
+		tokens = append(tokens, token)
+	}
+
+	var rewritten strings.Builder
+	written := 0
+	for i := 1; i+2 < len(tokens); i++ {
+		if tokens[i].Kind != lexer.TokenID || tokens[i].Val != "Request" || tokens[i+1].Kind != lexer.TokenSep ||
+			tokens[i+2].Kind != lexer.TokenID {
+			continue
+		}
+
+		var field string
+		var ok bool
+		switch tokens[i-1].Kind {
+		case lexer.TokenOpen, lexer.TokenOpenUnescaped, lexer.TokenOpenBlock, lexer.TokenOpenSexpr:
+			// the call has to open a mustache or sub expression, and be given a name
+			if i+3 < len(tokens) && tokens[i+3].Kind == lexer.TokenString {
+				field, ok = requestValueCalls[tokens[i+2].Val]
+			}
+		case lexer.TokenID, lexer.TokenString, lexer.TokenNumber, lexer.TokenBoolean, lexer.TokenCloseSexpr, lexer.TokenEquals:
+			// anything else before it means it is an argument of a helper
+			field, ok = requestValueArguments[tokens[i+2].Val]
+		}
+		if !ok {
+			continue
+		}
+
+		rewritten.WriteString(source[written:tokens[i+2].Pos])
+		rewritten.WriteString(field)
+		written = tokens[i+2].Pos + len(tokens[i+2].Val)
+	}
+	rewritten.WriteString(source[written:])
+
+	return rewritten.String()
 }
 
 func (t *Templator) RenderTemplate(tpl *raymond.Template, requestDetails *models.RequestDetails, literals *models.Literals, vars *models.Variables, state map[string]string) (string, error) {
@@ -104,14 +216,18 @@ func (t *Templator) NewTemplatingData(requestDetails *models.RequestDetails, lit
 
 	return &TemplatingData{
 		Request: Request{
-			Path:       strings.Split(requestDetails.Path, "/")[1:],
-			QueryParam: requestDetails.Query,
-			Header:     requestDetails.Headers,
-			Scheme:     requestDetails.Scheme,
-			Body:       templateHelpers{}.requestBody,
-			FormData:   requestDetails.FormData,
-			body:       requestDetails.Body,
-			Method:     requestDetails.Method,
+			Path:            strings.Split(requestDetails.Path, "/")[1:],
+			PathParam:       templateHelpers{}.pathParam,
+			QueryParam:      requestDetails.Query,
+			QueryParamValue: templateHelpers{}.queryParam,
+			Header:          requestDetails.Headers,
+			HeaderValue:     templateHelpers{}.requestHeader,
+			Scheme:          requestDetails.Scheme,
+			Body:            templateHelpers{}.requestBody,
+			RawBody:         requestDetails.Body,
+			FormData:        requestDetails.FormData,
+			body:            requestDetails.Body,
+			Method:          requestDetails.Method,
 		},
 		Literals: literalMap,
 		Vars:     variableMap,
@@ -141,7 +257,7 @@ func (t *Templator) getVariables(vars *models.Variables, requestDetails *models.
 func getDataFromRequestBody(variable models.Variable, body string) string {
 	defer func() {
 		if err := recover(); err != nil {
-			log.Error("panic occurred:", err)
+			logger.Error("panic occurred:", err)
 		}
 	}()
 	return fetchFromRequestBody(variable.Arguments[0].(string), variable.Arguments[1].(string), body)
@@ -151,7 +267,7 @@ func (t *Templator) callHelper(variable models.Variable) interface{} {
 
 	defer func() {
 		if rec := recover(); rec != nil {
-			log.Error("panic occurred:", rec)
+			logger.Error("panic occurred:", rec)
 		}
 	}()
 	function := reflect.ValueOf(t.SupportedMethodMap[variable.Function])
@@ -1,6 +1,7 @@
 package templating_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/models"
@@ -259,6 +260,209 @@ State One: A
 State Two: B`))
 }
 
+func Test_ApplyTemplate_QueryParam(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Query: map[string][]string{
+			"page": {"2", "3"},
+		},
+	}, make(map[string]string), `page={{ Request.QueryParam "page" }} size={{ Request.QueryParam 'size' }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("page=2 size="))
+}
+
+func Test_ApplyTemplate_QueryParamCanBeUsedInSubExpressions(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Query: map[string][]string{
+			"page": {"2"},
+		},
+	}, make(map[string]string), `{{#if (Request.QueryParam "page") }}page {{ Request.QueryParam "page" }}{{/if}}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("page 2"))
+}
+
+func Test_ApplyTemplate_QueryParamStillRendersTheValuesByName(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Query: map[string][]string{
+			"page": {"2", "3"},
+		},
+	}, make(map[string]string), `{{ Request.QueryParam.page.[1] }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("3"))
+}
+
+func Test_ApplyTemplate_QueryParamCallIsNotRewrittenOutsideOfMustaches(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Query: map[string][]string{
+			"page": {"2"},
+		},
+	}, make(map[string]string), `Use Request.QueryParam "page" to get {{ Request.QueryParam "page" }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal(`Use Request.QueryParam "page" to get 2`))
+}
+
+func Test_ApplyTemplate_PathParam(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Path: "/api/users/123",
+	}, make(map[string]string), `id={{ Request.PathParam 2 }} missing={{ Request.PathParam 5 }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("id=123 missing="))
+}
+
+func Test_ApplyTemplate_RequestHeader(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Headers: map[string][]string{
+			"X-Request-Id": {"abc", "def"},
+		},
+	}, make(map[string]string), `id={{ Request.Header "X-Request-Id" }} lower={{ Request.Header 'x-request-id' }} missing={{ Request.Header "X-Missing" }} path={{ Request.Header.X-Request-Id.[1] }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("id=abc lower=abc missing= path=def"))
+}
+
+func Test_ApplyTemplate_RequestHeaderInSubExpression(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Headers: map[string][]string{
+			"X-Count": {"4"},
+		},
+	}, make(map[string]string), `{{ multiply (Request.Header "X-Count") 2 }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("8"))
+}
+
+func Test_ApplyTemplate_RequestHeaderCallIsNotRewrittenOutsideOfMustaches(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Headers: map[string][]string{
+			"X-Request-Id": {"abc"},
+		},
+	}, make(map[string]string), `Send Request.Header "X-Request-Id" or Request.Header 'X-Request-Id', got {{ Request.Header "X-Request-Id" }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal(`Send Request.Header "X-Request-Id" or Request.Header 'X-Request-Id', got abc`))
+}
+
+func Test_ApplyTemplate_EqHelper(t *testing.T) {
+	RegisterTestingT(t)
+
+	template := `{{#if (eq (Request.QueryParam "tier") "gold") }}premium{{else}}standard{{/if}}`
+
+	gold, err := ApplyTemplate(&models.RequestDetails{
+		Query: map[string][]string{"tier": {"gold"}},
+	}, make(map[string]string), template)
+	Expect(err).To(BeNil())
+	Expect(gold).To(Equal("premium"))
+
+	silver, err := ApplyTemplate(&models.RequestDetails{
+		Query: map[string][]string{"tier": {"silver"}},
+	}, make(map[string]string), template)
+	Expect(err).To(BeNil())
+	Expect(silver).To(Equal("standard"))
+
+	missing, err := ApplyTemplate(&models.RequestDetails{}, make(map[string]string), template)
+	Expect(err).To(BeNil())
+	Expect(missing).To(Equal("standard"))
+}
+
+func Test_ApplyTemplate_NeHelper(t *testing.T) {
+	RegisterTestingT(t)
+
+	template := `{{#if (ne Request.Method "GET") }}changed{{else}}unchanged{{/if}}`
+
+	post, err := ApplyTemplate(&models.RequestDetails{Method: "POST"}, make(map[string]string), template)
+	Expect(err).To(BeNil())
+	Expect(post).To(Equal("changed"))
+
+	get, err := ApplyTemplate(&models.RequestDetails{Method: "GET"}, make(map[string]string), template)
+	Expect(err).To(BeNil())
+	Expect(get).To(Equal("unchanged"))
+}
+
+func Test_ApplyTemplate_ContainsHelper(t *testing.T) {
+	RegisterTestingT(t)
+
+	template := `{{#if (contains (Request.Header "Accept") "json") }}json{{else}}text{{/if}} {{#if (contains Request.QueryParam.tag "b") }}tagged{{else}}untagged{{/if}}`
+
+	matching, err := ApplyTemplate(&models.RequestDetails{
+		Headers: map[string][]string{"Accept": {"application/json"}},
+		Query:   map[string][]string{"tag": {"a", "b"}},
+	}, make(map[string]string), template)
+	Expect(err).To(BeNil())
+	Expect(matching).To(Equal("json tagged"))
+
+	notMatching, err := ApplyTemplate(&models.RequestDetails{
+		Headers: map[string][]string{"Accept": {"text/plain"}},
+		Query:   map[string][]string{"tag": {"a", "bc"}},
+	}, make(map[string]string), template)
+	Expect(err).To(BeNil())
+	Expect(notMatching).To(Equal("text untagged"))
+}
+
+func Test_ApplyTemplate_ArithmeticHelpers(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Path: "/items/3",
+		Query: map[string][]string{
+			"qty": {"3"},
+		},
+	}, make(map[string]string), `{{ multiply (Request.PathParam 1) 10 }} {{ multiply Request.QueryParam.qty 1.5 }} {{ add 1 (divide 10 4) }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("30 4.5 3.5"))
+}
+
+func Test_ApplyTemplate_StateHelper(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{}, map[string]string{
+		"order:id": "123",
+	}, `{{ state 'order:id' '' }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("123"))
+}
+
+func Test_ApplyTemplate_StateHelper_ReturnsDefaultWhenKeyIsNotSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{}, map[string]string{}, `{{ state 'order:id' 'unknown' }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("unknown"))
+}
+
 func Test_ApplyTemplate_now(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -379,6 +583,18 @@ func Test_ApplyTemplate_randomUuid(t *testing.T) {
 	Expect(template).To(Not(Equal(ContainSubstring(`{{randomUuid}}`))))
 }
 
+func Test_ApplyTemplate_randomNameAndAddress(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{}, make(map[string]string), `{{randomFirstName}}|{{randomLastName}}|{{randomFullName}}|{{randomStreetAddress}}|{{randomCity}}|{{randomPhone}}`)
+
+	Expect(err).To(BeNil())
+
+	for _, value := range strings.Split(template, "|") {
+		Expect(value).ToNot(BeEmpty())
+	}
+}
+
 func Test_ApplyTemplate_Request_Body_Jsonpath(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -403,6 +619,80 @@ func Test_ApplyTemplate_Request_Body_JsonPath_Unescaped(t *testing.T) {
 	Expect(template).To(Equal("O'Reilly"))
 }
 
+func Test_ApplyTemplate_JsonPath_RequestBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Body: `{ "user": { "id": 123 } }`,
+	}, make(map[string]string), `{{ jsonPath Request.Body "$.user.id" }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("123"))
+}
+
+func Test_ApplyTemplate_JsonPath_ReturnsEmptyStringWhenPathDoesNotMatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Body: `{ "user": { "id": 123 } }`,
+	}, make(map[string]string), `id: {{ jsonPath Request.Body '$.user.name' }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("id: "))
+}
+
+func Test_ApplyTemplate_JsonPath_ReturnsEmptyStringWhenBodyIsNotJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Body: `not json`,
+	}, make(map[string]string), `id: {{ jsonPath Request.Body '$.user.id' }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("id: "))
+}
+
+func Test_ApplyTemplate_JsonPath_RequestRawBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Body: `{ "user": { "id": 123 } }`,
+	}, make(map[string]string), `{{ jsonPath Request.RawBody '$.user.id' }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("123"))
+}
+
+func Test_ApplyTemplate_JsonPath_RequestBodyInSubExpression(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Body: `{ "user": { "name": "ben" } }`,
+	}, make(map[string]string), `{{ replace (jsonPath Request.Body '$.user.name') 'ben' 'Ben' }} {{ Request.Body 'jsonpath' '$.user.name' }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("Ben ben"))
+}
+
+func Test_ApplyTemplate_JsonPath_CanBeAppliedToOtherValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	template, err := ApplyTemplate(&models.RequestDetails{
+		Query: map[string][]string{
+			"filter": {`{ "status": "open" }`},
+		},
+	}, map[string]string{"order": `{ "id": 7 }`}, `{{ jsonPath Request.QueryParam.filter '$.status' }} {{ jsonPath (state 'order' '') '$.id' }}`)
+
+	Expect(err).To(BeNil())
+
+	Expect(template).To(Equal("open 7"))
+}
+
 func Test_ApplyTemplate_ReplaceStringInQueryParams(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -442,6 +732,29 @@ func Test_VarSetToNilInCaseOfInvalidArgsPassed(t *testing.T) {
 
 }
 
+func Test_ApplyTemplate_RandomHelpersAreReproducibleWhenSeeded(t *testing.T) {
+	RegisterTestingT(t)
+	defer templating.SeedRandom(0)
+
+	body := `{{ randomString }} {{ randomInteger }} {{ randomFloat }} {{ randomEmail }} {{ randomFullName }} {{ randomUuid }} {{ faker 'Name' }}`
+
+	templating.SeedRandom(42)
+	first, err := ApplyTemplate(&models.RequestDetails{}, make(map[string]string), body)
+	Expect(err).To(BeNil())
+
+	templating.SeedRandom(42)
+	second, err := ApplyTemplate(&models.RequestDetails{}, make(map[string]string), body)
+	Expect(err).To(BeNil())
+
+	Expect(first).To(Equal(second))
+
+	templating.SeedRandom(43)
+	third, err := ApplyTemplate(&models.RequestDetails{}, make(map[string]string), body)
+	Expect(err).To(BeNil())
+
+	Expect(third).ToNot(Equal(first))
+}
+
 func ApplyTemplate(requestDetails *models.RequestDetails, state map[string]string, responseBody string) (string, error) {
 	templator := templating.NewTemplator()
 	template, _ := templator.ParseTemplate(responseBody)
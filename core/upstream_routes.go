@@ -0,0 +1,53 @@
+package hoverfly
+
+import (
+	"net/url"
+	"regexp"
+	"sync"
+)
+
+// UpstreamRoute - sends outbound requests whose host matches Pattern to Upstream instead
+type UpstreamRoute struct {
+	Pattern  *regexp.Regexp
+	Upstream *url.URL
+}
+
+// UpstreamRoutes - the upstream routes consulted, in the order they were added, whenever
+// Hoverfly makes a request to a real server
+type UpstreamRoutes struct {
+	routes []UpstreamRoute
+	mu     sync.RWMutex
+}
+
+func (this *UpstreamRoutes) Add(route UpstreamRoute) {
+	this.mu.Lock()
+	this.routes = append(this.routes, route)
+	this.mu.Unlock()
+}
+
+func (this *UpstreamRoutes) List() []UpstreamRoute {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	return append([]UpstreamRoute{}, this.routes...)
+}
+
+func (this *UpstreamRoutes) Clear() {
+	this.mu.Lock()
+	this.routes = nil
+	this.mu.Unlock()
+}
+
+// Find - returns the upstream of the first route matching the host, or nil if none match
+func (this *UpstreamRoutes) Find(host string) *url.URL {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	for _, route := range this.routes {
+		if route.Pattern.MatchString(host) {
+			return route.Upstream
+		}
+	}
+
+	return nil
+}
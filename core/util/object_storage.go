@@ -0,0 +1,207 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// IsObjectStorageURI - whether the uri points at an object in S3, eg. s3://bucket/simulation.json, or in
+// Google Cloud Storage, eg. gs://bucket/simulation.json
+func IsObjectStorageURI(uri string) bool {
+	return strings.HasPrefix(uri, "s3://") || strings.HasPrefix(uri, "gs://")
+}
+
+// FetchObject - downloads an object from S3 or Google Cloud Storage. Credentials are read from the environment:
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and, optionally, AWS_SESSION_TOKEN and AWS_REGION for S3, and
+// GOOGLE_OAUTH_ACCESS_TOKEN for Google Cloud Storage. AWS_ENDPOINT_URL_S3 and STORAGE_EMULATOR_HOST point the
+// requests at another endpoint, eg. a local emulator.
+//
+// The requests are made without the cloud SDKs, so only these static credentials are supported, not shared config
+// files, profiles, instance roles or refreshing tokens. S3 buckets are addressed as bucket.s3.region.amazonaws.com,
+// which the S3 certificate does not cover for bucket names containing dots, so those are rejected unless
+// AWS_ENDPOINT_URL_S3 is set, in which case the bucket is part of the path instead.
+func FetchObject(uri string) ([]byte, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Host == "" || strings.Trim(parsed.Path, "/") == "" {
+		return nil, fmt.Errorf("%s is not a valid object storage uri, eg. s3://bucket/simulation.json", uri)
+	}
+
+	bucket := parsed.Host
+	key := strings.TrimPrefix(parsed.Path, "/")
+
+	var request *http.Request
+	if parsed.Scheme == "s3" {
+		request, err = newS3Request(bucket, key)
+	} else {
+		request, err = newGCSRequest(bucket, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch %s, %s", uri, err.Error())
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch %s, error %s", uri, err.Error())
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch %s, error %s", uri, err.Error())
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to fetch %s, got status %d: %s", uri, response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+func newS3Request(bucket, key string) (*http.Request, error) {
+	accessKey := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set to import from S3")
+	}
+
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	endpoint := os.Getenv("AWS_ENDPOINT_URL_S3")
+	if endpoint == "" && strings.Contains(bucket, ".") {
+		return nil, fmt.Errorf("bucket names containing dots are not supported, set AWS_ENDPOINT_URL_S3 to https://s3.%s.amazonaws.com to use %s", region, bucket)
+	}
+
+	objectUrl := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, region)
+	if endpoint != "" {
+		objectUrl = fmt.Sprintf("%s/%s", strings.TrimSuffix(endpoint, "/"), bucket)
+	}
+
+	request, err := http.NewRequest(http.MethodGet, objectUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// the signature covers the path escaped as S3 escapes it, which is stricter than the default escaping
+	basePath := request.URL.EscapedPath()
+	request.URL.Path += "/" + key
+	request.URL.RawPath = basePath + "/" + escapeS3Key(key)
+
+	signS3Request(request, accessKey, secretKey, os.Getenv("AWS_SESSION_TOKEN"), region, time.Now())
+
+	return request, nil
+}
+
+// signS3Request - signs the request with AWS Signature Version 4, signing the host and every header already set
+func signS3Request(request *http.Request, accessKey, secretKey, sessionToken, region string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	if sessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	canonicalHeaders := ""
+	for _, name := range names {
+		canonicalHeaders += name + ":" + headers[name] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalRequestHash[:])
+
+	signingKey := []byte("AWS4" + secretKey)
+	for _, part := range []string{date, region, "s3", "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+// escapeS3Key - percent encodes every byte of the key except the unreserved characters and the slashes between
+// its segments, as the canonical request of AWS Signature Version 4 requires
+func escapeS3Key(key string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~/", c) >= 0 {
+			escaped.WriteByte(c)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", c)
+		}
+	}
+	return escaped.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func newGCSRequest(bucket, object string) (*http.Request, error) {
+	baseUrl := "https://storage.googleapis.com"
+	token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN")
+
+	if emulator := os.Getenv("STORAGE_EMULATOR_HOST"); emulator != "" {
+		baseUrl = strings.TrimSuffix(emulator, "/")
+		if !strings.Contains(baseUrl, "://") {
+			baseUrl = "http://" + baseUrl
+		}
+	} else if token == "" {
+		return nil, fmt.Errorf("GOOGLE_OAUTH_ACCESS_TOKEN must be set to import from Google Cloud Storage")
+	}
+
+	request, err := http.NewRequest(http.MethodGet,
+		fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media", baseUrl, url.PathEscape(bucket), url.PathEscape(object)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return request, nil
+}
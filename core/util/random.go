@@ -2,10 +2,11 @@ package util
 
 import (
 	"math/rand"
+	"sync"
 	"time"
 )
 
-var src = rand.NewSource(time.Now().UnixNano())
+var random = newLockedRand(time.Now().UnixNano())
 
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 const (
@@ -14,16 +15,46 @@ const (
 	letterIdxMax  = 63 / letterIdxBits   // # of letter indices fitting in 63 bits
 )
 
+// lockedSource - a rand.Source which is safe for concurrent use
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+func newLockedRand(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(seed)})
+}
+
+// SeedRandom - seeds the generator behind the random functions so that they return the same sequence of
+// values every time. A seed of 0 seeds it from the current time, which is the default.
+func SeedRandom(seed int64) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	random.Seed(seed)
+}
+
 func RandomString() string {
-	rand.Seed(time.Now().UnixNano())
 	return RandomStringWithLength(RandomIntegerRange(3, 16))
 }
 
 func RandomStringWithLength(length int) string {
 	b := make([]byte, length)
-	for i, cache, remain := length-1, src.Int63(), letterIdxMax; i >= 0; {
+	for i, cache, remain := length-1, random.Int63(), letterIdxMax; i >= 0; {
 		if remain == 0 {
-			cache, remain = src.Int63(), letterIdxMax
+			cache, remain = random.Int63(), letterIdxMax
 		}
 		if idx := int(cache & letterIdxMask); idx < len(letterBytes) {
 			b[i] = letterBytes[idx]
@@ -37,26 +68,30 @@ func RandomStringWithLength(length int) string {
 }
 
 func RandomInteger() int {
-	rand.Seed(time.Now().UnixNano())
-	return rand.Int()
+	return random.Int()
 }
 
 func RandomIntegerRange(min, max int) int {
-	rand.Seed(time.Now().UnixNano())
-	return rand.Intn(max-min) + min
+	return random.Intn(max-min) + min
 }
 
 func RandomFloat() float64 {
-	rand.Seed(time.Now().UnixNano())
-	return rand.Float64()
+	return random.Float64()
 }
 
 func RandomFloatRange(min, max float64) float64 {
-	rand.Seed(time.Now().UnixNano())
-	return min + rand.Float64()*(max-min)
+	return min + random.Float64()*(max-min)
 }
 
 func RandomBoolean() bool {
-	cache := src.Int63()
+	cache := random.Int63()
 	return cache&0x01 == 1
 }
+
+// RandomBytes - fills p with bytes from the same generator as the other random functions
+func RandomBytes(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(random.Int63())
+	}
+	return len(p), nil
+}
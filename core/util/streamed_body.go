@@ -0,0 +1,77 @@
+package util
+
+import (
+	"bytes"
+	"io"
+)
+
+// StreamedBody is a response body which is sent on to the client as it is read, rather than being read into
+// memory first. Up to maxSize bytes of it are kept, all of it when maxSize is 0, and the functions given to
+// OnComplete are called with what was kept once the whole body has been read. They are not called when the
+// body is closed before then.
+type StreamedBody struct {
+	body       io.ReadCloser
+	remaining  int64
+	maxSize    int
+	kept       bytes.Buffer
+	truncated  bool
+	completed  bool
+	onComplete []func(body []byte, truncated bool)
+}
+
+// NewStreamedBody wraps a response body. contentLength is the length of the body, or -1 when it is not known,
+// which lets the body complete as soon as the last byte is read rather than when the next read returns EOF.
+func NewStreamedBody(body io.ReadCloser, contentLength int64, maxSize int) *StreamedBody {
+	return &StreamedBody{
+		body:      body,
+		remaining: contentLength,
+		maxSize:   maxSize,
+	}
+}
+
+// OnComplete adds a function which is called once the whole body has been read
+func (this *StreamedBody) OnComplete(onComplete func(body []byte, truncated bool)) {
+	this.onComplete = append(this.onComplete, onComplete)
+}
+
+func (this *StreamedBody) Read(p []byte) (int, error) {
+	n, err := this.body.Read(p)
+	this.keep(p[:n])
+
+	if this.remaining >= 0 {
+		this.remaining -= int64(n)
+		if this.remaining <= 0 && (err == nil || err == io.EOF) {
+			this.complete()
+		}
+	}
+
+	if err == io.EOF {
+		this.complete()
+	}
+
+	return n, err
+}
+
+func (this *StreamedBody) Close() error {
+	return this.body.Close()
+}
+
+func (this *StreamedBody) keep(read []byte) {
+	if this.maxSize > 0 && this.kept.Len()+len(read) > this.maxSize {
+		this.kept.Write(read[:this.maxSize-this.kept.Len()])
+		this.truncated = true
+		return
+	}
+	this.kept.Write(read)
+}
+
+func (this *StreamedBody) complete() {
+	if this.completed {
+		return
+	}
+	this.completed = true
+
+	for _, onComplete := range this.onComplete {
+		onComplete(this.kept.Bytes(), this.truncated)
+	}
+}
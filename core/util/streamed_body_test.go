@@ -0,0 +1,79 @@
+package util
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_StreamedBody_KeepsTheWholeBodyWithoutAMaxSize(t *testing.T) {
+	RegisterTestingT(t)
+
+	var kept string
+	unit := NewStreamedBody(ioutil.NopCloser(strings.NewReader("streamed body")), -1, 0)
+	unit.OnComplete(func(body []byte, truncated bool) {
+		kept = string(body)
+		Expect(truncated).To(BeFalse())
+	})
+
+	read, err := ioutil.ReadAll(unit)
+	Expect(err).To(BeNil())
+
+	Expect(string(read)).To(Equal("streamed body"))
+	Expect(kept).To(Equal("streamed body"))
+}
+
+func Test_StreamedBody_KeepsUpToTheMaxSize(t *testing.T) {
+	RegisterTestingT(t)
+
+	var kept string
+	var wasTruncated bool
+	unit := NewStreamedBody(ioutil.NopCloser(strings.NewReader(strings.Repeat("a", 100000))), -1, 1024)
+	unit.OnComplete(func(body []byte, truncated bool) {
+		kept = string(body)
+		wasTruncated = truncated
+	})
+
+	read, err := ioutil.ReadAll(unit)
+	Expect(err).To(BeNil())
+
+	Expect(read).To(HaveLen(100000))
+	Expect(kept).To(Equal(strings.Repeat("a", 1024)))
+	Expect(wasTruncated).To(BeTrue())
+}
+
+func Test_StreamedBody_CompletesWhenTheContentLengthHasBeenRead(t *testing.T) {
+	RegisterTestingT(t)
+
+	completed := false
+	unit := NewStreamedBody(ioutil.NopCloser(bytes.NewBufferString("test")), 4, 0)
+	unit.OnComplete(func(body []byte, truncated bool) {
+		completed = true
+	})
+
+	read := make([]byte, 4)
+	n, err := unit.Read(read)
+
+	Expect(err).To(BeNil())
+	Expect(n).To(Equal(4))
+	Expect(completed).To(BeTrue())
+}
+
+func Test_StreamedBody_DoesNotCompleteWhenClosedBeforeItWasRead(t *testing.T) {
+	RegisterTestingT(t)
+
+	completed := false
+	unit := NewStreamedBody(ioutil.NopCloser(strings.NewReader("streamed body")), -1, 0)
+	unit.OnComplete(func(body []byte, truncated bool) {
+		completed = true
+	})
+
+	_, err := unit.Read(make([]byte, 4))
+	Expect(err).To(BeNil())
+	Expect(unit.Close()).To(BeNil())
+
+	Expect(completed).To(BeFalse())
+}
@@ -0,0 +1,171 @@
+package hoverfly
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/SpectoLabs/hoverfly/core/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// isWebSocketUpgrade - checks whether the request is the handshake which upgrades a connection to WebSocket
+func isWebSocketUpgrade(r *http.Request) bool {
+	return headerContainsToken(r.Header, "Connection", "upgrade") &&
+		headerContainsToken(r.Header, "Upgrade", "websocket")
+}
+
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header[http.CanonicalHeaderKey(name)] {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withWebSocketPassthrough - passes WebSocket connections made through the proxy over plain HTTP straight to the
+// destination, whatever the mode. Only the handshake is recorded in capture mode, the frames which follow are not.
+func withWebSocketPassthrough(hf *Hoverfly, proxy http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodConnect || !r.URL.IsAbs() || !isWebSocketUpgrade(r) {
+			proxy.ServeHTTP(w, r)
+			return
+		}
+
+		if hf.Cfg.AuthEnabled {
+			if err := authFromHeader(r, hf.isValidProxyUser, hf.isValidProxyToken); err != nil {
+				http.Error(w, err.Error(), http.StatusProxyAuthRequired)
+				return
+			}
+		}
+
+		hf.passthroughWebSocket(w, r)
+	})
+}
+
+func (hf *Hoverfly) passthroughWebSocket(w http.ResponseWriter, r *http.Request) {
+	host := r.URL.Host
+	if r.URL.Port() == "" {
+		port := "80"
+		if r.URL.Scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(r.URL.Hostname(), port)
+	}
+
+	var upstream net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	if r.URL.Scheme == "https" {
+		upstream, err = tls.DialWithDialer(dialer, "tcp", host, &tls.Config{
+			ServerName:         r.URL.Hostname(),
+			InsecureSkipVerify: !hf.Cfg.TLSVerification,
+			RootCAs:            hf.Cfg.UpstreamRootCAs,
+		})
+	} else {
+		upstream, err = dialer.Dial("tcp", host)
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error":       err.Error(),
+			"destination": host,
+		}).Error("Could not connect to destination for WebSocket passthrough")
+		http.Error(w, fmt.Sprintf("Hoverfly could not connect to %s: %s", host, err.Error()), http.StatusBadGateway)
+		return
+	}
+	defer upstream.Close()
+
+	r.Header.Del("Proxy-Connection")
+	r.Header.Del("Proxy-Authorization")
+	if err := r.Write(upstream); err != nil {
+		http.Error(w, fmt.Sprintf("Hoverfly could not send the WebSocket handshake: %s", err.Error()), http.StatusBadGateway)
+		return
+	}
+
+	upstreamReader := bufio.NewReader(upstream)
+	resp, err := http.ReadResponse(upstreamReader, r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Hoverfly could not read the WebSocket handshake response: %s", err.Error()), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "Hoverfly cannot pass through WebSocket connections here", http.StatusInternalServerError)
+		return
+	}
+	client, clientBuffer, err := hijacker.Hijack()
+	if err != nil {
+		log.WithField("error", err.Error()).Error("Could not take over connection for WebSocket passthrough")
+		return
+	}
+	defer client.Close()
+
+	if matchesFilter(hf.Cfg.Destination)(r, nil) {
+		hf.recordWebSocketHandshake(r, resp)
+	}
+
+	if err := resp.Write(client); err != nil || resp.StatusCode != http.StatusSwitchingProtocols {
+		return
+	}
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(upstream, clientBuffer)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(client, upstreamReader)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// recordWebSocketHandshake - saves the handshake in capture mode, so that it can be seen in the simulation
+func (hf *Hoverfly) recordWebSocketHandshake(r *http.Request, resp *http.Response) {
+	if hf.Cfg.GetMode() != modes.Capture {
+		return
+	}
+
+	requestDetails, err := models.NewRequestDetailsFromHttpRequest(r)
+	if err != nil {
+		log.WithField("error", err.Error()).Error("Could not record WebSocket handshake")
+		return
+	}
+
+	captureMode, ok := hf.modeMap[modes.Capture].(*modes.CaptureMode)
+	if !ok {
+		return
+	}
+	arguments := captureMode.Arguments
+	if arguments.Headers == nil {
+		arguments.Headers = []string{}
+	}
+
+	responseDetails := &models.ResponseDetails{
+		Status:     resp.StatusCode,
+		Headers:    util.GetResponseHeaders(resp),
+		CapturedAt: time.Now().UTC(),
+	}
+
+	if err := hf.Save(&requestDetails, responseDetails, &arguments); err != nil {
+		log.WithField("error", err.Error()).Error("Could not record WebSocket handshake")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"mode":    modes.Capture,
+		"request": modes.GetRequestLogFields(&requestDetails),
+	}).Info("WebSocket handshake captured, frames are passed through without being captured")
+}
@@ -0,0 +1,155 @@
+package hoverfly
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/gomega"
+)
+
+func newWebSocketEchoServer() *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			messageType, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.WriteMessage(messageType, message)
+		}
+	}))
+}
+
+// writeWebSocketTextFrame writes a short masked text frame, as a client must
+func writeWebSocketTextFrame(w io.Writer, message string) error {
+	mask := []byte{1, 2, 3, 4}
+	frame := []byte{0x81, 0x80 | byte(len(message))}
+	frame = append(frame, mask...)
+	for i := 0; i < len(message); i++ {
+		frame = append(frame, message[i]^mask[i%4])
+	}
+	_, err := w.Write(frame)
+	return err
+}
+
+// readWebSocketTextFrame reads a short unmasked text frame, as sent by a server
+func readWebSocketTextFrame(r io.Reader) (string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return "", err
+	}
+	payload := make([]byte, header[1]&0x7f)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+func Test_withWebSocketPassthrough_PassesWebSocketConnectionsThroughAndCapturesTheHandshake(t *testing.T) {
+	RegisterTestingT(t)
+
+	upstream := newWebSocketEchoServer()
+	defer upstream.Close()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.SetMode("capture")
+
+	proxy := httptest.NewServer(withWebSocketPassthrough(unit, http.NotFoundHandler()))
+	defer proxy.Close()
+
+	conn, err := net.Dial("tcp", proxy.Listener.Addr().String())
+	Expect(err).To(BeNil())
+	defer conn.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, upstream.URL+"/socket", nil)
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Sec-WebSocket-Version", "13")
+	request.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	Expect(request.WriteProxy(conn)).To(Succeed())
+
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, request)
+	Expect(err).To(BeNil())
+	Expect(response.StatusCode).To(Equal(http.StatusSwitchingProtocols))
+	Expect(response.Header.Get("Sec-WebSocket-Accept")).To(Equal("s3pPLMBiTxaQ9kYGzzhZRbK+xOo="))
+
+	Expect(writeWebSocketTextFrame(conn, "hello")).To(Succeed())
+	message, err := readWebSocketTextFrame(reader)
+	Expect(err).To(BeNil())
+	Expect(message).To(Equal("hello"))
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].RequestMatcher.Path[0].Value).To(Equal("/socket"))
+	Expect(pairs[0].Response.Status).To(Equal(http.StatusSwitchingProtocols))
+}
+
+func Test_withWebSocketPassthrough_DoesNotCaptureOutsideCaptureMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	upstream := newWebSocketEchoServer()
+	defer upstream.Close()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.SetMode("simulate")
+
+	proxy := httptest.NewServer(withWebSocketPassthrough(unit, http.NotFoundHandler()))
+	defer proxy.Close()
+
+	conn, err := net.Dial("tcp", proxy.Listener.Addr().String())
+	Expect(err).To(BeNil())
+	defer conn.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, upstream.URL+"/socket", nil)
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Sec-WebSocket-Version", "13")
+	request.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	Expect(request.WriteProxy(conn)).To(Succeed())
+
+	response, err := http.ReadResponse(bufio.NewReader(conn), request)
+	Expect(err).To(BeNil())
+	Expect(response.StatusCode).To(Equal(http.StatusSwitchingProtocols))
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(BeEmpty())
+}
+
+func Test_withWebSocketPassthrough_HandsOtherRequestsToTheProxy(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	proxy := httptest.NewServer(withWebSocketPassthrough(unit, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})))
+	defer proxy.Close()
+
+	response, err := http.Get(proxy.URL)
+	Expect(err).To(BeNil())
+	Expect(response.StatusCode).To(Equal(http.StatusTeapot))
+}
+
+func Test_isWebSocketUpgrade(t *testing.T) {
+	RegisterTestingT(t)
+
+	request, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
+	Expect(isWebSocketUpgrade(request)).To(BeFalse())
+
+	request.Header.Set("Connection", "keep-alive, Upgrade")
+	request.Header.Set("Upgrade", "WebSocket")
+	Expect(isWebSocketUpgrade(request)).To(BeTrue())
+
+	request.Header.Set("Upgrade", "h2c")
+	Expect(isWebSocketUpgrade(request)).To(BeFalse())
+}
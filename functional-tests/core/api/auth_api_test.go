@@ -1,6 +1,10 @@
 package api_test
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"time"
+
 	"github.com/SpectoLabs/hoverfly/core/authentication/backends"
 	"github.com/SpectoLabs/hoverfly/functional-tests"
 	"github.com/dghubble/sling"
@@ -120,4 +124,78 @@ var _ = Describe("When I run Hoverfly with auth", func() {
 		})
 
 	})
+
+	Context("Using a configured failed login limit and lockout", func() {
+
+		BeforeEach(func() {
+			hoverfly.Start("-auth", "-username", username, "-password", password, "-failed-login-limit", "1", "-failed-login-lockout", "1s")
+		})
+
+		AfterEach(func() {
+			hoverfly.Stop()
+		})
+
+		It("should lock out the username after the limit, until the lockout has passed", func() {
+			response := functional_tests.DoRequest(sling.New().Post("http://localhost:" + hoverfly.GetAdminPort() + "/api/token-auth").BodyJSON(backends.User{
+				Username: username,
+				Password: "wfewrrw",
+			}))
+			Expect(response.StatusCode).To(Equal(401))
+
+			login := sling.New().Post("http://localhost:" + hoverfly.GetAdminPort() + "/api/token-auth").BodyJSON(backends.User{
+				Username: username,
+				Password: password,
+			})
+
+			response = functional_tests.DoRequest(login)
+			Expect(response.StatusCode).To(Equal(429))
+			Expect(response.Header.Get("Retry-After")).To(Equal("1"))
+
+			time.Sleep(1500 * time.Millisecond)
+
+			response = functional_tests.DoRequest(login)
+			Expect(response.StatusCode).To(Equal(200))
+		})
+	})
+
+	Context("Using a short token expiration", func() {
+
+		BeforeEach(func() {
+			hoverfly.Start("-auth", "-username", username, "-password", password, "-token-expiration", "2s", "-token-refresh-window", "1m")
+		})
+
+		AfterEach(func() {
+			hoverfly.Stop()
+		})
+
+		getToken := func(response []byte) string {
+			var token struct {
+				Token string `json:"token"`
+			}
+			Expect(json.Unmarshal(response, &token)).To(Succeed())
+			return token.Token
+		}
+
+		It("should expire tokens, which can be refreshed within the refresh window", func() {
+			response := functional_tests.DoRequest(sling.New().Post("http://localhost:" + hoverfly.GetAdminPort() + "/api/token-auth").BodyJSON(backends.User{
+				Username: username,
+				Password: password,
+			}))
+			Expect(response.StatusCode).To(Equal(200))
+			body, _ := ioutil.ReadAll(response.Body)
+			token := getToken(body)
+
+			time.Sleep(3 * time.Second)
+
+			response = functional_tests.DoRequest(sling.New().Get("http://localhost:"+hoverfly.GetAdminPort()+"/api/v2/hoverfly").Set("Authorization", "Bearer "+token))
+			Expect(response.StatusCode).To(Equal(401))
+
+			response = functional_tests.DoRequest(sling.New().Get("http://localhost:"+hoverfly.GetAdminPort()+"/api/refresh-token-auth").Set("Authorization", "Bearer "+token))
+			Expect(response.StatusCode).To(Equal(200))
+			body, _ = ioutil.ReadAll(response.Body)
+
+			response = functional_tests.DoRequest(sling.New().Get("http://localhost:"+hoverfly.GetAdminPort()+"/api/v2/hoverfly").Set("Authorization", "Bearer "+getToken(body)))
+			Expect(response.StatusCode).To(Equal(200))
+		})
+	})
 })
@@ -57,7 +57,7 @@ var _ = Describe("/api/v2/cache", func() {
 
 			Expect(cacheView.Cache).To(HaveLen(1))
 
-			Expect(cacheView.Cache[0].Key).To(Equal("0dd6716f7e5f5f06067de145a2933b2d"))
+			Expect(cacheView.Cache[0].Key).To(Equal("32e95b023f05084fbf8540d8d80dbf37"))
 			Expect(cacheView.Cache[0].MatchingPair).To(BeNil())
 			Expect(cacheView.Cache[0].ClosestMiss).ToNot(BeNil())
 
@@ -79,7 +79,7 @@ var _ = Describe("/api/v2/cache", func() {
 
 			Expect(cacheView.Cache).To(HaveLen(1))
 
-			Expect(cacheView.Cache[0].Key).To(Equal("0dd6716f7e5f5f06067de145a2933b2d"))
+			Expect(cacheView.Cache[0].Key).To(Equal("32e95b023f05084fbf8540d8d80dbf37"))
 			Expect(cacheView.Cache[0].MatchingPair).To(BeNil())
 			Expect(cacheView.Cache[0].ClosestMiss).To(BeNil())
 		})
@@ -109,7 +109,7 @@ var _ = Describe("/api/v2/cache", func() {
 			cacheView := hoverfly.GetCache()
 
 			Expect(cacheView.Cache).To(HaveLen(1))
-			Expect(cacheView.Cache[0].Key).To(Equal("0dd6716f7e5f5f06067de145a2933b2d"))
+			Expect(cacheView.Cache[0].Key).To(Equal("32e95b023f05084fbf8540d8d80dbf37"))
 		})
 	})
 
@@ -103,6 +103,54 @@ var _ = Describe("/api/v2/hoverfly/diff", func() {
 		})
 	})
 
+	Context("with a baseline captured in capture mode", func() {
+
+		It("Should compare each request with its own baseline response", func() {
+			users := `{"name": "Benjy", "role": "admin"}`
+			fakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/users" {
+					w.Write([]byte(users))
+				} else {
+					w.Write([]byte(`{"status": "ok"}`))
+				}
+			}))
+
+			defer fakeServer.Close()
+
+			resp := hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/users"))
+			Expect(resp.StatusCode).To(Equal(200))
+			resp = hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/health"))
+			Expect(resp.StatusCode).To(Equal(200))
+
+			users = `{"name": "Benjy", "role": "owner"}`
+			hoverfly.SetModeWithArgs("diff", v2.ModeArgumentsView{
+				Headers: []string{"Date"},
+			})
+
+			resp = hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/users"))
+			Expect(resp.StatusCode).To(Equal(200))
+			resp = hoverfly.Proxy(sling.New().Get(fakeServer.URL + "/health"))
+			Expect(resp.StatusCode).To(Equal(200))
+
+			req := sling.New().Get("http://localhost:" + hoverfly.GetAdminPort() + "/api/v2/diff")
+			res := functional_tests.DoRequest(req)
+			Expect(res.StatusCode).To(Equal(200))
+
+			var diffs v2.DiffView
+			functional_tests.UnmarshalFromResponse(res, &diffs)
+
+			Expect(diffs.Diff).To(HaveLen(1))
+			Expect(diffs.Diff[0].Request.Path).To(Equal("/users"))
+			Expect(diffs.Diff[0].DiffReport).To(HaveLen(1))
+			Expect(diffs.Diff[0].DiffReport[0].DiffEntries).To(ConsistOf(v2.DiffReportEntry{
+				Field:    "body/role",
+				Expected: "admin",
+				Actual:   "owner",
+			}))
+		})
+	})
+
 	Context("DELETE", func() {
 
 		It("Should delete diffs", func() {
@@ -37,7 +37,7 @@ var _ = Describe("/api/v2/hoverfly", func() {
 			Expect(hoverflyJson).To(MatchRegexp(`"cors":{"enabled":false}`))
 			Expect(hoverflyJson).To(MatchRegexp(`"destination":"."`))
 			Expect(hoverflyJson).To(MatchRegexp(`"middleware":{"binary":"","script":"","remote":""}`))
-			Expect(hoverflyJson).To(MatchRegexp(`"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":0,"spy":0,"synthesize":0}}`))
+			Expect(hoverflyJson).To(MatchRegexp(`"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":0,"spy":0,"synthesize":0},"matching":{"cacheHits":0,"cacheMisses":0,"matcherHits":0,"misses":0}}`))
 			Expect(hoverflyJson).To(MatchRegexp(`"version":"v\d+.\d+.\d+(-rc.\d)*"`))
 			Expect(hoverflyJson).To(MatchRegexp(`"upstreamProxy":""`))
 			Expect(hoverflyJson).To(MatchRegexp(`"mode":"simulate","arguments":{"matchingStrategy":"strongest"}`))
@@ -32,7 +32,7 @@ var _ = Describe("/api/v2/hoverfly/usage", func() {
 			Expect(res.StatusCode).To(Equal(200))
 			modeJson, err := ioutil.ReadAll(res.Body)
 			Expect(err).To(BeNil())
-			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":0,"spy":0,"synthesize":0}}}`)))
+			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":0,"spy":0,"synthesize":0},"matching":{"cacheHits":0,"cacheMisses":0,"matcherHits":0,"misses":0}}}`)))
 		})
 
 		It("Should get the usage counters with 1 simulate request when a request has been made", func() {
@@ -43,7 +43,7 @@ var _ = Describe("/api/v2/hoverfly/usage", func() {
 			Expect(res.StatusCode).To(Equal(200))
 			modeJson, err := ioutil.ReadAll(res.Body)
 			Expect(err).To(BeNil())
-			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":1,"spy":0,"synthesize":0}}}`)))
+			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":1,"spy":0,"synthesize":0},"matching":{"cacheHits":0,"cacheMisses":1,"matcherHits":0,"misses":1}}}`)))
 		})
 
 		It("Should get the usage counters with 1 capture request when a request has been made", func() {
@@ -56,7 +56,7 @@ var _ = Describe("/api/v2/hoverfly/usage", func() {
 			Expect(res.StatusCode).To(Equal(200))
 			modeJson, err := ioutil.ReadAll(res.Body)
 			Expect(err).To(BeNil())
-			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":1,"diff":0,"modify":0,"simulate":0,"spy":0,"synthesize":0}}}`)))
+			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":1,"diff":0,"modify":0,"simulate":0,"spy":0,"synthesize":0},"matching":{"cacheHits":0,"cacheMisses":0,"matcherHits":0,"misses":0}}}`)))
 		})
 
 		It("Should get the usage counters with 1 modify request when a request has been made", func() {
@@ -69,7 +69,7 @@ var _ = Describe("/api/v2/hoverfly/usage", func() {
 			Expect(res.StatusCode).To(Equal(200))
 			modeJson, err := ioutil.ReadAll(res.Body)
 			Expect(err).To(BeNil())
-			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":1,"simulate":0,"spy":0,"synthesize":0}}}`)))
+			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":1,"simulate":0,"spy":0,"synthesize":0},"matching":{"cacheHits":0,"cacheMisses":0,"matcherHits":0,"misses":0}}}`)))
 		})
 
 		It("Should get the usage counters with 1 modify request when a request has been made", func() {
@@ -82,7 +82,7 @@ var _ = Describe("/api/v2/hoverfly/usage", func() {
 			Expect(res.StatusCode).To(Equal(200))
 			modeJson, err := ioutil.ReadAll(res.Body)
 			Expect(err).To(BeNil())
-			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":0,"spy":0,"synthesize":1}}}`)))
+			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":0,"spy":0,"synthesize":1},"matching":{"cacheHits":0,"cacheMisses":0,"matcherHits":0,"misses":0}}}`)))
 		})
 
 		It("Should get the usage counters with 1 spy request when a request has been made", func() {
@@ -95,7 +95,7 @@ var _ = Describe("/api/v2/hoverfly/usage", func() {
 			Expect(res.StatusCode).To(Equal(200))
 			modeJson, err := ioutil.ReadAll(res.Body)
 			Expect(err).To(BeNil())
-			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":0,"spy":1,"synthesize":0}}}`)))
+			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":0,"spy":1,"synthesize":0},"matching":{"cacheHits":0,"cacheMisses":1,"matcherHits":0,"misses":1}}}`)))
 		})
 
 		It("Should get the usage counters with 1 diff request when a request has been made", func() {
@@ -108,7 +108,7 @@ var _ = Describe("/api/v2/hoverfly/usage", func() {
 			Expect(res.StatusCode).To(Equal(200))
 			modeJson, err := ioutil.ReadAll(res.Body)
 			Expect(err).To(BeNil())
-			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":1,"modify":0,"simulate":0,"spy":0,"synthesize":0}}}`)))
+			Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":1,"modify":0,"simulate":0,"spy":0,"synthesize":0},"matching":{"cacheHits":0,"cacheMisses":1,"matcherHits":0,"misses":1}}}`)))
 		})
 	})
 })
@@ -95,7 +95,13 @@ var _ = Describe("When I run Hoverfly", func() {
 					},
 				}))
 
-				Expect(payload.RequestResponsePairs[0].Response).To(Equal(v2.ResponseDetailsViewV5{
+				response := payload.RequestResponsePairs[0].Response
+				// the capture time and latency differ on every run
+				Expect(response.CapturedAt).ToNot(BeEmpty())
+				response.CapturedAt = ""
+				response.LatencyMs = 0
+
+				Expect(response).To(Equal(v2.ResponseDetailsViewV5{
 					Status:      200,
 					Body:        "Hello world",
 					EncodedBody: false,
@@ -502,4 +508,257 @@ var _ = Describe("When I run Hoverfly", func() {
 			Expect(payload.RequestResponsePairs[2].Response.TransitionsState).To(BeNil())
 		})
 	})
+
+	Context("When running in capture mode with a max body size", func() {
+
+		var fakeServer *httptest.Server
+
+		BeforeEach(func() {
+			fakeServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.Write([]byte(strings.Repeat("a", 2048)))
+			}))
+		})
+
+		AfterEach(func() {
+			fakeServer.Close()
+		})
+
+		It("Should truncate a larger response body and flag the pair", func() {
+			hoverfly.SetModeWithArgs("capture", v2.ModeArgumentsView{
+				MaxBodySize: 1024,
+			})
+
+			resp := hoverfly.Proxy(sling.New().Get(fakeServer.URL))
+			Expect(resp.StatusCode).To(Equal(200))
+
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+			Expect(body).To(HaveLen(2048))
+
+			payload := hoverfly.ExportSimulation()
+
+			Expect(payload.RequestResponsePairs).To(HaveLen(1))
+			Expect(payload.RequestResponsePairs[0].Response.Body).To(Equal(strings.Repeat("a", 1024)))
+			Expect(payload.RequestResponsePairs[0].Response.BodyTruncated).To(BeTrue())
+		})
+
+		It("Should not capture a larger response body when oversized bodies are skipped", func() {
+			hoverfly.SetModeWithArgs("capture", v2.ModeArgumentsView{
+				MaxBodySize:     1024,
+				OversizedBodies: "skip",
+			})
+
+			resp := hoverfly.Proxy(sling.New().Get(fakeServer.URL))
+			Expect(resp.StatusCode).To(Equal(200))
+
+			payload := hoverfly.ExportSimulation()
+
+			Expect(payload.RequestResponsePairs).To(HaveLen(0))
+		})
+	})
+
+	Context("When running in capture mode with a large chunked response", func() {
+
+		var fakeServer *httptest.Server
+
+		BeforeEach(func() {
+			fakeServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				for i := 0; i < 1024; i++ {
+					w.Write([]byte(strings.Repeat("a", 4096)))
+					w.(http.Flusher).Flush()
+				}
+			}))
+		})
+
+		AfterEach(func() {
+			fakeServer.Close()
+		})
+
+		It("Should stream the response and capture the whole body", func() {
+			hoverfly.SetMode("capture")
+
+			resp := hoverfly.Proxy(sling.New().Get(fakeServer.URL))
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(resp.TransferEncoding).To(Equal([]string{"chunked"}))
+
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+			Expect(body).To(HaveLen(4 * 1024 * 1024))
+
+			payload := hoverfly.ExportSimulation()
+
+			Expect(payload.RequestResponsePairs).To(HaveLen(1))
+			Expect(payload.RequestResponsePairs[0].Response.Body).To(HaveLen(4 * 1024 * 1024))
+			Expect(payload.RequestResponsePairs[0].Response.BodyTruncated).To(BeFalse())
+		})
+
+		It("Should stream the response and capture the body up to the max body size", func() {
+			hoverfly.SetModeWithArgs("capture", v2.ModeArgumentsView{
+				MaxBodySize: 1024,
+			})
+
+			resp := hoverfly.Proxy(sling.New().Get(fakeServer.URL))
+			Expect(resp.StatusCode).To(Equal(200))
+
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+			Expect(body).To(HaveLen(4 * 1024 * 1024))
+
+			payload := hoverfly.ExportSimulation()
+
+			Expect(payload.RequestResponsePairs).To(HaveLen(1))
+			Expect(payload.RequestResponsePairs[0].Response.Body).To(Equal(strings.Repeat("a", 1024)))
+			Expect(payload.RequestResponsePairs[0].Response.BodyTruncated).To(BeTrue())
+		})
+
+		It("Should pass the response through without capturing it when oversized bodies are skipped", func() {
+			hoverfly.SetModeWithArgs("capture", v2.ModeArgumentsView{
+				MaxBodySize:     1024,
+				OversizedBodies: "skip",
+			})
+
+			resp := hoverfly.Proxy(sling.New().Get(fakeServer.URL))
+			Expect(resp.StatusCode).To(Equal(200))
+
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+			Expect(body).To(HaveLen(4 * 1024 * 1024))
+
+			payload := hoverfly.ExportSimulation()
+
+			Expect(payload.RequestResponsePairs).To(HaveLen(0))
+		})
+	})
+
+	Context("When running in capture mode with retries", func() {
+
+		var (
+			fakeServer *httptest.Server
+			requests   int
+		)
+
+		BeforeEach(func() {
+			requests = 0
+			// a flaky destination which drops the connection of the first request without responding
+			fakeServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests == 1 {
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+				w.Write([]byte("Hello world"))
+			}))
+		})
+
+		AfterEach(func() {
+			fakeServer.Close()
+		})
+
+		It("Should retry a request after the connection is dropped and capture the response", func() {
+			hoverfly.SetModeWithArgs("capture", v2.ModeArgumentsView{
+				Retries:    2,
+				RetryDelay: 10,
+			})
+
+			resp := hoverfly.Proxy(sling.New().Get(fakeServer.URL))
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(requests).To(Equal(2))
+
+			payload := hoverfly.ExportSimulation()
+
+			Expect(payload.RequestResponsePairs).To(HaveLen(1))
+			Expect(payload.RequestResponsePairs[0].Response.Body).To(Equal("Hello world"))
+		})
+
+		It("Should not retry a POST request by default", func() {
+			hoverfly.SetModeWithArgs("capture", v2.ModeArgumentsView{
+				Retries: 2,
+			})
+
+			resp := hoverfly.Proxy(sling.New().Post(fakeServer.URL).Body(bytes.NewBuffer([]byte("body"))))
+			Expect(resp.StatusCode).To(Equal(http.StatusBadGateway))
+			Expect(requests).To(Equal(1))
+
+			Expect(hoverfly.ExportSimulation().RequestResponsePairs).To(HaveLen(0))
+		})
+	})
+
+	Context("When running in capture mode with redaction", func() {
+
+		BeforeEach(func() {
+			hoverfly.SetModeWithArgs("capture", v2.ModeArgumentsView{
+				Headers:           []string{"*"},
+				RedactedHeaders:   []string{"Authorization"},
+				RedactedBodyPaths: []string{"$.password"},
+			})
+		})
+
+		It("Should not store the redacted values", func() {
+			fakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"token":"abc","password":"hunter2"}`))
+			}))
+			defer fakeServer.Close()
+
+			resp := hoverfly.Proxy(sling.New().Post(fakeServer.URL).
+				Set("Authorization", "Bearer abc").
+				Set("Content-Type", "application/json").
+				Body(strings.NewReader(`{"username":"bob","password":"hunter2"}`)))
+			Expect(resp.StatusCode).To(Equal(200))
+
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(Equal(`{"token":"abc","password":"hunter2"}`))
+
+			payload := hoverfly.ExportSimulation()
+
+			Expect(payload.RequestResponsePairs).To(HaveLen(1))
+			Expect(payload.RequestResponsePairs[0].RequestMatcher.Headers["Authorization"][0].Value).To(Equal("REDACTED"))
+			Expect(payload.RequestResponsePairs[0].RequestMatcher.Body[0].Value).To(Equal(`{"password":"REDACTED","username":"bob"}`))
+			Expect(payload.RequestResponsePairs[0].Response.Body).To(Equal(`{"password":"REDACTED","token":"abc"}`))
+		})
+	})
+
+	Context("When capturing a response with a non-standard reason phrase", func() {
+
+		It("Should capture the reason phrase and replay it", func() {
+			// net/http always writes the standard reason phrase, so the status line is written by hand
+			fakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, buffer, _ := w.(http.Hijacker).Hijack()
+				defer conn.Close()
+				buffer.WriteString("HTTP/1.1 200 Custom Reason\r\nContent-Length: 5\r\nConnection: close\r\n\r\nhello")
+				buffer.Flush()
+			}))
+			defer fakeServer.Close()
+
+			hoverfly.SetMode("capture")
+
+			resp := hoverfly.Proxy(sling.New().Get(fakeServer.URL))
+			Expect(resp.StatusCode).To(Equal(200))
+
+			payload := hoverfly.ExportSimulation()
+
+			Expect(payload.RequestResponsePairs).To(HaveLen(1))
+			Expect(payload.RequestResponsePairs[0].Response.StatusText).To(Equal("Custom Reason"))
+
+			// the reason phrase is only written by Hoverfly's HTTPS proxy
+			payload.RequestResponsePairs[0].RequestMatcher.Scheme[0].Value = "https"
+			simulation, err := json.Marshal(payload)
+			Expect(err).To(BeNil())
+
+			hoverfly.ImportSimulation(string(simulation))
+			hoverfly.SetMode("simulate")
+
+			resp = hoverfly.Proxy(sling.New().Get(strings.Replace(fakeServer.URL, "http://", "https://", 1)))
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(resp.Status).To(Equal("200 Custom Reason"))
+
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(Equal("hello"))
+		})
+	})
 })
@@ -119,6 +119,31 @@ var _ = Describe("Running Hoverfly with delays", func() {
 			Expect(reqDuration < (1000 * time.Millisecond)).To(BeTrue())
 		})
 
+		It("should apply pair delay in addition to the global delay", func() {
+			simulation := `{
+"data": {
+        "pairs": [
+			{
+				"request": {"destination": [{"matcher": "exact", "value": "test-server.com"}]},
+				"response": {"status": 200, "body": "delayed", "delay": {"fixed": 300, "jitter": 50}}
+			}
+        ],
+        "globalActions": {"delays": [{"urlPattern": "test-server\\.com", "delay": 300}]}
+    },
+    "meta": {"schemaVersion": "v5", "hoverflyVersion": "v1.2.0"}
+}`
+			hoverfly.ImportSimulation(simulation)
+
+			start := time.Now()
+			resp := hoverfly.Proxy(sling.New().Get("http://test-server.com/path1"))
+			end := time.Now()
+			reqDuration := end.Sub(start)
+			body, err := ioutil.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(Equal("delayed"))
+			Expect(reqDuration > (600 * time.Millisecond)).To(BeTrue())
+		})
+
 		It("should apply log normal response delay to the cached response", func() {
 			hoverfly.ImportSimulation(testdata.ResponseLogNormalDelays)
 
@@ -0,0 +1,105 @@
+package hoverfly_test
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	functional_tests "github.com/SpectoLabs/hoverfly/functional-tests"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("When I run Hoverfly with faults", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	importSimulationWithFault := func(fault string) {
+		hoverfly.ImportSimulation(fmt.Sprintf(`{
+			"data": {
+				"pairs": [{
+					"request": {
+						"path": [{"matcher": "exact", "value": "/faulty"}]
+					},
+					"response": {
+						"status": 200,
+						"body": "a body which is twenty six"
+					}
+				}],
+				"globalActions": {
+					"faults": [%s]
+				}
+			},
+			"meta": {
+				"schemaVersion": "v5"
+			}
+		}`, fault))
+	}
+
+	// a new client for each request, so that a closed connection is not reused
+	proxyGet := func() (*http.Response, error) {
+		proxy, _ := url.Parse("http://localhost:" + hoverfly.GetProxyPort())
+		client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxy), DisableKeepAlives: true}}
+		return client.Get("http://test-server.com/faulty")
+	}
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start()
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+	})
+
+	It("should return an error response", func() {
+		importSimulationWithFault(`{"urlPattern": "test-server.com/faulty", "type": "error", "status": 503, "probability": 1}`)
+
+		response, err := proxyGet()
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	It("should close the connection without a response", func() {
+		importSimulationWithFault(`{"urlPattern": "test-server.com/faulty", "type": "reset", "probability": 1}`)
+
+		_, err := proxyGet()
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should send a truncated body", func() {
+		importSimulationWithFault(`{"urlPattern": "test-server.com/faulty", "type": "truncate", "probability": 1}`)
+
+		response, err := proxyGet()
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(response.ContentLength).To(Equal(int64(26)))
+
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).To(Equal(io.ErrUnexpectedEOF))
+		Expect(string(body)).To(Equal("a body which "))
+	})
+
+	It("should fault the same requests each time with a seed", func() {
+		faultedRequests := func() []bool {
+			importSimulationWithFault(`{"urlPattern": "test-server.com/faulty", "type": "error", "probability": 0.5, "seed": 7}`)
+
+			faulted := []bool{}
+			for i := 0; i < 20; i++ {
+				response, err := proxyGet()
+				Expect(err).To(BeNil())
+				faulted = append(faulted, response.StatusCode == http.StatusInternalServerError)
+			}
+			return faulted
+		}
+
+		first := faultedRequests()
+		Expect(first).To(ContainElement(true))
+		Expect(first).To(ContainElement(false))
+		Expect(faultedRequests()).To(Equal(first))
+	})
+})
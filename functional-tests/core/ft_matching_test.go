@@ -2,6 +2,7 @@ package hoverfly_test
 
 import (
 	"io/ioutil"
+	"strings"
 
 	"github.com/SpectoLabs/hoverfly/functional-tests"
 	"github.com/SpectoLabs/hoverfly/functional-tests/testdata"
@@ -89,4 +90,46 @@ var _ = Describe("When I match with Hoverfly", func() {
 		})
 
 	})
+
+	Context("using an empty body matcher", func() {
+
+		BeforeEach(func() {
+			hoverfly.Start()
+			hoverfly.SetMode("simulate")
+		})
+
+		It("should match a request without a body", func() {
+			hoverfly.ImportSimulation(testdata.EmptyBody)
+
+			resp := hoverfly.Proxy(sling.New().Post("http://test-server.com"))
+			Expect(resp.StatusCode).To(Equal(200))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+
+			Expect(string(body)).To(Equal("no body"))
+		})
+
+		It("should not match a request with a body", func() {
+			hoverfly.ImportSimulation(testdata.EmptyBody)
+
+			resp := hoverfly.Proxy(sling.New().Post("http://test-server.com").Body(strings.NewReader(`{"event": "push"}`)))
+			Expect(resp.StatusCode).To(Equal(502))
+		})
+
+		It("should prefer it over a pair without a body matcher only when the body is empty", func() {
+			hoverfly.ImportSimulation(testdata.EmptyAndAnyBody)
+
+			resp := hoverfly.Proxy(sling.New().Post("http://test-server.com"))
+			body, err := ioutil.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(Equal("no body"))
+
+			resp = hoverfly.Proxy(sling.New().Post("http://test-server.com").Body(strings.NewReader(`{"event": "push"}`)))
+			body, err = ioutil.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(Equal("any body"))
+		})
+
+	})
 })
@@ -1,6 +1,7 @@
 package hoverfly_test
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -201,6 +202,69 @@ var _ = Describe("Running Hoverfly in various modes", func() {
 			})
 		})
 
+		Context("With middleware rewriting the destination", func() {
+
+			var originalServer, rewrittenServer, middlewareServer *httptest.Server
+			var originalHits int
+			var rewrittenRequest *http.Request
+			var rewrittenBody string
+
+			BeforeEach(func() {
+				originalHits = 0
+				originalServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					originalHits++
+					w.Write([]byte("original"))
+				}))
+
+				rewrittenServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					body, _ := ioutil.ReadAll(r.Body)
+					rewrittenRequest = r
+					rewrittenBody = string(body)
+					w.Write([]byte("rewritten"))
+				}))
+				rewrittenUrl, _ := url.Parse(rewrittenServer.URL)
+
+				// remote middleware which rewrites requests and leaves responses alone
+				middlewareServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var pair map[string]map[string]interface{}
+					Expect(json.NewDecoder(r.Body).Decode(&pair)).To(Succeed())
+
+					if pair["response"]["status"] == float64(0) {
+						pair["request"]["destination"] = rewrittenUrl.Host
+						pair["request"]["path"] = "/rewritten"
+						pair["request"]["body"] = "rewritten body"
+						pair["request"]["headers"] = map[string][]string{"X-Rewritten": {"true"}}
+					}
+
+					json.NewEncoder(w).Encode(pair)
+				}))
+
+				hoverfly.Start("-middleware", middlewareServer.URL)
+				hoverfly.SetMode("modify")
+			})
+
+			AfterEach(func() {
+				originalServer.Close()
+				rewrittenServer.Close()
+				middlewareServer.Close()
+			})
+
+			It("Should send the rewritten request to the new destination", func() {
+				resp := hoverfly.Proxy(sling.New().Post(originalServer.URL + "/original").BodyJSON(map[string]string{"original": "body"}))
+				Expect(resp.StatusCode).To(Equal(200))
+
+				body, err := ioutil.ReadAll(resp.Body)
+				Expect(err).To(BeNil())
+				Expect(string(body)).To(Equal("rewritten"))
+
+				Expect(originalHits).To(Equal(0))
+				Expect(rewrittenRequest.Method).To(Equal(http.MethodPost))
+				Expect(rewrittenRequest.URL.Path).To(Equal("/rewritten"))
+				Expect(rewrittenRequest.Header.Get("X-Rewritten")).To(Equal("true"))
+				Expect(rewrittenBody).To(Equal("rewritten body"))
+			})
+		})
+
 		Context("Without middleware", func() {
 
 			BeforeEach(func() {
@@ -1,13 +1,23 @@
 package hoverfly_test
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/certs"
 	"github.com/SpectoLabs/hoverfly/functional-tests"
 	"github.com/dghubble/sling"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
-	"io/ioutil"
-	"net/http"
-	"net/http/httptest"
 )
 
 var _ = Describe("When I run Hoverfly", func() {
@@ -213,4 +223,88 @@ var _ = Describe("When I run Hoverfly", func() {
 			Expect(response.TLS.PeerCertificates[0].Subject.Names[0].Value).To(Equal("GoProxy untrusted MITM proxy Inc"))
 		})
 	})
+
+	Context("and it is given a CA certificate and key", func() {
+
+		var (
+			caCert  *x509.Certificate
+			certDir string
+		)
+
+		BeforeEach(func() {
+			cert, key, err := certs.NewCertificatePair("provided.ca", "Provided Certificate Authority", time.Hour)
+			Expect(err).To(BeNil())
+			caCert = cert
+
+			certDir, err = ioutil.TempDir("", "hoverfly-ca")
+			Expect(err).To(BeNil())
+
+			certFile := filepath.Join(certDir, "cert.pem")
+			Expect(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0644)).To(Succeed())
+
+			keyFile := filepath.Join(certDir, "key.pem")
+			Expect(ioutil.WriteFile(keyFile, pem.EncodeToMemory(certs.PemBlockForKey(key)), 0600)).To(Succeed())
+
+			hoverfly.Start("-cert", certFile, "-key", keyFile, "-tls-verification=false")
+			hoverfly.SetMode("capture")
+		})
+
+		AfterEach(func() {
+			hoverfly.Stop()
+			os.RemoveAll(certDir)
+		})
+
+		It("should serve the CA certificate from the admin API", func() {
+			response := functional_tests.DoRequest(sling.New().Get(fmt.Sprintf("http://localhost:%v/api/v2/hoverfly/ca-cert", hoverfly.GetAdminPort())))
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/x-pem-file"))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).To(BeNil())
+
+			block, _ := pem.Decode(body)
+			Expect(block).ToNot(BeNil())
+			Expect(block.Bytes).To(Equal(caCert.Raw))
+		})
+
+		It("should capture HTTPS requests from a client which only trusts the CA certificate", func() {
+			fakeServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.Write([]byte("Hello secure world"))
+			}))
+
+			defer fakeServer.Close()
+
+			caResponse := functional_tests.DoRequest(sling.New().Get(fmt.Sprintf("http://localhost:%v/api/v2/hoverfly/ca-cert", hoverfly.GetAdminPort())))
+			caPEM, err := ioutil.ReadAll(caResponse.Body)
+			Expect(err).To(BeNil())
+
+			rootCAs := x509.NewCertPool()
+			Expect(rootCAs.AppendCertsFromPEM(caPEM)).To(BeTrue())
+
+			proxyURL, err := url.Parse("http://localhost:" + hoverfly.GetProxyPort())
+			Expect(err).To(BeNil())
+
+			client := &http.Client{
+				Transport: &http.Transport{
+					Proxy:           http.ProxyURL(proxyURL),
+					TLSClientConfig: &tls.Config{RootCAs: rootCAs},
+				},
+			}
+
+			response, err := client.Get(fakeServer.URL)
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.TLS.PeerCertificates[0].Issuer.CommonName).To(Equal("provided.ca"))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(Equal("Hello secure world"))
+
+			simulation := hoverfly.ExportSimulation()
+			Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+			Expect(simulation.RequestResponsePairs[0].RequestMatcher.Scheme[0].Value).To(Equal("https"))
+			Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("Hello secure world"))
+		})
+	})
 })
@@ -0,0 +1,63 @@
+package hoverfly_test
+
+import (
+	"io"
+
+	"github.com/dghubble/sling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	functional_tests "github.com/SpectoLabs/hoverfly/functional-tests"
+)
+
+var _ = Describe("When running Hoverfly with a random seed", func() {
+
+	const simulation = `{
+		"data": {
+			"pairs": [{
+				"request": {
+					"path": [{"matcher": "exact", "value": "/random"}]
+				},
+				"response": {
+					"status": 200,
+					"body": "{{ randomString }} {{ randomInteger }} {{ randomEmail }} {{ randomUuid }} {{ faker 'Name' }}",
+					"templated": true
+				}
+			}]
+		},
+		"meta": {
+			"schemaVersion": "v5"
+		}
+	}`
+
+	renderWithArgs := func(args ...string) string {
+		hoverfly := functional_tests.NewHoverfly()
+		hoverfly.Start(args...)
+		defer hoverfly.Stop()
+
+		hoverfly.SetMode("simulate")
+		hoverfly.ImportSimulation(simulation)
+
+		resp := hoverfly.Proxy(sling.New().Get("http://test-server.com/random"))
+		Expect(resp.StatusCode).To(Equal(200))
+
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).To(BeNil())
+
+		return string(body)
+	}
+
+	It("should render the same templated response on every run", func() {
+		first := renderWithArgs("-random-seed=42")
+		second := renderWithArgs("-random-seed=42")
+
+		Expect(first).To(Equal(second))
+	})
+
+	It("should render different templated responses without a seed", func() {
+		first := renderWithArgs()
+		second := renderWithArgs()
+
+		Expect(first).ToNot(Equal(second))
+	})
+})
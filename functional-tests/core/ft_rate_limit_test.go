@@ -0,0 +1,72 @@
+package hoverfly_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	functional_tests "github.com/SpectoLabs/hoverfly/functional-tests"
+	"github.com/dghubble/sling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("When I run Hoverfly with a rate limit", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start()
+		hoverfly.ImportSimulation(`{
+			"data": {
+				"pairs": [{
+					"request": {
+						"path": [{"matcher": "exact", "value": "/throttled"}]
+					},
+					"response": {
+						"status": 200,
+						"body": "ok"
+					}
+				}],
+				"globalActions": {
+					"rateLimits": [{
+						"urlPattern": "test-server.com/throttled",
+						"limit": 2,
+						"window": 500
+					}]
+				}
+			},
+			"meta": {
+				"schemaVersion": "v5"
+			}
+		}`)
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+	})
+
+	It("should return 429 once the limit is reached and resume after the window", func() {
+		Expect(hoverfly.Proxy(sling.New().Get("http://test-server.com/throttled")).StatusCode).To(Equal(http.StatusOK))
+		Expect(hoverfly.Proxy(sling.New().Get("http://test-server.com/throttled")).StatusCode).To(Equal(http.StatusOK))
+
+		response := hoverfly.Proxy(sling.New().Get("http://test-server.com/throttled"))
+		Expect(response.StatusCode).To(Equal(http.StatusTooManyRequests))
+		Expect(response.Header.Get("Retry-After")).To(Equal("1"))
+
+		time.Sleep(600 * time.Millisecond)
+
+		Expect(hoverfly.Proxy(sling.New().Get("http://test-server.com/throttled")).StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should export the rate limit with the simulation", func() {
+		Expect(hoverfly.ExportSimulation().GlobalActions.RateLimits).To(ConsistOf(v2.RateLimitView{
+			UrlPattern: "test-server.com/throttled",
+			Limit:      2,
+			Window:     500,
+		}))
+	})
+})
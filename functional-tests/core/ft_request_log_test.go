@@ -0,0 +1,79 @@
+package hoverfly_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/dghubble/sling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	functional_tests "github.com/SpectoLabs/hoverfly/functional-tests"
+)
+
+var _ = Describe("When running Hoverfly with a request log", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+	})
+
+	It("should append an entry for every request served", func() {
+		hoverfly.Start("-request-log=requests.log")
+		hoverfly.ImportSimulation(`{
+			"data": {
+				"pairs": [{
+					"request": {
+						"path": [{"matcher": "exact", "value": "/matched"}]
+					},
+					"response": {
+						"status": 201
+					}
+				}]
+			},
+			"meta": {
+				"schemaVersion": "v5"
+			}
+		}`)
+
+		hoverfly.Proxy(sling.New().Get("http://test-server.com/matched"))
+		hoverfly.Proxy(sling.New().Post("http://test-server.com/missed"))
+
+		text, err := hoverfly.GetLogFile("requests.log")
+		Expect(err).To(BeNil())
+
+		lines := strings.Split(strings.TrimSpace(text), "\n")
+		Expect(lines).To(HaveLen(2))
+
+		var entry map[string]interface{}
+		Expect(json.Unmarshal([]byte(lines[0]), &entry)).To(Succeed())
+		Expect(entry).To(HaveKeyWithValue("mode", "simulate"))
+		Expect(entry).To(HaveKeyWithValue("method", "GET"))
+		Expect(entry).To(HaveKeyWithValue("destination", "test-server.com"))
+		Expect(entry).To(HaveKeyWithValue("path", "/matched"))
+		Expect(entry).To(HaveKeyWithValue("matched", true))
+		Expect(entry).To(HaveKeyWithValue("status", float64(201)))
+
+		Expect(json.Unmarshal([]byte(lines[1]), &entry)).To(Succeed())
+		Expect(entry).To(HaveKeyWithValue("method", "POST"))
+		Expect(entry).To(HaveKeyWithValue("path", "/missed"))
+		Expect(entry).To(HaveKeyWithValue("matched", false))
+		Expect(entry).To(HaveKeyWithValue("status", float64(502)))
+	})
+
+	It("should not write a request log by default", func() {
+		hoverfly.Start()
+
+		hoverfly.Proxy(sling.New().Get("http://test-server.com/missed"))
+
+		_, err := hoverfly.GetLogFile("requests.log")
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -3,6 +3,7 @@ package hoverfly_test
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 
 	v2 "github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/util"
@@ -43,7 +44,7 @@ var _ = Describe("When I run Hoverfly in simulate mode", func() {
 	})
 
 	It("should match against the first request matcher in simulation over HTTPS", func() {
-		hoverfly.ImportSimulation(testdata.JsonPayload)
+		hoverfly.ImportSimulation(strings.Replace(testdata.JsonPayload, `"value": "http"`, `"value": "https"`, 1))
 
 		resp := hoverfly.Proxy(sling.New().Get("https://test-server.com/path1"))
 		Expect(resp.StatusCode).To(Equal(200))
@@ -55,6 +56,29 @@ var _ = Describe("When I run Hoverfly in simulate mode", func() {
 		Expect(resp.Header).To(HaveKeyWithValue("Header", []string{"value1", "value2"}))
 	})
 
+	It("should not match a request matcher for a different scheme", func() {
+		hoverfly.ImportSimulation(testdata.JsonPayload)
+
+		resp := hoverfly.Proxy(sling.New().Get("https://test-server.com/path1"))
+		Expect(resp.StatusCode).To(Equal(502))
+	})
+
+	It("should match http and https requests against a glob scheme matcher", func() {
+		hoverfly.ImportSimulation(testdata.SchemeGlobMatch)
+
+		resp := hoverfly.Proxy(sling.New().Get("http://test-server.com/path1"))
+		Expect(resp.StatusCode).To(Equal(200))
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(Equal("any scheme"))
+
+		resp = hoverfly.Proxy(sling.New().Get("https://test-server.com/path1"))
+		Expect(resp.StatusCode).To(Equal(200))
+		body, err = ioutil.ReadAll(resp.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(Equal("any scheme"))
+	})
+
 	It("should match against the second request matcher in simulation", func() {
 		hoverfly.ImportSimulation(testdata.JsonPayload)
 
@@ -487,4 +511,53 @@ Which if hit would have given the following response:
 		Expect(body).To(Equal(expectedImage))
 		Expect(response.Header.Get("Content-Length")).To(Equal("67"))
 	})
+
+	It("Should simulate a chunked response without a content length", func() {
+		hoverfly.ImportSimulation(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {"path": [{"matcher": "exact", "value": "/chunked"}]},
+				"response": {"status": 200, "body": "chunked body", "chunked": true}
+			}
+		]
+	},
+	"meta": {"schemaVersion": "v5", "hoverflyVersion": "v1.2.0"}
+}`)
+
+		response := hoverfly.Proxy(sling.New().Get("http://test-server.com/chunked"))
+		Expect(response.StatusCode).To(Equal(200))
+
+		Expect(response.TransferEncoding).To(Equal([]string{"chunked"}))
+		Expect(response.ContentLength).To(Equal(int64(-1)))
+		Expect(response.Header.Get("Content-Length")).To(Equal(""))
+
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(Equal("chunked body"))
+	})
+
+	It("Should simulate a chunked response without a content length over HTTPS", func() {
+		hoverfly.ImportSimulation(`{
+	"data": {
+		"pairs": [
+			{
+				"request": {"path": [{"matcher": "exact", "value": "/chunked"}]},
+				"response": {"status": 200, "body": "chunked body", "chunked": true}
+			}
+		]
+	},
+	"meta": {"schemaVersion": "v5", "hoverflyVersion": "v1.2.0"}
+}`)
+
+		response := hoverfly.Proxy(sling.New().Get("https://test-server.com/chunked"))
+		Expect(response.StatusCode).To(Equal(200))
+
+		Expect(response.TransferEncoding).To(Equal([]string{"chunked"}))
+		Expect(response.ContentLength).To(Equal(int64(-1)))
+
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(Equal("chunked body"))
+	})
 })
@@ -194,6 +194,100 @@ var _ = Describe("When I run Hoverfly", func() {
 			Expect(parsedImageId > 0).To(BeTrue())
 		})
 
+		It("Location header", func() {
+			hoverfly.ImportSimulation(`{
+				"data": {
+					"pairs": [{
+						"request": {"path": [{"matcher": "exact", "value": "/orders"}]},
+						"response": {
+							"status": 201,
+							"headers": {"Location": ["/orders/{{ randomUuid }}"], "X-Literal": ["literal"]},
+							"templated": true
+						}
+					}, {
+						"request": {"path": [{"matcher": "exact", "value": "/ordered"}]},
+						"response": {
+							"status": 201,
+							"orderedHeaders": [["Location", "/orders/{{ randomUuid }}"]],
+							"templated": true
+						}
+					}, {
+						"request": {"path": [{"matcher": "exact", "value": "/literal"}]},
+						"response": {
+							"status": 201,
+							"headers": {"Location": ["/orders/{{ randomUuid }}"]}
+						}
+					}]
+				},
+				"meta": {"schemaVersion": "v5"}
+			}`)
+
+			resp := hoverfly.Proxy(sling.New().Post("http://test-server.com/orders"))
+			Expect(resp.StatusCode).To(Equal(201))
+			Expect(uuid.Parse(strings.TrimPrefix(resp.Header.Get("Location"), "/orders/"))).NotTo(BeNil())
+			Expect(resp.Header.Get("X-Literal")).To(Equal("literal"))
+
+			resp = hoverfly.Proxy(sling.New().Post("http://test-server.com/ordered"))
+			Expect(resp.StatusCode).To(Equal(201))
+			Expect(uuid.Parse(strings.TrimPrefix(resp.Header.Get("Location"), "/orders/"))).NotTo(BeNil())
+
+			resp = hoverfly.Proxy(sling.New().Post("http://test-server.com/literal"))
+			Expect(resp.StatusCode).To(Equal(201))
+			Expect(resp.Header.Get("Location")).To(Equal("/orders/{{ randomUuid }}"))
+		})
+
+		It("Request.Header", func() {
+			hoverfly.ImportSimulation(`{
+				"data": {
+					"pairs": [{
+						"request": {"path": [{"matcher": "exact", "value": "/echo"}]},
+						"response": {
+							"status": 200,
+							"body": "id={{ Request.Header \"X-Request-Id\" }} missing={{ Request.Header \"X-Missing\" }}",
+							"headers": {"X-Request-Id": ["{{ Request.Header 'X-Request-Id' }}"]},
+							"templated": true
+						}
+					}]
+				},
+				"meta": {"schemaVersion": "v5"}
+			}`)
+
+			resp := hoverfly.Proxy(sling.New().Get("http://test-server.com/echo").Set("X-Request-Id", "1234"))
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(resp.Header.Get("X-Request-Id")).To(Equal("1234"))
+
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+
+			Expect(string(body)).To(Equal("id=1234 missing="))
+		})
+
+		It("eq, ne and contains", func() {
+			hoverfly.ImportSimulation(`{
+				"data": {
+					"pairs": [{
+						"request": {"path": [{"matcher": "exact", "value": "/account"}]},
+						"response": {
+							"status": 200,
+							"body": "{{#if (eq (Request.QueryParam 'tier') 'gold') }}premium{{else}}standard{{/if}} {{#if (ne Request.Method 'GET') }}write{{else}}read{{/if}} {{#if (contains (Request.Header 'Accept') 'json') }}json{{else}}text{{/if}}",
+							"templated": true
+						}
+					}]
+				},
+				"meta": {"schemaVersion": "v5"}
+			}`)
+
+			resp := hoverfly.Proxy(sling.New().Get("http://test-server.com/account?tier=gold").Set("Accept", "application/json"))
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(Equal("premium read json"))
+
+			resp = hoverfly.Proxy(sling.New().Post("http://test-server.com/account?tier=silver").Set("Accept", "text/plain"))
+			body, err = io.ReadAll(resp.Body)
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(Equal("standard write text"))
+		})
+
 		It("randomIntegerRange", func() {
 			hoverfly.ImportSimulation(testdata.TemplatingHelpers)
 
@@ -318,7 +412,8 @@ var _ = Describe("When I run Hoverfly", func() {
 			Expect(err).To(BeNil())
 
 			// TODO: Handle this?
-			Expect(string(body)).To(ContainSubstring("[Request] http %!s(func(string, string, *raymond.Options)"))
+			Expect(string(body)).To(ContainSubstring("[Request] %!s(func(int, *raymond.Options)"))
+			Expect(string(body)).To(ContainSubstring("http %!s(func(string, string, *raymond.Options)"))
 		})
 
 		It("Request.Body jsonpath", func() {
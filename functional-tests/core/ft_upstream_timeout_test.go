@@ -0,0 +1,56 @@
+package hoverfly_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/functional-tests"
+	"github.com/dghubble/sling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("When I run Hoverfly with an upstream timeout", func() {
+
+	var (
+		hoverfly   *functional_tests.Hoverfly
+		slowServer *httptest.Server
+	)
+
+	BeforeEach(func() {
+		slowServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(time.Second)
+			w.Write([]byte("too late"))
+		}))
+
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start("-upstream-timeout=100ms")
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+		slowServer.Close()
+	})
+
+	It("Should return a gateway timeout in capture mode and not capture the request", func() {
+		hoverfly.SetMode("capture")
+
+		resp := hoverfly.Proxy(sling.New().Get(slowServer.URL))
+		Expect(resp.StatusCode).To(Equal(http.StatusGatewayTimeout))
+
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(ContainSubstring("Client.Timeout exceeded"))
+
+		Expect(hoverfly.ExportSimulation().RequestResponsePairs).To(HaveLen(0))
+	})
+
+	It("Should return a gateway timeout in spy mode", func() {
+		hoverfly.SetMode("spy")
+
+		resp := hoverfly.Proxy(sling.New().Get(slowServer.URL))
+		Expect(resp.StatusCode).To(Equal(http.StatusGatewayTimeout))
+	})
+})
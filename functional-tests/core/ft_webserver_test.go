@@ -59,7 +59,7 @@ var _ = Describe("When running Hoverfly as a webserver", func() {
 					Expect(res.StatusCode).To(Equal(200))
 					modeJson, err := ioutil.ReadAll(res.Body)
 					Expect(err).To(BeNil())
-					Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":1,"spy":0,"synthesize":0}}}`)))
+					Expect(modeJson).To(Equal([]byte(`{"usage":{"counters":{"capture":0,"diff":0,"modify":0,"simulate":1,"spy":0,"synthesize":0},"matching":{"cacheHits":0,"cacheMisses":1,"matcherHits":1,"misses":0}}}`)))
 				})
 			})
 
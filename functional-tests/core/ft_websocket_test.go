@@ -0,0 +1,119 @@
+package hoverfly_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/SpectoLabs/hoverfly/functional-tests"
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("When I run Hoverfly", func() {
+
+	var (
+		hoverfly   *functional_tests.Hoverfly
+		echoServer *httptest.Server
+	)
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start()
+
+		upgrader := websocket.Upgrader{}
+		echoServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			for {
+				messageType, message, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				conn.WriteMessage(messageType, message)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		echoServer.Close()
+		hoverfly.Stop()
+	})
+
+	// The handshake and frames are written by hand, as WebSocket clients tunnel through a proxy with CONNECT
+	openWebSocketThroughProxy := func() (net.Conn, *bufio.Reader, *http.Response) {
+		conn, err := net.Dial("tcp", "localhost:"+hoverfly.GetProxyPort())
+		Expect(err).To(BeNil())
+
+		request, _ := http.NewRequest(http.MethodGet, echoServer.URL+"/socket", nil)
+		request.Header.Set("Connection", "Upgrade")
+		request.Header.Set("Upgrade", "websocket")
+		request.Header.Set("Sec-WebSocket-Version", "13")
+		request.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		Expect(request.WriteProxy(conn)).To(Succeed())
+
+		reader := bufio.NewReader(conn)
+		response, err := http.ReadResponse(reader, request)
+		Expect(err).To(BeNil())
+
+		return conn, reader, response
+	}
+
+	sendAndReceive := func(conn net.Conn, reader *bufio.Reader, message string) string {
+		mask := []byte{1, 2, 3, 4}
+		frame := []byte{0x81, 0x80 | byte(len(message))}
+		frame = append(frame, mask...)
+		for i := 0; i < len(message); i++ {
+			frame = append(frame, message[i]^mask[i%4])
+		}
+		_, err := conn.Write(frame)
+		Expect(err).To(BeNil())
+
+		header := make([]byte, 2)
+		_, err = io.ReadFull(reader, header)
+		Expect(err).To(BeNil())
+		payload := make([]byte, header[1]&0x7f)
+		_, err = io.ReadFull(reader, payload)
+		Expect(err).To(BeNil())
+
+		return string(payload)
+	}
+
+	Context("in capture mode", func() {
+
+		BeforeEach(func() {
+			hoverfly.SetMode("capture")
+		})
+
+		It("should pass a WebSocket connection through and capture the handshake", func() {
+			conn, reader, response := openWebSocketThroughProxy()
+			defer conn.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusSwitchingProtocols))
+			Expect(sendAndReceive(conn, reader, "hello")).To(Equal("hello"))
+			Expect(sendAndReceive(conn, reader, "again")).To(Equal("again"))
+
+			simulation := hoverfly.ExportSimulation()
+			Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+			Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/socket"))
+			Expect(simulation.RequestResponsePairs[0].Response.Status).To(Equal(http.StatusSwitchingProtocols))
+		})
+	})
+
+	Context("in simulate mode", func() {
+
+		It("should pass a WebSocket connection through", func() {
+			conn, reader, response := openWebSocketThroughProxy()
+			defer conn.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusSwitchingProtocols))
+			Expect(sendAndReceive(conn, reader, "hello")).To(Equal("hello"))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package hoverctl_suite
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/functional-tests"
+	"github.com/dghubble/sling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("When I capture with hoverctl", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start()
+
+		functional_tests.Run(hoverctlBinary, "targets", "update", "local", "--admin-port", hoverfly.GetAdminPort())
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+	})
+
+	It("should error without an output file", func() {
+		output := functional_tests.Run(hoverctlBinary, "capture", "--duration", "1s")
+
+		Expect(output).To(ContainSubstring("You have not provided a path to write the simulation to"))
+		Expect(hoverfly.GetMode().Mode).To(Equal("simulate"))
+	})
+
+	It("should capture for the duration, export the simulation and restore the mode", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "captured body")
+		}))
+		defer server.Close()
+
+		hoverfly.SetMode("spy")
+		fileName := functional_tests.GenerateFileName()
+
+		var stdout bytes.Buffer
+		capture := exec.Command(hoverctlBinary, "capture", "--duration", "3s", "--output", fileName)
+		capture.Stdout = &stdout
+		Expect(capture.Start()).To(Succeed())
+
+		Eventually(func() string {
+			return hoverfly.GetMode().Mode
+		}, time.Second, 50*time.Millisecond).Should(Equal("capture"))
+
+		resp := hoverfly.Proxy(sling.New().Get(server.URL + "/captured"))
+		Expect(resp.StatusCode).To(Equal(200))
+
+		Expect(capture.Wait()).To(Succeed())
+
+		Expect(stdout.String()).To(ContainSubstring("Hoverfly is capturing for 3s"))
+		Expect(stdout.String()).To(ContainSubstring("Successfully exported simulation to " + fileName))
+		Expect(stdout.String()).To(ContainSubstring("Hoverfly has been set back to spy mode"))
+		Expect(hoverfly.GetMode().Mode).To(Equal("spy"))
+
+		data, err := ioutil.ReadFile(fileName)
+		Expect(err).To(BeNil())
+
+		var simulation v2.SimulationViewV5
+		functional_tests.Unmarshal(data, &simulation)
+
+		Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+		Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/captured"))
+		Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("captured body"))
+	})
+
+	It("should set the mode to simulate when Hoverfly was already capturing", func() {
+		hoverfly.SetMode("capture")
+		fileName := functional_tests.GenerateFileName()
+
+		output := functional_tests.Run(hoverctlBinary, "capture", "--duration", "100ms", "--output", fileName)
+
+		Expect(output).To(ContainSubstring("Successfully exported simulation to " + fileName))
+		Expect(output).To(ContainSubstring("Hoverfly has been set back to simulate mode"))
+		Expect(hoverfly.GetMode().Mode).To(Equal("simulate"))
+	})
+})
@@ -0,0 +1,117 @@
+package hoverctl_suite
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os/exec"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/functional-tests"
+	"github.com/dghubble/sling"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("When I use hoverctl with diff mode", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+		server   *httptest.Server
+	)
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start()
+
+		functional_tests.Run(hoverctlBinary, "targets", "update", "local", "--admin-port", hoverfly.GetAdminPort())
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("actual"))
+		}))
+		serverUrl, _ := url.Parse(server.URL)
+
+		hoverfly.ImportSimulation(fmt.Sprintf(`{
+			"data": {
+				"pairs": [{
+					"request": {
+						"destination": [{"matcher": "exact", "value": "%s"}]
+					},
+					"response": {
+						"status": 200,
+						"body": "simulated"
+					}
+				}]
+			},
+			"meta": {
+				"schemaVersion": "v5"
+			}
+		}`, serverUrl.Host))
+		hoverfly.SetMode("diff")
+	})
+
+	AfterEach(func() {
+		server.Close()
+		hoverfly.Stop()
+	})
+
+	runCheck := func(args ...string) (string, int) {
+		cmd := exec.Command(hoverctlBinary, append([]string{"diff", "check"}, args...)...)
+		output, _ := cmd.Output()
+		return string(output), cmd.ProcessState.ExitCode()
+	}
+
+	It("check exits with zero when there are no diffs", func() {
+		output, exitCode := runCheck()
+
+		Expect(exitCode).To(Equal(0))
+		Expect(output).To(ContainSubstring("There are no diffs stored in Hoverfly"))
+	})
+
+	It("check exits with non-zero and prints the number of diffs when there are diffs", func() {
+		hoverfly.Proxy(sling.New().Get(server.URL))
+		hoverfly.Proxy(sling.New().Get(server.URL))
+
+		output, exitCode := runCheck()
+
+		Expect(exitCode).To(Equal(1))
+		Expect(output).To(ContainSubstring("2 diffs recorded for 1 requests"))
+	})
+
+	It("check prints the diffs as json", func() {
+		hoverfly.Proxy(sling.New().Get(server.URL))
+
+		output, exitCode := runCheck("--format", "json")
+		Expect(exitCode).To(Equal(1))
+
+		var summary struct {
+			Count int                             `json:"count"`
+			Diff  []v2.ResponseDiffForRequestView `json:"diff"`
+		}
+		Expect(json.Unmarshal([]byte(output), &summary)).To(Succeed())
+		Expect(summary.Count).To(Equal(1))
+		Expect(summary.Diff[0].DiffReport[0].DiffEntries[0].Field).To(Equal("body"))
+		Expect(summary.Diff[0].DiffReport[0].DiffEntries[0].Expected).To(Equal("simulated"))
+		Expect(summary.Diff[0].DiffReport[0].DiffEntries[0].Actual).To(Equal("actual"))
+	})
+
+	It("get keeps its human readable output", func() {
+		hoverfly.Proxy(sling.New().Get(server.URL))
+
+		output := functional_tests.Run(hoverctlBinary, "diff", "get")
+
+		Expect(output).To(ContainSubstring("1 diff recorded"))
+		Expect(output).To(ContainSubstring("the expected value was [simulated], but actual value was [actual]"))
+	})
+
+	It("get prints the diffs as json", func() {
+		hoverfly.Proxy(sling.New().Get(server.URL))
+
+		output := functional_tests.Run(hoverctlBinary, "diff", "get", "--format", "json")
+
+		Expect(output).To(ContainSubstring(`"diff": [`))
+		Expect(output).To(ContainSubstring(`"actual": "actual"`))
+	})
+})
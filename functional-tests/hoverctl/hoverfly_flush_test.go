@@ -38,6 +38,19 @@ var _ = Describe("hoverctl flush cache", func() {
 		Expect(cacheView.Cache).To(HaveLen(0))
 	})
 
+	It("should flush only the cache for a destination", func() {
+		hoverfly.Proxy(sling.New().Get("http://other-server.com"))
+
+		output := functional_tests.Run(hoverctlBinary, "flush", "--destination", "destination-server.com", "--force")
+
+		Expect(output).To(ContainSubstring("Successfully flushed cache for destination-server.com"))
+
+		cacheView := hoverfly.GetCache()
+
+		Expect(cacheView.Cache).To(HaveLen(1))
+		Expect(cacheView.Cache[0].MatchingPair).To(BeNil())
+	})
+
 	It("should error nicely when trying to flush but cache is disabled", func() {
 		hoverfly.Stop()
 		hoverfly.Start("-disable-cache")
@@ -1,6 +1,9 @@
 package hoverctl_suite
 
 import (
+	"bytes"
+	"os/exec"
+
 	"github.com/SpectoLabs/hoverfly/functional-tests"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -169,6 +172,56 @@ var _ = Describe("When I use hoverfly-cli", func() {
 				Expect(modeView.Arguments.OverwriteDuplicate).To(BeTrue())
 			})
 
+			It("to capture mode with trimmed header names and stateful", func() {
+				output := functional_tests.Run(hoverctlBinary, "mode", "capture", "--stateful", "--headers", "Authorization, Content-Type")
+
+				Expect(output).To(ContainSubstring("Hoverfly has been set to capture mode and will capture the following request headers: [Authorization Content-Type]"))
+
+				modeView := hoverfly.GetMode()
+				Expect(modeView.Mode).To(Equal(capture))
+				Expect(modeView.Arguments.Stateful).To(BeTrue())
+				Expect(modeView.Arguments.Headers).To(Equal([]string{"Authorization", "Content-Type"}))
+			})
+
+			It("to capture mode with retries", func() {
+				output := functional_tests.Run(hoverctlBinary, "mode", "capture", "--retries", "3", "--retry-delay", "250ms", "--retry-non-idempotent")
+
+				Expect(output).To(ContainSubstring("Hoverfly has been set to capture mode"))
+
+				modeView := hoverfly.GetMode()
+				Expect(modeView.Mode).To(Equal(capture))
+				Expect(modeView.Arguments.Retries).To(Equal(3))
+				Expect(modeView.Arguments.RetryDelay).To(Equal(250))
+				Expect(modeView.Arguments.RetryNonIdempotent).To(BeTrue())
+			})
+
+			It("to capture mode and error if a retry delay is used without retries", func() {
+				output := functional_tests.Run(hoverctlBinary, "mode", "capture", "--retry-delay", "1s")
+
+				Expect(output).To(ContainSubstring("--retry-delay and --retry-non-idempotent can only be used with --retries"))
+				Expect(hoverfly.GetMode().Mode).To(Equal(simulate))
+			})
+
+			It("to capture mode and error if stateful and overwrite duplicate are both used", func() {
+				output := functional_tests.Run(hoverctlBinary, "mode", "capture", "--stateful", "--overwrite-duplicate")
+
+				Expect(output).To(ContainSubstring("--stateful and --overwrite-duplicate cannot be used together"))
+				Expect(hoverfly.GetMode().Mode).To(Equal(simulate))
+			})
+
+			It("and error if a capture argument is used with another mode", func() {
+				output := functional_tests.Run(hoverctlBinary, "mode", "modify", "--stateful")
+
+				Expect(output).To(ContainSubstring("--stateful can only be used with capture mode"))
+				Expect(hoverfly.GetMode().Mode).To(Equal(simulate))
+			})
+
+			It("and error if headers are used with a mode which ignores them", func() {
+				output := functional_tests.Run(hoverctlBinary, "mode", "simulate", "--headers", "Content-Type")
+
+				Expect(output).To(ContainSubstring("--headers can only be used with capture or diff mode"))
+			})
+
 			It("to synthesize mode", func() {
 				output := functional_tests.Run(hoverctlBinary, "mode", "synthesize")
 
@@ -316,6 +369,70 @@ var _ = Describe("When I use hoverfly-cli", func() {
 		})
 	})
 
+	Describe("with several running hoverflies", func() {
+
+		var (
+			otherHoverfly *functional_tests.Hoverfly
+		)
+
+		BeforeEach(func() {
+			hoverfly = functional_tests.NewHoverfly()
+			hoverfly.Start()
+			otherHoverfly = functional_tests.NewHoverfly()
+			otherHoverfly.Start()
+
+			functional_tests.Run(hoverctlBinary, "targets", "update", "local", "--admin-port", hoverfly.GetAdminPort())
+			functional_tests.Run(hoverctlBinary, "targets", "create", "other", "--admin-port", otherHoverfly.GetAdminPort())
+		})
+
+		AfterEach(func() {
+			hoverfly.Stop()
+			otherHoverfly.Stop()
+		})
+
+		It("should set the mode of every target", func() {
+			output := functional_tests.Run(hoverctlBinary, "mode", "capture", "--all")
+
+			Expect(output).To(ContainSubstring("local: Hoverfly has been set to capture mode"))
+			Expect(output).To(ContainSubstring("other: Hoverfly has been set to capture mode"))
+			Expect(hoverfly.GetMode().Mode).To(Equal(capture))
+			Expect(otherHoverfly.GetMode().Mode).To(Equal(capture))
+		})
+
+		It("should set the mode of the other targets and exit with an error when one of them fails", func() {
+			functional_tests.Run(hoverctlBinary, "targets", "create", "stopped", "--admin-port", "1")
+
+			var stdout, stderr bytes.Buffer
+			mode := exec.Command(hoverctlBinary, "mode", "capture", "--all")
+			mode.Stdout = &stdout
+			mode.Stderr = &stderr
+
+			err := mode.Run()
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*exec.ExitError).ExitCode()).To(Equal(1))
+
+			Expect(stdout.String()).To(ContainSubstring("local: Hoverfly has been set to capture mode"))
+			Expect(stdout.String()).To(ContainSubstring("other: Hoverfly has been set to capture mode"))
+			Expect(stdout.String()).To(ContainSubstring("stopped: Could not set the mode"))
+			Expect(stderr.String()).To(ContainSubstring("Could not set the mode of 1 of 3 targets"))
+			Expect(hoverfly.GetMode().Mode).To(Equal(capture))
+			Expect(otherHoverfly.GetMode().Mode).To(Equal(capture))
+		})
+
+		It("should not set the mode of every target when a target is given", func() {
+			output := functional_tests.Run(hoverctlBinary, "mode", "capture", "--all", "--target", "other")
+
+			Expect(output).To(ContainSubstring("--all and --target cannot be used together"))
+			Expect(otherHoverfly.GetMode().Mode).To(Equal(simulate))
+		})
+
+		It("should not get the mode of every target", func() {
+			output := functional_tests.Run(hoverctlBinary, "mode", "--all")
+
+			Expect(output).To(ContainSubstring("--all can only be used when setting a mode"))
+		})
+	})
+
 	Context("with a target that doesn't exist", func() {
 		It("should error", func() {
 			output := functional_tests.Run(hoverctlBinary, "mode", "--target", "test-target")
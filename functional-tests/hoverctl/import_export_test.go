@@ -341,6 +341,61 @@ var _ = Describe("When I use hoverctl", func() {
 				Expect(buffer.String()).To(MatchRegexp(hoverflyMeta))
 			})
 
+			It("can export with a different indent", func() {
+
+				fileName := functional_tests.GenerateFileName()
+				output := functional_tests.Run(hoverctlBinary, "export", fileName, "--indent", "2")
+				Expect(output).To(ContainSubstring("Successfully exported simulation to " + fileName))
+
+				data, err := ioutil.ReadFile(fileName)
+				Expect(err).To(BeNil())
+				Expect(string(data)).To(HavePrefix("{\n  \"data\": {\n    \"pairs\": ["))
+
+				output = functional_tests.Run(hoverctlBinary, "export", fileName, "--indent", "none")
+				Expect(output).To(ContainSubstring("Successfully exported simulation to " + fileName))
+
+				data, err = ioutil.ReadFile(fileName)
+				Expect(err).To(BeNil())
+				Expect(string(data)).To(HavePrefix(`{"data":{"pairs":[`))
+				Expect(string(data)).ToNot(ContainSubstring("\n"))
+
+				output = functional_tests.Run(hoverctlBinary, "export", fileName, "--indent", "3")
+				Expect(output).To(ContainSubstring("Unknown indent 3, use none, 2, 4 or tab"))
+			})
+
+			It("exports pairs in the same order whatever order they were imported in", func() {
+
+				hoverfly.ImportSimulation(hoverflyDataWithMultiplePairs)
+				fileName := functional_tests.GenerateFileName()
+				functional_tests.Run(hoverctlBinary, "export", fileName)
+
+				firstData, err := ioutil.ReadFile(fileName)
+				Expect(err).To(BeNil())
+
+				var simulation v2.SimulationViewV5
+				Expect(json.Unmarshal(firstData, &simulation)).To(Succeed())
+				Expect(len(simulation.RequestResponsePairs)).To(BeNumerically(">", 1))
+
+				pairs := simulation.RequestResponsePairs
+				for i, j := 0, len(pairs)-1; i < j; i, j = i+1, j-1 {
+					pairs[i], pairs[j] = pairs[j], pairs[i]
+				}
+				reversed, err := json.Marshal(simulation)
+				Expect(err).To(BeNil())
+				hoverfly.ImportSimulation(string(reversed))
+
+				functional_tests.Run(hoverctlBinary, "export", fileName)
+
+				secondData, err := ioutil.ReadFile(fileName)
+				Expect(err).To(BeNil())
+
+				var first, second map[string]json.RawMessage
+				Expect(json.Unmarshal(firstData, &first)).To(Succeed())
+				Expect(json.Unmarshal(secondData, &second)).To(Succeed())
+
+				Expect(string(second["data"])).To(Equal(string(first["data"])))
+			})
+
 			It("can import", func() {
 
 				fileName := functional_tests.GenerateFileName()
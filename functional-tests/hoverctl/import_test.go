@@ -2,6 +2,7 @@ package hoverctl_suite
 
 import (
 	"io/ioutil"
+	"os"
 
 	"github.com/SpectoLabs/hoverfly/functional-tests"
 	. "github.com/onsi/ginkgo"
@@ -104,5 +105,87 @@ var _ = Describe("When I import with hoverctl", func() {
 			Expect(output).To(ContainSubstring("Successfully imported simulation "))
 
 		})
+
+		It("can expand environment variables in the simulation", func() {
+			os.Setenv("HOVERCTL_FT_DESTINATION", "staging.example.com")
+			defer os.Unsetenv("HOVERCTL_FT_DESTINATION")
+
+			fileName := functional_tests.GenerateFileName()
+			err := ioutil.WriteFile(fileName, []byte(`{
+				"data": {
+					"pairs": [{
+						"request": {
+							"destination": [{
+								"matcher": "exact",
+								"value": "${HOVERCTL_FT_DESTINATION}"
+							}]
+						},
+						"response": {
+							"status": 200,
+							"body": "${HOVERCTL_FT_BODY:-default body}"
+						}
+					}]
+				},
+				"meta": {
+					"schemaVersion": "v5"
+				}
+			}`), 0644)
+			Expect(err).To(BeNil())
+
+			output := functional_tests.Run(hoverctlBinary, "import", "--expand-env", fileName)
+			Expect(output).To(ContainSubstring("Successfully imported simulation from " + fileName))
+
+			simulation := hoverfly.ExportSimulation()
+			Expect(simulation.RequestResponsePairs[0].RequestMatcher.Destination[0].Value).To(Equal("staging.example.com"))
+			Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("default body"))
+
+			os.Unsetenv("HOVERCTL_FT_DESTINATION")
+			output = functional_tests.Run(hoverctlBinary, "import", "--expand-env", fileName)
+			Expect(output).To(ContainSubstring("Environment variables referenced in simulation are not set: HOVERCTL_FT_DESTINATION"))
+		})
+
+		It("can import v1 records with their delays", func() {
+			fileName := functional_tests.GenerateFileName()
+			err := ioutil.WriteFile(fileName, []byte(`{
+				"data": [{
+					"request": {
+						"requestType": "recording",
+						"destination": "test-server.com",
+						"method": "GET"
+					},
+					"response": {
+						"status": 200,
+						"body": "v1 body"
+					}
+				}]
+			}`), 0644)
+			Expect(err).To(BeNil())
+
+			delaysFileName := functional_tests.GenerateFileName()
+			err = ioutil.WriteFile(delaysFileName, []byte(`{
+				"data": [{
+					"urlPattern": "test-server.com",
+					"httpMethod": "GET",
+					"delay": 100
+				}]
+			}`), 0644)
+			Expect(err).To(BeNil())
+
+			output := functional_tests.Run(hoverctlBinary, "import", "--v1", fileName, "--delays", delaysFileName)
+			Expect(output).To(ContainSubstring("Successfully imported simulation from " + fileName))
+
+			simulation := hoverfly.ExportSimulation()
+			Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+			Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("v1 body"))
+			Expect(simulation.GlobalActions.Delays).To(HaveLen(1))
+			Expect(simulation.GlobalActions.Delays[0].UrlPattern).To(Equal("test-server.com"))
+			Expect(simulation.GlobalActions.Delays[0].HttpMethod).To(Equal("GET"))
+			Expect(simulation.GlobalActions.Delays[0].Delay).To(Equal(100))
+		})
+
+		It("does not import delays without --v1", func() {
+			output := functional_tests.Run(hoverctlBinary, "import", "simulation.json", "--delays", "delays.json")
+			Expect(output).To(ContainSubstring("--delays can only be used with --v1"))
+		})
 	})
 })
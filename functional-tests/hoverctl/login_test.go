@@ -2,6 +2,8 @@ package hoverctl_suite
 
 import (
 	"io/ioutil"
+	"path/filepath"
+	"regexp"
 
 	"github.com/SpectoLabs/hoverfly/functional-tests"
 	"github.com/SpectoLabs/hoverfly/functional-tests/testdata"
@@ -225,6 +227,40 @@ var _ = Describe("hoverctl login", func() {
 		})
 	})
 
+	Context("with an expired auth token", func() {
+
+		BeforeEach(func() {
+			hoverfly = functional_tests.NewHoverfly()
+			hoverfly.Start("-auth", "-username", functional_tests.HoverflyUsername, "-password", functional_tests.HoverflyPassword)
+
+			functional_tests.Run(hoverctlBinary, "targets", "update", "local", "--admin-port", hoverfly.GetAdminPort())
+		})
+
+		AfterEach(func() {
+			hoverfly.Stop()
+		})
+
+		It("should ask to log in again without storing the credentials in the config file", func() {
+			output := functional_tests.Run(hoverctlBinary, "login", "--no-keychain", "--username", functional_tests.HoverflyUsername, "--password", functional_tests.HoverflyPassword)
+			Expect(output).To(ContainSubstring("Login successful"))
+
+			configPath := filepath.Join(workingDirectory, ".hoverfly", "config.yaml")
+			config, err := ioutil.ReadFile(configPath)
+			Expect(err).To(BeNil())
+			Expect(string(config)).To(ContainSubstring("auth.token: "))
+			Expect(string(config)).ToNot(ContainSubstring("auth.password"))
+			Expect(string(config)).ToNot(ContainSubstring("password: " + functional_tests.HoverflyPassword))
+			Expect(string(config)).ToNot(ContainSubstring("auth.username"))
+
+			expiredConfig := regexp.MustCompile(`auth.token: .*`).ReplaceAllString(string(config), "auth.token: expired")
+			Expect(ioutil.WriteFile(configPath, []byte(expiredConfig), 0644)).To(Succeed())
+
+			output = functional_tests.Run(hoverctlBinary, "mode")
+			Expect(output).To(ContainSubstring("Hoverfly requires authentication"))
+			Expect(output).To(ContainSubstring("Run `hoverctl login -t local`"))
+		})
+	})
+
 	Context("with a target that doesn't exist", func() {
 		It("should error", func() {
 			output := functional_tests.Run(hoverctlBinary, "login", "--target", "test-target")
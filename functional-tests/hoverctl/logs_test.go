@@ -67,6 +67,25 @@ var _ = Describe("When I use hoverctl", func() {
 		})
 	})
 
+	Context("I can filter the logs by level", func() {
+
+		It("should only return logs at the level or more severe", func() {
+			functional_tests.Run(hoverctlBinary, "start", "--admin-port="+adminPort, "--proxy-port="+proxyPort)
+
+			Expect(functional_tests.Run(hoverctlBinary, "logs", "--level", "info")).To(ContainSubstring("Proxy prepared..."))
+			Expect(functional_tests.Run(hoverctlBinary, "logs", "--level", "warn")).ToNot(ContainSubstring("Proxy prepared..."))
+		})
+
+		It("should error if the level is not valid", func() {
+			functional_tests.Run(hoverctlBinary, "start", "--admin-port="+adminPort, "--proxy-port="+proxyPort)
+
+			output := functional_tests.Run(hoverctlBinary, "logs", "--level", "loud")
+
+			Expect(output).To(ContainSubstring("Could not retrieve logs"))
+			Expect(output).To(ContainSubstring("not a valid logrus Level"))
+		})
+	})
+
 	Context("with a target that doesn't exist", func() {
 		It("should error", func() {
 			output := functional_tests.Run(hoverctlBinary, "logs", "--target", "test-target")
@@ -119,5 +119,94 @@ var _ = Describe("When I add simulation with hoverctl", func() {
 			Expect(output).To(ContainSubstring("Successfully added simulation from " + file2))
 
 		})
+
+		It("can generate delays from captured latency without changing the pairs", func() {
+			hoverfly.ImportSimulation(`{
+				"data": {
+					"pairs": [{
+						"request": {
+							"destination": [{
+								"matcher": "exact",
+								"value": "test-server.com"
+							}],
+							"path": [{
+								"matcher": "exact",
+								"value": "/foo"
+							}],
+							"method": [{
+								"matcher": "exact",
+								"value": "GET"
+							}]
+						},
+						"response": {
+							"status": 200,
+							"body": "captured",
+							"latencyMs": 400
+						}
+					}],
+					"globalActions": {
+						"delays": []
+					}
+				},
+				"meta": {
+					"schemaVersion": "v5"
+				}
+			}`)
+			pairsBefore := hoverfly.ExportSimulation().RequestResponsePairs
+
+			output := functional_tests.Run(hoverctlBinary, "simulation", "delays", "--from-capture", "--scale", "0.5")
+			Expect(output).To(ContainSubstring(`GET ^test-server\.com/foo$ 200ms`))
+			Expect(output).To(ContainSubstring("Generated 1 delays from captured latency"))
+
+			simulation := hoverfly.ExportSimulation()
+			Expect(simulation.RequestResponsePairs).To(Equal(pairsBefore))
+			Expect(simulation.GlobalActions.Delays).To(HaveLen(1))
+			Expect(simulation.GlobalActions.Delays[0].Delay).To(Equal(200))
+		})
+
+		It("can preview the response for a request", func() {
+			hoverfly.ImportSimulation(`{
+				"data": {
+					"pairs": [{
+						"request": {
+							"destination": [{
+								"matcher": "exact",
+								"value": "test-server.com"
+							}],
+							"path": [{
+								"matcher": "exact",
+								"value": "/orders"
+							}],
+							"method": [{
+								"matcher": "exact",
+								"value": "POST"
+							}]
+						},
+						"response": {
+							"status": 201,
+							"body": "order {{ Request.QueryParam.id }}",
+							"templated": true
+						}
+					}],
+					"globalActions": {
+						"delays": []
+					}
+				},
+				"meta": {
+					"schemaVersion": "v5"
+				}
+			}`)
+
+			output := functional_tests.Run(hoverctlBinary, "simulation", "test", "--method", "POST", "--url", "http://test-server.com/orders?id=7")
+			Expect(output).To(ContainSubstring("Matched pair 0"))
+			Expect(output).To(ContainSubstring("Status: 201"))
+			Expect(output).To(ContainSubstring("Body: order 7"))
+
+			output = functional_tests.Run(hoverctlBinary, "simulation", "test", "--url", "http://test-server.com/orders")
+			Expect(output).To(ContainSubstring("Could not find a match for request"))
+			Expect(output).To(ContainSubstring("[method]"))
+
+			Expect(hoverfly.GetCache().Cache).To(BeEmpty())
+		})
 	})
 })
@@ -127,6 +127,20 @@ var _ = Describe("When using the `targets` command", func() {
 			}))
 		})
 
+		It("should keep the values of the target which are not provided", func() {
+			functional_tests.Run(hoverctlBinary, "targets", "create", "new-target", "--host", "staging.internal", "--proxy-port", "8765")
+			output := functional_tests.Run(hoverctlBinary, "targets", "update", "new-target", "--admin-port", "1234")
+			targets := functional_tests.TableToSliceMapStringString(output)
+
+			Expect(targets["new-target"]).To(Equal(map[string]string{
+				"TARGET NAME": "new-target",
+				"HOST":        "staging.internal",
+				"ADMIN PORT":  "1234",
+				"PROXY PORT":  "8765",
+				"DEFAULT":     "",
+			}))
+		})
+
 		It("should not update a target if no target name is provided", func() {
 			output := functional_tests.Run(hoverctlBinary, "targets", "update")
 
@@ -0,0 +1,78 @@
+package hoverctl_suite
+
+import (
+	"github.com/SpectoLabs/hoverfly/functional-tests"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("hoverctl users", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	BeforeEach(func() {
+		hoverfly = functional_tests.NewHoverfly()
+		hoverfly.Start("-auth", "-username", functional_tests.HoverflyUsername, "-password", functional_tests.HoverflyPassword)
+
+		functional_tests.Run(hoverctlBinary, "targets", "update", "local", "--admin-port", hoverfly.GetAdminPort())
+	})
+
+	AfterEach(func() {
+		hoverfly.Stop()
+	})
+
+	Context("list", func() {
+
+		It("should require authentication", func() {
+			output := functional_tests.Run(hoverctlBinary, "users", "list")
+
+			Expect(output).To(ContainSubstring("Hoverfly requires authentication"))
+		})
+
+		It("should list the users", func() {
+			functional_tests.Run(hoverctlBinary, "login", "--no-keychain", "--username", functional_tests.HoverflyUsername, "--password", functional_tests.HoverflyPassword)
+
+			output := functional_tests.Run(hoverctlBinary, "users", "list")
+
+			users := functional_tests.TableToSliceMapStringString(output)
+			Expect(users).To(HaveLen(1))
+			for _, user := range users {
+				Expect(user).To(HaveKeyWithValue("USERNAME", functional_tests.HoverflyUsername))
+				Expect(user).To(HaveKeyWithValue("ADMIN", "X"))
+			}
+		})
+	})
+
+	Context("add and delete", func() {
+
+		BeforeEach(func() {
+			functional_tests.Run(hoverctlBinary, "login", "--no-keychain", "--username", functional_tests.HoverflyUsername, "--password", functional_tests.HoverflyPassword)
+		})
+
+		It("should add a user who can log in, and delete them", func() {
+			output := functional_tests.Run(hoverctlBinary, "users", "add", "carol", "--password", "longenough")
+			Expect(output).To(ContainSubstring("User carol has been added to Hoverfly"))
+
+			output = functional_tests.Run(hoverctlBinary, "users", "list")
+			Expect(output).To(ContainSubstring("carol"))
+
+			output = functional_tests.Run(hoverctlBinary, "users", "delete", "carol", "--force")
+			Expect(output).To(ContainSubstring("User carol has been deleted from Hoverfly"))
+
+			output = functional_tests.Run(hoverctlBinary, "users", "list")
+			Expect(output).ToNot(ContainSubstring("carol"))
+		})
+
+		It("should reject a weak password", func() {
+			output := functional_tests.Run(hoverctlBinary, "users", "add", "carol", "--password", "short")
+			Expect(output).To(ContainSubstring("Password must be at least 8 characters"))
+		})
+
+		It("should not delete the last admin user", func() {
+			output := functional_tests.Run(hoverctlBinary, "users", "delete", functional_tests.HoverflyUsername, "--force")
+			Expect(output).To(ContainSubstring("Cannot delete the last admin user"))
+		})
+	})
+})
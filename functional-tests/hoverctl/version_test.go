@@ -0,0 +1,36 @@
+package hoverctl_suite
+
+import (
+	"github.com/SpectoLabs/hoverfly/functional-tests"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("when I use hoverctl version", func() {
+
+	var (
+		hoverfly *functional_tests.Hoverfly
+	)
+
+	Describe("with a running hoverfly", func() {
+
+		BeforeEach(func() {
+			hoverfly = functional_tests.NewHoverfly()
+			hoverfly.Start()
+
+			functional_tests.Run(hoverctlBinary, "targets", "update", "local", "--admin-port", hoverfly.GetAdminPort())
+		})
+
+		AfterEach(func() {
+			hoverfly.Stop()
+		})
+
+		It("should print the version of hoverctl and the version of the running Hoverfly", func() {
+			output := functional_tests.Run(hoverctlBinary, "version")
+
+			Expect(output).To(ContainSubstring("hoverctl | dev"))
+			Expect(output).To(MatchRegexp(`hoverfly \| v\d+\.\d+\.\d+`))
+			Expect(output).ToNot(ContainSubstring("Warning"))
+		})
+	})
+})
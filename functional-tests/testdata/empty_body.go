@@ -0,0 +1,81 @@
+package testdata
+
+var EmptyBody = `{
+	"data": {
+		"pairs": [
+			{
+				"request": {
+					"method": [
+						{
+							"matcher": "exact",
+							"value": "POST"
+						}
+					],
+					"body": [
+						{
+							"matcher": "empty"
+						}
+					]
+				},
+				"response": {
+					"status": 200,
+					"body": "no body"
+				}
+			}
+		],
+		"globalActions": {
+			"delays": []
+		}
+	},
+	"meta": {
+		"schemaVersion": "v5",
+		"hoverflyVersion": "v0.17.3"
+	}
+}`
+
+var EmptyAndAnyBody = `{
+	"data": {
+		"pairs": [
+			{
+				"request": {
+					"method": [
+						{
+							"matcher": "exact",
+							"value": "POST"
+						}
+					],
+					"body": [
+						{
+							"matcher": "empty"
+						}
+					]
+				},
+				"response": {
+					"status": 200,
+					"body": "no body"
+				}
+			},
+			{
+				"request": {
+					"method": [
+						{
+							"matcher": "exact",
+							"value": "POST"
+						}
+					]
+				},
+				"response": {
+					"status": 200,
+					"body": "any body"
+				}
+			}
+		],
+		"globalActions": {
+			"delays": []
+		}
+	},
+	"meta": {
+		"schemaVersion": "v5",
+		"hoverflyVersion": "v0.17.3"
+	}
+}`
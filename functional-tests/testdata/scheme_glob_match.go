@@ -0,0 +1,45 @@
+package testdata
+
+var SchemeGlobMatch = `{
+	"data": {
+		"pairs": [
+			{
+				"request": {
+					"scheme": [
+						{
+							"matcher": "glob",
+							"value": "*"
+						}
+					],
+					"destination": [
+						{
+							"matcher": "exact",
+							"value": "test-server.com"
+						}
+					],
+					"path": [
+						{
+							"matcher": "exact",
+							"value": "/path1"
+						}
+					]
+				},
+				"response": {
+					"status": 200,
+					"body": "any scheme",
+					"encodedBody": false,
+					"templated": false
+				}
+			}
+		],
+		"globalActions": {
+			"delays": [],
+			"delaysLogNormal": []
+		}
+	},
+	"meta": {
+		"schemaVersion": "v5",
+		"hoverflyVersion": "v0.17.0",
+		"timeExported": "2018-05-03T12:14:36+01:00"
+	}
+}`
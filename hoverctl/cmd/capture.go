@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
+	"github.com/spf13/cobra"
+)
+
+var captureDuration time.Duration
+var captureOutput string
+
+var captureCmd = &cobra.Command{
+	Use:   "capture",
+	Short: "Capture a simulation for a while and export it",
+	Long: `
+Sets Hoverfly to capture mode, waits for the given
+duration, or until Ctrl-C is pressed, and then exports
+the simulation to the file given with --output.
+
+Hoverfly is then set back to the mode it was in before,
+or to simulate mode if it was already capturing.
+	`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		if len(captureOutput) == 0 {
+			handleIfError(errors.New("You have not provided a path to write the simulation to\n\nTry hoverctl capture --help for more information"))
+		}
+
+		originalMode, err := wrapper.GetMode(*target)
+		handleIfError(err)
+
+		_, err = wrapper.SetModeWithArguments(*target, &v2.ModeView{Mode: modes.Capture})
+		handleIfError(err)
+
+		if captureDuration > 0 {
+			fmt.Println("Hoverfly is capturing for", captureDuration, "- press Ctrl-C to stop early")
+		} else {
+			fmt.Println("Hoverfly is capturing - press Ctrl-C to stop")
+		}
+
+		waitForCapture(captureDuration)
+
+		simulationView, exportErr := wrapper.ExportSimulation(*target, "")
+		if exportErr == nil {
+			exportErr = writeSimulationFile(captureOutput, "", "", wrapper.SortSimulation(simulationView))
+		}
+
+		restoredMode := originalMode
+		if restoredMode.Mode == modes.Capture {
+			restoredMode = &v2.ModeView{Mode: modes.Simulate}
+		}
+
+		// The mode is restored even when the export fails, so that Hoverfly is not left capturing
+		mode, err := wrapper.SetModeWithArguments(*target, restoredMode)
+		handleIfError(exportErr)
+		fmt.Println("Successfully exported simulation to", captureOutput)
+
+		handleIfError(err)
+		fmt.Println("Hoverfly has been set back to", mode, "mode")
+	},
+}
+
+// waitForCapture returns once the duration has passed, or when hoverctl is interrupted. It waits until it is
+// interrupted when the duration is 0.
+func waitForCapture(duration time.Duration) {
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupted)
+
+	var timeout <-chan time.Time
+	if duration > 0 {
+		timeout = time.After(duration)
+	}
+
+	select {
+	case <-timeout:
+	case <-interrupted:
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(captureCmd)
+
+	captureCmd.Flags().DurationVar(&captureDuration, "duration", 0, "How long to capture for, eg. 30s or 5m. Captures until Ctrl-C is pressed when not set")
+	captureCmd.Flags().StringVar(&captureOutput, "output", "", "The file to export the captured simulation to")
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var delaysCmd = &cobra.Command{
+	Use:   "delays",
+	Short: "Manage the response delays in Hoverfly",
+	Long: `
+This allows you to inspect and change the global 
+response delays of the simulation in Hoverfly 
+without importing a whole simulation.
+	`,
+}
+
+var listDelaysCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the response delays",
+	Long: `
+Lists the global response delays of the simulation 
+in Hoverfly.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkTargetAndExit(target)
+
+		delays, err := wrapper.GetDelays(*target)
+		handleIfError(err)
+
+		if len(delays) == 0 {
+			fmt.Println("There are no delays set in Hoverfly")
+			return
+		}
+
+		data := [][]string{
+			{"Url pattern", "Method", "Delay (ms)"},
+		}
+		for _, delay := range delays {
+			data = append(data, []string{delay.UrlPattern, delayMethod(delay.HttpMethod), strconv.Itoa(delay.Delay)})
+		}
+
+		drawTable(data, true)
+	},
+}
+
+var addDelayCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a response delay",
+	Long: `
+Adds a global response delay, in milliseconds, for 
+requests to a host, optionally only to one path, or 
+for requests whose host and path match --url-pattern. 
+Without --method the delay applies to every method.
+
+A delay with the same url pattern and method as an 
+existing one replaces it.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkTargetAndExit(target)
+
+		urlPattern, err := delayUrlPatternFromFlags(cmd)
+		handleIfError(err)
+
+		delay, _ := cmd.Flags().GetInt("delay")
+		if delay < 0 {
+			fmt.Fprintln(os.Stderr, "--delay cannot be negative")
+			os.Exit(1)
+		}
+		if delay == 0 {
+			fmt.Fprintln(os.Stderr, "You have not provided a delay, eg. --delay 500")
+			fmt.Fprintln(os.Stderr, "\nTry hoverctl delays add --help for more information")
+			os.Exit(1)
+		}
+
+		method, _ := cmd.Flags().GetString("method")
+		newDelay := wrapper.ResponseDelaySchema{
+			UrlPattern: urlPattern,
+			HttpMethod: strings.ToUpper(method),
+			Delay:      delay,
+		}
+
+		delays, err := wrapper.GetDelays(*target)
+		handleIfError(err)
+
+		_, err = wrapper.SetDelays(*target, wrapper.AddDelay(delays, newDelay))
+		handleIfError(err)
+
+		fmt.Printf("Requests matching %s with method %s will be delayed by %dms\n", newDelay.UrlPattern, delayMethod(newDelay.HttpMethod), newDelay.Delay)
+	},
+}
+
+var deleteDelayCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a response delay",
+	Long: `
+Deletes the global response delay added for the host 
+and path, or url pattern, and method. Use --all to 
+delete all of the response delays.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkTargetAndExit(target)
+
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			if !askForConfirmation("Are you sure you want to delete all of the delays?") {
+				return
+			}
+
+			err := wrapper.DeleteDelays(*target)
+			handleIfError(err)
+
+			fmt.Println("All delays have been deleted from Hoverfly")
+			return
+		}
+
+		urlPattern, err := delayUrlPatternFromFlags(cmd)
+		handleIfError(err)
+
+		method, _ := cmd.Flags().GetString("method")
+		method = strings.ToUpper(method)
+
+		delays, err := wrapper.GetDelays(*target)
+		handleIfError(err)
+
+		delays, removed := wrapper.RemoveDelay(delays, urlPattern, method)
+		if !removed {
+			handleIfError(fmt.Errorf("There is no delay for %s with method %s", urlPattern, delayMethod(method)))
+		}
+
+		_, err = wrapper.SetDelays(*target, delays)
+		handleIfError(err)
+
+		fmt.Printf("Delay for %s with method %s has been deleted\n", urlPattern, delayMethod(method))
+	},
+}
+
+func delayUrlPatternFromFlags(cmd *cobra.Command) (string, error) {
+	urlPattern, _ := cmd.Flags().GetString("url-pattern")
+	host, _ := cmd.Flags().GetString("host")
+	path, _ := cmd.Flags().GetString("path")
+
+	if urlPattern != "" {
+		if host != "" || path != "" {
+			return "", errors.New("Use either --url-pattern or --host and --path, not both")
+		}
+
+		if _, err := regexp.Compile(urlPattern); err != nil {
+			log.Debug(err.Error())
+			return "", errors.New("Regex pattern does not compile")
+		}
+
+		return urlPattern, nil
+	}
+
+	if host == "" {
+		return "", errors.New("You have not provided a host or url pattern, eg. --host payments.internal\n\nTry hoverctl delays --help for more information")
+	}
+
+	return wrapper.DelayUrlPattern(host, path), nil
+}
+
+func delayMethod(method string) string {
+	if method == "" {
+		return "*"
+	}
+
+	return method
+}
+
+func init() {
+	RootCmd.AddCommand(delaysCmd)
+	delaysCmd.AddCommand(listDelaysCmd)
+	delaysCmd.AddCommand(addDelayCmd)
+	delaysCmd.AddCommand(deleteDelayCmd)
+
+	for _, command := range []*cobra.Command{addDelayCmd, deleteDelayCmd} {
+		command.Flags().String("host", "", "Host of the requests to delay, including the port if it is not the default")
+		command.Flags().String("path", "", "Path of the requests to delay. Without it requests to any path on the host are delayed")
+		command.Flags().String("url-pattern", "", "Golang regular expression matched against the host and path, instead of --host and --path")
+		command.Flags().String("method", "", "HTTP method of the requests to delay. Without it requests with any method are delayed")
+	}
+	addDelayCmd.Flags().Int("delay", 0, "Delay in milliseconds")
+	deleteDelayCmd.Flags().Bool("all", false, "Delete all of the delays")
+}
@@ -60,8 +60,61 @@ setting.
 	},
 }
 
+var destinationAddCmd = &cobra.Command{
+	Use:   "add [pattern] [upstream]",
+	Short: "Send requests for some hosts to a different upstream",
+	Long: `
+Adds a route which sends the requests Hoverfly makes to 
+real servers, eg. in capture or spy mode, to a different 
+upstream when the request host matches the Golang regular 
+expression pattern.
+
+Routes are checked in the order they were added. Requests 
+to hosts which do not match any route are sent to the 
+host they were made to.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		if len(args) < 2 {
+			handleIfError(errors.New("You have not provided a pattern and an upstream\n\nTry hoverctl destination add --help for more information"))
+		}
+
+		if _, err := regexp.Compile(args[0]); err != nil {
+			log.Debug(err.Error())
+			handleIfError(errors.New("Regex pattern does not compile"))
+		}
+
+		_, err := wrapper.AddUpstreamRoute(*target, args[0], args[1])
+		handleIfError(err)
+
+		fmt.Println("Requests to hosts matching", args[0], "will be sent to", args[1])
+	},
+}
+
+var destinationClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete all of the upstream routes",
+	Long: `
+Deletes all of the routes added with "hoverctl destination 
+add", so requests are sent to the host they were made to.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		err := wrapper.DeleteUpstreamRoutes(*target)
+		handleIfError(err)
+
+		fmt.Println("Upstream routes have been deleted")
+	},
+}
+
 func init() {
 	RootCmd.AddCommand(destinationCmd)
+	destinationCmd.AddCommand(destinationAddCmd)
+	destinationCmd.AddCommand(destinationClearCmd)
 	destinationCmd.Flags().StringVar(&dryRun, "dry-run", "",
 		"The destination regexp will be applied to the URL provided. This allows the regexp to be tested.")
 }
@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 
 	"bytes"
 
@@ -34,8 +37,15 @@ Returns all differences between expected and actual responses from Hoverfly.
 		checkTargetAndExit(target)
 
 		if len(args) == 0 {
+			format := getDiffFormat(cmd)
 			diffs, err := wrapper.GetAllDiffs(*target)
 			handleIfError(err)
+
+			if format == "json" {
+				printDiffsAsJson(v2.DiffView{Diff: diffs})
+				return
+			}
+
 			var output bytes.Buffer
 
 			for _, diffsWithRequest := range diffs {
@@ -70,6 +80,51 @@ Returns all differences between expected and actual responses from Hoverfly.
 	},
 }
 
+type diffCheckView struct {
+	Count int                             `json:"count"`
+	Diff  []v2.ResponseDiffForRequestView `json:"diff"`
+}
+
+var checkDiffsCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Checks whether any diffs are stored in Hoverfly",
+	Long: `
+Prints the number of differences between expected and
+actual responses stored in Hoverfly, and exits with a
+non-zero status code when there are any. This can be
+used to fail a CI build when a service no longer
+matches its simulation.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkTargetAndExit(target)
+
+		format := getDiffFormat(cmd)
+		diffs, err := wrapper.GetAllDiffs(*target)
+		handleIfError(err)
+
+		count := 0
+		for _, diffsWithRequest := range diffs {
+			count += len(diffsWithRequest.DiffReport)
+		}
+
+		if format == "json" {
+			if diffs == nil {
+				diffs = []v2.ResponseDiffForRequestView{}
+			}
+			printDiffsAsJson(diffCheckView{Count: count, Diff: diffs})
+		} else if count == 0 {
+			fmt.Println("There are no diffs stored in Hoverfly")
+		} else {
+			fmt.Printf("%d diffs recorded for %d requests\n", count, len(diffs))
+		}
+
+		if count > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
 var deleteDiffsCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Deletes all diffs",
@@ -94,8 +149,26 @@ func diffReportMessage(report v2.DiffReport) string {
 	return msg.String()
 }
 
+func getDiffFormat(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("format")
+	if format != "" && format != "text" && format != "json" {
+		handleIfError(errors.New("Format must be either text or json"))
+	}
+	return format
+}
+
+func printDiffsAsJson(diffs interface{}) {
+	output, err := json.MarshalIndent(diffs, "", "\t")
+	handleIfError(err)
+	fmt.Println(string(output))
+}
+
 func init() {
 	RootCmd.AddCommand(diffCmd)
 	diffCmd.AddCommand(getAllDiffCmd)
+	diffCmd.AddCommand(checkDiffsCmd)
 	diffCmd.AddCommand(deleteDiffsCmd)
+
+	getAllDiffCmd.Flags().String("format", "text", "Format of the diffs, text or json")
+	checkDiffsCmd.Flags().String("format", "text", "Format of the summary, text or json")
 }
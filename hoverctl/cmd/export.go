@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
@@ -16,6 +15,17 @@ var exportCmd = &cobra.Command{
 	Long: `
 Exports a simulation from Hoverfly. The simulation JSON
 will be written to the file path provided.
+
+The simulation is written as YAML instead if the path
+ends in .yml or .yaml, or with --format yaml.
+
+Pairs are sorted by destination, method and path, so
+exporting the same simulation always gives the same
+file.
+
+The simulation JSON is indented with tabs, use --indent
+to indent it with 2 or 4 spaces instead, or none to
+write it on a single line.
 	`,
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -26,6 +36,8 @@ will be written to the file path provided.
 		simulationView, err := wrapper.ExportSimulation(*target, urlPattern)
 		handleIfError(err)
 
+		simulationView = wrapper.SortSimulation(simulationView)
+
 		for i, pair := range simulationView.DataViewV5.RequestResponsePairs {
 			bodyFile := pair.Response.GetBodyFile()
 			if len(bodyFile) == 0 {
@@ -39,10 +51,9 @@ will be written to the file path provided.
 			simulationView.DataViewV5.RequestResponsePairs[i].Response.Body = ""
 		}
 
-		simulationData, err := json.MarshalIndent(simulationView, "", "\t")
-		handleIfError(err)
-
-		err = configuration.WriteFile(args[0], simulationData)
+		format, _ := cmd.Flags().GetString("format")
+		indent, _ := cmd.Flags().GetString("indent")
+		err = writeSimulationFile(args[0], format, indent, simulationView)
 		handleIfError(err)
 
 		fmt.Println("Successfully exported simulation to", args[0])
@@ -52,5 +63,7 @@ will be written to the file path provided.
 func init() {
 	RootCmd.AddCommand(exportCmd)
 
+	exportCmd.Flags().String("format", "", "Format of the simulation file, json or yaml. Defaults to yaml for .yml and .yaml files, otherwise json")
+	exportCmd.Flags().String("indent", "tab", "Indentation of the simulation JSON, none for compact JSON on a single line, 2, 4 or tab")
 	exportCmd.Flags().StringVar(&urlPattern, "url-pattern", "", "Export simulation for the urls that matches a pattern, eg. foo.com/api/v(.+)")
 }
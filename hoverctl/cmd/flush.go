@@ -20,11 +20,26 @@ When changing the mode to simulate, the cache will be
 flushed and rebuilt, pre-caching cacheable matching requests.
 
 This command will flush this cache regardless of mode.
+Use --destination to flush only the cached responses 
+for requests to one host, keeping the rest of the cache.
 	`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		checkTargetAndExit(target)
 
+		destination, _ := cmd.Flags().GetString("destination")
+		if destination != "" {
+			if !askForConfirmation("Are you sure you want to flush the cache for " + destination + "?") {
+				return
+			}
+
+			err := wrapper.FlushCacheForDestination(*target, destination)
+			handleIfError(err)
+
+			fmt.Println("Successfully flushed cache for", destination)
+			return
+		}
+
 		if !askForConfirmation("Are you sure you want to flush the cache?") {
 			return
 		}
@@ -38,4 +53,6 @@ This command will flush this cache regardless of mode.
 
 func init() {
 	RootCmd.AddCommand(flushCmd)
+
+	flushCmd.Flags().String("destination", "", "Only flush the cached responses for requests to this host, eg. api.example.com")
 }
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
@@ -18,15 +20,103 @@ simulation data will be replaced. An absolute or
 relative path to a Hoverfly simulation JSON file
 must be provided. To add multiple simulations,
 use "hoverctl simulation add [paths]" instead.
+
+The simulation can also be downloaded from a URL, 
+from S3 with s3://bucket/key, using the AWS_* 
+credentials in the environment, or from Google 
+Cloud Storage with gs://bucket/object, using the 
+token in GOOGLE_OAUTH_ACCESS_TOKEN.
+
+Simulation files ending in .yml or .yaml are read
+as YAML, as are other files with --format yaml.
+
+Use --openapi to generate a simulation from an
+OpenAPI 3 or Swagger 2 document in YAML or JSON
+instead. A pair is created for each operation,
+using the example responses from the document.
+
+Use --postman to generate a simulation from a
+Postman v2.1 collection. A pair is created for each
+example response saved in the collection, requests
+without example responses are skipped.
+
+Use --v1 to import the records exported from 
+/api/records by older versions of Hoverfly, or a v1 
+simulation. The delays exported from /api/delays can 
+be given with --delays, and are kept when Hoverfly 
+upgrades the simulation.
+
+Use --expand-env to replace ${VAR} references in the 
+string values of the simulation with environment 
+variables before it is imported. ${VAR:-default} is 
+replaced with the default when VAR is not set, any 
+other variable which is not set is an error.
 	`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		checkTargetAndExit(target)
 
 		checkArgAndExit(args, "You have not provided a path to simulation", "import")
-		simulationData, err := configuration.ReadFile(args[0])
+
+		openAPI, _ := cmd.Flags().GetBool("openapi")
+		postman, _ := cmd.Flags().GetBool("postman")
+		v1, _ := cmd.Flags().GetBool("v1")
+		delaysPath, _ := cmd.Flags().GetString("delays")
+
+		if delaysPath != "" && !v1 {
+			handleIfError(errors.New("--delays can only be used with --v1"))
+		}
+
+		var simulationData []byte
+		var err error
+		if openAPI || postman {
+			simulationData, err = configuration.ReadFile(args[0])
+		} else {
+			format, _ := cmd.Flags().GetString("format")
+			simulationData, err = readSimulationFile(args[0], format)
+		}
 		handleIfError(err)
 
+		if openAPI {
+			simulation, err := wrapper.NewSimulationFromOpenAPI(simulationData)
+			handleIfError(err)
+
+			simulationData, err = json.Marshal(simulation)
+			handleIfError(err)
+		}
+
+		if postman {
+			postmanImport, err := wrapper.NewSimulationFromPostman(simulationData)
+			handleIfError(err)
+
+			fmt.Printf("Converted %d requests from Postman collection\n", len(postmanImport.Imported))
+			if len(postmanImport.Skipped) > 0 {
+				fmt.Printf("Skipped %d requests without example responses:\n", len(postmanImport.Skipped))
+				for _, name := range postmanImport.Skipped {
+					fmt.Println("  " + name)
+				}
+			}
+
+			simulationData, err = json.Marshal(postmanImport.Simulation)
+			handleIfError(err)
+		}
+
+		if v1 {
+			var delaysData []byte
+			if delaysPath != "" {
+				delaysData, err = configuration.ReadFile(delaysPath)
+				handleIfError(err)
+			}
+
+			simulationData, err = wrapper.NewSimulationFromV1(simulationData, delaysData)
+			handleIfError(err)
+		}
+
+		if expandEnv, _ := cmd.Flags().GetBool("expand-env"); expandEnv {
+			simulationData, err = wrapper.ExpandEnvInSimulation(simulationData)
+			handleIfError(err)
+		}
+
 		err = wrapper.ImportSimulation(*target, string(simulationData))
 		handleIfError(err)
 
@@ -36,4 +126,10 @@ use "hoverctl simulation add [paths]" instead.
 
 func init() {
 	RootCmd.AddCommand(importCmd)
+	importCmd.Flags().Bool("openapi", false, "Generate the simulation from an OpenAPI or Swagger document")
+	importCmd.Flags().Bool("postman", false, "Generate the simulation from a Postman v2.1 collection")
+	importCmd.Flags().Bool("v1", false, "Import records exported from /api/records, or a v1 simulation")
+	importCmd.Flags().String("delays", "", "Path to the delays exported from /api/delays to import with --v1")
+	importCmd.Flags().Bool("expand-env", false, "Replace ${VAR} and ${VAR:-default} in the simulation with environment variables")
+	importCmd.Flags().String("format", "", "Format of the simulation file, json or yaml. Defaults to yaml for .yml and .yaml files, otherwise json")
 }
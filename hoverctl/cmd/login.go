@@ -19,8 +19,12 @@ Will authenticate against the /api/token-auth API endpoint
 target Hoverfly instance using the provided username and 
 password.
 
-The generated authentication token is then stored on the
-target in the hoverctl configuration file.
+The generated authentication token is stored in the OS
+keychain where one is available, along with the username
+and password, which are used to log in again when the
+token expires. Otherwise, or with --no-keychain, only the
+token is stored on the target in the hoverctl configuration
+file, and you need to log in again when it expires.
 	`,
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -59,7 +63,10 @@ target in the hoverctl configuration file.
 			handleIfError(err)
 		}
 
-		target.AuthToken = token
+		noKeychain, _ := cmd.Flags().GetBool("no-keychain")
+
+		target.SetAuth(token, username, password)
+		target.StoreAuth(!noKeychain)
 
 		config.NewTarget(*target)
 		handleIfError(config.WriteToFile(hoverflyDirectory))
@@ -77,4 +84,5 @@ func init() {
 	loginCmd.Flags().String("host", "", "A host on which a Hoverfly instance is running. Overrides the default Hoverfly host (localhost). HTTP protocol is assumed if scheme is not specified.")
 	loginCmd.Flags().StringVar(&username, "username", "", "Username to authenticate against Hoverfly with")
 	loginCmd.Flags().StringVar(&password, "password", "", "Password to authenticate against Hoverfly with")
+	loginCmd.Flags().Bool("no-keychain", false, "Store the authentication token in the hoverctl configuration file instead of the OS keychain")
 }
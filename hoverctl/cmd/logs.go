@@ -9,12 +9,16 @@ import (
 )
 
 var followLogs bool
+var logsLevel, logsComponent string
 
 var logsCmd = &cobra.Command{
 	Use:   "logs",
 	Short: "Get the logs from Hoverfly",
 	Long: `
-Shows the Hoverfly logs.
+Shows the Hoverfly logs. Use --level to only show logs at
+that level or more severe, and --component to only show logs
+from one part of Hoverfly, such as matching, middleware or
+templating.
 `,
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -30,7 +34,7 @@ Shows the Hoverfly logs.
 		var lastLogRequestTime *time.Time
 
 		for followLogs || lastLogRequestTime == nil {
-			logs, err := wrapper.GetLogs(*target, format, lastLogRequestTime)
+			logs, err := wrapper.GetFilteredLogs(*target, format, lastLogRequestTime, logsLevel, logsComponent)
 			currentLogRequestTime := time.Now()
 			handleIfError(err)
 
@@ -51,4 +55,6 @@ func init() {
 
 	logsCmd.Flags().Bool("json", false, "Retrieve the logs in JSON format")
 	logsCmd.Flags().BoolVar(&followLogs, "follow", false, "Follows the Hoverfly logs")
+	logsCmd.Flags().StringVar(&logsLevel, "level", "", "Only show logs at this level or more severe (debug, info, warn, error)")
+	logsCmd.Flags().StringVar(&logsComponent, "component", "", "Only show logs from this component (matching, middleware, templating)")
 }
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
+	"github.com/spf13/cobra"
+)
+
+var missResponseStatus int
+var missResponseBody, missResponseBodyFile string
+var missResponseHeaders []string
+var missResponseTemplated, missResponseReset bool
+
+var missResponseCmd = &cobra.Command{
+	Use:   "miss-response",
+	Short: "Get and set the response for unmatched requests",
+	Long: `
+The miss response is returned in simulate mode when a
+request does not match any pair in the simulation. It
+can be set using the following flags:
+
+	--status
+	--body or --body-file
+	--header (repeatable, eg. "Content-Type: text/plain")
+	--templated
+
+Use --reset to go back to Hoverfly's default error
+response. If flags are not used, the current miss
+response will be shown.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		if missResponseReset {
+			handleIfError(wrapper.DeleteMissResponse(*target))
+			fmt.Println("Hoverfly will return its default error response for unmatched requests")
+			return
+		}
+
+		var missResponse v2.MissResponseView
+		var err error
+		if missResponseStatus == 0 {
+			missResponse, err = wrapper.GetMissResponse(*target)
+			handleIfError(err)
+		} else {
+			missResponse = v2.MissResponseView{
+				Status:    missResponseStatus,
+				Body:      missResponseBody,
+				Templated: missResponseTemplated,
+			}
+
+			if missResponseBodyFile != "" {
+				body, err := configuration.ReadFile(missResponseBodyFile)
+				handleIfError(err)
+				missResponse.Body = string(body)
+			}
+
+			for _, header := range missResponseHeaders {
+				nameAndValue := strings.SplitN(header, ":", 2)
+				if len(nameAndValue) != 2 {
+					handleIfError(errors.New("Headers must be given as \"Name: value\""))
+				}
+				if missResponse.Headers == nil {
+					missResponse.Headers = map[string][]string{}
+				}
+				name := strings.TrimSpace(nameAndValue[0])
+				missResponse.Headers[name] = append(missResponse.Headers[name], strings.TrimSpace(nameAndValue[1]))
+			}
+
+			missResponse, err = wrapper.SetMissResponse(*target, missResponse)
+			handleIfError(err)
+		}
+
+		if missResponse.Status == 0 {
+			fmt.Println("Hoverfly returns its default error response for unmatched requests")
+			return
+		}
+
+		fmt.Println("Hoverfly returns the following response for unmatched requests")
+		fmt.Println("Status:", missResponse.Status)
+		var names []string
+		for name := range missResponse.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, value := range missResponse.Headers[name] {
+				fmt.Printf("Header: %s: %s\n", name, value)
+			}
+		}
+		if missResponse.Body != "" {
+			fmt.Println("Body:", missResponse.Body)
+		}
+		if missResponse.Templated {
+			fmt.Println("Templated: true")
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(missResponseCmd)
+	missResponseCmd.Flags().IntVar(&missResponseStatus, "status", 0,
+		"The status code returned for unmatched requests")
+	missResponseCmd.Flags().StringVar(&missResponseBody, "body", "",
+		"The body returned for unmatched requests")
+	missResponseCmd.Flags().StringVar(&missResponseBodyFile, "body-file", "",
+		"A path to a file containing the body returned for unmatched requests")
+	missResponseCmd.Flags().StringArrayVar(&missResponseHeaders, "header", []string{},
+		"A header returned for unmatched requests, eg. \"Content-Type: text/plain\"")
+	missResponseCmd.Flags().BoolVar(&missResponseTemplated, "templated", false,
+		"Render the body as a template")
+	missResponseCmd.Flags().BoolVar(&missResponseReset, "reset", false,
+		"Return Hoverfly's default error response for unmatched requests")
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
 	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
 	"github.com/spf13/cobra"
 )
@@ -14,7 +19,18 @@ var specificHeaders string
 var allHeaders bool
 var stateful bool
 var overwriteDuplicate bool
+var orderedHeaders bool
+var ignoredBodyPaths string
+var maxBodySize int
+var oversizedBodies string
+var redactHeaders string
+var redactQueryParams string
+var redactBodyPaths string
 var matchingStrategy string
+var retries int
+var retryDelay time.Duration
+var retryNonIdempotent bool
+var allTargets bool
 
 var modeCmd = &cobra.Command{
 	Use:   "mode [capture|diff|simulate|spy|modify|synthesize (optional)]",
@@ -26,10 +42,20 @@ requests.
 
 If a mode is not specified, the current Hoverfly 
 mode is shown.
+
+Use --all to set the mode of every target at once.
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		checkTargetAndExit(target)
 
+		if allTargets && len(args) == 0 {
+			handleIfError(errors.New("--all can only be used when setting a mode"))
+		}
+
+		if allTargets && len(targetNameFlag) > 0 {
+			handleIfError(errors.New("--all and --target cannot be used together"))
+		}
+
 		if len(args) == 0 {
 			mode, err := wrapper.GetMode(*target)
 			handleIfError(err)
@@ -41,6 +67,8 @@ mode is shown.
 				Mode: args[0],
 			}
 
+			handleIfError(validateModeFlags(cmd, modeView.Mode))
+
 			switch modeView.Mode {
 			case modes.Simulate:
 				if len(matchingStrategy) > 0 {
@@ -50,13 +78,36 @@ mode is shown.
 			case modes.Capture:
 				modeView.Arguments.Stateful = stateful
 				modeView.Arguments.OverwriteDuplicate = overwriteDuplicate
+				modeView.Arguments.OrderedHeaders = orderedHeaders
+				modeView.Arguments.MaxBodySize = maxBodySize
+				if cmd.Flags().Changed("oversized-bodies") {
+					modeView.Arguments.OversizedBodies = oversizedBodies
+				}
+				modeView.Arguments.RedactedHeaders = splitList(redactHeaders)
+				modeView.Arguments.RedactedQueryParams = splitList(redactQueryParams)
+				modeView.Arguments.RedactedBodyPaths = splitList(redactBodyPaths)
+				modeView.Arguments.Retries = retries
+				if retries > 0 {
+					modeView.Arguments.RetryDelay = int(retryDelay / time.Millisecond)
+					modeView.Arguments.RetryNonIdempotent = retryNonIdempotent
+				}
 				setHeaderArgument(modeView)
 				break
 			case modes.Diff:
 				setHeaderArgument(modeView)
+				if len(ignoredBodyPaths) > 0 {
+					for _, path := range strings.Split(ignoredBodyPaths, ",") {
+						modeView.Arguments.IgnoredBodyPaths = append(modeView.Arguments.IgnoredBodyPaths, strings.TrimSpace(path))
+					}
+				}
 				break
 			}
 
+			if allTargets {
+				handleIfError(setModeOfAllTargets(modeView))
+				return
+			}
+
 			mode, err := wrapper.SetModeWithArguments(*target, modeView)
 			handleIfError(err)
 
@@ -65,13 +116,122 @@ mode is shown.
 	},
 }
 
+// setModeOfAllTargets - sets the mode of every target in parallel, reporting how each one went. It returns an
+// error when any of them could not be set
+func setModeOfAllTargets(modeView *v2.ModeView) error {
+	names := make([]string, 0, len(config.Targets))
+	for name := range config.Targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// the config is shared by every target, so refreshed tokens are stored one at a time
+	var configLock sync.Mutex
+	wrapper.TokenRefreshed = func(refreshed configuration.Target) {
+		configLock.Lock()
+		defer configLock.Unlock()
+		refreshed.StoreAuth(refreshed.AuthKeychain)
+		config.NewTarget(refreshed)
+	}
+
+	setModes := make([]string, len(names))
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, target configuration.Target) {
+			defer wg.Done()
+			setModes[i], errs[i] = wrapper.SetModeWithArguments(target, modeView)
+		}(i, config.Targets[name])
+	}
+	wg.Wait()
+
+	failed := 0
+	for i, name := range names {
+		if errs[i] != nil {
+			failed++
+			fmt.Println(name+":", "Could not set the mode -", errs[i].Error())
+		} else {
+			fmt.Println(name+":", "Hoverfly has been set to", setModes[i], "mode", getExtraInfo(modeView))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("Could not set the mode of %d of %d targets", failed, len(names))
+	}
+
+	return nil
+}
+
 func setHeaderArgument(mode *v2.ModeView) {
 	if allHeaders {
 		mode.Arguments.Headers = append(mode.Arguments.Headers, "*")
 	} else if len(specificHeaders) > 0 {
-		splitHeaders := strings.Split(specificHeaders, ",")
-		mode.Arguments.Headers = append(mode.Arguments.Headers, splitHeaders...)
+		for _, header := range strings.Split(specificHeaders, ",") {
+			mode.Arguments.Headers = append(mode.Arguments.Headers, strings.TrimSpace(header))
+		}
+	}
+}
+
+// splitList - splits a comma separated flag value, returning nil when it is empty
+func splitList(value string) []string {
+	var values []string
+	if len(value) > 0 {
+		for _, item := range strings.Split(value, ",") {
+			values = append(values, strings.TrimSpace(item))
+		}
 	}
+	return values
+}
+
+// validateModeFlags - returns an error when a flag is given that the mode would ignore
+func validateModeFlags(cmd *cobra.Command, mode string) error {
+	flagModes := []struct {
+		flag  string
+		modes []string
+	}{
+		{"headers", []string{modes.Capture, modes.Diff}},
+		{"all-headers", []string{modes.Capture, modes.Diff}},
+		{"matching-strategy", []string{modes.Simulate}},
+		{"stateful", []string{modes.Capture}},
+		{"overwrite-duplicate", []string{modes.Capture}},
+		{"ordered-headers", []string{modes.Capture}},
+		{"max-body-size", []string{modes.Capture}},
+		{"oversized-bodies", []string{modes.Capture}},
+		{"redact-headers", []string{modes.Capture}},
+		{"redact-query-params", []string{modes.Capture}},
+		{"redact-body-paths", []string{modes.Capture}},
+		{"retries", []string{modes.Capture}},
+		{"retry-delay", []string{modes.Capture}},
+		{"retry-non-idempotent", []string{modes.Capture}},
+		{"ignore-body-paths", []string{modes.Diff}},
+	}
+
+	for _, flagMode := range flagModes {
+		if !cmd.Flags().Changed(flagMode.flag) {
+			continue
+		}
+		allowed := false
+		for _, allowedMode := range flagMode.modes {
+			if mode == allowedMode {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("--%s can only be used with %s mode", flagMode.flag, strings.Join(flagMode.modes, " or "))
+		}
+	}
+
+	if stateful && overwriteDuplicate {
+		return errors.New("--stateful and --overwrite-duplicate cannot be used together")
+	}
+
+	if retries == 0 && (cmd.Flags().Changed("retry-delay") || retryNonIdempotent) {
+		return errors.New("--retry-delay and --retry-non-idempotent can only be used with --retries")
+	}
+
+	return nil
 }
 
 func getExtraInfo(mode *v2.ModeView) string {
@@ -99,6 +259,12 @@ func getExtraInfo(mode *v2.ModeView) string {
 				extraInfo = fmt.Sprintf("and will exclude the following response headers from diffing: %s", mode.Arguments.Headers)
 			}
 		}
+		if len(mode.Arguments.IgnoredBodyPaths) > 0 {
+			if len(extraInfo) > 0 {
+				extraInfo += " "
+			}
+			extraInfo += fmt.Sprintf("and will exclude the following body paths from diffing: %s", mode.Arguments.IgnoredBodyPaths)
+		}
 		break
 	}
 
@@ -118,4 +284,26 @@ func init() {
 		"Record stateful responses as a sequence in capture mode")
 	modeCmd.PersistentFlags().BoolVar(&overwriteDuplicate, "overwrite-duplicate", false,
 		"Overwrite duplicate requests in capture mode")
+	modeCmd.PersistentFlags().BoolVar(&orderedHeaders, "ordered-headers", false,
+		"Record response headers as an ordered list in capture mode, keeping the order of repeated headers")
+	modeCmd.PersistentFlags().IntVar(&maxBodySize, "max-body-size", 0,
+		"The largest response body in bytes to record in capture mode, larger bodies are truncated or skipped")
+	modeCmd.PersistentFlags().StringVar(&oversizedBodies, "oversized-bodies", "truncate",
+		"What to do with response bodies larger than --max-body-size in capture mode - 'truncate | skip'")
+	modeCmd.PersistentFlags().StringVar(&redactHeaders, "redact-headers", "",
+		"A comma separated list of request and response headers to redact in capture mode `Authorization,Set-Cookie`")
+	modeCmd.PersistentFlags().StringVar(&redactQueryParams, "redact-query-params", "",
+		"A comma separated list of query params to redact in capture mode `token,apiKey`")
+	modeCmd.PersistentFlags().StringVar(&redactBodyPaths, "redact-body-paths", "",
+		"A comma separated list of JSON body paths or form fields to redact in capture mode `$.password,$.user.ssn`")
+	modeCmd.PersistentFlags().IntVar(&retries, "retries", 0,
+		"How many times to retry a request which fails with a connection error in capture mode")
+	modeCmd.PersistentFlags().DurationVar(&retryDelay, "retry-delay", 100*time.Millisecond,
+		"How long to wait before retrying a request in capture mode, doubled after each retry")
+	modeCmd.PersistentFlags().BoolVar(&retryNonIdempotent, "retry-non-idempotent", false,
+		"Also retry requests with methods which are not idempotent, such as POST and PATCH, in capture mode")
+	modeCmd.PersistentFlags().StringVar(&ignoredBodyPaths, "ignore-body-paths", "",
+		"A comma separated list of JSON body paths to ignore in diff mode `$.meta.requestId,$.timestamp`")
+	modeCmd.PersistentFlags().BoolVar(&allTargets, "all", false,
+		"Set the mode of every target instead of only the current one")
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -86,4 +87,10 @@ func initConfig() {
 	var err error
 	hoverflyDirectory, err = configuration.NewHoverflyDirectory(*config)
 	handleIfError(err)
+
+	wrapper.TokenRefreshed = func(refreshed configuration.Target) {
+		target.AuthToken = refreshed.AuthToken
+		target.StoreAuth(target.AuthKeychain)
+		config.NewTarget(*target)
+	}
 }
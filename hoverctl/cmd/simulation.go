@@ -1,8 +1,15 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
 	"github.com/spf13/cobra"
 )
@@ -27,7 +34,12 @@ to those in the existing data will be discarded with a
 warning message. 
 
 You may provide an absolute or relative path to each 
-simulation file.
+simulation file. Files ending in .yml or .yaml are read 
+as YAML.
+
+Use --expand-env to replace ${VAR} and ${VAR:-default} 
+references in the simulations with environment variables, 
+as with "hoverctl import".
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
 
@@ -35,11 +47,18 @@ simulation file.
 
 		checkArgAndExit(args, "You have not provided a path to simulation", "simulation add")
 
+		format, _ := cmd.Flags().GetString("format")
+
 		for _, arg := range args {
 
-			simulationData, err := configuration.ReadFile(arg)
+			simulationData, err := readSimulationFile(arg, format)
 			handleIfError(err)
 
+			if expandEnv, _ := cmd.Flags().GetBool("expand-env"); expandEnv {
+				simulationData, err = wrapper.ExpandEnvInSimulation(simulationData)
+				handleIfError(err)
+			}
+
 			err = wrapper.AddSimulation(*target, string(simulationData))
 			handleIfError(err)
 			fmt.Println("Successfully added simulation from", arg)
@@ -48,7 +67,374 @@ simulation file.
 	},
 }
 
+var validateSimulationCmd = &cobra.Command{
+	Use:   "validate [path to simulations]",
+	Short: "Validate one or more simulation files",
+	Long: `
+Validates one or more simulation files without needing a 
+running Hoverfly. Each file is checked against the 
+simulation schema, and every request matcher is checked 
+for an unknown matcher type or an invalid regex.
+
+Each problem is printed with the JSON path of the field 
+that caused it. The command exits with a non-zero status 
+if any file is invalid.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkArgAndExit(args, "You have not provided a path to simulation", "simulation validate")
+
+		format, _ := cmd.Flags().GetString("format")
+
+		valid := true
+		for _, arg := range args {
+
+			simulationData, err := readSimulationFile(arg, format)
+			handleIfError(err)
+
+			problems := wrapper.ValidateSimulation(simulationData)
+			if len(problems) == 0 {
+				fmt.Println("Simulation is valid:", arg)
+				continue
+			}
+
+			valid = false
+			fmt.Fprintln(os.Stderr, "Simulation is invalid:", arg)
+			for _, problem := range problems {
+				fmt.Fprintln(os.Stderr, "  "+problem)
+			}
+		}
+
+		if !valid {
+			os.Exit(1)
+		}
+	},
+}
+
+var deleteSimulationPairCmd = &cobra.Command{
+	Use:   "delete [pair index or hash]",
+	Short: "Delete a single pair from the simulation",
+	Long: `
+Deletes a single request/response pair from the simulation 
+in Hoverfly, leaving the rest of the simulation in place.
+
+The pair is identified either by its zero-based index in 
+the simulation's pairs, or by the hash of its request 
+matcher.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkTargetAndExit(target)
+
+		checkArgAndExit(args, "You have not provided a pair index or hash", "simulation delete")
+
+		if !askForConfirmation("Are you sure you want to delete pair " + args[0] + " from the simulation?") {
+			return
+		}
+
+		err := wrapper.DeleteSimulationPair(*target, args[0])
+		handleIfError(err)
+
+		fmt.Println("Pair", args[0], "has been deleted from the simulation")
+	},
+}
+
+var summarySimulationCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "Summarise the simulation in Hoverfly",
+	Long: `
+Prints a summary of the simulation currently loaded in 
+Hoverfly: the total number of pairs, how many pairs use 
+each matcher kind, how many pairs have a delay, how many 
+pairs are stateful, and the schema and Hoverfly versions.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkTargetAndExit(target)
+
+		simulationView, err := wrapper.ExportSimulation(*target, "")
+		handleIfError(err)
+
+		summary := wrapper.SummariseSimulation(simulationView)
+
+		fmt.Println("Schema version:", summary.SchemaVersion)
+		fmt.Println("Hoverfly version:", summary.HoverflyVersion)
+		fmt.Println("Pairs:", summary.Pairs)
+		fmt.Println("Pairs with delays:", summary.DelayedPairs)
+		fmt.Println("Stateful pairs:", summary.StatefulPairs)
+		fmt.Println("Global delays:", summary.GlobalDelays)
+
+		if len(summary.MatcherPairs) == 0 {
+			return
+		}
+
+		kinds := make([]string, 0, len(summary.MatcherPairs))
+		for kind := range summary.MatcherPairs {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+
+		fmt.Println("Pairs by matcher:")
+		for _, kind := range kinds {
+			fmt.Printf("  %s: %d\n", kind, summary.MatcherPairs[kind])
+		}
+	},
+}
+
+var delaysSimulationCmd = &cobra.Command{
+	Use:   "delays",
+	Short: "Generate delays for the simulation in Hoverfly",
+	Long: `
+Generates global delays for the simulation in Hoverfly 
+from the latency recorded on captured pairs, so that 
+simulated responses take as long as the real ones did.
+
+A delay is created for each captured host, path and 
+method, using the average latency when a request was 
+captured more than once. Use --scale to scale the delays 
+and --max to cap them. Existing delays for the same host, 
+path and method are replaced.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkTargetAndExit(target)
+
+		if fromCapture, _ := cmd.Flags().GetBool("from-capture"); !fromCapture {
+			fmt.Fprintln(os.Stderr, "You have not provided a source for the delays, eg. --from-capture")
+			fmt.Fprintln(os.Stderr, "\nTry hoverctl simulation delays --help for more information")
+			os.Exit(1)
+		}
+
+		scale, _ := cmd.Flags().GetFloat64("scale")
+		max, _ := cmd.Flags().GetInt("max")
+		if scale < 0 || max < 0 {
+			fmt.Fprintln(os.Stderr, "--scale and --max cannot be negative")
+			os.Exit(1)
+		}
+
+		simulationView, err := wrapper.ExportSimulation(*target, "")
+		handleIfError(err)
+
+		delays := wrapper.DelaysFromCapture(simulationView, scale, max)
+		if len(delays) == 0 {
+			fmt.Println("No captured latency found in the simulation")
+			return
+		}
+
+		existing, err := wrapper.GetDelays(*target)
+		handleIfError(err)
+
+		_, err = wrapper.SetDelays(*target, wrapper.MergeDelays(existing, delays))
+		handleIfError(err)
+
+		for _, delay := range delays {
+			method := delay.HttpMethod
+			if method == "" {
+				method = "*"
+			}
+			fmt.Printf("%s %s %dms\n", method, delay.UrlPattern, delay.Delay)
+		}
+		fmt.Println("Generated", len(delays), "delays from captured latency")
+	},
+}
+
+var testSimulationCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Preview the response Hoverfly would serve for a request",
+	Long: `
+Asks Hoverfly which pair in the simulation would match 
+a request, and prints the response it would serve, 
+without making the request. Templated responses are 
+rendered, but the cache and state are left unchanged.
+
+If no pair matches, the closest pair and the fields it 
+did not match on are printed instead.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkTargetAndExit(target)
+
+		requestUrl, _ := cmd.Flags().GetString("url")
+		if requestUrl == "" {
+			fmt.Fprintln(os.Stderr, "You have not provided a url to test, eg. --url http://api.example.com/orders")
+			fmt.Fprintln(os.Stderr, "\nTry hoverctl simulation test --help for more information")
+			os.Exit(1)
+		}
+
+		method, _ := cmd.Flags().GetString("method")
+		body, _ := cmd.Flags().GetString("body")
+		headerFlags, _ := cmd.Flags().GetStringArray("header")
+
+		var headers map[string][]string
+		for _, header := range headerFlags {
+			nameAndValue := strings.SplitN(header, ":", 2)
+			if len(nameAndValue) != 2 {
+				handleIfError(errors.New("Headers must be given as \"Name: value\""))
+			}
+			if headers == nil {
+				headers = map[string][]string{}
+			}
+			name := strings.TrimSpace(nameAndValue[0])
+			headers[name] = append(headers[name], strings.TrimSpace(nameAndValue[1]))
+		}
+
+		preview, err := wrapper.PreviewMatch(*target, strings.ToUpper(method), requestUrl, headers, body)
+		handleIfError(err)
+
+		if !preview.Matched {
+			fmt.Fprintln(os.Stderr, preview.Error)
+			os.Exit(1)
+		}
+
+		fmt.Println("Matched pair", *preview.PairIndex)
+		fmt.Println("Status:", preview.Response.Status)
+		var names []string
+		for name := range preview.Response.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, value := range preview.Response.Headers[name] {
+				fmt.Printf("Header: %s: %s\n", name, value)
+			}
+		}
+		if preview.Response.EncodedBody {
+			fmt.Println("Body (base64):", preview.Response.Body)
+		} else if preview.Response.Body != "" {
+			fmt.Println("Body:", preview.Response.Body)
+		}
+	},
+}
+
+var splitSimulationCmd = &cobra.Command{
+	Use:   "split [path to simulation] [output directory]",
+	Short: "Split a simulation into one file per host",
+	Long: `
+Splits a simulation file into one simulation file per 
+destination host, written to the output directory. Each 
+file can be imported on its own.
+
+Pairs which do not match a single exact destination are 
+written to any-host.json. A delay is written to the file 
+of each host it applies to, or to every file if it does 
+not apply to any of the pairs' hosts. Literals and 
+variables are written to every file.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, "You must provide a path to a simulation and an output directory")
+			fmt.Fprintln(os.Stderr, "\nTry hoverctl simulation split --help for more information")
+			os.Exit(1)
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+
+		simulationData, err := readSimulationFile(args[0], format)
+		handleIfError(err)
+
+		simulation, err := wrapper.ParseSimulation(simulationData)
+		handleIfError(err)
+
+		extension := ".json"
+		if isYAML, _ := wrapper.IsYAMLSimulation(format, args[0]); isYAML {
+			extension = ".yaml"
+		}
+
+		splits := wrapper.SplitSimulationByHost(simulation)
+		hosts := make([]string, 0, len(splits))
+		for host := range splits {
+			hosts = append(hosts, host)
+		}
+		sort.Strings(hosts)
+
+		for _, host := range hosts {
+			path := filepath.Join(args[1], host+extension)
+
+			err = writeSimulationFile(path, format, "", splits[host])
+			handleIfError(err)
+
+			fmt.Printf("Wrote %d pairs to %s\n", len(splits[host].RequestResponsePairs), path)
+		}
+	},
+}
+
+var mergeSimulationCmd = &cobra.Command{
+	Use:   "merge [paths to simulations]",
+	Short: "Merge simulation files into one",
+	Long: `
+Merges simulation files, eg. those written by 
+"hoverctl simulation split", into one simulation, 
+written to --output or printed if there is no output 
+path.
+
+A pair with the same request matcher as a pair in an 
+earlier file is dropped. Identical delays, and literals 
+and variables with the same name, are only kept once.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		checkArgAndExit(args, "You have not provided a path to simulation", "simulation merge")
+
+		format, _ := cmd.Flags().GetString("format")
+
+		simulations := []v2.SimulationViewV5{}
+		for _, arg := range args {
+			simulationData, err := readSimulationFile(arg, format)
+			handleIfError(err)
+
+			simulation, err := wrapper.ParseSimulation(simulationData)
+			handleIfError(err)
+
+			simulations = append(simulations, simulation)
+		}
+
+		merged, duplicates := wrapper.MergeSimulations(simulations...)
+
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" {
+			simulationData, err := json.MarshalIndent(merged, "", "\t")
+			handleIfError(err)
+
+			fmt.Println(string(simulationData))
+			return
+		}
+
+		err := writeSimulationFile(output, format, "", merged)
+		handleIfError(err)
+
+		fmt.Printf("Merged %d pairs into %s\n", len(merged.RequestResponsePairs), output)
+		if duplicates > 0 {
+			fmt.Printf("Dropped %d pairs with the same request matcher as an earlier pair\n", duplicates)
+		}
+	},
+}
+
 func init() {
 	RootCmd.AddCommand(simulationCmd)
 	simulationCmd.AddCommand(addSimulationCmd)
+	simulationCmd.AddCommand(validateSimulationCmd)
+	simulationCmd.AddCommand(deleteSimulationPairCmd)
+	simulationCmd.AddCommand(summarySimulationCmd)
+	simulationCmd.AddCommand(delaysSimulationCmd)
+	simulationCmd.AddCommand(testSimulationCmd)
+	simulationCmd.AddCommand(splitSimulationCmd)
+	simulationCmd.AddCommand(mergeSimulationCmd)
+
+	addSimulationCmd.Flags().Bool("expand-env", false, "Replace ${VAR} and ${VAR:-default} in the simulations with environment variables")
+	addSimulationCmd.Flags().String("format", "", "Format of the simulation files, json or yaml. Defaults to yaml for .yml and .yaml files, otherwise json")
+	validateSimulationCmd.Flags().String("format", "", "Format of the simulation files, json or yaml. Defaults to yaml for .yml and .yaml files, otherwise json")
+
+	splitSimulationCmd.Flags().String("format", "", "Format of the simulation files, json or yaml. Defaults to the format of the simulation being split")
+	mergeSimulationCmd.Flags().String("format", "", "Format of the simulation files, json or yaml. Defaults to yaml for .yml and .yaml files, otherwise json")
+	mergeSimulationCmd.Flags().StringP("output", "o", "", "Path to write the merged simulation to")
+
+	testSimulationCmd.Flags().String("method", "GET", "Method of the request")
+	testSimulationCmd.Flags().String("url", "", "Url of the request, eg. http://api.example.com/orders?id=1")
+	testSimulationCmd.Flags().StringArray("header", []string{}, "A header of the request, eg. \"Content-Type: application/json\". Can be repeated")
+	testSimulationCmd.Flags().String("body", "", "Body of the request")
+
+	delaysSimulationCmd.Flags().Bool("from-capture", false, "Generate delays from the latency recorded on captured pairs")
+	delaysSimulationCmd.Flags().Float64("scale", 1, "Multiply each generated delay by this factor, eg. 0.5")
+	delaysSimulationCmd.Flags().Int("max", 0, "Cap each generated delay at this many milliseconds. 0 means no cap")
 }
@@ -67,6 +67,7 @@ hoverctl configuration file.
 		target.CachePath, _ = cmd.Flags().GetString("cache")
 		target.DisableCache, _ = cmd.Flags().GetBool("disable-cache")
 		target.ListenOnHost, _ = cmd.Flags().GetString("listen-on-host")
+		target.AdminOnProxyPort, _ = cmd.Flags().GetBool("admin-on-proxy-port")
 
 		target.CertificatePath, _ = cmd.Flags().GetString("certificate")
 		target.KeyPath, _ = cmd.Flags().GetString("key")
@@ -181,7 +182,8 @@ func init() {
 	startCmd.Flags().Bool("disable-tls", false, "Disable TLS verification")
 	startCmd.Flags().String("upstream-proxy", "", "A host for which Hoverfly will proxy its requests to")
 	startCmd.Flags().String("pac-file", "", "Configure upstream proxy by PAC file")
-	startCmd.Flags().String("listen-on-host", "", "Bind hoverfly listener to a host")
+	startCmd.Flags().String("listen-on-host", "", "An interface for the Hoverfly proxy and admin listeners to bind to, eg. 0.0.0.0 for all interfaces. Overrides the default (127.0.0.1)")
+	startCmd.Flags().Bool("admin-on-proxy-port", false, "Serve the Hoverfly API on the proxy port under the /__hoverfly path prefix instead of on the admin port")
 	startCmd.Flags().Bool("cors", false, "Enable CORS support")
 	startCmd.Flags().Bool("no-import-check", false, "Skip duplicate request check when importing simulations")
 
@@ -93,7 +93,7 @@ separated by a space.
 
 		checkTargetAndExit(target)
 
-		if len(args) < 2 {
+		if len(args) != 2 {
 			fmt.Fprintln(os.Stderr, "You must only provide a key and a value, separated by a space")
 			fmt.Fprintln(os.Stderr, "\nTry hoverctl state-store set --help for more information")
 			os.Exit(1)
@@ -109,9 +109,7 @@ var deleteStateCmd = &cobra.Command{
 	Use:   "delete-all",
 	Short: "Deletes all state",
 	Long: `
-Deletes the  state of Hoverfly. 
-
-Provide two arguments, the state key and the state value.
+Deletes all of the state keys and their values from Hoverfly.
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
 
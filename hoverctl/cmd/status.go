@@ -14,7 +14,8 @@ var statusCmd = &cobra.Command{
 	Long: `
 If Hoverfly is running, this command will show an overview
 of the instance of Hoverfly. This includes reporting the
-mode and middleware set.
+mode and middleware set, and how many requests were answered
+from the cache, matched by a request matcher or missed.
 `,
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -56,6 +57,9 @@ mode and middleware set.
 			{"Mode", hoverflyInfo.Mode},
 			{"Middleware", middlewareStatus},
 			{"CORS", corsStatus},
+			{"Cache hits", strconv.FormatInt(hoverflyInfo.Usage.Matching.CacheHits, 10)},
+			{"Matches", strconv.FormatInt(hoverflyInfo.Usage.Matching.MatcherHits, 10)},
+			{"Misses", strconv.FormatInt(hoverflyInfo.Usage.Matching.Misses, 10)},
 		}
 
 		drawTable(data, false)
@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
@@ -25,7 +26,14 @@ Get the current targets registered with hoverctl
 			{"Target name", "Host", "Admin port", "Proxy port", "Default"},
 		}
 
-		for key, target := range config.Targets {
+		names := make([]string, 0, len(config.Targets))
+		for name := range config.Targets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, key := range names {
+			target := config.Targets[key]
 			defaultMarker := ""
 			if target.Name == config.DefaultTarget {
 				defaultMarker = "X"
@@ -113,9 +121,10 @@ Update target
 		proxyPortFlag, err := cmd.Flags().GetInt("proxy-port")
 		handleIfError(err)
 
-		newTarget := configuration.NewTarget(args[0], hostFlag, adminPortFlag, proxyPortFlag)
+		updatedTarget := config.GetTarget(args[0])
+		updatedTarget.Update(hostFlag, adminPortFlag, proxyPortFlag)
 
-		config.NewTarget(*newTarget)
+		config.NewTarget(*updatedTarget)
 
 		handleIfError(config.WriteToFile(hoverflyDirectory))
 
@@ -166,7 +175,7 @@ func init() {
 	targetsNewCmd.Flags().Int("admin-port", 0, "A port number for the Hoverfly API/GUI. Overrides the default Hoverfly admin port (8888)")
 	targetsNewCmd.Flags().Int("proxy-port", 0, "A port number for the Hoverfly proxy. Overrides the default Hoverfly proxy port (8500)")
 	targetsNewCmd.Flags().String("host", "", "A host on which a Hoverfly instance is running. Overrides the default Hoverfly host (localhost)")
-	targetsUpdateCmd.Flags().Int("admin-port", 0, "A port number for the Hoverfly API/GUI. Overrides the default Hoverfly admin port (8888)")
-	targetsUpdateCmd.Flags().Int("proxy-port", 0, "A port number for the Hoverfly proxy. Overrides the default Hoverfly proxy port (8500)")
-	targetsUpdateCmd.Flags().String("host", "", "A host on which a Hoverfly instance is running. Overrides the default Hoverfly host (localhost)")
+	targetsUpdateCmd.Flags().Int("admin-port", 0, "A port number for the Hoverfly API/GUI. Keeps the target's current admin port if not set")
+	targetsUpdateCmd.Flags().Int("proxy-port", 0, "A port number for the Hoverfly proxy. Keeps the target's current proxy port if not set")
+	targetsUpdateCmd.Flags().String("host", "", "A host on which a Hoverfly instance is running. Keeps the target's current host if not set")
 }
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
+	"github.com/spf13/cobra"
+)
+
+var usersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Manage the users of Hoverfly",
+	Long: `
+Manages the users who can log in to a Hoverfly
+instance running with authentication enabled.
+	`,
+}
+
+var usersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the users of Hoverfly",
+	Long: `
+Lists the users who can log in to Hoverfly,
+and whether they are admins.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		users, err := wrapper.GetUsers(*target)
+		handleIfError(err)
+
+		if len(users) == 0 {
+			fmt.Println("Hoverfly has no users")
+			return
+		}
+
+		sort.Slice(users, func(i, j int) bool {
+			return users[i].Username < users[j].Username
+		})
+
+		data := [][]string{
+			{"Username", "Admin"},
+		}
+		for _, user := range users {
+			admin := ""
+			if user.IsAdmin {
+				admin = "X"
+			}
+			data = append(data, []string{user.Username, admin})
+		}
+
+		drawTable(data, true)
+	},
+}
+
+var usersAddCmd = &cobra.Command{
+	Use:   "add [username]",
+	Short: "Add a user to Hoverfly",
+	Long: `
+Adds a user who can log in to Hoverfly. You will
+be prompted for their password unless --password
+is given. Passwords must be at least 8 characters.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+		checkArgAndExit(args, "You have not provided a username", "users add")
+
+		userPassword, _ := cmd.Flags().GetString("password")
+		if userPassword == "" {
+			userPassword = askForInput("Password", true)
+		}
+		if userPassword == "" {
+			handleIfError(errors.New("missing password"))
+		}
+
+		admin, _ := cmd.Flags().GetBool("admin")
+
+		handleIfError(wrapper.AddUser(*target, args[0], userPassword, admin))
+
+		fmt.Println("User", args[0], "has been added to Hoverfly")
+	},
+}
+
+var usersDeleteCmd = &cobra.Command{
+	Use:   "delete [username]",
+	Short: "Delete a user from Hoverfly",
+	Long: `
+Deletes a user from Hoverfly. Their tokens stop
+working straight away. The last admin user cannot
+be deleted.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+		checkArgAndExit(args, "You have not provided a username", "users delete")
+
+		if !askForConfirmation("Are you sure you want to delete the user " + args[0] + "?") {
+			return
+		}
+
+		handleIfError(wrapper.DeleteUser(*target, args[0]))
+
+		fmt.Println("User", args[0], "has been deleted from Hoverfly")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(usersCmd)
+	usersCmd.AddCommand(usersListCmd)
+	usersCmd.AddCommand(usersAddCmd)
+	usersCmd.AddCommand(usersDeleteCmd)
+
+	usersAddCmd.Flags().String("password", "", "The password for the user")
+	usersAddCmd.Flags().Bool("admin", false, "Make the user an admin, who can manage users")
+}
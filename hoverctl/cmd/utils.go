@@ -7,7 +7,9 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
 	"github.com/olekukonko/tablewriter"
 	"golang.org/x/crypto/ssh/terminal"
 )
@@ -86,3 +88,42 @@ func drawTable(data [][]string, header bool) {
 	fmt.Print("\n")
 	table.Render()
 }
+
+// readSimulationFile reads a simulation from a file or URL, converting it to JSON if the format
+// is yaml or, when no format is given, the path ends in .yml or .yaml
+func readSimulationFile(path, format string) ([]byte, error) {
+	simulationData, err := configuration.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	isYAML, err := wrapper.IsYAMLSimulation(format, path)
+	if err != nil || !isYAML {
+		return simulationData, err
+	}
+
+	return wrapper.SimulationYAMLToJSON(simulationData)
+}
+
+// writeSimulationFile writes a simulation as JSON indented with indent, or as YAML if the format is yaml
+// or, when no format is given, the path ends in .yml or .yaml
+func writeSimulationFile(path, format, indent string, simulation v2.SimulationViewV5) error {
+	isYAML, err := wrapper.IsYAMLSimulation(format, path)
+	if err != nil {
+		return err
+	}
+
+	simulationData, err := wrapper.MarshalSimulation(simulation, indent)
+	if err != nil {
+		return err
+	}
+
+	if isYAML {
+		simulationData, err = wrapper.SimulationJSONToYAML(simulationData)
+		if err != nil {
+			return err
+		}
+	}
+
+	return configuration.WriteFile(path, simulationData)
+}
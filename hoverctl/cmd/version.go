@@ -1,34 +1,55 @@
 package cmd
 
 import (
+	"fmt"
 	"os/exec"
+	"strings"
 
+	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
 	"github.com/kardianos/osext"
 	"github.com/spf13/cobra"
 )
 
 var versionCmd = &cobra.Command{
 	Use:   "version",
-	Short: "Get the version of hoverctl",
+	Short: "Get the version of hoverctl and Hoverfly",
 	Long: `
-Shows the hoverctl version.
+Shows the hoverctl version, and the version of Hoverfly.
+
+When the target Hoverfly is running, its version is
+retrieved from it, otherwise the version of the hoverfly
+binary next to hoverctl is shown. A warning is printed
+when hoverctl and Hoverfly are different major or minor
+versions, as they may not be compatible.
 `,
 
 	Run: func(cmd *cobra.Command, args []string) {
 
-		binaryLocation, err := osext.ExecutableFolder()
-		handleIfError(err)
+		hoverflyVersion := ""
+		if target != nil && wrapper.CheckIfRunning(*target) == nil {
+			var err error
+			hoverflyVersion, err = wrapper.GetVersion(*target)
+			handleIfError(err)
+		} else {
+			binaryLocation, err := osext.ExecutableFolder()
+			handleIfError(err)
 
-		hoverflyCmd := exec.Command(binaryLocation+"/hoverfly", "-version")
+			hoverflyCmd := exec.Command(binaryLocation+"/hoverfly", "-version")
 
-		hoverflyVersion, _ := hoverflyCmd.CombinedOutput()
+			output, _ := hoverflyCmd.CombinedOutput()
+			hoverflyVersion = strings.TrimSpace(string(output))
+		}
 
 		data := [][]string{
 			{"hoverctl", version},
-			{"hoverfly", string(hoverflyVersion)},
+			{"hoverfly", hoverflyVersion},
 		}
 
 		drawTable(data, false)
+
+		if err := wrapper.CheckVersionCompatibility(version, hoverflyVersion); err != nil {
+			fmt.Println("Warning: " + err.Error())
+		}
 	},
 }
 
@@ -0,0 +1,88 @@
+package configuration
+
+import (
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type keychainAuth struct {
+	Token    string `json:"token"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// SetAuth - sets the auth token and the credentials used to get it, which are used to log in again when the
+// token expires
+func (this *Target) SetAuth(token, username, password string) {
+	this.AuthToken = token
+	this.AuthUsername = username
+	this.AuthPassword = password
+}
+
+// StoreAuth - stores the auth token and credentials in the keychain, so that they are left out of the config
+// file. When useKeychain is false, or there is no keychain, only the auth token is kept in the config file, and
+// the credentials are dropped, so the user has to log in again when it expires.
+func (this *Target) StoreAuth(useKeychain bool) {
+	if useKeychain {
+		auth, _ := json.Marshal(keychainAuth{
+			Token:    this.AuthToken,
+			Username: this.AuthUsername,
+			Password: this.AuthPassword,
+		})
+
+		err := SystemKeychain.Set(this.Name, string(auth))
+		if err == nil {
+			this.AuthKeychain = true
+			return
+		}
+		log.Debug("Could not store the auth token in the keychain, storing it in the config file: " + err.Error())
+	}
+
+	if this.AuthKeychain {
+		this.DeleteAuth()
+	}
+	this.AuthKeychain = false
+	this.AuthUsername = ""
+	this.AuthPassword = ""
+}
+
+// LoadAuth - reads the auth token and credentials from the keychain, when they are stored there
+func (this *Target) LoadAuth() error {
+	if !this.AuthKeychain {
+		return nil
+	}
+
+	secret, err := SystemKeychain.Get(this.Name)
+	if err != nil {
+		return err
+	}
+
+	var auth keychainAuth
+	if err := json.Unmarshal([]byte(secret), &auth); err != nil {
+		return err
+	}
+
+	this.SetAuth(auth.Token, auth.Username, auth.Password)
+	return nil
+}
+
+// DeleteAuth - removes the auth token and credentials from the keychain
+func (this *Target) DeleteAuth() {
+	if !this.AuthKeychain {
+		return
+	}
+
+	if err := SystemKeychain.Delete(this.Name); err != nil {
+		log.Debug("Could not delete the auth token from the keychain: " + err.Error())
+	}
+	this.AuthKeychain = false
+}
+
+// withoutKeychainAuth - the target as it is written to the config file, without anything stored in the keychain
+func (this Target) withoutKeychainAuth() Target {
+	if this.AuthKeychain {
+		this.SetAuth("", "", "")
+	}
+	return this
+}
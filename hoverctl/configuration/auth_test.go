@@ -0,0 +1,128 @@
+package configuration
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type fakeKeychain struct {
+	secrets map[string]string
+}
+
+func (this *fakeKeychain) Get(account string) (string, error) {
+	secret, found := this.secrets[account]
+	if !found {
+		return "", errors.New("not found")
+	}
+	return secret, nil
+}
+
+func (this *fakeKeychain) Set(account, secret string) error {
+	if this.secrets == nil {
+		return errNoKeychain
+	}
+	this.secrets[account] = secret
+	return nil
+}
+
+func (this *fakeKeychain) Delete(account string) error {
+	delete(this.secrets, account)
+	return nil
+}
+
+func useKeychain(t *testing.T, keychain Keychain) {
+	original := SystemKeychain
+	SystemKeychain = keychain
+	t.Cleanup(func() {
+		SystemKeychain = original
+	})
+}
+
+func Test_Target_StoreAuth_StoresTheTokenAndCredentialsInTheKeychain(t *testing.T) {
+	RegisterTestingT(t)
+
+	keychain := &fakeKeychain{secrets: map[string]string{}}
+	useKeychain(t, keychain)
+
+	unit := NewTarget("remote", "", 0, 0)
+	unit.SetAuth("token", "bob", "secret")
+	unit.StoreAuth(true)
+
+	Expect(unit.AuthKeychain).To(BeTrue())
+	Expect(keychain.secrets["remote"]).To(Equal(`{"token":"token","username":"bob","password":"secret"}`))
+
+	loaded := Target{Name: "remote", AuthKeychain: true}
+	Expect(loaded.LoadAuth()).To(Succeed())
+	Expect(loaded.AuthToken).To(Equal("token"))
+	Expect(loaded.AuthUsername).To(Equal("bob"))
+	Expect(loaded.AuthPassword).To(Equal("secret"))
+}
+
+func Test_Target_StoreAuth_FallsBackToTheConfigFileWithoutAKeychain(t *testing.T) {
+	RegisterTestingT(t)
+
+	useKeychain(t, &fakeKeychain{})
+
+	unit := NewTarget("remote", "", 0, 0)
+	unit.SetAuth("token", "bob", "secret")
+	unit.StoreAuth(true)
+
+	Expect(unit.AuthKeychain).To(BeFalse())
+	Expect(unit.withoutKeychainAuth().AuthToken).To(Equal("token"))
+	Expect(unit.AuthUsername).To(BeEmpty())
+	Expect(unit.AuthPassword).To(BeEmpty())
+}
+
+func Test_Target_StoreAuth_RemovesTheTokenFromTheKeychainWhenNotUsingIt(t *testing.T) {
+	RegisterTestingT(t)
+
+	keychain := &fakeKeychain{secrets: map[string]string{"remote": `{"token":"old"}`}}
+	useKeychain(t, keychain)
+
+	unit := Target{Name: "remote", AuthKeychain: true}
+	unit.SetAuth("token", "bob", "secret")
+	unit.StoreAuth(false)
+
+	Expect(unit.AuthKeychain).To(BeFalse())
+	Expect(keychain.secrets).To(BeEmpty())
+}
+
+func Test_Config_WriteToFile_LeavesOutAuthStoredInTheKeychain(t *testing.T) {
+	RegisterTestingT(t)
+
+	useKeychain(t, &fakeKeychain{secrets: map[string]string{}})
+
+	directory, err := ioutil.TempDir("", "hoverctl")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(directory)
+
+	keychainTarget := NewTarget("keychain", "", 0, 0)
+	keychainTarget.SetAuth("keychain-token", "bob", "keychain-secret")
+	keychainTarget.StoreAuth(true)
+
+	fileTarget := NewTarget("file", "", 0, 0)
+	fileTarget.SetAuth("file-token", "alice", "file-secret")
+	fileTarget.StoreAuth(false)
+
+	unit := &Config{Targets: map[string]Target{}}
+	unit.NewTarget(*keychainTarget)
+	unit.NewTarget(*fileTarget)
+
+	Expect(unit.WriteToFile(HoverflyDirectory{Path: directory})).To(Succeed())
+
+	data, err := ioutil.ReadFile(filepath.Join(directory, "config.yaml"))
+	Expect(err).To(BeNil())
+	Expect(string(data)).ToNot(ContainSubstring("keychain-token"))
+	Expect(string(data)).ToNot(ContainSubstring("keychain-secret"))
+	Expect(string(data)).To(ContainSubstring("auth.keychain: true"))
+	Expect(string(data)).To(ContainSubstring("auth.token: file-token"))
+	Expect(string(data)).ToNot(ContainSubstring("file-secret"))
+	Expect(string(data)).ToNot(ContainSubstring("auth.username"))
+
+	Expect(unit.Targets["keychain"].AuthToken).To(Equal("keychain-token"))
+}
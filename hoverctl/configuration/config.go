@@ -63,6 +63,10 @@ func parseConfig() *Config {
 		if target.ProxyPort == 0 {
 			target.ProxyPort = defaultTarget.ProxyPort
 		}
+
+		if err := target.LoadAuth(); err != nil {
+			log.Debug("Could not read the auth token for " + key + " from the keychain: " + err.Error())
+		}
 		config.Targets[key] = target
 	}
 
@@ -93,6 +97,8 @@ func (this *Config) DeleteTarget(targetToDelete Target) {
 	for key, target := range this.Targets {
 		if key != targetToDelete.Name {
 			targets[key] = target
+		} else {
+			target.DeleteAuth()
 		}
 	}
 
@@ -104,7 +110,15 @@ func (c *Config) GetFilepath() string {
 }
 
 func (c *Config) WriteToFile(hoverflyDirectory HoverflyDirectory) error {
-	data, err := yaml.Marshal(c)
+	fileConfig := Config{
+		DefaultTarget: c.DefaultTarget,
+		Targets:       map[string]Target{},
+	}
+	for key, target := range c.Targets {
+		fileConfig.Targets[key] = target.withoutKeychainAuth()
+	}
+
+	data, err := yaml.Marshal(fileConfig)
 
 	if err != nil {
 		log.Debug(err.Error())
@@ -10,6 +10,7 @@ import (
 
 	"net/http"
 
+	"github.com/SpectoLabs/hoverfly/core/util"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -27,6 +28,9 @@ func WriteFile(filePath string, data []byte) error {
 }
 
 func ReadFile(filePath string) ([]byte, error) {
+	if util.IsObjectStorageURI(filePath) {
+		return util.FetchObject(filePath)
+	}
 	if strings.HasPrefix(filePath, "http://") || strings.HasPrefix(filePath, "https://") {
 		return DownloadFile(filePath)
 	}
@@ -0,0 +1,82 @@
+package configuration
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const keychainService = "hoverctl"
+
+var errNoKeychain = errors.New("No keychain is available")
+
+// Keychain - stores secrets in the operating system's keychain, one per account
+type Keychain interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// SystemKeychain - the keychain auth tokens are stored in. It uses the macOS keychain through `security`, and
+// the Secret Service, eg. GNOME Keyring, on Linux through `secret-tool`. Other systems have no keychain.
+var SystemKeychain Keychain = commandKeychain{}
+
+type commandKeychain struct{}
+
+func (commandKeychain) Get(account string) (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return runKeychainCommand("", "security", "find-generic-password", "-s", keychainService, "-a", account, "-w")
+	case "linux":
+		return runKeychainCommand("", "secret-tool", "lookup", "service", keychainService, "account", account)
+	}
+	return "", errNoKeychain
+}
+
+func (commandKeychain) Set(account, secret string) error {
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		// -w is given last without a value so that security prompts for the secret, which is then read from stdin
+		// rather than being visible to other processes in the arguments. The prompt asks for it twice.
+		_, err = runKeychainCommand(secret+"\n"+secret+"\n", "security", "add-generic-password", "-U", "-s", keychainService, "-a", account, "-w")
+	case "linux":
+		_, err = runKeychainCommand(secret, "secret-tool", "store", "--label=hoverctl "+account, "service", keychainService, "account", account)
+	default:
+		err = errNoKeychain
+	}
+	return err
+}
+
+func (commandKeychain) Delete(account string) error {
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		_, err = runKeychainCommand("", "security", "delete-generic-password", "-s", keychainService, "-a", account)
+	case "linux":
+		_, err = runKeychainCommand("", "secret-tool", "clear", "service", keychainService, "account", account)
+	default:
+		err = errNoKeychain
+	}
+	return err
+}
+
+func runKeychainCommand(stdin, name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", errNoKeychain
+	}
+
+	var stdout, stderr bytes.Buffer
+	command := exec.Command(name, args...)
+	command.Stdin = strings.NewReader(stdin)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	if err := command.Run(); err != nil {
+		return "", errors.New("Keychain error: " + strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -14,11 +14,20 @@ type Target struct {
 	AuthToken string `mapstructure:"auth.token,omitempty" yaml:"auth.token,omitempty"`
 	Pid       int    `yaml:"pid,omitempty"`
 
+	// AuthUsername and AuthPassword are the credentials hoverctl logged in with, kept to log in again when the
+	// auth token expires. They are only ever stored in the OS keychain, with the token, when AuthKeychain is set,
+	// and are never written to the config file.
+	AuthUsername string `mapstructure:"-" yaml:"-"`
+	AuthPassword string `mapstructure:"-" yaml:"-"`
+	AuthKeychain bool   `mapstructure:"auth.keychain,omitempty" yaml:"auth.keychain,omitempty"`
+
 	Webserver    bool   `yaml:",omitempty"`
 	CachePath    string `yaml:",omitempty"`
 	DisableCache bool   `yaml:",omitempty"`
 	ListenOnHost string `yaml:",omitempty"`
 
+	AdminOnProxyPort bool `yaml:",omitempty"`
+
 	CertificatePath string `yaml:",omitempty"`
 	KeyPath         string `yaml:",omitempty"`
 	DisableTls      bool   `yaml:",omitempty"`
@@ -60,19 +69,25 @@ func NewTarget(name, host string, adminPort, proxyPort int) *Target {
 		target.Name = name
 	}
 
+	target.Update(host, adminPort, proxyPort)
+
+	return target
+}
+
+// Update overrides the host and ports of the target with any that are not empty,
+// keeping the rest of the target, such as its auth token, as it is.
+func (this *Target) Update(host string, adminPort, proxyPort int) {
 	if host != "" {
-		target.Host = host
+		this.Host = host
 	}
 
 	if adminPort != 0 {
-		target.AdminPort = adminPort
+		this.AdminPort = adminPort
 	}
 
 	if proxyPort != 0 {
-		target.ProxyPort = proxyPort
+		this.ProxyPort = proxyPort
 	}
-
-	return target
 }
 
 func (this Target) BuildFlags() Flags {
@@ -120,6 +135,10 @@ func (this Target) BuildFlags() Flags {
 		flags = append(flags, "-listen-on-host="+this.ListenOnHost)
 	}
 
+	if this.AdminOnProxyPort {
+		flags = append(flags, "-admin-on-proxy-port")
+	}
+
 	if this.CertificatePath != "" {
 		flags = append(flags, "-cert="+this.CertificatePath)
 	}
@@ -61,6 +61,45 @@ func Test_NewTarget_OverridesProxyPortfNotEmpty(t *testing.T) {
 	}))
 }
 
+func Test_Target_Update_OverridesOnlyValuesThatAreNotEmpty(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := Target{
+		Name:      "staging",
+		Host:      "staging.internal",
+		AdminPort: 8888,
+		ProxyPort: 8500,
+		AuthToken: "token",
+	}
+
+	unit.Update("", 1234, 0)
+
+	Expect(unit).To(Equal(Target{
+		Name:      "staging",
+		Host:      "staging.internal",
+		AdminPort: 1234,
+		ProxyPort: 8500,
+		AuthToken: "token",
+	}))
+}
+
+func Test_Target_Update_OverridesHostAndProxyPort(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := Target{
+		Name:      "staging",
+		Host:      "staging.internal",
+		AdminPort: 8888,
+		ProxyPort: 8500,
+	}
+
+	unit.Update("ci.internal", 0, 9500)
+
+	Expect(unit.Host).To(Equal("ci.internal"))
+	Expect(unit.AdminPort).To(Equal(8888))
+	Expect(unit.ProxyPort).To(Equal(9500))
+}
+
 func Test_Target_BuildFlags_AdminPortSetsTheApFlag(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -83,6 +122,16 @@ func Test_Target_BuildFlags_ProxyPortSetsThePpFlag(t *testing.T) {
 	Expect(unit.BuildFlags()[0]).To(Equal("-pp=3421"))
 }
 
+func Test_Target_BuildFlags_AdminOnProxyPortAddsTheFlag(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := Target{
+		AdminOnProxyPort: true,
+	}
+
+	Expect(unit.BuildFlags()).To(Equal(Flags{"-admin-on-proxy-port"}))
+}
+
 func Test_Target_BuildFlags_SettingWebserverToTrueAddsTheFlag(t *testing.T) {
 	RegisterTestingT(t)
 
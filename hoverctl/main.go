@@ -3,7 +3,8 @@ package main
 import "github.com/SpectoLabs/hoverfly/hoverctl/cmd"
 
 var (
-	hoverctlVersion = "0.0.1"
+	// set with -ldflags "-X main.hoverctlVersion=<tag>" when releasing
+	hoverctlVersion = "dev"
 )
 
 func main() {
@@ -1,6 +1,8 @@
 package wrapper
 
 import (
+	"net/url"
+
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
 )
 
@@ -17,3 +19,13 @@ func FlushCache(target configuration.Target) error {
 
 	return nil
 }
+
+// FlushCacheForDestination will flush only the cached responses for requests to the destination
+func FlushCacheForDestination(target configuration.Target, destination string) error {
+	response, err := doRequest(target, "DELETE", v2ApiCache+"?destination="+url.QueryEscape(destination), "", nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponseError(response, "Could not flush cache")
+}
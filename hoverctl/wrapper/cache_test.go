@@ -92,3 +92,49 @@ func Test_FlushCache_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 	Expect(err.Error()).To(Equal("Could not flush cache\n\ntest error"))
 }
+
+func Test_FlushCacheForDestination_SendsTheDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "DELETE",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/cache",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"destination": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "api.example.com",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"cache": []}`,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := FlushCacheForDestination(target, "api.example.com")
+	Expect(err).To(BeNil())
+}
@@ -0,0 +1,92 @@
+package wrapper
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+)
+
+type captureDelayKey struct {
+	urlPattern string
+	httpMethod string
+}
+
+// DelaysFromCapture builds a global delay for each captured host, path and method from the
+// latency recorded on the captured pairs. Pairs captured more than once, eg. with different
+// query strings, are averaged. Each delay is multiplied by scale and, when max is greater
+// than zero, capped at max milliseconds. Pairs without recorded latency, or without exact
+// destination and path matchers, are ignored.
+func DelaysFromCapture(simulation v2.SimulationViewV5, scale float64, max int) []ResponseDelaySchema {
+	totals := map[captureDelayKey]int{}
+	counts := map[captureDelayKey]int{}
+
+	for _, pair := range simulation.RequestResponsePairs {
+		if pair.Response.LatencyMs <= 0 {
+			continue
+		}
+
+		destination, ok := exactMatcherValue(pair.RequestMatcher.Destination)
+		if !ok {
+			continue
+		}
+
+		path, ok := exactMatcherValue(pair.RequestMatcher.Path)
+		if !ok {
+			continue
+		}
+
+		method, _ := exactMatcherValue(pair.RequestMatcher.Method)
+
+		key := captureDelayKey{
+			urlPattern: "^" + regexp.QuoteMeta(destination+path) + "$",
+			httpMethod: method,
+		}
+		totals[key] += pair.Response.LatencyMs
+		counts[key]++
+	}
+
+	delays := []ResponseDelaySchema{}
+	for key, total := range totals {
+		delay := int(float64(total) / float64(counts[key]) * scale)
+		if max > 0 && delay > max {
+			delay = max
+		}
+
+		delays = append(delays, ResponseDelaySchema{
+			UrlPattern: key.urlPattern,
+			HttpMethod: key.httpMethod,
+			Delay:      delay,
+		})
+	}
+
+	sort.Slice(delays, func(i, j int) bool {
+		if delays[i].UrlPattern != delays[j].UrlPattern {
+			return delays[i].UrlPattern < delays[j].UrlPattern
+		}
+		return delays[i].HttpMethod < delays[j].HttpMethod
+	})
+
+	return delays
+}
+
+// MergeDelays replaces any existing delay with the same url pattern and method as one of the
+// generated delays, and appends the rest.
+func MergeDelays(existing, generated []ResponseDelaySchema) []ResponseDelaySchema {
+	merged := append([]ResponseDelaySchema{}, existing...)
+
+	for _, delay := range generated {
+		merged = AddDelay(merged, delay)
+	}
+
+	return merged
+}
+
+func exactMatcherValue(matchers []v2.MatcherViewV5) (string, bool) {
+	if len(matchers) != 1 || matchers[0].Matcher != "exact" {
+		return "", false
+	}
+
+	value, ok := matchers[0].Value.(string)
+	return value, ok
+}
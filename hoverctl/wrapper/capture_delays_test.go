@@ -0,0 +1,96 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	. "github.com/onsi/gomega"
+)
+
+func capturedPair(destination, path, method string, latency int) v2.RequestMatcherResponsePairViewV5 {
+	return v2.RequestMatcherResponsePairViewV5{
+		RequestMatcher: v2.RequestMatcherViewV5{
+			Destination: []v2.MatcherViewV5{{Matcher: "exact", Value: destination}},
+			Path:        []v2.MatcherViewV5{{Matcher: "exact", Value: path}},
+			Method:      []v2.MatcherViewV5{{Matcher: "exact", Value: method}},
+		},
+		Response: v2.ResponseDetailsViewV5{Status: 200, LatencyMs: latency},
+	}
+}
+
+func Test_DelaysFromCapture_AveragesLatencyPerHostPathAndMethod(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				capturedPair("test.com", "/a", "GET", 100),
+				capturedPair("test.com", "/a", "GET", 300),
+				capturedPair("test.com", "/a", "POST", 50),
+				capturedPair("other.com", "/b.json", "GET", 20),
+				capturedPair("test.com", "/not-captured", "GET", 0),
+			},
+		},
+	}
+
+	delays := DelaysFromCapture(simulation, 1, 0)
+
+	Expect(delays).To(Equal([]ResponseDelaySchema{
+		{UrlPattern: `^other\.com/b\.json$`, HttpMethod: "GET", Delay: 20},
+		{UrlPattern: `^test\.com/a$`, HttpMethod: "GET", Delay: 200},
+		{UrlPattern: `^test\.com/a$`, HttpMethod: "POST", Delay: 50},
+	}))
+}
+
+func Test_DelaysFromCapture_ScalesAndCapsDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				capturedPair("test.com", "/fast", "GET", 100),
+				capturedPair("test.com", "/slow", "GET", 5000),
+			},
+		},
+	}
+
+	delays := DelaysFromCapture(simulation, 0.5, 1000)
+
+	Expect(delays).To(HaveLen(2))
+	Expect(delays[0].Delay).To(Equal(50))
+	Expect(delays[1].Delay).To(Equal(1000))
+}
+
+func Test_DelaysFromCapture_IgnoresPairsWithoutExactDestinationAndPath(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := capturedPair("test.com", "/a", "GET", 100)
+	pair.RequestMatcher.Path = []v2.MatcherViewV5{{Matcher: "glob", Value: "/*"}}
+
+	delays := DelaysFromCapture(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{pair}},
+	}, 1, 0)
+
+	Expect(delays).To(BeEmpty())
+}
+
+func Test_MergeDelays_ReplacesMatchingDelaysAndAppendsTheRest(t *testing.T) {
+	RegisterTestingT(t)
+
+	merged := MergeDelays(
+		[]ResponseDelaySchema{
+			{UrlPattern: ".", Delay: 10},
+			{UrlPattern: "^test.com/a$", HttpMethod: "GET", Delay: 10},
+		},
+		[]ResponseDelaySchema{
+			{UrlPattern: "^test.com/a$", HttpMethod: "GET", Delay: 200},
+			{UrlPattern: "^test.com/b$", HttpMethod: "GET", Delay: 300},
+		},
+	)
+
+	Expect(merged).To(Equal([]ResponseDelaySchema{
+		{UrlPattern: ".", Delay: 10},
+		{UrlPattern: "^test.com/a$", HttpMethod: "GET", Delay: 200},
+		{UrlPattern: "^test.com/b$", HttpMethod: "GET", Delay: 300},
+	}))
+}
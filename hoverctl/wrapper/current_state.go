@@ -18,6 +18,11 @@ func GetCurrentState(target configuration.Target) (map[string]string, error) {
 
 	defer res.Body.Close()
 
+	err = handleResponseError(res, "Could not retrieve state")
+	if err != nil {
+		return nil, err
+	}
+
 	responseBody, err := ioutil.ReadAll(res.Body)
 
 	if err != nil {
@@ -47,14 +52,24 @@ func PatchCurrentState(target configuration.Target, key, value string) error {
 		return err
 	}
 
-	_, err = doRequest(target, "PATCH", v2ApiState, string(marshal), nil)
+	res, err := doRequest(target, "PATCH", v2ApiState, string(marshal), nil)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
 
-	return err
+	return handleResponseError(res, "Could not set state")
 }
 
 func DeleteCurrentState(target configuration.Target) error {
 
-	_, err := doRequest(target, "DELETE", v2ApiState, "", nil)
+	res, err := doRequest(target, "DELETE", v2ApiState, "", nil)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
 
-	return err
+	return handleResponseError(res, "Could not delete state")
 }
@@ -0,0 +1,117 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func stateSimulation(method string, status int, body string) v2.SimulationViewV5 {
+	return v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   method,
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/state",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: status,
+						Body:   body,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	}
+}
+
+func Test_GetCurrentState_GetsStateFromHoverfly(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(stateSimulation("GET", 200, `{"state": {"sequence:1": "2", "basket": "full"}}`))
+
+	state, err := GetCurrentState(target)
+	Expect(err).To(BeNil())
+
+	Expect(state).To(HaveLen(2))
+	Expect(state["sequence:1"]).To(Equal("2"))
+	Expect(state["basket"]).To(Equal("full"))
+}
+
+func Test_GetCurrentState_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := GetCurrentState(inaccessibleTarget)
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_GetCurrentState_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(stateSimulation("GET", 400, `{"error":"test error"}`))
+
+	_, err := GetCurrentState(target)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not retrieve state\n\ntest error"))
+}
+
+func Test_PatchCurrentState_SetsStateInHoverfly(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(stateSimulation("PATCH", 200, `{"state": {"basket": "full"}}`))
+
+	err := PatchCurrentState(target, "basket", "full")
+	Expect(err).To(BeNil())
+}
+
+func Test_PatchCurrentState_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(stateSimulation("PATCH", 400, `{"error":"test error"}`))
+
+	err := PatchCurrentState(target, "basket", "full")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not set state\n\ntest error"))
+}
+
+func Test_DeleteCurrentState_DeletesStateInHoverfly(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(stateSimulation("DELETE", 200, ``))
+
+	err := DeleteCurrentState(target)
+	Expect(err).To(BeNil())
+}
+
+func Test_DeleteCurrentState_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(stateSimulation("DELETE", 400, `{"error":"test error"}`))
+
+	err := DeleteCurrentState(target)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not delete state\n\ntest error"))
+}
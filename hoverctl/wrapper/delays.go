@@ -0,0 +1,104 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"regexp"
+
+	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+)
+
+// GetDelays will go the delays endpoint in Hoverfly and return the global response delays
+func GetDelays(target configuration.Target) ([]ResponseDelaySchema, error) {
+	response, err := doRequest(target, "GET", v2ApiDelays, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = handleResponseError(response, "Could not retrieve delays")
+	if err != nil {
+		return nil, err
+	}
+
+	var delaysView APIDelaySchema
+
+	err = UnmarshalToInterface(response, &delaysView)
+	if err != nil {
+		return nil, err
+	}
+
+	return delaysView.Data, nil
+}
+
+// SetDelays will go the delays endpoint in Hoverfly, replace the global response delays and return them
+func SetDelays(target configuration.Target, delays []ResponseDelaySchema) ([]ResponseDelaySchema, error) {
+	bytes, _ := json.Marshal(APIDelaySchema{Data: delays})
+
+	response, err := doRequest(target, "PUT", v2ApiDelays, string(bytes), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = handleResponseError(response, "Could not set delays")
+	if err != nil {
+		return nil, err
+	}
+
+	var delaysView APIDelaySchema
+
+	err = UnmarshalToInterface(response, &delaysView)
+	if err != nil {
+		return nil, err
+	}
+
+	return delaysView.Data, nil
+}
+
+// DeleteDelays will go the delays endpoint in Hoverfly and delete all of the global response delays
+func DeleteDelays(target configuration.Target) error {
+	response, err := doRequest(target, "DELETE", v2ApiDelays, "", nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponseError(response, "Could not delete delays")
+}
+
+// DelayUrlPattern builds the url pattern of a delay for requests to the host and, if given,
+// the exact path. Without a path the delay applies to every path on the host.
+func DelayUrlPattern(host, path string) string {
+	if path == "" {
+		return "^" + regexp.QuoteMeta(host) + "/"
+	}
+
+	return "^" + regexp.QuoteMeta(host+path) + "$"
+}
+
+// AddDelay replaces the delay with the same url pattern and method, or appends it if there is none.
+func AddDelay(delays []ResponseDelaySchema, delay ResponseDelaySchema) []ResponseDelaySchema {
+	updated := append([]ResponseDelaySchema{}, delays...)
+
+	for i, current := range updated {
+		if current.UrlPattern == delay.UrlPattern && current.HttpMethod == delay.HttpMethod {
+			updated[i] = delay
+			return updated
+		}
+	}
+
+	return append(updated, delay)
+}
+
+// RemoveDelay removes the delay with the url pattern and method, reporting whether there was one.
+func RemoveDelay(delays []ResponseDelaySchema, urlPattern, httpMethod string) ([]ResponseDelaySchema, bool) {
+	updated := []ResponseDelaySchema{}
+	removed := false
+
+	for _, current := range delays {
+		if current.UrlPattern == urlPattern && current.HttpMethod == httpMethod {
+			removed = true
+			continue
+		}
+		updated = append(updated, current)
+	}
+
+	return updated, removed
+}
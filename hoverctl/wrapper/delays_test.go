@@ -0,0 +1,220 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_GetDelays_GetsDelaysFromHoverfly(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/delays",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"data": [{"urlPattern": "^payments\\.internal/", "httpMethod": "GET", "delay": 500}]}`,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	delays, err := GetDelays(target)
+	Expect(err).To(BeNil())
+
+	Expect(delays).To(ConsistOf(ResponseDelaySchema{UrlPattern: `^payments\.internal/`, HttpMethod: "GET", Delay: 500}))
+}
+
+func Test_GetDelays_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := GetDelays(inaccessibleTarget)
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_SetDelays_SendsDelaysAndReturnsDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "PUT",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/delays",
+							},
+						},
+						Body: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Json,
+								Value:   `{"data": [{"urlPattern": "orders", "httpMethod": "POST", "delay": 100}]}`,
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"data": [{"urlPattern": "orders", "httpMethod": "POST", "delay": 100}]}`,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	delays, err := SetDelays(target, []ResponseDelaySchema{{UrlPattern: "orders", HttpMethod: "POST", Delay: 100}})
+	Expect(err).To(BeNil())
+
+	Expect(delays).To(ConsistOf(ResponseDelaySchema{UrlPattern: "orders", HttpMethod: "POST", Delay: 100}))
+}
+
+func Test_SetDelays_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "PUT",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/delays",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 422,
+						Body:   "{\"error\":\"Config error - Delay cannot be negative\"}",
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	_, err := SetDelays(target, []ResponseDelaySchema{{UrlPattern: "orders", Delay: -1}})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not set delays\n\nConfig error - Delay cannot be negative"))
+}
+
+func Test_DeleteDelays_SendsCorrectHTTPRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "DELETE",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/delays",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"data": []}`,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := DeleteDelays(target)
+	Expect(err).To(BeNil())
+}
+
+func Test_DelayUrlPattern_MatchesAnyPathOnHostWithoutPath(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(DelayUrlPattern("payments.internal", "")).To(Equal(`^payments\.internal/`))
+	Expect(DelayUrlPattern("payments.internal", "/charges")).To(Equal(`^payments\.internal/charges$`))
+}
+
+func Test_AddDelay_ReplacesDelayWithSameUrlPatternAndMethod(t *testing.T) {
+	RegisterTestingT(t)
+
+	delays := []ResponseDelaySchema{
+		{UrlPattern: "payments", HttpMethod: "GET", Delay: 100},
+		{UrlPattern: "payments", HttpMethod: "", Delay: 200},
+	}
+
+	Expect(AddDelay(delays, ResponseDelaySchema{UrlPattern: "payments", HttpMethod: "GET", Delay: 500})).To(Equal([]ResponseDelaySchema{
+		{UrlPattern: "payments", HttpMethod: "GET", Delay: 500},
+		{UrlPattern: "payments", HttpMethod: "", Delay: 200},
+	}))
+	Expect(AddDelay(delays, ResponseDelaySchema{UrlPattern: "orders", Delay: 50})).To(HaveLen(3))
+	Expect(delays[0].Delay).To(Equal(100))
+}
+
+func Test_RemoveDelay_RemovesOnlyDelayWithSameUrlPatternAndMethod(t *testing.T) {
+	RegisterTestingT(t)
+
+	delays := []ResponseDelaySchema{
+		{UrlPattern: "payments", HttpMethod: "GET", Delay: 100},
+		{UrlPattern: "payments", HttpMethod: "", Delay: 200},
+	}
+
+	remaining, removed := RemoveDelay(delays, "payments", "GET")
+	Expect(removed).To(BeTrue())
+	Expect(remaining).To(Equal([]ResponseDelaySchema{{UrlPattern: "payments", HttpMethod: "", Delay: 200}}))
+
+	_, removed = RemoveDelay(delays, "orders", "")
+	Expect(removed).To(BeFalse())
+}
@@ -56,3 +56,38 @@ func SetDestination(target configuration.Target, destination string) (string, er
 
 	return destinationView.Destination, nil
 }
+
+// AddUpstreamRoute will go the destination routes endpoint in Hoverfly, adding a route which sends requests to hosts
+// matching the pattern to the upstream, and return all of the routes
+func AddUpstreamRoute(target configuration.Target, pattern, upstream string) ([]v2.UpstreamRouteView, error) {
+	bytes, _ := json.Marshal(v2.UpstreamRouteView{Pattern: pattern, Upstream: upstream})
+
+	response, err := doRequest(target, "POST", v2ApiRoutes, string(bytes), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = handleResponseError(response, "Could not add upstream route")
+	if err != nil {
+		return nil, err
+	}
+
+	var routesView v2.UpstreamRoutesView
+
+	err = UnmarshalToInterface(response, &routesView)
+	if err != nil {
+		return nil, err
+	}
+
+	return routesView.Routes, nil
+}
+
+// DeleteUpstreamRoutes will go the destination routes endpoint in Hoverfly and delete all of the routes
+func DeleteUpstreamRoutes(target configuration.Target) error {
+	response, err := doRequest(target, "DELETE", v2ApiRoutes, "", nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponseError(response, "Could not delete upstream routes")
+}
@@ -183,3 +183,135 @@ func Test_SetDestination_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 	Expect(err.Error()).To(Equal("Could not set destination\n\ntest error"))
 }
+
+func Test_AddUpstreamRoute_SendsRouteAndReturnsRoutes(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/hoverfly/destination/routes",
+							},
+						},
+						Body: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Json,
+								Value:   `{"pattern": "payments", "upstream": "localhost:9000"}`,
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"routes": [{"pattern": "payments", "upstream": "http://localhost:9000"}]}`,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	routes, err := AddUpstreamRoute(target, "payments", "localhost:9000")
+	Expect(err).To(BeNil())
+
+	Expect(routes).To(ConsistOf(v2.UpstreamRouteView{Pattern: "payments", Upstream: "http://localhost:9000"}))
+}
+
+func Test_AddUpstreamRoute_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := AddUpstreamRoute(inaccessibleTarget, "payments", "localhost:9000")
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_AddUpstreamRoute_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/hoverfly/destination/routes",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 422,
+						Body:   "{\"error\":\"upstream is not a valid host\"}",
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	_, err := AddUpstreamRoute(target, "payments", "http://")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not add upstream route\n\nupstream is not a valid host"))
+}
+
+func Test_DeleteUpstreamRoutes_SendsCorrectHTTPRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "DELETE",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/hoverfly/destination/routes",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"routes": []}`,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := DeleteUpstreamRoutes(target)
+	Expect(err).To(BeNil())
+}
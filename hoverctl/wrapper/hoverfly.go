@@ -20,19 +20,27 @@ import (
 )
 
 const (
-	v2ApiSimulation  = "/api/v2/simulation"
-	v2ApiMode        = "/api/v2/hoverfly/mode"
-	v2ApiDestination = "/api/v2/hoverfly/destination"
-	v2ApiState       = "/api/v2/state"
-	v2ApiMiddleware  = "/api/v2/hoverfly/middleware"
-	v2ApiPac         = "/api/v2/hoverfly/pac"
-	v2ApiCache       = "/api/v2/cache"
-	v2ApiLogs        = "/api/v2/logs"
-	v2ApiHoverfly    = "/api/v2/hoverfly"
-	v2ApiDiff        = "/api/v2/diff"
+	v2ApiSimulation   = "/api/v2/simulation"
+	v2ApiDelays       = "/api/v2/simulation/delays"
+	v2ApiMode         = "/api/v2/hoverfly/mode"
+	v2ApiDestination  = "/api/v2/hoverfly/destination"
+	v2ApiRoutes       = "/api/v2/hoverfly/destination/routes"
+	v2ApiState        = "/api/v2/state"
+	v2ApiMiddleware   = "/api/v2/hoverfly/middleware"
+	v2ApiPac          = "/api/v2/hoverfly/pac"
+	v2ApiMissResponse = "/api/v2/hoverfly/miss-response"
+	v2ApiCache        = "/api/v2/cache"
+	v2ApiLogs         = "/api/v2/logs"
+	v2ApiHoverfly     = "/api/v2/hoverfly"
+	v2ApiVersion      = "/api/v2/hoverfly/version"
+	v2ApiDiff         = "/api/v2/diff"
+	v2ApiUsers        = "/api/v2/users"
 
 	v2ApiShutdown = "/api/v2/shutdown"
 	v2ApiHealth   = "/api/health"
+	v2ApiReady    = "/api/ready"
+
+	adminPathPrefix = "/__hoverfly"
 )
 
 type APIStateSchema struct {
@@ -45,9 +53,9 @@ type APIDelaySchema struct {
 }
 
 type ResponseDelaySchema struct {
-	UrlPattern string `json:"urlpattern"`
+	UrlPattern string `json:"urlPattern"`
 	Delay      int    `json:"delay"`
-	HttpMethod string `json:"httpmethod"`
+	HttpMethod string `json:"httpMethod"`
 }
 
 type HoverflyAuthSchema struct {
@@ -106,7 +114,7 @@ func Login(target configuration.Target, username, password string) (string, erro
 	}
 
 	if response.StatusCode == http.StatusTooManyRequests {
-		return "", fmt.Errorf("Too many failed login attempts, please wait 10 minutes")
+		return "", fmt.Errorf("Too many failed login attempts, please wait %v", retryAfter(response))
 	}
 
 	if response.StatusCode == http.StatusUnauthorized {
@@ -127,11 +135,37 @@ func Login(target configuration.Target, username, password string) (string, erro
 	return authToken.Token, nil
 }
 
+// retryAfter describes how long to wait before logging in again, from the Retry-After header of a 429. Versions
+// of Hoverfly without it lock out for 10 minutes.
+func retryAfter(response *http.Response) string {
+	seconds, err := strconv.Atoi(response.Header.Get("Retry-After"))
+	if err != nil {
+		return "10 minutes"
+	}
+
+	if seconds%60 == 0 && seconds >= 60 {
+		if seconds == 60 {
+			return "1 minute"
+		}
+		return fmt.Sprintf("%d minutes", seconds/60)
+	}
+	if seconds == 1 {
+		return "1 second"
+	}
+	return fmt.Sprintf("%d seconds", seconds)
+}
+
 func BuildURL(target configuration.Target, endpoint string) string {
+	port := target.AdminPort
+	if target.AdminOnProxyPort {
+		port = target.ProxyPort
+		endpoint = adminPathPrefix + endpoint
+	}
+
 	if !strings.HasPrefix(target.Host, "http://") && !strings.HasPrefix(target.Host, "https://") {
-		return fmt.Sprintf("http://%v:%v%v", target.Host, target.AdminPort, endpoint)
+		return fmt.Sprintf("http://%v:%v%v", target.Host, port, endpoint)
 	}
-	return fmt.Sprintf("%v:%v%v", target.Host, target.AdminPort, endpoint)
+	return fmt.Sprintf("%v:%v%v", target.Host, port, endpoint)
 }
 
 func IsLocal(url string) bool {
@@ -159,7 +193,12 @@ func runBinary(target *configuration.Target, path string) (*exec.Cmd, error) {
 
 func Start(target *configuration.Target) error {
 	// TODO only check port if is it localhost
-	err := checkPorts(target.AdminPort, target.ProxyPort)
+	ports := []int{target.AdminPort, target.ProxyPort}
+	if target.AdminOnProxyPort {
+		ports = []int{target.ProxyPort}
+	}
+
+	err := checkPorts(ports...)
 	if err != nil {
 		return err
 	}
@@ -188,9 +227,12 @@ func Start(target *configuration.Target) error {
 			if err != nil {
 				log.Debug(err)
 			}
-			return errors.New(fmt.Sprintf("Timed out waiting for Hoverfly to become healthy, returns status: %v", statusCode))
+			return errors.New(fmt.Sprintf("Timed out waiting for Hoverfly to become ready, returns status: %v", statusCode))
 		case <-tick:
-			resp, err := http.Get(fmt.Sprintf("http://localhost:%v/api/health", target.AdminPort))
+			// the readiness check also waits for the proxy to accept connections, not just the admin API
+			localTarget := *target
+			localTarget.Host = "localhost"
+			resp, err := http.Get(BuildURL(localTarget, v2ApiReady))
 			if err == nil {
 				statusCode = resp.StatusCode
 			} else {
@@ -259,7 +301,40 @@ func GetHoverfly(target configuration.Target) (*v2.HoverflyView, error) {
 	return &hoverflyView, nil
 }
 
+// TokenRefreshed - called with the target, and its new auth token, whenever hoverctl logs in again because
+// the auth token has expired
+var TokenRefreshed func(target configuration.Target)
+
 func doRequest(target configuration.Target, method, url, body string, headers map[string]string) (*http.Response, error) {
+	response, err := sendRequest(target, method, url, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == 401 && target.AuthUsername != "" && target.AuthPassword != "" {
+		if token, err := Login(target, target.AuthUsername, target.AuthPassword); err == nil {
+			response.Body.Close()
+
+			target.AuthToken = token
+			if TokenRefreshed != nil {
+				TokenRefreshed(target)
+			}
+
+			response, err = sendRequest(target, method, url, body, headers)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if response.StatusCode == 401 {
+		return nil, errors.New("Hoverfly requires authentication\n\nRun `hoverctl login -t " + target.Name + "`")
+	}
+
+	return response, nil
+}
+
+func sendRequest(target configuration.Target, method, url, body string, headers map[string]string) (*http.Response, error) {
 	url = BuildURL(target, url)
 
 	request, err := http.NewRequest(method, url, strings.NewReader(body))
@@ -282,10 +357,6 @@ func doRequest(target configuration.Target, method, url, body string, headers ma
 		return nil, fmt.Errorf("Could not connect to Hoverfly at %v:%v", target.Host, target.AdminPort)
 	}
 
-	if response.StatusCode == 401 {
-		return nil, errors.New("Hoverfly requires authentication\n\nRun `hoverctl login -t " + target.Name + "`")
-	}
-
 	return response, nil
 }
 
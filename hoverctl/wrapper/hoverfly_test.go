@@ -1,6 +1,10 @@
 package wrapper
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
@@ -72,6 +76,19 @@ func Test_BuildUrl_AddsHttpAsDefaultProtocol(t *testing.T) {
 	Expect(BuildURL(target, "/something")).To(Equal("http://localhost:1234/something"))
 }
 
+func Test_BuildUrl_UsesProxyPortAndPathPrefixWhenAdminIsOnProxyPort(t *testing.T) {
+	RegisterTestingT(t)
+
+	target := configuration.Target{
+		Host:             "localhost",
+		AdminPort:        1234,
+		ProxyPort:        5678,
+		AdminOnProxyPort: true,
+	}
+
+	Expect(BuildURL(target, "/something")).To(Equal("http://localhost:5678/__hoverfly/something"))
+}
+
 func Test_Stop_SendsCorrectHTTPRequest(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -250,6 +267,12 @@ func Test_GetHoverfly_GetsHoverfly(t *testing.T) {
 									"simulate": 0,
 									"spy": 0,
 									"synthesize": 0
+								},
+								"matching": {
+									"cacheHits": 4,
+									"cacheMisses": 2,
+									"matcherHits": 1,
+									"misses": 1
 								}
 							},
 							"version": "v0.14.2",
@@ -269,4 +292,93 @@ func Test_GetHoverfly_GetsHoverfly(t *testing.T) {
 
 	Expect(hoverfly.IsWebServer).To(BeFalse())
 	Expect(hoverfly.Version).To(Equal("v0.14.2"))
+	Expect(hoverfly.Usage.Matching.CacheHits).To(Equal(int64(4)))
+	Expect(hoverfly.Usage.Matching.MatcherHits).To(Equal(int64(1)))
+}
+
+func Test_doRequest_LogsInAgainWhenTheTokenHasExpired(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/token-auth" {
+			w.Write([]byte(`{"token": "new-token"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverUrl.Port())
+
+	var refreshed configuration.Target
+	TokenRefreshed = func(target configuration.Target) {
+		refreshed = target
+	}
+	defer func() {
+		TokenRefreshed = nil
+	}()
+
+	response, err := doRequest(configuration.Target{
+		Host:         "127.0.0.1",
+		AdminPort:    port,
+		AuthToken:    "expired-token",
+		AuthUsername: "bob",
+		AuthPassword: "secret",
+	}, "GET", "/api/v2/hoverfly", "", nil)
+	Expect(err).To(BeNil())
+	Expect(response.StatusCode).To(Equal(http.StatusOK))
+	Expect(refreshed.AuthToken).To(Equal("new-token"))
+}
+
+func Test_doRequest_ErrorsWhenUnauthorizedWithoutCredentials(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverUrl.Port())
+
+	_, err := doRequest(configuration.Target{
+		Name:      "remote",
+		Host:      "127.0.0.1",
+		AdminPort: port,
+		AuthToken: "expired-token",
+	}, "GET", "/api/v2/hoverfly", "", nil)
+	Expect(err).To(MatchError("Hoverfly requires authentication\n\nRun `hoverctl login -t remote`"))
+}
+
+func Test_Login_ErrorsWithTheLockoutFromRetryAfter(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "300")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverUrl.Port())
+
+	_, err := Login(configuration.Target{
+		Host:      "127.0.0.1",
+		AdminPort: port,
+	}, "bob", "secret")
+	Expect(err).To(MatchError("Too many failed login attempts, please wait 5 minutes"))
+}
+
+func Test_retryAfter_DescribesTheLockout(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(retryAfter(&http.Response{Header: http.Header{"Retry-After": {"600"}}})).To(Equal("10 minutes"))
+	Expect(retryAfter(&http.Response{Header: http.Header{"Retry-After": {"60"}}})).To(Equal("1 minute"))
+	Expect(retryAfter(&http.Response{Header: http.Header{"Retry-After": {"90"}}})).To(Equal("90 seconds"))
+	Expect(retryAfter(&http.Response{Header: http.Header{}})).To(Equal("10 minutes"))
 }
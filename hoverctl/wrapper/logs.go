@@ -4,28 +4,45 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+	"github.com/sirupsen/logrus"
 )
 
+// GetLogs - gets the logs from Hoverfly, always as JSON so that each log is one element even when its message spans
+// several lines. Plain logs are formatted here in the same way as Hoverfly formats them.
 func GetLogs(target configuration.Target, format string, filterTime *time.Time) ([]string, error) {
+	return GetFilteredLogs(target, format, filterTime, "", "")
+}
+
+// GetFilteredLogs - gets the logs from Hoverfly at the given level or more severe, and optionally only those logged
+// by the given component. Empty level and component values are not sent to Hoverfly.
+func GetFilteredLogs(target configuration.Target, format string, filterTime *time.Time, level, component string) ([]string, error) {
 	headers := map[string]string{
-		"Accept": "text/plain",
+		"Accept": "application/json",
 	}
 
-	if format == "json" {
-		headers["Accept"] = "application/json"
+	query := url.Values{}
+	if filterTime != nil {
+		query.Set("from", fmt.Sprint(filterTime.Unix()))
+	}
+	if level != "" {
+		query.Set("level", level)
+	}
+	if component != "" {
+		query.Set("component", component)
 	}
 
-	url := v2ApiLogs
-	if filterTime != nil {
-		url = fmt.Sprintf("%v%v%v", url, "?from=", filterTime.Unix())
+	logsUrl := v2ApiLogs
+	if len(query) > 0 {
+		logsUrl = logsUrl + "?" + query.Encode()
 	}
 
-	response, err := doRequest(target, "GET", url, "", headers)
+	response, err := doRequest(target, "GET", logsUrl, "", headers)
 	if err != nil {
 		return []string{}, err
 	}
@@ -38,35 +55,77 @@ func GetLogs(target configuration.Target, format string, filterTime *time.Time)
 	}
 
 	responseBody, _ := ioutil.ReadAll(response.Body)
-	if format == "json" {
-		var logsView v2.LogsView
 
-		err := json.Unmarshal(responseBody, &logsView)
+	var logsView v2.LogsView
+	err = json.Unmarshal(responseBody, &logsView)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := []string{}
+	for _, log := range logsView.Logs {
+		var formattedLog string
+		if format == "json" {
+			formattedLog, err = logToJson(log)
+		} else {
+			formattedLog, err = logToPlainText(log)
+		}
 		if err != nil {
 			return nil, err
 		}
 
-		var logs []string
-		for _, log := range logsView.Logs {
-			jsonLog, err := json.Marshal(log)
-			if err != nil {
-				return nil, err
-			}
+		logs = append(logs, formattedLog)
+	}
 
-			logs = append(logs, string(jsonLog))
-		}
+	return logs, nil
+}
 
-		return logs, nil
-	} else {
-		if string(responseBody) == "" {
-			return []string{}, err
-		}
-		logs := strings.Split(string(responseBody), "\n")
-		lastLogPosition := len(logs) - 1
-		if logs[lastLogPosition] == "" {
-			return logs[:lastLogPosition], nil
+func logToJson(log map[string]interface{}) (string, error) {
+	jsonLog, err := json.Marshal(log)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonLog), nil
+}
+
+func logToPlainText(log map[string]interface{}) (string, error) {
+	entry := &logrus.Entry{
+		Logger: logrus.New(),
+		Data:   logrus.Fields{},
+		Level:  logrus.InfoLevel,
+	}
+	entry.Logger.Formatter = &logrus.TextFormatter{
+		ForceColors:      true,
+		DisableTimestamp: false,
+		FullTimestamp:    true,
+	}
+
+	for key, value := range log {
+		switch key {
+		case "msg":
+			entry.Message = fmt.Sprint(value)
+		case "level":
+			level, err := logrus.ParseLevel(fmt.Sprint(value))
+			if err != nil {
+				return "", err
+			}
+			entry.Level = level
+		case "time":
+			entryTime, err := time.Parse(time.RFC3339, fmt.Sprint(value))
+			if err != nil {
+				return "", err
+			}
+			entry.Time = entryTime
+		default:
+			entry.Data[key] = value
 		}
+	}
 
-		return logs, nil
+	formatted, err := entry.String()
+	if err != nil {
+		return "", err
 	}
+
+	return strings.TrimSuffix(formatted, "\n"), nil
 }
@@ -10,11 +10,8 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-func Test_GetLogs_GetsLogsWithCorrect_Text_Plain_AcceptHeader(t *testing.T) {
-	RegisterTestingT(t)
-
-	hoverfly.DeleteSimulation()
-	hoverfly.PutSimulation(v2.SimulationViewV5{
+func logsSimulation(body string) v2.SimulationViewV5 {
+	return v2.SimulationViewV5{
 		DataViewV5: v2.DataViewV5{
 			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
 				{
@@ -35,14 +32,14 @@ func Test_GetLogs_GetsLogsWithCorrect_Text_Plain_AcceptHeader(t *testing.T) {
 							"Accept": {
 								{
 									Matcher: matchers.Exact,
-									Value:   "text/plain",
+									Value:   "application/json",
 								},
 							},
 						},
 					},
 					Response: v2.ResponseDetailsViewV5{
 						Status: 200,
-						Body:   "logs line 1\nlogs line 2",
+						Body:   body,
 					},
 				},
 			},
@@ -50,64 +47,81 @@ func Test_GetLogs_GetsLogsWithCorrect_Text_Plain_AcceptHeader(t *testing.T) {
 		MetaView: v2.MetaView{
 			SchemaVersion: "v2",
 		},
-	})
+	}
+}
+
+func Test_GetLogs_FormatsPlainLogsFromJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(logsSimulation(`{"logs":[
+		{"level": "info", "msg": "logs line 1", "time": "2018-01-01T10:00:00Z", "mode": "simulate"},
+		{"level": "error", "msg": "logs line 2", "time": "2018-01-01T10:00:01Z"}
+	]}`))
 
 	logs, err := GetLogs(target, "plain", nil)
 	Expect(err).To(BeNil())
 
 	Expect(logs).To(HaveLen(2))
-	Expect(logs[0]).To(Equal("logs line 1"))
-	Expect(logs[1]).To(Equal("logs line 2"))
+	Expect(logs[0]).To(ContainSubstring("INFO"))
+	Expect(logs[0]).To(ContainSubstring("[2018-01-01T10:00:00Z]"))
+	Expect(logs[0]).To(ContainSubstring("logs line 1"))
+	Expect(logs[0]).To(ContainSubstring("=simulate"))
+	Expect(logs[1]).To(ContainSubstring("ERRO"))
+	Expect(logs[1]).To(ContainSubstring("logs line 2"))
 }
 
-func Test_GetLogs_CanHandleEmptyTextPlainLogResponse(t *testing.T) {
+func Test_GetLogs_KeepsMultiLineMessagesInOneLog(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
-	hoverfly.PutSimulation(v2.SimulationViewV5{
-		DataViewV5: v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
-				{
-					RequestMatcher: v2.RequestMatcherViewV5{
-						Method: []v2.MatcherViewV5{
-							{
-								Matcher: matchers.Exact,
-								Value:   "GET",
-							},
-						},
-						Path: []v2.MatcherViewV5{
-							{
-								Matcher: matchers.Exact,
-								Value:   "/api/v2/logs",
-							},
-						},
-						Headers: map[string][]v2.MatcherViewV5{
-							"Accept": {
-								{
-									Matcher: matchers.Exact,
-									Value:   "text/plain",
-								},
-							},
-						},
-					},
-					Response: v2.ResponseDetailsViewV5{
-						Status: 200,
-						Body:   ``,
-					},
-				},
-			},
-		},
-		MetaView: v2.MetaView{
-			SchemaVersion: "v2",
-		},
-	})
+	hoverfly.PutSimulation(logsSimulation(`{"logs":[
+		{"level": "info", "msg": "first line\nsecond line", "time": "2018-01-01T10:00:00Z"},
+		{"level": "info", "msg": "another log", "time": "2018-01-01T10:00:01Z"}
+	]}`))
 
 	logs, err := GetLogs(target, "plain", nil)
 	Expect(err).To(BeNil())
-	Expect(logs).To(HaveLen(0))
+
+	Expect(logs).To(HaveLen(2))
+	Expect(logs[0]).To(ContainSubstring("first line\nsecond line"))
+	Expect(logs[1]).To(ContainSubstring("another log"))
+
+	logs, err = GetLogs(target, "json", nil)
+	Expect(err).To(BeNil())
+
+	Expect(logs).To(HaveLen(2))
+	Expect(logs[0]).To(Equal(`{"level":"info","msg":"first line\nsecond line","time":"2018-01-01T10:00:00Z"}`))
+}
+
+func Test_GetLogs_CanHandleEmptyLogs(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, body := range []string{`{"logs":[]}`, `{"logs":null}`} {
+		hoverfly.DeleteSimulation()
+		hoverfly.PutSimulation(logsSimulation(body))
+
+		logs, err := GetLogs(target, "plain", nil)
+		Expect(err).To(BeNil())
+		Expect(logs).To(HaveLen(0))
+
+		logs, err = GetLogs(target, "json", nil)
+		Expect(err).To(BeNil())
+		Expect(logs).To(HaveLen(0))
+	}
+}
+
+func Test_GetLogs_ErrorsWhenLogsAreNotJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(logsSimulation("logs line 1\nlogs line 2"))
+
+	_, err := GetLogs(target, "plain", nil)
+	Expect(err).ToNot(BeNil())
 }
 
-func Test_GetLogs_CanHandleEmptyLineAtEndOfTextPlainLogResponse(t *testing.T) {
+func Test_GetLogs_GetsLogsWithCorrect_JSON_AcceptHeader(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
@@ -132,14 +146,14 @@ func Test_GetLogs_CanHandleEmptyLineAtEndOfTextPlainLogResponse(t *testing.T) {
 							"Accept": {
 								{
 									Matcher: matchers.Exact,
-									Value:   "text/plain",
+									Value:   "application/json",
 								},
 							},
 						},
 					},
 					Response: v2.ResponseDetailsViewV5{
 						Status: 200,
-						Body:   "this is log message one\n",
+						Body:   `{"logs":[{"msg": "logs line 1"}]}`,
 					},
 				},
 			},
@@ -149,14 +163,21 @@ func Test_GetLogs_CanHandleEmptyLineAtEndOfTextPlainLogResponse(t *testing.T) {
 		},
 	})
 
-	logs, err := GetLogs(target, "plain", nil)
-
+	logs, err := GetLogs(target, "json", nil)
 	Expect(err).To(BeNil())
-	Expect(logs).To(HaveLen(1))
-	Expect(logs[0]).To(Equal("this is log message one"))
+	Expect(logs[0]).To(Equal(`{"msg":"logs line 1"}`))
 }
 
-func Test_GetLogs_GetsLogsWithCorrect_JSON_AcceptHeader(t *testing.T) {
+func Test_GetLogs_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := GetLogs(inaccessibleTarget, "plain", nil)
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_GetLogs_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
@@ -177,18 +198,10 @@ func Test_GetLogs_GetsLogsWithCorrect_JSON_AcceptHeader(t *testing.T) {
 								Value:   "/api/v2/logs",
 							},
 						},
-						Headers: map[string][]v2.MatcherViewV5{
-							"Accept": {
-								{
-									Matcher: matchers.Exact,
-									Value:   "application/json",
-								},
-							},
-						},
 					},
 					Response: v2.ResponseDetailsViewV5{
-						Status: 200,
-						Body:   `{"logs":[{"msg": "logs line 1"}]}`,
+						Status: 400,
+						Body:   "{\"error\":\"test error\"}",
 					},
 				},
 			},
@@ -198,21 +211,12 @@ func Test_GetLogs_GetsLogsWithCorrect_JSON_AcceptHeader(t *testing.T) {
 		},
 	})
 
-	logs, err := GetLogs(target, "json", nil)
-	Expect(err).To(BeNil())
-	Expect(logs[0]).To(Equal(`{"msg":"logs line 1"}`))
-}
-
-func Test_GetLogs_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
-	RegisterTestingT(t)
-
-	_, err := GetLogs(inaccessibleTarget, "plain", nil)
-
+	_, err := GetLogs(target, "plain", nil)
 	Expect(err).ToNot(BeNil())
-	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+	Expect(err.Error()).To(Equal("Could not retrieve logs\n\ntest error"))
 }
 
-func Test_GetLogs_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+func Test_GetLogs_FiltersByDateWhenFilterTimeProvided(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
@@ -233,10 +237,18 @@ func Test_GetLogs_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 								Value:   "/api/v2/logs",
 							},
 						},
+						Query: &v2.QueryMatcherViewV5{
+							"from": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "684552180",
+								},
+							},
+						},
 					},
 					Response: v2.ResponseDetailsViewV5{
-						Status: 400,
-						Body:   "{\"error\":\"test error\"}",
+						Status: 200,
+						Body:   `{"logs":[{"msg": "filtered logs"}]}`,
 					},
 				},
 			},
@@ -245,13 +257,14 @@ func Test_GetLogs_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 			SchemaVersion: "v2",
 		},
 	})
+	fromTime := time.Unix(int64(684552180), 0)
 
-	_, err := GetLogs(target, "plain", nil)
-	Expect(err).ToNot(BeNil())
-	Expect(err.Error()).To(Equal("Could not retrieve logs\n\ntest error"))
+	logs, err := GetLogs(target, "json", &fromTime)
+	Expect(err).To(BeNil())
+	Expect(logs[0]).To(Equal(`{"msg":"filtered logs"}`))
 }
 
-func Test_GetLogs_FiltersByDateWhenFilterTimeProvided(t *testing.T) {
+func Test_GetFilteredLogs_SendsLevelAndComponent(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
@@ -273,17 +286,23 @@ func Test_GetLogs_FiltersByDateWhenFilterTimeProvided(t *testing.T) {
 							},
 						},
 						Query: &v2.QueryMatcherViewV5{
-							"from": []v2.MatcherViewV5{
+							"level": []v2.MatcherViewV5{
 								{
 									Matcher: matchers.Exact,
-									Value:   "684552180",
+									Value:   "warn",
+								},
+							},
+							"component": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "matching",
 								},
 							},
 						},
 					},
 					Response: v2.ResponseDetailsViewV5{
 						Status: 200,
-						Body:   `{"logs":[{"msg": "filtered logs"}]}`,
+						Body:   `{"logs":[{"msg": "warn logs"}]}`,
 					},
 				},
 			},
@@ -292,9 +311,9 @@ func Test_GetLogs_FiltersByDateWhenFilterTimeProvided(t *testing.T) {
 			SchemaVersion: "v2",
 		},
 	})
-	fromTime := time.Unix(int64(684552180), 0)
 
-	logs, err := GetLogs(target, "json", &fromTime)
+	logs, err := GetFilteredLogs(target, "json", nil, "warn", "matching")
 	Expect(err).To(BeNil())
-	Expect(logs[0]).To(Equal(`{"msg":"filtered logs"}`))
+	Expect(logs).To(HaveLen(1))
+	Expect(logs[0]).To(Equal(`{"msg":"warn logs"}`))
 }
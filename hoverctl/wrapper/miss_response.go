@@ -0,0 +1,73 @@
+package wrapper
+
+import (
+	"encoding/json"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+)
+
+// GetMissResponse will go the miss response endpoint in Hoverfly and return the response used when nothing matches
+func GetMissResponse(target configuration.Target) (v2.MissResponseView, error) {
+	response, err := doRequest(target, "GET", v2ApiMissResponse, "", nil)
+	if err != nil {
+		return v2.MissResponseView{}, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not retrieve miss response")
+	if err != nil {
+		return v2.MissResponseView{}, err
+	}
+
+	var missResponseView v2.MissResponseView
+
+	err = UnmarshalToInterface(response, &missResponseView)
+	if err != nil {
+		return v2.MissResponseView{}, err
+	}
+
+	return missResponseView, nil
+}
+
+// SetMissResponse will go the miss response endpoint in Hoverfly, setting the response used when nothing matches
+func SetMissResponse(target configuration.Target, missResponse v2.MissResponseView) (v2.MissResponseView, error) {
+	marshalledMissResponse, err := json.Marshal(missResponse)
+	if err != nil {
+		return v2.MissResponseView{}, err
+	}
+
+	response, err := doRequest(target, "PUT", v2ApiMissResponse, string(marshalledMissResponse), nil)
+	if err != nil {
+		return v2.MissResponseView{}, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not set miss response")
+	if err != nil {
+		return v2.MissResponseView{}, err
+	}
+
+	var missResponseView v2.MissResponseView
+
+	err = UnmarshalToInterface(response, &missResponseView)
+	if err != nil {
+		return v2.MissResponseView{}, err
+	}
+
+	return missResponseView, nil
+}
+
+// DeleteMissResponse will go the miss response endpoint in Hoverfly, going back to Hoverfly's error when nothing matches
+func DeleteMissResponse(target configuration.Target) error {
+	response, err := doRequest(target, "DELETE", v2ApiMissResponse, "", nil)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	return handleResponseError(response, "Could not reset miss response")
+}
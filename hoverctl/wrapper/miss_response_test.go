@@ -0,0 +1,83 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func missResponseSimulation(method string, status int, body string) v2.SimulationViewV5 {
+	return v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   method,
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/hoverfly/miss-response",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: status,
+						Body:   body,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	}
+}
+
+func Test_GetMissResponse_GetsMissResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(missResponseSimulation("GET", 200, `{"status": 404, "body": "not found"}`))
+
+	missResponse, err := GetMissResponse(target)
+	Expect(err).To(BeNil())
+	Expect(missResponse).To(Equal(v2.MissResponseView{Status: 404, Body: "not found"}))
+}
+
+func Test_SetMissResponse_SetsMissResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(missResponseSimulation("PUT", 200, `{"status": 404, "body": "not found", "templated": true}`))
+
+	missResponse, err := SetMissResponse(target, v2.MissResponseView{Status: 404, Body: "not found", Templated: true})
+	Expect(err).To(BeNil())
+	Expect(missResponse).To(Equal(v2.MissResponseView{Status: 404, Body: "not found", Templated: true}))
+}
+
+func Test_SetMissResponse_ErrorsWhenHoverflyRejectsIt(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(missResponseSimulation("PUT", 422, `{"error": "status must be a valid HTTP status code"}`))
+
+	_, err := SetMissResponse(target, v2.MissResponseView{})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not set miss response\n\nstatus must be a valid HTTP status code"))
+}
+
+func Test_DeleteMissResponse_DeletesMissResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(missResponseSimulation("DELETE", 200, `{}`))
+
+	Expect(DeleteMissResponse(target)).To(Succeed())
+}
@@ -0,0 +1,304 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"gopkg.in/yaml.v2"
+)
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+var openAPIPathParameter = regexp.MustCompile(`\{[^/}]+\}`)
+
+const maxOpenAPISchemaDepth = 10
+
+// NewSimulationFromOpenAPI creates a simulation from an OpenAPI 3 or Swagger 2 document in YAML or
+// JSON. One pair is created for each operation, matching on the method and path, with path
+// parameters turned into glob matchers. The response uses the lowest 2xx status defined by the
+// operation, and its body comes from the response example, or is built from the response schema.
+func NewSimulationFromOpenAPI(data []byte) (v2.SimulationViewV5, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return v2.SimulationViewV5{}, fmt.Errorf("Could not parse OpenAPI document\n\n%s", err.Error())
+	}
+
+	spec, ok := normalizeYaml(document).(map[string]interface{})
+	if !ok || (spec["openapi"] == nil && spec["swagger"] == nil) {
+		return v2.SimulationViewV5{}, errors.New("Could not parse OpenAPI document\n\nMissing \"openapi\" or \"swagger\" version field")
+	}
+
+	basePath := openAPIBasePath(spec)
+
+	paths, _ := spec["paths"].(map[string]interface{})
+	var pairs []v2.RequestMatcherResponsePairViewV5
+	for _, path := range sortedMapKeys(paths) {
+		pathItem, _ := paths[path].(map[string]interface{})
+		for _, method := range openAPIMethods {
+			operation, ok := pathItem[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			pairs = append(pairs, v2.RequestMatcherResponsePairViewV5{
+				RequestMatcher: v2.RequestMatcherViewV5{
+					Method: []v2.MatcherViewV5{
+						{
+							Matcher: matchers.Exact,
+							Value:   strings.ToUpper(method),
+						},
+					},
+					Path: []v2.MatcherViewV5{openAPIPathMatcher(basePath + path)},
+				},
+				Response: openAPIResponse(spec, operation),
+			})
+		}
+	}
+
+	if len(pairs) == 0 {
+		return v2.SimulationViewV5{}, errors.New("Could not parse OpenAPI document\n\nNo operations found in \"paths\"")
+	}
+
+	return newSimulationView(pairs), nil
+}
+
+func openAPIBasePath(spec map[string]interface{}) string {
+	if basePath, ok := spec["basePath"].(string); ok {
+		return strings.TrimSuffix(basePath, "/")
+	}
+
+	servers, _ := spec["servers"].([]interface{})
+	if len(servers) == 0 {
+		return ""
+	}
+
+	server, _ := servers[0].(map[string]interface{})
+	serverUrl, _ := server["url"].(string)
+	parsed, err := url.Parse(serverUrl)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(parsed.Path, "/")
+}
+
+func openAPIPathMatcher(path string) v2.MatcherViewV5 {
+	if openAPIPathParameter.MatchString(path) {
+		return v2.MatcherViewV5{
+			Matcher: matchers.Glob,
+			Value:   openAPIPathParameter.ReplaceAllString(path, "*"),
+		}
+	}
+
+	return v2.MatcherViewV5{
+		Matcher: matchers.Exact,
+		Value:   path,
+	}
+}
+
+func openAPIResponse(spec, operation map[string]interface{}) v2.ResponseDetailsViewV5 {
+	responses, _ := operation["responses"].(map[string]interface{})
+
+	statusKey, status := openAPIResponseStatus(responses)
+	response := v2.ResponseDetailsViewV5{
+		Status: status,
+	}
+
+	definition, ok := resolveOpenAPIRef(spec, responses[statusKey]).(map[string]interface{})
+	if !ok {
+		return response
+	}
+
+	contentType, example, found := openAPIExample(spec, definition)
+	if !found {
+		return response
+	}
+
+	if contentType != "" {
+		response.Headers = map[string][]string{"Content-Type": {contentType}}
+	}
+
+	if body, isString := example.(string); isString && !strings.Contains(contentType, "json") {
+		response.Body = body
+	} else if body, err := json.Marshal(example); err == nil {
+		response.Body = string(body)
+	}
+
+	return response
+}
+
+// openAPIResponseStatus picks the lowest 2xx response, falling back to the default response as a
+// 200, and then to the lowest status code defined
+func openAPIResponseStatus(responses map[string]interface{}) (string, int) {
+	var statuses []int
+	for key := range responses {
+		if status, err := strconv.Atoi(key); err == nil {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Ints(statuses)
+
+	for _, status := range statuses {
+		if status >= 200 && status < 300 {
+			return strconv.Itoa(status), status
+		}
+	}
+
+	if _, ok := responses["default"]; ok {
+		return "default", 200
+	}
+
+	if len(statuses) > 0 {
+		return strconv.Itoa(statuses[0]), statuses[0]
+	}
+
+	return "", 200
+}
+
+func openAPIExample(spec, response map[string]interface{}) (string, interface{}, bool) {
+	// OpenAPI 3 describes the body per media type
+	if content, ok := response["content"].(map[string]interface{}); ok && len(content) > 0 {
+		contentType := preferredContentType(content)
+		mediaType, _ := content[contentType].(map[string]interface{})
+
+		if example, ok := mediaType["example"]; ok {
+			return contentType, example, true
+		}
+		if examples, ok := mediaType["examples"].(map[string]interface{}); ok && len(examples) > 0 {
+			example, _ := resolveOpenAPIRef(spec, examples[sortedMapKeys(examples)[0]]).(map[string]interface{})
+			if value, ok := example["value"]; ok {
+				return contentType, value, true
+			}
+		}
+		if schema, ok := mediaType["schema"]; ok {
+			return contentType, exampleFromSchema(spec, schema, 0), true
+		}
+		return contentType, nil, false
+	}
+
+	// Swagger 2 has examples keyed by media type and a single schema
+	if examples, ok := response["examples"].(map[string]interface{}); ok && len(examples) > 0 {
+		contentType := preferredContentType(examples)
+		return contentType, examples[contentType], true
+	}
+	if schema, ok := response["schema"]; ok {
+		return "application/json", exampleFromSchema(spec, schema, 0), true
+	}
+
+	return "", nil, false
+}
+
+func preferredContentType(content map[string]interface{}) string {
+	if _, ok := content["application/json"]; ok {
+		return "application/json"
+	}
+	return sortedMapKeys(content)[0]
+}
+
+func exampleFromSchema(spec map[string]interface{}, schemaDefinition interface{}, depth int) interface{} {
+	schema, ok := resolveOpenAPIRef(spec, schemaDefinition).(map[string]interface{})
+	if !ok || depth > maxOpenAPISchemaDepth {
+		return nil
+	}
+
+	if example, ok := schema["example"]; ok {
+		return example
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		merged := map[string]interface{}{}
+		for _, subSchema := range allOf {
+			if properties, ok := exampleFromSchema(spec, subSchema, depth+1).(map[string]interface{}); ok {
+				for key, value := range properties {
+					merged[key] = value
+				}
+			}
+		}
+		return merged
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if choices, ok := schema[key].([]interface{}); ok && len(choices) > 0 {
+			return exampleFromSchema(spec, choices[0], depth+1)
+		}
+	}
+
+	switch schema["type"] {
+	case "array":
+		return []interface{}{exampleFromSchema(spec, schema["items"], depth+1)}
+	case "string":
+		return "string"
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	object := map[string]interface{}{}
+	for name, property := range properties {
+		object[name] = exampleFromSchema(spec, property, depth+1)
+	}
+	return object
+}
+
+// resolveOpenAPIRef follows local references such as "#/components/schemas/Pet"
+func resolveOpenAPIRef(spec map[string]interface{}, value interface{}) interface{} {
+	for i := 0; i < maxOpenAPISchemaDepth; i++ {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return value
+		}
+
+		var current interface{} = spec
+		for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			parent, _ := current.(map[string]interface{})
+			current = parent[strings.Replace(strings.Replace(segment, "~1", "/", -1), "~0", "~", -1)]
+		}
+		value = current
+	}
+
+	return value
+}
+
+// normalizeYaml converts the map[interface{}]interface{} values produced by the YAML parser so
+// that they can be marshalled as JSON
+func normalizeYaml(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(typed))
+		for key, inner := range typed {
+			normalized[fmt.Sprint(key)] = normalizeYaml(inner)
+		}
+		return normalized
+	case []interface{}:
+		for i, inner := range typed {
+			typed[i] = normalizeYaml(inner)
+		}
+		return typed
+	}
+
+	return value
+}
+
+func sortedMapKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
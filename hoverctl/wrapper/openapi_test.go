@@ -0,0 +1,137 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	. "github.com/onsi/gomega"
+)
+
+const openAPIDocument = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: A list of pets
+          content:
+            application/json:
+              example:
+                - id: 1
+                  name: Rex
+    post:
+      responses:
+        "400":
+          description: Bad request
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+    delete:
+      responses:
+        "204":
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+        tags:
+          type: array
+          items:
+            type: string
+`
+
+func Test_NewSimulationFromOpenAPI_CreatesOnePairPerOperation(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := NewSimulationFromOpenAPI([]byte(openAPIDocument))
+	Expect(err).To(BeNil())
+
+	pairs := simulation.RequestResponsePairs
+	Expect(pairs).To(HaveLen(3))
+
+	Expect(pairs[0].RequestMatcher.Method).To(Equal([]v2.MatcherViewV5{{Matcher: "exact", Value: "GET"}}))
+	Expect(pairs[0].RequestMatcher.Path).To(Equal([]v2.MatcherViewV5{{Matcher: "exact", Value: "/v1/pets"}}))
+	Expect(pairs[0].Response.Status).To(Equal(200))
+	Expect(pairs[0].Response.Body).To(Equal(`[{"id":1,"name":"Rex"}]`))
+	Expect(pairs[0].Response.Headers).To(Equal(map[string][]string{"Content-Type": {"application/json"}}))
+
+	Expect(pairs[1].RequestMatcher.Method).To(Equal([]v2.MatcherViewV5{{Matcher: "exact", Value: "POST"}}))
+	Expect(pairs[1].Response.Status).To(Equal(201))
+	Expect(pairs[1].Response.Body).To(MatchJSON(`{"id": 0, "name": "string", "tags": ["string"]}`))
+
+	Expect(pairs[2].RequestMatcher.Method).To(Equal([]v2.MatcherViewV5{{Matcher: "exact", Value: "DELETE"}}))
+	Expect(pairs[2].RequestMatcher.Path).To(Equal([]v2.MatcherViewV5{{Matcher: "glob", Value: "/v1/pets/*"}}))
+	Expect(pairs[2].Response.Status).To(Equal(204))
+	Expect(pairs[2].Response.Body).To(Equal(""))
+
+	Expect(simulation.SchemaVersion).To(Equal("v5.2"))
+
+	simulationData, err := json.Marshal(simulation)
+	Expect(err).To(BeNil())
+	Expect(ValidateSimulation(simulationData)).To(BeEmpty())
+}
+
+func Test_NewSimulationFromOpenAPI_SupportsSwagger2Json(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := NewSimulationFromOpenAPI([]byte(`{
+		"swagger": "2.0",
+		"basePath": "/api",
+		"paths": {
+			"/users/{id}": {
+				"get": {
+					"responses": {
+						"default": {
+							"description": "A user",
+							"examples": {
+								"application/json": {"id": "abc"}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`))
+	Expect(err).To(BeNil())
+
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path).To(Equal([]v2.MatcherViewV5{{Matcher: "glob", Value: "/api/users/*"}}))
+	Expect(simulation.RequestResponsePairs[0].Response.Status).To(Equal(200))
+	Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal(`{"id":"abc"}`))
+}
+
+func Test_NewSimulationFromOpenAPI_ReturnsErrorWhenDocumentIsNotOpenAPI(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewSimulationFromOpenAPI([]byte(`{"data": {}}`))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not parse OpenAPI document\n\nMissing \"openapi\" or \"swagger\" version field"))
+}
+
+func Test_NewSimulationFromOpenAPI_ReturnsErrorWhenThereAreNoOperations(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewSimulationFromOpenAPI([]byte(`openapi: 3.0.0`))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not parse OpenAPI document\n\nNo operations found in \"paths\""))
+}
@@ -0,0 +1,274 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+)
+
+var postmanVariable = regexp.MustCompile(`\{\{[^}]*\}\}`)
+
+type postmanCollection struct {
+	Info postmanInfo   `json:"info"`
+	Item []postmanItem `json:"item"`
+}
+
+type postmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+type postmanItem struct {
+	Name     string            `json:"name"`
+	Item     []postmanItem     `json:"item"`
+	Request  *postmanRequest   `json:"request"`
+	Response []postmanResponse `json:"response"`
+}
+
+type postmanRequest struct {
+	Method string          `json:"method"`
+	URL    json.RawMessage `json:"url"`
+	Body   *postmanBody    `json:"body"`
+}
+
+type postmanURL struct {
+	Raw   string            `json:"raw"`
+	Host  []string          `json:"host"`
+	Path  []string          `json:"path"`
+	Query []postmanKeyValue `json:"query"`
+}
+
+type postmanBody struct {
+	Mode string `json:"mode"`
+	Raw  string `json:"raw"`
+}
+
+type postmanKeyValue struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled"`
+}
+
+type postmanResponse struct {
+	Name            string            `json:"name"`
+	OriginalRequest *postmanRequest   `json:"originalRequest"`
+	Code            int               `json:"code"`
+	Header          []postmanKeyValue `json:"header"`
+	Body            string            `json:"body"`
+}
+
+// PostmanImport is the result of converting a Postman collection, listing the requests which
+// were converted into pairs and the requests skipped because they have no example responses
+type PostmanImport struct {
+	Simulation v2.SimulationViewV5
+	Imported   []string
+	Skipped    []string
+}
+
+// NewSimulationFromPostman creates a simulation from a Postman v2.1 collection. A pair is created
+// for each example response saved against a request. Postman variables such as {{base_url}} are
+// removed from the URL, so that only the literal host and path are matched, and variables in the
+// path are matched with a glob.
+func NewSimulationFromPostman(data []byte) (PostmanImport, error) {
+	var collection postmanCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return PostmanImport{}, fmt.Errorf("Could not parse Postman collection\n\n%s", err.Error())
+	}
+
+	if !strings.Contains(collection.Info.Schema, "v2.1") {
+		return PostmanImport{}, errors.New("Could not parse Postman collection\n\nOnly v2.1 collections are supported")
+	}
+
+	result := PostmanImport{}
+	var pairs []v2.RequestMatcherResponsePairViewV5
+	walkPostmanItems(collection.Item, "", func(name string, item postmanItem) {
+		if len(item.Response) == 0 {
+			result.Skipped = append(result.Skipped, name)
+			return
+		}
+
+		for _, response := range item.Response {
+			request := item.Request
+			if response.OriginalRequest != nil {
+				request = response.OriginalRequest
+			}
+
+			pairs = append(pairs, v2.RequestMatcherResponsePairViewV5{
+				RequestMatcher: postmanRequestMatcher(request),
+				Response:       postmanResponseDetails(response),
+			})
+		}
+		result.Imported = append(result.Imported, name)
+	})
+
+	result.Simulation = newSimulationView(pairs)
+
+	return result, nil
+}
+
+func walkPostmanItems(items []postmanItem, folder string, visit func(string, postmanItem)) {
+	for _, item := range items {
+		name := item.Name
+		if folder != "" {
+			name = folder + " / " + item.Name
+		}
+
+		if item.Request == nil {
+			walkPostmanItems(item.Item, name, visit)
+		} else {
+			visit(name, item)
+		}
+	}
+}
+
+func postmanRequestMatcher(request *postmanRequest) v2.RequestMatcherViewV5 {
+	matcher := v2.RequestMatcherViewV5{}
+	if request == nil {
+		return matcher
+	}
+
+	if request.Method != "" {
+		matcher.Method = []v2.MatcherViewV5{
+			{
+				Matcher: matchers.Exact,
+				Value:   strings.ToUpper(request.Method),
+			},
+		}
+	}
+
+	requestUrl := parsePostmanURL(request.URL)
+
+	host := strings.Join(requestUrl.Host, ".")
+	if host != "" && !postmanVariable.MatchString(host) {
+		matcher.Destination = []v2.MatcherViewV5{
+			{
+				Matcher: matchers.Exact,
+				Value:   host,
+			},
+		}
+	}
+
+	if len(requestUrl.Path) > 0 {
+		segments := make([]string, len(requestUrl.Path))
+		isGlob := false
+		for i, segment := range requestUrl.Path {
+			if postmanVariable.MatchString(segment) || strings.HasPrefix(segment, ":") {
+				segment = "*"
+				isGlob = true
+			}
+			segments[i] = segment
+		}
+
+		pathMatcher := v2.MatcherViewV5{
+			Matcher: matchers.Exact,
+			Value:   "/" + strings.Join(segments, "/"),
+		}
+		if isGlob {
+			pathMatcher.Matcher = matchers.Glob
+		}
+		matcher.Path = []v2.MatcherViewV5{pathMatcher}
+	}
+
+	query := v2.QueryMatcherViewV5{}
+	for _, parameter := range requestUrl.Query {
+		if parameter.Disabled || postmanVariable.MatchString(parameter.Value) {
+			continue
+		}
+		query[parameter.Key] = append(query[parameter.Key], v2.MatcherViewV5{
+			Matcher: matchers.Exact,
+			Value:   parameter.Value,
+		})
+	}
+	if len(query) > 0 {
+		matcher.Query = &query
+	}
+
+	if request.Body != nil && request.Body.Mode == "raw" && request.Body.Raw != "" && !postmanVariable.MatchString(request.Body.Raw) {
+		bodyMatcher := matchers.Exact
+		if json.Valid([]byte(request.Body.Raw)) {
+			bodyMatcher = matchers.Json
+		}
+		matcher.Body = []v2.MatcherViewV5{
+			{
+				Matcher: bodyMatcher,
+				Value:   request.Body.Raw,
+			},
+		}
+	}
+
+	return matcher
+}
+
+// parsePostmanURL reads a URL which Postman saves either as a raw string or as an object
+func parsePostmanURL(data json.RawMessage) postmanURL {
+	var requestUrl postmanURL
+	if err := json.Unmarshal(data, &requestUrl); err == nil {
+		return requestUrl
+	}
+
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return requestUrl
+	}
+
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "{{") {
+		// the host is a variable, so only the path and query are kept
+		raw = raw[strings.Index(raw, "}}")+2:]
+		if !strings.HasPrefix(raw, "/") {
+			raw = "/" + raw
+		}
+	} else if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return requestUrl
+	}
+
+	if parsed.Hostname() != "" {
+		requestUrl.Host = []string{parsed.Hostname()}
+	}
+	for _, segment := range strings.Split(strings.Trim(parsed.Path, "/"), "/") {
+		if segment != "" {
+			requestUrl.Path = append(requestUrl.Path, segment)
+		}
+	}
+	for key, values := range parsed.Query() {
+		for _, value := range values {
+			requestUrl.Query = append(requestUrl.Query, postmanKeyValue{Key: key, Value: value})
+		}
+	}
+
+	return requestUrl
+}
+
+func postmanResponseDetails(response postmanResponse) v2.ResponseDetailsViewV5 {
+	details := v2.ResponseDetailsViewV5{
+		Status: response.Code,
+		Body:   response.Body,
+	}
+
+	if details.Status == 0 {
+		details.Status = 200
+	}
+
+	for _, header := range response.Header {
+		if header.Disabled {
+			continue
+		}
+		if details.Headers == nil {
+			details.Headers = map[string][]string{}
+		}
+		details.Headers[header.Key] = append(details.Headers[header.Key], header.Value)
+	}
+
+	return details
+}
@@ -0,0 +1,118 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	. "github.com/onsi/gomega"
+)
+
+const postmanCollectionJson = `{
+	"info": {
+		"name": "Pets",
+		"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+	},
+	"item": [{
+		"name": "Pets",
+		"item": [{
+			"name": "Get pet",
+			"request": {
+				"method": "GET",
+				"url": {
+					"raw": "{{base_url}}/pets/:petId?verbose=true",
+					"host": ["{{base_url}}"],
+					"path": ["pets", ":petId"],
+					"query": [{"key": "verbose", "value": "true"}, {"key": "debug", "value": "true", "disabled": true}]
+				}
+			},
+			"response": [{
+				"name": "A pet",
+				"code": 200,
+				"header": [{"key": "Content-Type", "value": "application/json"}],
+				"body": "{\"id\": 1}"
+			}]
+		}]
+	}, {
+		"name": "Create pet",
+		"request": {
+			"method": "POST",
+			"url": "https://api.example.com/pets",
+			"body": {"mode": "raw", "raw": "{\"name\": \"Rex\"}"}
+		},
+		"response": [{
+			"name": "Created",
+			"code": 201,
+			"body": "created"
+		}]
+	}, {
+		"name": "Delete pet",
+		"request": {
+			"method": "DELETE",
+			"url": "{{base_url}}/pets/1"
+		},
+		"response": []
+	}]
+}`
+
+func Test_NewSimulationFromPostman_CreatesPairsFromExampleResponses(t *testing.T) {
+	RegisterTestingT(t)
+
+	result, err := NewSimulationFromPostman([]byte(postmanCollectionJson))
+	Expect(err).To(BeNil())
+
+	Expect(result.Imported).To(Equal([]string{"Pets / Get pet", "Create pet"}))
+	Expect(result.Skipped).To(Equal([]string{"Delete pet"}))
+
+	pairs := result.Simulation.RequestResponsePairs
+	Expect(pairs).To(HaveLen(2))
+
+	Expect(pairs[0].RequestMatcher.Method).To(Equal([]v2.MatcherViewV5{{Matcher: "exact", Value: "GET"}}))
+	Expect(pairs[0].RequestMatcher.Destination).To(BeNil())
+	Expect(pairs[0].RequestMatcher.Path).To(Equal([]v2.MatcherViewV5{{Matcher: "glob", Value: "/pets/*"}}))
+	Expect(*pairs[0].RequestMatcher.Query).To(Equal(v2.QueryMatcherViewV5{
+		"verbose": {{Matcher: "exact", Value: "true"}},
+	}))
+	Expect(pairs[0].Response.Status).To(Equal(200))
+	Expect(pairs[0].Response.Body).To(Equal(`{"id": 1}`))
+	Expect(pairs[0].Response.Headers).To(Equal(map[string][]string{"Content-Type": {"application/json"}}))
+
+	Expect(pairs[1].RequestMatcher.Method).To(Equal([]v2.MatcherViewV5{{Matcher: "exact", Value: "POST"}}))
+	Expect(pairs[1].RequestMatcher.Destination).To(Equal([]v2.MatcherViewV5{{Matcher: "exact", Value: "api.example.com"}}))
+	Expect(pairs[1].RequestMatcher.Path).To(Equal([]v2.MatcherViewV5{{Matcher: "exact", Value: "/pets"}}))
+	Expect(pairs[1].RequestMatcher.Body).To(Equal([]v2.MatcherViewV5{{Matcher: "json", Value: `{"name": "Rex"}`}}))
+	Expect(pairs[1].Response.Status).To(Equal(201))
+	Expect(pairs[1].Response.Body).To(Equal("created"))
+
+	simulationData, err := json.Marshal(result.Simulation)
+	Expect(err).To(BeNil())
+	Expect(ValidateSimulation(simulationData)).To(BeEmpty())
+}
+
+func Test_NewSimulationFromPostman_StripsVariableHostFromRawUrl(t *testing.T) {
+	RegisterTestingT(t)
+
+	matcher := postmanRequestMatcher(&postmanRequest{
+		Method: "get",
+		URL:    json.RawMessage(`"{{base_url}}/users/{{userId}}/orders"`),
+	})
+
+	Expect(matcher.Method).To(Equal([]v2.MatcherViewV5{{Matcher: "exact", Value: "GET"}}))
+	Expect(matcher.Destination).To(BeNil())
+	Expect(matcher.Path).To(Equal([]v2.MatcherViewV5{{Matcher: "glob", Value: "/users/*/orders"}}))
+}
+
+func Test_NewSimulationFromPostman_ReturnsErrorForUnsupportedSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewSimulationFromPostman([]byte(`{"info": {"schema": "https://schema.getpostman.com/json/collection/v2.0.0/collection.json"}}`))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not parse Postman collection\n\nOnly v2.1 collections are supported"))
+}
+
+func Test_NewSimulationFromPostman_ReturnsErrorForInvalidJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewSimulationFromPostman([]byte(`{`))
+	Expect(err).ToNot(BeNil())
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
 )
@@ -33,6 +34,23 @@ func ExportSimulation(target configuration.Target, urlPattern string) (v2.Simula
 	return view, err
 }
 
+// MarshalSimulation writes a simulation as JSON indented with indent, which is "tab", "2" or "4" spaces, or
+// "none" for compact JSON on a single line. No indent is the same as "tab".
+func MarshalSimulation(simulation v2.SimulationViewV5, indent string) ([]byte, error) {
+	switch indent {
+	case "", "tab":
+		return json.MarshalIndent(simulation, "", "\t")
+	case "2":
+		return json.MarshalIndent(simulation, "", "  ")
+	case "4":
+		return json.MarshalIndent(simulation, "", "    ")
+	case "none":
+		return json.Marshal(simulation)
+	}
+
+	return nil, fmt.Errorf("Unknown indent %s, use none, 2, 4 or tab", indent)
+}
+
 func ImportSimulation(target configuration.Target, simulationData string) error {
 	response, err := doRequest(target, "PUT", v2ApiSimulation, simulationData, nil)
 	if err != nil {
@@ -97,3 +115,97 @@ func DeleteSimulations(target configuration.Target) error {
 
 	return nil
 }
+
+// GetSimulationPair gets a single pair from the simulation, identified by its index or hash
+func GetSimulationPair(target configuration.Target, id string) (v2.RequestMatcherResponsePairViewV5, error) {
+	view := v2.RequestMatcherResponsePairViewV5{}
+
+	response, err := doRequest(target, "GET", v2ApiSimulation+"/pairs/"+url.PathEscape(id), "", nil)
+	if err != nil {
+		return view, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not retrieve pair")
+	if err != nil {
+		return view, err
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&view)
+	return view, err
+}
+
+// DeleteSimulationPair deletes a single pair from the simulation, identified by its index or hash
+func DeleteSimulationPair(target configuration.Target, id string) error {
+	response, err := doRequest(target, "DELETE", v2ApiSimulation+"/pairs/"+url.PathEscape(id), "", nil)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not delete pair")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PreviewMatch asks Hoverfly which pair would match a request to the url and the response it would serve,
+// without making the request
+func PreviewMatch(target configuration.Target, method, requestUrl string, headers map[string][]string, body string) (v2.MatchPreviewView, error) {
+	preview := v2.MatchPreviewView{}
+
+	parsedUrl, err := url.Parse(requestUrl)
+	if err != nil || parsedUrl.Host == "" {
+		return preview, fmt.Errorf("%s is not a valid url, eg. http://api.example.com/orders", requestUrl)
+	}
+
+	path := parsedUrl.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	requestView := v2.RequestDetailsView{
+		Method:      &method,
+		Scheme:      &parsedUrl.Scheme,
+		Destination: &parsedUrl.Host,
+		Path:        &path,
+		Query:       &parsedUrl.RawQuery,
+		Body:        &body,
+		Headers:     headers,
+	}
+
+	requestBytes, _ := json.Marshal(requestView)
+
+	response, err := doRequest(target, "POST", v2ApiSimulation+"/match", string(requestBytes), nil)
+	if err != nil {
+		return preview, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not preview the match")
+	if err != nil {
+		return preview, err
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&preview)
+	return preview, err
+}
+
+// newSimulationView wraps pairs generated by hoverctl in a simulation which can be imported
+func newSimulationView(pairs []v2.RequestMatcherResponsePairViewV5) v2.SimulationViewV5 {
+	return v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: pairs,
+			GlobalActions: v2.GlobalActionsView{
+				Delays:          []v1.ResponseDelayView{},
+				DelaysLogNormal: []v1.ResponseDelayLogNormalView{},
+			},
+		},
+		MetaView: *v2.NewMetaView(""),
+	}
+}
@@ -0,0 +1,77 @@
+package wrapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var envReferenceRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// ExpandEnvInSimulation replaces ${VAR} references in the string values of a simulation with the
+// value of the environment variable, or with the default in ${VAR:-default} when it is not set.
+// Field names, numbers and booleans are left as they are. It is an error to reference a variable
+// which is not set and has no default.
+func ExpandEnvInSimulation(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var simulation interface{}
+	if err := decoder.Decode(&simulation); err != nil {
+		return nil, fmt.Errorf("Could not expand environment variables in simulation\n\n%s", err.Error())
+	}
+
+	missing := map[string]bool{}
+	simulation = expandEnvInValue(simulation, missing)
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("Environment variables referenced in simulation are not set: %s", strings.Join(names, ", "))
+	}
+
+	var expanded bytes.Buffer
+	encoder := json.NewEncoder(&expanded)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(simulation); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(expanded.Bytes()), nil
+}
+
+func expandEnvInValue(value interface{}, missing map[string]bool) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, field := range typed {
+			typed[key] = expandEnvInValue(field, missing)
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = expandEnvInValue(item, missing)
+		}
+	case string:
+		return envReferenceRegex.ReplaceAllStringFunc(typed, func(reference string) string {
+			groups := envReferenceRegex.FindStringSubmatch(reference)
+			if env, ok := os.LookupEnv(groups[1]); ok {
+				return env
+			}
+			if groups[2] != "" {
+				return groups[3]
+			}
+
+			missing[groups[1]] = true
+			return reference
+		})
+	}
+
+	return value
+}
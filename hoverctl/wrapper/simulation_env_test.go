@@ -0,0 +1,59 @@
+package wrapper
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_ExpandEnvInSimulation_ReplacesReferencesInStrings(t *testing.T) {
+	RegisterTestingT(t)
+
+	t.Setenv("API_HOST", "staging.example.com")
+	t.Setenv("API_TOKEN", "secret")
+
+	expanded, err := ExpandEnvInSimulation([]byte(`{"destination": "${API_HOST}", "headers": {"Authorization": ["Bearer ${API_TOKEN}"]}, "status": 200}`))
+	Expect(err).To(BeNil())
+
+	Expect(string(expanded)).To(MatchJSON(`{"destination": "staging.example.com", "headers": {"Authorization": ["Bearer secret"]}, "status": 200}`))
+}
+
+func Test_ExpandEnvInSimulation_UsesTheDefaultWhenTheVariableIsNotSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	t.Setenv("API_HOST", "staging.example.com")
+
+	expanded, err := ExpandEnvInSimulation([]byte(`{"destination": "${API_HOST:-localhost}", "path": "${API_PATH:-/v1/orders}", "body": "${EMPTY:-}"}`))
+	Expect(err).To(BeNil())
+
+	Expect(string(expanded)).To(MatchJSON(`{"destination": "staging.example.com", "path": "/v1/orders", "body": ""}`))
+}
+
+func Test_ExpandEnvInSimulation_ErrorsForVariablesWhichAreNotSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := ExpandEnvInSimulation([]byte(`{"destination": "${HOVERCTL_TEST_UNSET_HOST}", "path": ["${HOVERCTL_TEST_UNSET_PATH}", "${HOVERCTL_TEST_UNSET_HOST}"]}`))
+	Expect(err).ToNot(BeNil())
+
+	Expect(err.Error()).To(Equal("Environment variables referenced in simulation are not set: HOVERCTL_TEST_UNSET_HOST, HOVERCTL_TEST_UNSET_PATH"))
+}
+
+func Test_ExpandEnvInSimulation_OnlyExpandsStringValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	t.Setenv("FIELD", "renamed")
+
+	expanded, err := ExpandEnvInSimulation([]byte(`{"${FIELD}": 1.50, "templated": true, "body": "<p>${FIELD}</p>"}`))
+	Expect(err).To(BeNil())
+
+	Expect(string(expanded)).To(Equal(`{"${FIELD}":1.50,"body":"<p>renamed</p>","templated":true}`))
+}
+
+func Test_ExpandEnvInSimulation_ErrorsForInvalidJSON(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := ExpandEnvInSimulation([]byte(`{"destination":`))
+	Expect(err).ToNot(BeNil())
+
+	Expect(err.Error()).To(ContainSubstring("Could not expand environment variables in simulation"))
+}
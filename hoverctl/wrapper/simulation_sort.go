@@ -0,0 +1,84 @@
+package wrapper
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+)
+
+// SortSimulation puts the pairs of a simulation in a deterministic order, by destination, method, path and then
+// a hash of the pair, and sorts the matchers of their headers and query parameters, so that exporting the same
+// simulation always gives the same file.
+func SortSimulation(simulation v2.SimulationViewV5) v2.SimulationViewV5 {
+	pairs := simulation.RequestResponsePairs
+
+	for _, pair := range pairs {
+		for _, headerMatchers := range pair.RequestMatcher.Headers {
+			sortMatchers(headerMatchers)
+		}
+
+		if pair.RequestMatcher.Query != nil {
+			for _, queryMatchers := range *pair.RequestMatcher.Query {
+				sortMatchers(queryMatchers)
+			}
+		}
+	}
+
+	keys := make([]string, len(pairs))
+	sortedPairs := make([]v2.RequestMatcherResponsePairViewV5, len(pairs))
+	for i, pair := range pairs {
+		keys[i] = pairSortKey(pair)
+		sortedPairs[i] = pair
+	}
+
+	sort.Sort(pairsByKey{pairs: sortedPairs, keys: keys})
+	simulation.RequestResponsePairs = sortedPairs
+
+	return simulation
+}
+
+type pairsByKey struct {
+	pairs []v2.RequestMatcherResponsePairViewV5
+	keys  []string
+}
+
+func (this pairsByKey) Len() int { return len(this.pairs) }
+
+func (this pairsByKey) Less(i, j int) bool { return this.keys[i] < this.keys[j] }
+
+func (this pairsByKey) Swap(i, j int) {
+	this.pairs[i], this.pairs[j] = this.pairs[j], this.pairs[i]
+	this.keys[i], this.keys[j] = this.keys[j], this.keys[i]
+}
+
+func pairSortKey(pair v2.RequestMatcherResponsePairViewV5) string {
+	pairBytes, _ := json.Marshal(pair)
+	hash := sha1.Sum(pairBytes)
+
+	return strings.Join([]string{
+		matchersSortKey(pair.RequestMatcher.Destination),
+		matchersSortKey(pair.RequestMatcher.Method),
+		matchersSortKey(pair.RequestMatcher.Path),
+		hex.EncodeToString(hash[:]),
+	}, "\x00")
+}
+
+func matchersSortKey(matchers []v2.MatcherViewV5) string {
+	values := make([]string, len(matchers))
+	for i, matcher := range matchers {
+		values[i] = fmt.Sprintf("%v\x01%s", matcher.Value, matcher.Matcher)
+	}
+
+	return strings.Join(values, "\x01")
+}
+
+func sortMatchers(matchers []v2.MatcherViewV5) {
+	sort.SliceStable(matchers, func(i, j int) bool {
+		return matchersSortKey(matchers[i:i+1]) < matchersSortKey(matchers[j:j+1])
+	})
+}
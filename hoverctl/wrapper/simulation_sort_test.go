@@ -0,0 +1,88 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func sortTestPair(destination, method, path, body string) v2.RequestMatcherResponsePairViewV5 {
+	return v2.RequestMatcherResponsePairViewV5{
+		RequestMatcher: v2.RequestMatcherViewV5{
+			Destination: []v2.MatcherViewV5{{Matcher: matchers.Exact, Value: destination}},
+			Method:      []v2.MatcherViewV5{{Matcher: matchers.Exact, Value: method}},
+			Path:        []v2.MatcherViewV5{{Matcher: matchers.Exact, Value: path}},
+		},
+		Response: v2.ResponseDetailsViewV5{
+			Status: 200,
+			Body:   body,
+		},
+	}
+}
+
+func Test_SortSimulation_SortsPairsByDestinationMethodAndPath(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := SortSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				sortTestPair("b.com", "GET", "/", "4"),
+				sortTestPair("a.com", "POST", "/", "3"),
+				sortTestPair("a.com", "GET", "/z", "2"),
+				sortTestPair("a.com", "GET", "/a", "1"),
+			},
+		},
+	})
+
+	Expect(simulation.RequestResponsePairs).To(HaveLen(4))
+	Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("1"))
+	Expect(simulation.RequestResponsePairs[1].Response.Body).To(Equal("2"))
+	Expect(simulation.RequestResponsePairs[2].Response.Body).To(Equal("3"))
+	Expect(simulation.RequestResponsePairs[3].Response.Body).To(Equal("4"))
+}
+
+func Test_SortSimulation_ExportsTheSameDataIdentically(t *testing.T) {
+	RegisterTestingT(t)
+
+	headerPair := func(values ...string) v2.RequestMatcherResponsePairViewV5 {
+		pair := sortTestPair("a.com", "GET", "/", "same request")
+		pair.RequestMatcher.Headers = map[string][]v2.MatcherViewV5{}
+		pair.RequestMatcher.Query = &v2.QueryMatcherViewV5{}
+		for _, value := range values {
+			pair.RequestMatcher.Headers["Accept"] = append(pair.RequestMatcher.Headers["Accept"], v2.MatcherViewV5{Matcher: matchers.Glob, Value: value})
+			(*pair.RequestMatcher.Query)["q"] = append((*pair.RequestMatcher.Query)["q"], v2.MatcherViewV5{Matcher: matchers.Exact, Value: value})
+		}
+		return pair
+	}
+
+	first := SortSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				sortTestPair("a.com", "GET", "/", "first"),
+				headerPair("text/*", "application/*"),
+				sortTestPair("a.com", "GET", "/", "second"),
+			},
+		},
+	})
+
+	second := SortSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				headerPair("application/*", "text/*"),
+				sortTestPair("a.com", "GET", "/", "second"),
+				sortTestPair("a.com", "GET", "/", "first"),
+			},
+		},
+	})
+
+	firstBytes, err := json.Marshal(first)
+	Expect(err).To(BeNil())
+
+	secondBytes, err := json.Marshal(second)
+	Expect(err).To(BeNil())
+
+	Expect(string(firstBytes)).To(Equal(string(secondBytes)))
+}
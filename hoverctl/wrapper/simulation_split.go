@@ -0,0 +1,168 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+)
+
+// AnyHostSimulation is the name given by SplitSimulationByHost to the simulation holding the
+// pairs which do not match a single exact destination
+const AnyHostSimulation = "any-host"
+
+var unsafeFileNameCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// ParseSimulation unmarshals a v5 simulation. Older simulations need importing into Hoverfly
+// and exporting again first, as they do not map onto the v5 views.
+func ParseSimulation(data []byte) (v2.SimulationViewV5, error) {
+	var simulation v2.SimulationViewV5
+	if err := json.Unmarshal(data, &simulation); err != nil {
+		return simulation, fmt.Errorf("Could not parse simulation\n\n%s", err.Error())
+	}
+
+	if !strings.HasPrefix(simulation.SchemaVersion, "v5") {
+		return simulation, errors.New("Could not parse simulation\n\nOnly v5 simulations are supported, import and export the simulation with Hoverfly to upgrade it")
+	}
+
+	return simulation, nil
+}
+
+// SplitSimulationByHost splits a simulation into one simulation per destination, keyed by a name
+// made from the destination which is safe to use as a file name. Pairs without a single exact
+// destination matcher go in the AnyHostSimulation. Delays go in each simulation with a pair whose
+// destination and path they match, and in every simulation if they match none. Literals and
+// variables go in every simulation.
+func SplitSimulationByHost(simulation v2.SimulationViewV5) map[string]v2.SimulationViewV5 {
+	pairsByHost := map[string][]v2.RequestMatcherResponsePairViewV5{}
+	for _, pair := range simulation.RequestResponsePairs {
+		host := AnyHostSimulation
+		if destination, ok := exactMatcherValue(pair.RequestMatcher.Destination); ok {
+			host = unsafeFileNameCharacters.ReplaceAllString(destination, "_")
+		}
+
+		pairsByHost[host] = append(pairsByHost[host], pair)
+	}
+
+	delaysByHost := map[string][]v1.ResponseDelayView{}
+	for _, delay := range simulation.GlobalActions.Delays {
+		for _, host := range delayHosts(pairsByHost, delay.UrlPattern) {
+			delaysByHost[host] = append(delaysByHost[host], delay)
+		}
+	}
+
+	logNormalDelaysByHost := map[string][]v1.ResponseDelayLogNormalView{}
+	for _, delay := range simulation.GlobalActions.DelaysLogNormal {
+		for _, host := range delayHosts(pairsByHost, delay.UrlPattern) {
+			logNormalDelaysByHost[host] = append(logNormalDelaysByHost[host], delay)
+		}
+	}
+
+	simulations := map[string]v2.SimulationViewV5{}
+	for host, pairs := range pairsByHost {
+		split := newSimulationView(pairs)
+		if delays, ok := delaysByHost[host]; ok {
+			split.GlobalActions.Delays = delays
+		}
+		if delays, ok := logNormalDelaysByHost[host]; ok {
+			split.GlobalActions.DelaysLogNormal = delays
+		}
+		split.GlobalLiterals = simulation.GlobalLiterals
+		split.GlobalVariables = simulation.GlobalVariables
+
+		simulations[host] = split
+	}
+
+	return simulations
+}
+
+// delayHosts returns the hosts with a pair whose exact destination and path match the url pattern,
+// or all of the hosts if there are none
+func delayHosts(pairsByHost map[string][]v2.RequestMatcherResponsePairViewV5, urlPattern string) []string {
+	all := []string{}
+	matched := []string{}
+
+	pattern, err := regexp.Compile(urlPattern)
+	for host, pairs := range pairsByHost {
+		all = append(all, host)
+		if err != nil {
+			continue
+		}
+
+		for _, pair := range pairs {
+			destination, _ := exactMatcherValue(pair.RequestMatcher.Destination)
+			path, _ := exactMatcherValue(pair.RequestMatcher.Path)
+			if destination != "" && pattern.MatchString(destination+path) {
+				matched = append(matched, host)
+				break
+			}
+		}
+	}
+
+	if len(matched) == 0 {
+		return all
+	}
+
+	return matched
+}
+
+// MergeSimulations combines simulations into one. Pairs with the same request matcher as an
+// earlier pair are dropped, and their number returned. Identical delays, and literals and
+// variables with the same name as an earlier one, are only kept once.
+func MergeSimulations(simulations ...v2.SimulationViewV5) (v2.SimulationViewV5, int) {
+	merged := newSimulationView([]v2.RequestMatcherResponsePairViewV5{})
+	duplicates := 0
+
+	pairHashes := map[string]bool{}
+	delays := map[v1.ResponseDelayView]bool{}
+	logNormalDelays := map[v1.ResponseDelayLogNormalView]bool{}
+	literals := map[string]bool{}
+	variables := map[string]bool{}
+
+	for _, simulation := range simulations {
+		for _, pair := range simulation.RequestResponsePairs {
+			hash := models.NewRequestMatcherResponsePairFromView(&pair).Hash()
+			if pairHashes[hash] {
+				duplicates++
+				continue
+			}
+			pairHashes[hash] = true
+			merged.RequestResponsePairs = append(merged.RequestResponsePairs, pair)
+		}
+
+		for _, delay := range simulation.GlobalActions.Delays {
+			if !delays[delay] {
+				delays[delay] = true
+				merged.GlobalActions.Delays = append(merged.GlobalActions.Delays, delay)
+			}
+		}
+
+		for _, delay := range simulation.GlobalActions.DelaysLogNormal {
+			if !logNormalDelays[delay] {
+				logNormalDelays[delay] = true
+				merged.GlobalActions.DelaysLogNormal = append(merged.GlobalActions.DelaysLogNormal, delay)
+			}
+		}
+
+		for _, literal := range simulation.GlobalLiterals {
+			if !literals[literal.Name] {
+				literals[literal.Name] = true
+				merged.GlobalLiterals = append(merged.GlobalLiterals, literal)
+			}
+		}
+
+		for _, variable := range simulation.GlobalVariables {
+			if !variables[variable.Name] {
+				variables[variable.Name] = true
+				merged.GlobalVariables = append(merged.GlobalVariables, variable)
+			}
+		}
+	}
+
+	return merged, duplicates
+}
@@ -0,0 +1,131 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func splitTestPair(destination, path string, status int) v2.RequestMatcherResponsePairViewV5 {
+	return v2.RequestMatcherResponsePairViewV5{
+		RequestMatcher: v2.RequestMatcherViewV5{
+			Destination: []v2.MatcherViewV5{v2.NewMatcherView(matchers.Exact, destination)},
+			Path:        []v2.MatcherViewV5{v2.NewMatcherView(matchers.Exact, path)},
+		},
+		Response: v2.ResponseDetailsViewV5{Status: status},
+	}
+}
+
+func Test_SplitSimulationByHost_WritesPairsAndDelaysToTheirHost(t *testing.T) {
+	RegisterTestingT(t)
+
+	anyHostPair := v2.RequestMatcherResponsePairViewV5{
+		RequestMatcher: v2.RequestMatcherViewV5{
+			Destination: []v2.MatcherViewV5{v2.NewMatcherView(matchers.Glob, "*.internal")},
+		},
+		Response: v2.ResponseDetailsViewV5{Status: 404},
+	}
+
+	simulation := newSimulationView([]v2.RequestMatcherResponsePairViewV5{
+		splitTestPair("payments.internal", "/charges", 200),
+		splitTestPair("orders.internal:8443", "/orders", 201),
+		splitTestPair("payments.internal", "/refunds", 202),
+		anyHostPair,
+	})
+	simulation.GlobalActions.Delays = []v1.ResponseDelayView{
+		{UrlPattern: `^payments\.internal/`, Delay: 100},
+		{UrlPattern: ".", Delay: 50},
+	}
+	simulation.GlobalActions.DelaysLogNormal = []v1.ResponseDelayLogNormalView{
+		{UrlPattern: "orders", Min: 1, Max: 10, Mean: 5, Median: 4},
+	}
+	simulation.GlobalLiterals = []v2.GlobalLiteralViewV5{{Name: "currency", Value: "GBP"}}
+
+	splits := SplitSimulationByHost(simulation)
+	Expect(splits).To(HaveLen(3))
+
+	payments := splits["payments.internal"]
+	Expect(payments.RequestResponsePairs).To(Equal([]v2.RequestMatcherResponsePairViewV5{
+		splitTestPair("payments.internal", "/charges", 200),
+		splitTestPair("payments.internal", "/refunds", 202),
+	}))
+	Expect(payments.GlobalActions.Delays).To(Equal(simulation.GlobalActions.Delays))
+	Expect(payments.GlobalActions.DelaysLogNormal).To(BeEmpty())
+	Expect(payments.GlobalLiterals).To(Equal(simulation.GlobalLiterals))
+	Expect(payments.SchemaVersion).To(Equal(simulation.SchemaVersion))
+
+	orders := splits["orders.internal_8443"]
+	Expect(orders.RequestResponsePairs).To(ConsistOf(splitTestPair("orders.internal:8443", "/orders", 201)))
+	Expect(orders.GlobalActions.Delays).To(ConsistOf(v1.ResponseDelayView{UrlPattern: ".", Delay: 50}))
+	Expect(orders.GlobalActions.DelaysLogNormal).To(Equal(simulation.GlobalActions.DelaysLogNormal))
+
+	anyHost := splits[AnyHostSimulation]
+	Expect(anyHost.RequestResponsePairs).To(ConsistOf(anyHostPair))
+	Expect(anyHost.GlobalActions.Delays).To(BeEmpty())
+}
+
+func Test_SplitSimulationByHost_WritesDelaysMatchingNoHostToEveryHost(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := newSimulationView([]v2.RequestMatcherResponsePairViewV5{
+		splitTestPair("payments.internal", "/charges", 200),
+		splitTestPair("orders.internal", "/orders", 201),
+	})
+	simulation.GlobalActions.Delays = []v1.ResponseDelayView{{UrlPattern: "inventory", Delay: 100}}
+
+	splits := SplitSimulationByHost(simulation)
+
+	Expect(splits["payments.internal"].GlobalActions.Delays).To(Equal(simulation.GlobalActions.Delays))
+	Expect(splits["orders.internal"].GlobalActions.Delays).To(Equal(simulation.GlobalActions.Delays))
+}
+
+func Test_MergeSimulations_CombinesSplitSimulationsWithoutDuplicates(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := newSimulationView([]v2.RequestMatcherResponsePairViewV5{
+		splitTestPair("payments.internal", "/charges", 200),
+		splitTestPair("orders.internal", "/orders", 201),
+	})
+	simulation.GlobalActions.Delays = []v1.ResponseDelayView{{UrlPattern: ".", Delay: 50}}
+	simulation.GlobalVariables = []v2.GlobalVariableViewV5{{Name: "id", Function: "faker", Arguments: []interface{}{"UUID"}}}
+
+	splits := SplitSimulationByHost(simulation)
+
+	merged, duplicates := MergeSimulations(splits["payments.internal"], splits["orders.internal"])
+	Expect(duplicates).To(Equal(0))
+	Expect(merged.RequestResponsePairs).To(ConsistOf(simulation.RequestResponsePairs))
+	Expect(merged.GlobalActions.Delays).To(Equal(simulation.GlobalActions.Delays))
+	Expect(merged.GlobalVariables).To(Equal(simulation.GlobalVariables))
+}
+
+func Test_MergeSimulations_KeepsTheFirstPairWithTheSameRequestMatcher(t *testing.T) {
+	RegisterTestingT(t)
+
+	first := newSimulationView([]v2.RequestMatcherResponsePairViewV5{splitTestPair("payments.internal", "/charges", 200)})
+	second := newSimulationView([]v2.RequestMatcherResponsePairViewV5{
+		splitTestPair("payments.internal", "/charges", 500),
+		splitTestPair("payments.internal", "/refunds", 202),
+	})
+
+	merged, duplicates := MergeSimulations(first, second)
+	Expect(duplicates).To(Equal(1))
+	Expect(merged.RequestResponsePairs).To(Equal([]v2.RequestMatcherResponsePairViewV5{
+		splitTestPair("payments.internal", "/charges", 200),
+		splitTestPair("payments.internal", "/refunds", 202),
+	}))
+}
+
+func Test_ParseSimulation_RejectsSimulationsOlderThanV5(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := ParseSimulation([]byte(`{"data": {"pairs": []}, "meta": {"schemaVersion": "v3"}}`))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Only v5 simulations are supported"))
+
+	simulation, err := ParseSimulation([]byte(`{"data": {"pairs": []}, "meta": {"schemaVersion": "v5.2"}}`))
+	Expect(err).To(BeNil())
+	Expect(simulation.SchemaVersion).To(Equal("v5.2"))
+}
@@ -0,0 +1,80 @@
+package wrapper
+
+import (
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+)
+
+// SimulationSummary holds counts describing a simulation, as printed by
+// `hoverctl simulation summary`.
+type SimulationSummary struct {
+	SchemaVersion   string
+	HoverflyVersion string
+	Pairs           int
+	DelayedPairs    int
+	StatefulPairs   int
+	GlobalDelays    int
+	// MatcherPairs is the number of pairs using each matcher kind. A pair is
+	// counted once per kind however many of its fields use that kind.
+	MatcherPairs map[string]int
+}
+
+// SummariseSimulation counts the pairs, delays, state usage and matcher kinds
+// in a simulation exported from Hoverfly.
+func SummariseSimulation(simulation v2.SimulationViewV5) SimulationSummary {
+	summary := SimulationSummary{
+		SchemaVersion:   simulation.MetaView.SchemaVersion,
+		HoverflyVersion: simulation.MetaView.HoverflyVersion,
+		Pairs:           len(simulation.RequestResponsePairs),
+		GlobalDelays:    len(simulation.GlobalActions.Delays) + len(simulation.GlobalActions.DelaysLogNormal),
+		MatcherPairs:    map[string]int{},
+	}
+
+	for _, pair := range simulation.RequestResponsePairs {
+		if pair.Response.FixedDelay > 0 || pair.Response.LogNormalDelay != nil {
+			summary.DelayedPairs++
+		}
+
+		if len(pair.RequestMatcher.RequiresState) > 0 || len(pair.Response.TransitionsState) > 0 || len(pair.Response.RemovesState) > 0 {
+			summary.StatefulPairs++
+		}
+
+		for kind := range pairMatcherKinds(pair.RequestMatcher) {
+			summary.MatcherPairs[kind]++
+		}
+	}
+
+	return summary
+}
+
+func pairMatcherKinds(request v2.RequestMatcherViewV5) map[string]bool {
+	kinds := map[string]bool{}
+
+	fields := [][]v2.MatcherViewV5{
+		request.Path,
+		request.Method,
+		request.Destination,
+		request.Scheme,
+		request.Body,
+		request.DeprecatedQuery,
+	}
+	for _, matchers := range request.Headers {
+		fields = append(fields, matchers)
+	}
+	if request.Query != nil {
+		for _, matchers := range *request.Query {
+			fields = append(fields, matchers)
+		}
+	}
+
+	for _, matchers := range fields {
+		for _, matcher := range matchers {
+			for current := &matcher; current != nil; current = current.DoMatch {
+				kinds[strings.ToLower(current.Matcher)] = true
+			}
+		}
+	}
+
+	return kinds
+}
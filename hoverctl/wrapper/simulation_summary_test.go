@@ -0,0 +1,76 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	. "github.com/onsi/gomega"
+)
+
+func Test_SummariseSimulation_CountsPairsDelaysStateAndMatchers(t *testing.T) {
+	RegisterTestingT(t)
+
+	query := v2.QueryMatcherViewV5{
+		"id": {{Matcher: "glob", Value: "*"}},
+	}
+
+	summary := SummariseSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Path:   []v2.MatcherViewV5{{Matcher: "exact", Value: "/a"}},
+						Method: []v2.MatcherViewV5{{Matcher: "exact", Value: "GET"}},
+						Query:  &query,
+					},
+					Response: v2.ResponseDetailsViewV5{Status: 200, FixedDelay: 100},
+				},
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Headers: map[string][]v2.MatcherViewV5{
+							"Accept": {{Matcher: "regex", Value: "json"}},
+						},
+						Body: []v2.MatcherViewV5{{
+							Matcher: "jsonpath",
+							Value:   "$.id",
+							DoMatch: &v2.MatcherViewV5{Matcher: "exact", Value: "1"},
+						}},
+						RequiresState: map[string]string{"logged-in": "true"},
+					},
+					Response: v2.ResponseDetailsViewV5{Status: 200, LogNormalDelay: &v2.LogNormalDelayOptions{Min: 1}},
+				},
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{},
+					Response:       v2.ResponseDetailsViewV5{Status: 200, RemovesState: []string{"logged-in"}},
+				},
+			},
+			GlobalActions: v2.GlobalActionsView{
+				Delays: []v1.ResponseDelayView{{UrlPattern: ".", Delay: 10}},
+			},
+		},
+		MetaView: v2.MetaView{SchemaVersion: "v5", HoverflyVersion: "v1.0.0"},
+	})
+
+	Expect(summary.SchemaVersion).To(Equal("v5"))
+	Expect(summary.HoverflyVersion).To(Equal("v1.0.0"))
+	Expect(summary.Pairs).To(Equal(3))
+	Expect(summary.DelayedPairs).To(Equal(2))
+	Expect(summary.StatefulPairs).To(Equal(2))
+	Expect(summary.GlobalDelays).To(Equal(1))
+	Expect(summary.MatcherPairs).To(Equal(map[string]int{
+		"exact":    2,
+		"glob":     1,
+		"regex":    1,
+		"jsonpath": 1,
+	}))
+}
+
+func Test_SummariseSimulation_HandlesEmptySimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	summary := SummariseSimulation(v2.SimulationViewV5{})
+
+	Expect(summary.Pairs).To(Equal(0))
+	Expect(summary.MatcherPairs).To(BeEmpty())
+}
@@ -378,3 +378,284 @@ func Test_DeleteSimulations_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 	Expect(err.Error()).To(Equal("Could not delete simulation\n\ntest error"))
 }
+
+func Test_GetSimulationPair_GetsPairFromHoverfly(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/pairs/1a2b3c",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"request": {"path": [{"matcher": "exact", "value": "/orders"}]}, "response": {"status": 201, "body": "order"}}`,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	pair, err := GetSimulationPair(target, "1a2b3c")
+	Expect(err).To(BeNil())
+
+	Expect(pair.RequestMatcher.Path[0].Value).To(Equal("/orders"))
+	Expect(pair.Response.Status).To(Equal(201))
+	Expect(pair.Response.Body).To(Equal("order"))
+}
+
+func Test_GetSimulationPair_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := GetSimulationPair(inaccessibleTarget, "0")
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_GetSimulationPair_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/pairs/7",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 404,
+						Body:   "{\"error\":\"No pair found with index or hash 7\"}",
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	_, err := GetSimulationPair(target, "7")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not retrieve pair\n\nNo pair found with index or hash 7"))
+}
+
+func Test_DeleteSimulationPair_SendsCorrectHTTPRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "DELETE",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/pairs/1a2b3c",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"simulation": true}`,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := DeleteSimulationPair(target, "1a2b3c")
+	Expect(err).To(BeNil())
+}
+
+func Test_DeleteSimulationPair_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	err := DeleteSimulationPair(inaccessibleTarget, "0")
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_DeleteSimulationPair_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "DELETE",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/pairs/7",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 404,
+						Body:   "{\"error\":\"No pair found with index or hash 7\"}",
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := DeleteSimulationPair(target, "7")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not delete pair\n\nNo pair found with index or hash 7"))
+}
+
+func Test_PreviewMatch_SendsTheRequestFromTheUrl(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/match",
+							},
+						},
+						Body: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.JsonPartial,
+								Value:   `{"method": "GET", "scheme": "https", "destination": "api.example.com", "path": "/orders", "query": "id=7"}`,
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"matched": true, "pairIndex": 3, "response": {"status": 201, "body": "order 7"}}`,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	preview, err := PreviewMatch(target, "GET", "https://api.example.com/orders?id=7", nil, "")
+	Expect(err).To(BeNil())
+
+	Expect(preview.Matched).To(BeTrue())
+	Expect(*preview.PairIndex).To(Equal(3))
+	Expect(preview.Response.Status).To(Equal(201))
+	Expect(preview.Response.Body).To(Equal("order 7"))
+}
+
+func Test_PreviewMatch_ErrorsWhen_UrlHasNoHost(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := PreviewMatch(target, "GET", "/orders", nil, "")
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("/orders is not a valid url, eg. http://api.example.com/orders"))
+}
+
+func Test_MarshalSimulation_IndentsWithTabsByDefault(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := v2.SimulationViewV5{MetaView: v2.MetaView{SchemaVersion: "v5.2"}}
+
+	data, err := MarshalSimulation(simulation, "")
+	Expect(err).To(BeNil())
+	Expect(string(data)).To(ContainSubstring("\n\t\"meta\": {\n\t\t\"schemaVersion\": \"v5.2\""))
+
+	tabData, err := MarshalSimulation(simulation, "tab")
+	Expect(err).To(BeNil())
+	Expect(tabData).To(Equal(data))
+}
+
+func Test_MarshalSimulation_IndentsWithSpaces(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := v2.SimulationViewV5{MetaView: v2.MetaView{SchemaVersion: "v5.2"}}
+
+	data, err := MarshalSimulation(simulation, "2")
+	Expect(err).To(BeNil())
+	Expect(string(data)).To(ContainSubstring("\n  \"meta\": {\n    \"schemaVersion\": \"v5.2\""))
+
+	data, err = MarshalSimulation(simulation, "4")
+	Expect(err).To(BeNil())
+	Expect(string(data)).To(ContainSubstring("\n    \"meta\": {\n        \"schemaVersion\": \"v5.2\""))
+}
+
+func Test_MarshalSimulation_WritesCompactJSONWithoutIndent(t *testing.T) {
+	RegisterTestingT(t)
+
+	data, err := MarshalSimulation(v2.SimulationViewV5{MetaView: v2.MetaView{SchemaVersion: "v5.2"}}, "none")
+	Expect(err).To(BeNil())
+
+	Expect(string(data)).ToNot(ContainSubstring("\n"))
+	Expect(string(data)).To(ContainSubstring(`"meta":{"schemaVersion":"v5.2"`))
+}
+
+func Test_MarshalSimulation_ErrorsForUnknownIndent(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := MarshalSimulation(v2.SimulationViewV5{}, "3")
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Unknown indent 3, use none, 2, 4 or tab"))
+}
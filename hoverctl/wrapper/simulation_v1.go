@@ -0,0 +1,76 @@
+package wrapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+)
+
+// NewSimulationFromV1 builds a v1 simulation from the records exported from /api/records by older versions of
+// Hoverfly, or from a v1 simulation. The delays exported from /api/delays are added to its global actions when
+// they are given, so they are kept when Hoverfly upgrades the simulation.
+func NewSimulationFromV1(records, delays []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(records))
+	decoder.UseNumber()
+
+	var simulation map[string]interface{}
+	if err := decoder.Decode(&simulation); err != nil {
+		return nil, fmt.Errorf("Could not read v1 simulation\n\n%s", err.Error())
+	}
+
+	var data map[string]interface{}
+	switch simulationData := simulation["data"].(type) {
+	case []interface{}:
+		data = map[string]interface{}{
+			"pairs": simulationData,
+		}
+	case map[string]interface{}:
+		data = simulationData
+	default:
+		return nil, errors.New("Could not read v1 simulation\n\nmissing \"data\"")
+	}
+
+	meta, ok := simulation["meta"].(map[string]interface{})
+	if !ok {
+		meta = map[string]interface{}{
+			"schemaVersion": "v1",
+		}
+	} else if meta["schemaVersion"] != "v1" {
+		return nil, fmt.Errorf("Could not read v1 simulation\n\nschema version is %v", meta["schemaVersion"])
+	}
+
+	if len(delays) > 0 {
+		var delaysView v1.ResponseDelayPayloadView
+		if err := json.Unmarshal(delays, &delaysView); err != nil {
+			return nil, fmt.Errorf("Could not read v1 delays\n\n%s", err.Error())
+		}
+
+		globalActions, ok := data["globalActions"].(map[string]interface{})
+		if !ok {
+			globalActions = map[string]interface{}{}
+		}
+
+		existingDelays, _ := globalActions["delays"].([]interface{})
+		for _, delay := range delaysView.Data {
+			existingDelays = append(existingDelays, delay)
+		}
+
+		globalActions["delays"] = existingDelays
+		data["globalActions"] = globalActions
+	}
+
+	var converted bytes.Buffer
+	encoder := json.NewEncoder(&converted)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(map[string]interface{}{
+		"data": data,
+		"meta": meta,
+	}); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(converted.Bytes()), nil
+}
@@ -0,0 +1,108 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	. "github.com/onsi/gomega"
+)
+
+func Test_NewSimulationFromV1_ConvertsRecords(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := NewSimulationFromV1([]byte(`{"data": [{"request": {"destination": "test-server.com"}, "response": {"status": 200, "body": "<p>body</p>"}}]}`), nil)
+	Expect(err).To(BeNil())
+
+	Expect(string(simulation)).To(MatchJSON(`{
+		"data": {
+			"pairs": [{"request": {"destination": "test-server.com"}, "response": {"status": 200, "body": "<p>body</p>"}}]
+		},
+		"meta": {"schemaVersion": "v1"}
+	}`))
+}
+
+func Test_NewSimulationFromV1_AddsDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := NewSimulationFromV1(
+		[]byte(`{"data": [{"request": {"destination": "test-server.com"}, "response": {"status": 200}}]}`),
+		[]byte(`{"data": [{"urlPattern": "test-server.com", "httpMethod": "GET", "delay": 100}]}`),
+	)
+	Expect(err).To(BeNil())
+
+	Expect(string(simulation)).To(MatchJSON(`{
+		"data": {
+			"pairs": [{"request": {"destination": "test-server.com"}, "response": {"status": 200}}],
+			"globalActions": {
+				"delays": [{"urlPattern": "test-server.com", "httpMethod": "GET", "delay": 100}]
+			}
+		},
+		"meta": {"schemaVersion": "v1"}
+	}`))
+}
+
+func Test_NewSimulationFromV1_AddsDelaysToThoseOfAV1Simulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := NewSimulationFromV1(
+		[]byte(`{
+			"data": {
+				"pairs": [],
+				"globalActions": {"delays": [{"urlPattern": "one.com", "httpMethod": "", "delay": 10}]}
+			},
+			"meta": {"schemaVersion": "v1", "hoverflyVersion": "v0.10.0", "timeExported": "2017-02-23T12:43:48Z"}
+		}`),
+		[]byte(`{"data": [{"urlPattern": "two.com", "httpMethod": "POST", "delay": 20}]}`),
+	)
+	Expect(err).To(BeNil())
+
+	Expect(string(simulation)).To(MatchJSON(`{
+		"data": {
+			"pairs": [],
+			"globalActions": {
+				"delays": [
+					{"urlPattern": "one.com", "httpMethod": "", "delay": 10},
+					{"urlPattern": "two.com", "httpMethod": "POST", "delay": 20}
+				]
+			}
+		},
+		"meta": {"schemaVersion": "v1", "hoverflyVersion": "v0.10.0", "timeExported": "2017-02-23T12:43:48Z"}
+	}`))
+}
+
+func Test_NewSimulationFromV1_DelaysSurviveTheUpgrade(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := NewSimulationFromV1(
+		[]byte(`{"data": [{"request": {"destination": "test-server.com"}, "response": {"status": 200}}]}`),
+		[]byte(`{"data": [{"urlPattern": "test-server.com", "httpMethod": "GET", "delay": 100}]}`),
+	)
+	Expect(err).To(BeNil())
+
+	upgraded, err := v2.NewSimulationViewFromRequestBody(simulation)
+	Expect(err).To(BeNil())
+
+	Expect(upgraded.RequestResponsePairs).To(HaveLen(1))
+	Expect(upgraded.GlobalActions.Delays).To(HaveLen(1))
+	Expect(upgraded.GlobalActions.Delays[0].UrlPattern).To(Equal("test-server.com"))
+	Expect(upgraded.GlobalActions.Delays[0].HttpMethod).To(Equal("GET"))
+	Expect(upgraded.GlobalActions.Delays[0].Delay).To(Equal(100))
+}
+
+func Test_NewSimulationFromV1_ErrorsForSimulationsWhichAreNotV1(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewSimulationFromV1([]byte(`{"data": {"pairs": []}, "meta": {"schemaVersion": "v5"}}`), nil)
+	Expect(err).ToNot(BeNil())
+
+	Expect(err.Error()).To(Equal("Could not read v1 simulation\n\nschema version is v5"))
+}
+
+func Test_NewSimulationFromV1_ErrorsForInvalidDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewSimulationFromV1([]byte(`{"data": []}`), []byte(`{"data":`))
+	Expect(err).ToNot(BeNil())
+
+	Expect(err.Error()).To(ContainSubstring("Could not read v1 delays"))
+}
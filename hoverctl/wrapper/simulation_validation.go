@@ -0,0 +1,87 @@
+package wrapper
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+)
+
+// ValidateSimulation checks simulation data without a running Hoverfly. The data is validated
+// against the same JSON schema as the simulation API, then every request matcher is checked for
+// an unknown matcher type or an invalid regular expression. Each problem found is returned
+// prefixed with the JSON path of the offending field.
+func ValidateSimulation(simulationData []byte) []string {
+	simulation, err := v2.NewSimulationViewFromRequestBody(simulationData)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var problems []string
+	for i, pair := range simulation.RequestResponsePairs {
+		path := fmt.Sprintf("data.pairs[%d].request", i)
+		request := pair.RequestMatcher
+
+		problems = append(problems, validateMatchers(path+".path", request.Path)...)
+		problems = append(problems, validateMatchers(path+".method", request.Method)...)
+		problems = append(problems, validateMatchers(path+".destination", request.Destination)...)
+		problems = append(problems, validateMatchers(path+".scheme", request.Scheme)...)
+		problems = append(problems, validateMatchers(path+".body", request.Body)...)
+		problems = append(problems, validateMatchers(path+".deprecatedQuery", request.DeprecatedQuery)...)
+
+		for _, key := range sortedKeys(request.Headers) {
+			problems = append(problems, validateMatchers(fmt.Sprintf("%s.headers.%s", path, key), request.Headers[key])...)
+		}
+
+		if request.Query != nil {
+			for _, key := range sortedKeys(*request.Query) {
+				problems = append(problems, validateMatchers(fmt.Sprintf("%s.query.%s", path, key), (*request.Query)[key])...)
+			}
+		}
+	}
+
+	return problems
+}
+
+func validateMatchers(path string, fieldMatchers []v2.MatcherViewV5) []string {
+	var problems []string
+	for i, matcher := range fieldMatchers {
+		problems = append(problems, validateMatcher(fmt.Sprintf("%s[%d]", path, i), matcher)...)
+	}
+	return problems
+}
+
+func validateMatcher(path string, matcher v2.MatcherViewV5) []string {
+	var problems []string
+	name := strings.ToLower(matcher.Matcher)
+
+	if _, ok := matchers.Matchers[name]; !ok && name != "form" {
+		problems = append(problems, fmt.Sprintf("%s.matcher: unknown matcher \"%s\"", path, matcher.Matcher))
+	}
+
+	if name == matchers.Regex {
+		if expression, ok := matcher.Value.(string); !ok {
+			problems = append(problems, fmt.Sprintf("%s.value: regex matcher value must be a string", path))
+		} else if _, err := regexp.Compile(expression); err != nil {
+			problems = append(problems, fmt.Sprintf("%s.value: invalid regex: %s", path, err.Error()))
+		}
+	}
+
+	if matcher.DoMatch != nil {
+		problems = append(problems, validateMatcher(path+".doMatch", *matcher.DoMatch)...)
+	}
+
+	return problems
+}
+
+func sortedKeys(fields map[string][]v2.MatcherViewV5) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,81 @@
+package wrapper
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_ValidateSimulation_ReturnsNoProblemsForValidSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	problems := ValidateSimulation([]byte(`{
+		"data": {
+			"pairs": [{
+				"request": {
+					"path": [{"matcher": "exact", "value": "/api"}],
+					"body": [{"matcher": "jsonpath", "value": "$.id", "doMatch": {"matcher": "regex", "value": "^[0-9]+$"}}]
+				},
+				"response": {"status": 200}
+			}]
+		},
+		"meta": {"schemaVersion": "v5"}
+	}`))
+
+	Expect(problems).To(BeEmpty())
+}
+
+func Test_ValidateSimulation_ReportsInvalidJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	problems := ValidateSimulation([]byte(`{`))
+
+	Expect(problems).To(ConsistOf("Invalid JSON"))
+}
+
+func Test_ValidateSimulation_ReportsUnknownSchemaVersion(t *testing.T) {
+	RegisterTestingT(t)
+
+	problems := ValidateSimulation([]byte(`{"data": {}, "meta": {"schemaVersion": "v99"}}`))
+
+	Expect(problems).To(HaveLen(1))
+	Expect(problems[0]).To(ContainSubstring("schema version v99 is not supported"))
+}
+
+func Test_ValidateSimulation_ReportsSchemaErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	problems := ValidateSimulation([]byte(`{"data": {"pairs": [{"request": {}, "response": {"status": "200"}}]}, "meta": {"schemaVersion": "v5"}}`))
+
+	Expect(problems).To(HaveLen(1))
+	Expect(problems[0]).To(ContainSubstring("Invalid v5 simulation"))
+	Expect(problems[0]).To(ContainSubstring("status"))
+}
+
+func Test_ValidateSimulation_ReportsUnknownMatchersAndInvalidRegexesWithPaths(t *testing.T) {
+	RegisterTestingT(t)
+
+	problems := ValidateSimulation([]byte(`{
+		"data": {
+			"pairs": [{
+				"request": {
+					"path": [{"matcher": "exact", "value": "/api"}, {"matcher": "fuzzy", "value": "/api"}],
+					"headers": {
+						"Accept": [{"matcher": "regex", "value": "[a-z"}]
+					},
+					"query": {
+						"page": [{"matcher": "jsonpath", "value": "$.id", "doMatch": {"matcher": "between", "value": 1}}]
+					}
+				},
+				"response": {"status": 200}
+			}]
+		},
+		"meta": {"schemaVersion": "v5"}
+	}`))
+
+	Expect(problems).To(Equal([]string{
+		`data.pairs[0].request.path[1].matcher: unknown matcher "fuzzy"`,
+		"data.pairs[0].request.headers.Accept[0].value: invalid regex: error parsing regexp: missing closing ]: `[a-z`",
+		`data.pairs[0].request.query.page[0].doMatch.matcher: unknown matcher "between"`,
+	}))
+}
@@ -0,0 +1,101 @@
+package wrapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// IsYAMLSimulation reports whether a simulation file should be read or written as YAML, either
+// because format is "yaml" or, when no format is given, because the path ends in .yml or .yaml.
+func IsYAMLSimulation(format, path string) (bool, error) {
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		return true, nil
+	case "json":
+		return false, nil
+	case "":
+		extension := strings.ToLower(filepath.Ext(path))
+		return extension == ".yaml" || extension == ".yml", nil
+	}
+
+	return false, fmt.Errorf("Unknown simulation format %s, use json or yaml", format)
+}
+
+// SimulationJSONToYAML converts a simulation from JSON to YAML, keeping the order of the fields.
+// Strings containing new lines, such as response bodies, are written as block scalars.
+func SimulationJSONToYAML(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	simulation, err := decodeOrderedJSON(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("Could not convert simulation to YAML\n\n%s", err.Error())
+	}
+
+	return yaml.Marshal(simulation)
+}
+
+// decodeOrderedJSON decodes the next JSON value, using yaml.MapSlice for objects so that the
+// order of their fields is kept
+func decodeOrderedJSON(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch typed := token.(type) {
+	case json.Delim:
+		if typed == '{' {
+			object := yaml.MapSlice{}
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+
+				value, err := decodeOrderedJSON(decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				object = append(object, yaml.MapItem{Key: key, Value: value})
+			}
+			_, err = decoder.Token()
+			return object, err
+		}
+
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrderedJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			array = append(array, value)
+		}
+		_, err = decoder.Token()
+		return array, err
+	case json.Number:
+		if integer, err := typed.Int64(); err == nil {
+			return integer, nil
+		}
+		return typed.Float64()
+	}
+
+	return token, nil
+}
+
+// SimulationYAMLToJSON converts a simulation from YAML to the JSON accepted by Hoverfly.
+func SimulationYAMLToJSON(data []byte) ([]byte, error) {
+	var simulation interface{}
+	if err := yaml.Unmarshal(data, &simulation); err != nil {
+		return nil, fmt.Errorf("Could not parse YAML simulation\n\n%s", err.Error())
+	}
+
+	return json.Marshal(normalizeYaml(simulation))
+}
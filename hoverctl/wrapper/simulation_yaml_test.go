@@ -0,0 +1,160 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func yamlTestSimulation() v2.SimulationViewV5 {
+	simulation := newSimulationView([]v2.RequestMatcherResponsePairViewV5{
+		{
+			RequestMatcher: v2.RequestMatcherViewV5{
+				Method:      []v2.MatcherViewV5{v2.NewMatcherView(matchers.Exact, "POST")},
+				Destination: []v2.MatcherViewV5{v2.NewMatcherView(matchers.Glob, "*.internal")},
+				Path:        []v2.MatcherViewV5{v2.NewMatcherView(matchers.Exact, "/api/v1/orders")},
+				Body: []v2.MatcherViewV5{
+					{
+						Matcher: matchers.JsonPath,
+						Value:   "$.items",
+						DoMatch: &v2.MatcherViewV5{
+							Matcher: matchers.Array,
+							Value:   []interface{}{"a", "b"},
+							Config:  map[string]interface{}{"ignoreOrder": true},
+						},
+					},
+				},
+				Headers: map[string][]v2.MatcherViewV5{
+					"Authorization": {v2.NewMatcherView(matchers.Glob, "Bearer *")},
+				},
+				Query: &v2.QueryMatcherViewV5{
+					"status": {v2.NewMatcherView(matchers.Exact, "on")},
+				},
+				RequiresState: map[string]string{"basket": "full"},
+			},
+			Response: v2.ResponseDetailsViewV5{
+				Status:           201,
+				Body:             "{\n\t\"id\": 1,\n\t\"note\": \"<a & b>\"\n}\n",
+				Headers:          map[string][]string{"Content-Type": {"application/json"}, "X-Version": {"1.0"}},
+				Templated:        true,
+				TransitionsState: map[string]string{"basket": "empty"},
+				FixedDelay:       250,
+				CapturedAt:       "2024-01-02T03:04:05.123456789Z",
+				LatencyMs:        12,
+			},
+		},
+	})
+	simulation.GlobalActions.Delays = []v1.ResponseDelayView{{UrlPattern: `^payments\.internal/`, HttpMethod: "GET", Delay: 500}}
+
+	return simulation
+}
+
+func Test_SimulationJSONToYAML_RoundTripsWithoutLosingAnything(t *testing.T) {
+	RegisterTestingT(t)
+
+	original, err := json.Marshal(yamlTestSimulation())
+	Expect(err).To(BeNil())
+
+	yamlData, err := SimulationJSONToYAML(original)
+	Expect(err).To(BeNil())
+
+	roundTripped, err := SimulationYAMLToJSON(yamlData)
+	Expect(err).To(BeNil())
+
+	var expected, actual interface{}
+	Expect(json.Unmarshal(original, &expected)).To(Succeed())
+	Expect(json.Unmarshal(roundTripped, &actual)).To(Succeed())
+	Expect(actual).To(Equal(expected))
+
+	var simulation v2.SimulationViewV5
+	Expect(json.Unmarshal(roundTripped, &simulation)).To(Succeed())
+	Expect(simulation).To(Equal(yamlTestSimulation()))
+}
+
+func Test_SimulationJSONToYAML_KeepsFieldOrderAndWritesMultilineBodiesAsBlocks(t *testing.T) {
+	RegisterTestingT(t)
+
+	yamlData, err := SimulationJSONToYAML([]byte(`{"data":{"pairs":[{"response":{"status":200,"body":"line 1\nline 2\n"}}]},"meta":{"schemaVersion":"v5.2"}}`))
+	Expect(err).To(BeNil())
+
+	Expect(string(yamlData)).To(Equal(`data:
+  pairs:
+  - response:
+      status: 200
+      body: |
+        line 1
+        line 2
+meta:
+  schemaVersion: v5.2
+`))
+}
+
+func Test_SimulationJSONToYAML_AcceptsEscapedSlashes(t *testing.T) {
+	RegisterTestingT(t)
+
+	yamlData, err := SimulationJSONToYAML([]byte(`{"path":"\/api\/v1"}`))
+	Expect(err).To(BeNil())
+	Expect(string(yamlData)).To(Equal("path: /api/v1\n"))
+}
+
+func Test_SimulationJSONToYAML_ErrorsOnInvalidJSON(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := SimulationJSONToYAML([]byte(`{"data":`))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Could not convert simulation to YAML"))
+}
+
+func Test_SimulationYAMLToJSON_ConvertsHandWrittenYAML(t *testing.T) {
+	RegisterTestingT(t)
+
+	jsonData, err := SimulationYAMLToJSON([]byte(`
+data:
+  pairs:
+  - request:
+      path:
+      - matcher: exact
+        value: /health
+    response:
+      status: 200
+      body: |
+        {"status": "ok"}
+meta:
+  schemaVersion: v5.2
+`))
+	Expect(err).To(BeNil())
+
+	var simulation v2.SimulationViewV5
+	Expect(json.Unmarshal(jsonData, &simulation)).To(Succeed())
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/health"))
+	Expect(simulation.RequestResponsePairs[0].Response.Status).To(Equal(200))
+	Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("{\"status\": \"ok\"}\n"))
+	Expect(simulation.SchemaVersion).To(Equal("v5.2"))
+}
+
+func Test_SimulationYAMLToJSON_ErrorsOnInvalidYAML(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := SimulationYAMLToJSON([]byte("data: [pairs"))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Could not parse YAML simulation"))
+}
+
+func Test_IsYAMLSimulation_UsesFormatOrExtension(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(IsYAMLSimulation("", "simulation.yaml")).To(BeTrue())
+	Expect(IsYAMLSimulation("", "simulation.YML")).To(BeTrue())
+	Expect(IsYAMLSimulation("", "simulation.json")).To(BeFalse())
+	Expect(IsYAMLSimulation("yaml", "simulation.json")).To(BeTrue())
+	Expect(IsYAMLSimulation("json", "simulation.yaml")).To(BeFalse())
+
+	_, err := IsYAMLSimulation("toml", "simulation.toml")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Unknown simulation format toml, use json or yaml"))
+}
@@ -0,0 +1,68 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+)
+
+type UserSchema struct {
+	UUID     string `json:"uuid"`
+	Username string `json:"username"`
+	IsAdmin  bool   `json:"is_admin"`
+}
+
+type UsersSchema struct {
+	Users []UserSchema `json:"users"`
+}
+
+// GetUsers will go to the users endpoint in Hoverfly, parse the JSON response and return the users who can log in
+func GetUsers(target configuration.Target) ([]UserSchema, error) {
+	response, err := doRequest(target, "GET", v2ApiUsers, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not retrieve users")
+	if err != nil {
+		return nil, err
+	}
+
+	var users UsersSchema
+
+	err = UnmarshalToInterface(response, &users)
+	if err != nil {
+		return nil, err
+	}
+
+	return users.Users, nil
+}
+
+// AddUser will go to the users endpoint in Hoverfly and add a user who can log in with the password
+func AddUser(target configuration.Target, username, password string, admin bool) error {
+	userBytes, _ := json.Marshal(map[string]interface{}{
+		"username": username,
+		"password": password,
+		"is_admin": admin,
+	})
+
+	response, err := doRequest(target, "POST", v2ApiUsers, string(userBytes), nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponseError(response, "Could not add user")
+}
+
+// DeleteUser will go to the users endpoint in Hoverfly and delete the user
+func DeleteUser(target configuration.Target, username string) error {
+	response, err := doRequest(target, "DELETE", v2ApiUsers+"/"+url.PathEscape(username), "", nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponseError(response, "Could not delete user")
+}
@@ -0,0 +1,51 @@
+package wrapper
+
+import (
+	"fmt"
+	"regexp"
+
+	v2 "github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+)
+
+var releaseVersion = regexp.MustCompile(`^v?(\d+)\.(\d+)\.\d+`)
+
+// GetVersion will go to the version endpoint in Hoverfly, parse the JSON response and return the version of Hoverfly
+func GetVersion(target configuration.Target) (string, error) {
+	response, err := doRequest(target, "GET", v2ApiVersion, "", nil)
+	if err != nil {
+		return "", err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not retrieve the version of Hoverfly")
+	if err != nil {
+		return "", err
+	}
+
+	var version v2.VersionView
+
+	err = UnmarshalToInterface(response, &version)
+	if err != nil {
+		return "", err
+	}
+
+	return version.Version, nil
+}
+
+// CheckVersionCompatibility errors when hoverctl and Hoverfly are different major or minor versions. Versions
+// which are not releases, such as development builds, are not checked.
+func CheckVersionCompatibility(hoverctlVersion, hoverflyVersion string) error {
+	hoverctlRelease := releaseVersion.FindStringSubmatch(hoverctlVersion)
+	hoverflyRelease := releaseVersion.FindStringSubmatch(hoverflyVersion)
+	if hoverctlRelease == nil || hoverflyRelease == nil {
+		return nil
+	}
+
+	if hoverctlRelease[1] != hoverflyRelease[1] || hoverctlRelease[2] != hoverflyRelease[2] {
+		return fmt.Errorf("hoverctl %s may not be compatible with Hoverfly %s, you should use the same version of both", hoverctlVersion, hoverflyVersion)
+	}
+
+	return nil
+}
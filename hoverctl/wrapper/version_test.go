@@ -0,0 +1,127 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_GetVersion_GetsVersionFromHoverfly(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/hoverfly/version",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 200,
+						Body:   `{"version": "v1.5.2"}`,
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	version, err := GetVersion(target)
+	Expect(err).To(BeNil())
+
+	Expect(version).To(Equal("v1.5.2"))
+}
+
+func Test_GetVersion_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := GetVersion(inaccessibleTarget)
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_GetVersion_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV5{
+		DataViewV5: v2.DataViewV5{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/hoverfly/version",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV5{
+						Status: 400,
+						Body:   "{\"error\":\"test error\"}",
+					},
+				},
+			},
+		},
+		MetaView: v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	_, err := GetVersion(target)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not retrieve the version of Hoverfly\n\ntest error"))
+}
+
+func Test_CheckVersionCompatibility_AllowsTheSameMajorAndMinorVersion(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(CheckVersionCompatibility("v1.5.2", "v1.5.2")).To(BeNil())
+	Expect(CheckVersionCompatibility("v1.5.0", "1.5.3")).To(BeNil())
+}
+
+func Test_CheckVersionCompatibility_ErrorsWhenMinorVersionIsDifferent(t *testing.T) {
+	RegisterTestingT(t)
+
+	err := CheckVersionCompatibility("v1.4.0", "v1.5.2")
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("hoverctl v1.4.0 may not be compatible with Hoverfly v1.5.2, you should use the same version of both"))
+}
+
+func Test_CheckVersionCompatibility_ErrorsWhenMajorVersionIsDifferent(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(CheckVersionCompatibility("v2.5.2", "v1.5.2")).ToNot(BeNil())
+}
+
+func Test_CheckVersionCompatibility_IgnoresVersionsWhichAreNotReleases(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(CheckVersionCompatibility("dev", "v1.5.2")).To(BeNil())
+	Expect(CheckVersionCompatibility("master-1234", "v1.5.2")).To(BeNil())
+	Expect(CheckVersionCompatibility("v1.4.0", "")).To(BeNil())
+}